@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -26,6 +27,7 @@ import (
 	"github.com/containers/podman/v5/pkg/util"
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -598,6 +600,17 @@ func AutocompleteSecretCreate(cmd *cobra.Command, args []string, toComplete stri
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteSecretUpdate - Autocomplete a secret followed by a file.
+func AutocompleteSecretUpdate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return getSecrets(cmd, toComplete, completeDefault)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -616,6 +629,11 @@ func AutocompletePodExitPolicy(cmd *cobra.Command, args []string, toComplete str
 	return config.PodExitPolicies, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompletePodCPUPlacement - Autocomplete pod CPU placement hints.
+func AutocompletePodCPUPlacement(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{define.CPUPlacementDomain, define.CPUPlacementCPUs}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCreateRun - Autocomplete only the fist argument as image and then do file completion.
 func AutocompleteCreateRun(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -972,6 +990,27 @@ func AutocompleteCgroupMode(cmd *cobra.Command, args []string, toComplete string
 	return cgroupModes, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteJailProfile - Autocomplete jail profile options.
+// -> "hardened", "permissive", "router" and the profiles in containers.conf
+func AutocompleteJailProfile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	jailProfiles := []string{"hardened", "permissive", "router"}
+	configured := make([]string, 0, len(podmanConfig.ContainersConfDefaultsRO.JailProfiles))
+	for name := range podmanConfig.ContainersConfDefaultsRO.JailProfiles {
+		if !slices.Contains(jailProfiles, name) {
+			configured = append(configured, name)
+		}
+	}
+	sort.Strings(configured)
+	return append(jailProfiles, configured...), cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteIOPriority - Autocomplete I/O priority class options.
+// -> "critical", "normal", "batch"
+func AutocompleteIOPriority(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ioPriorities := []string{"critical", "normal", "batch"}
+	return ioPriorities, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImageVolume - Autocomplete image volume options.
 // -> "bind", "tmpfs", "ignore"
 func AutocompleteImageVolume(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -980,10 +1019,10 @@ func AutocompleteImageVolume(cmd *cobra.Command, args []string, toComplete strin
 }
 
 // AutocompleteLogDriver - Autocomplete log-driver options.
-// -> "journald", "none", "k8s-file", "passthrough", "passthrough-tty"
+// -> "journald", "none", "k8s-file", "syslog", "passthrough", "passthrough-tty"
 func AutocompleteLogDriver(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// don't show json-file
-	logDrivers := []string{define.JournaldLogging, define.NoLogging, define.KubernetesLogging}
+	logDrivers := []string{define.JournaldLogging, define.NoLogging, define.KubernetesLogging, define.SyslogLogging}
 	if !registry.IsRemote() {
 		logDrivers = append(logDrivers, define.PassthroughLogging, define.PassthroughTTYLogging)
 	}
@@ -991,9 +1030,9 @@ func AutocompleteLogDriver(cmd *cobra.Command, args []string, toComplete string)
 }
 
 // AutocompleteLogOpt - Autocomplete log-opt options.
-// -> "path=", "tag="
+// -> "path=", "tag=", "max-size=", "max-file=", "syslog-address=", "syslog-facility="
 func AutocompleteLogOpt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	logOptions := []string{"path=", "tag=", "max-size="}
+	logOptions := []string{"path=", "tag=", "max-size=", "max-file=", "syslog-address=", "syslog-facility="}
 	if strings.HasPrefix(toComplete, "path=") {
 		return nil, cobra.ShellCompDirectiveDefault
 	}
@@ -1419,11 +1458,12 @@ func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete strin
 			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
 			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
 			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
-			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
+			events.NetworkDisconnect.String(), events.NetworkJailCreate.String(), events.NetworkJailRemove.String(),
+			events.NetworkSetupError.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
 			events.PullError.String(), events.Push.String(), events.Refresh.String(), events.Remove.String(),
 			events.Rename.String(), events.Renumber.String(), events.Restart.String(), events.Restore.String(),
 			events.Save.String(), events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(),
-			events.Unmount.String(), events.Unpause.String(), events.Untag.String(),
+			events.Unmount.String(), events.Unpause.String(), events.Untag.String(), events.Update.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	eventTypes := func(_ string) ([]string, cobra.ShellCompDirective) {
@@ -1527,6 +1567,12 @@ func AutocompleteImageSaveFormat(cmd *cobra.Command, args []string, toComplete s
 	return ValidSaveFormats, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteExportFormat - Autocomplete container export formats.
+// -> "tar", "zfs"
+func AutocompleteExportFormat(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"tar", "zfs"}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteWaitCondition - Autocomplete wait condition options.
 // -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
 func AutocompleteWaitCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1578,7 +1624,7 @@ func AutocompleteContainersConfModules(cmd *cobra.Command, args []string, toComp
 // AutocompleteEventBackend - Autocomplete event backend options.
 // -> "file", "journald", "none"
 func AutocompleteEventBackend(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	types := []string{events.LogFile.String(), events.Journald.String(), events.Null.String()}
+	types := []string{events.LogFile.String(), events.Journald.String(), events.Syslog.String(), events.Null.String()}
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1602,6 +1648,13 @@ func AutocompleteSDNotify(cmd *cobra.Command, args []string, toComplete string)
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteTraceTool - Autocomplete trace tools.
+// -> "dtrace", "ktrace"
+func AutocompleteTraceTool(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tools := []string{"dtrace", "ktrace"}
+	return tools, cobra.ShellCompDirectiveNoFileComp
+}
+
 var containerStatuses = []string{"created", "running", "paused", "stopped", "exited", "unknown"}
 
 // AutocompletePsFilters - Autocomplete ps filter options.
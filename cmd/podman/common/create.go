@@ -232,6 +232,12 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(hostUserFlagName, completion.AutocompleteNone)
 
+		createFlags.BoolVar(
+			&cf.HostUserGroups,
+			"hostuser-groups", false,
+			"Add the groups of the host users to /etc/group within container",
+		)
+
 		imageVolumeFlagName := "image-volume"
 		createFlags.String(
 			imageVolumeFlagName, cf.ImageVolume,
@@ -259,6 +265,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"interactive", "i", false,
 			"Keep STDIN open even if not attached",
 		)
+		ioPriorityFlagName := "io-priority"
+		createFlags.StringVar(
+			&cf.IOPriority,
+			ioPriorityFlagName, "",
+			"I/O priority class of the container: critical, normal or batch (FreeBSD only)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(ioPriorityFlagName, AutocompleteIOPriority)
+
 		ipcFlagName := "ipc"
 		createFlags.String(
 			ipcFlagName, "",
@@ -266,6 +280,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(ipcFlagName, AutocompleteNamespace)
 
+		jailProfileFlagName := "jail-profile"
+		createFlags.StringVar(
+			&cf.JailProfile,
+			jailProfileFlagName, "",
+			"Jail parameter profile to use (FreeBSD only)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(jailProfileFlagName, AutocompleteJailProfile)
+
 		createFlags.String(
 			"kernel-memory", "",
 			"DEPRECATED: Option is just hear for compatibility with Docker",
@@ -414,6 +436,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"The first argument is not an image but the rootfs to the exploded container",
 		)
 
+		scheduleFlagName := "schedule"
+		createFlags.StringVar(
+			&cf.Schedule,
+			scheduleFlagName, "",
+			"Run the container periodically on the given cron schedule using the Podman service",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(scheduleFlagName, completion.AutocompleteNone)
+
 		sdnotifyFlagName := "sdnotify"
 		createFlags.StringVar(
 			&cf.SdNotifyMode,
@@ -470,6 +500,22 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(startupHCTimeoutFlagName, completion.AutocompleteNone)
 
+		memoryLockFlagName := "memory-lock"
+		createFlags.StringVar(
+			&cf.MemoryLock,
+			memoryLockFlagName, "",
+			"Amount of memory the container may lock to protect it from paging (experimental, FreeBSD only) "+sizeWithUnitFormat,
+		)
+		_ = cmd.RegisterFlagCompletionFunc(memoryLockFlagName, completion.AutocompleteNone)
+
+		memoryReclaimSignalFlagName := "memory-reclaim-signal"
+		createFlags.StringVar(
+			&cf.MemoryReclaimSignal,
+			memoryReclaimSignalFlagName, "",
+			"Signal to send to the container when it exceeds the memory soft limit (FreeBSD only)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(memoryReclaimSignalFlagName, AutocompleteStopSignal)
+
 		stopSignalFlagName := "stop-signal"
 		createFlags.StringVar(
 			&cf.StopSignal,
@@ -609,6 +655,42 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(chrootDirsFlagName, completion.AutocompleteDefault)
 
+		createFlags.BoolVar(
+			&cf.CollectCores,
+			"collect-cores", false,
+			"Collect core dumps from the container processes on the host (FreeBSD only)",
+		)
+
+		createFlags.BoolVar(
+			&cf.Console,
+			"console", false,
+			"Allocate a console which can be attached to with podman console",
+		)
+
+		metadataKeysFlagName := "metadata-keys"
+		createFlags.StringSliceVar(
+			&cf.MetadataKeys,
+			metadataKeysFlagName, []string{},
+			"Labels and annotations to write to /run/.containermeta in the container",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(metadataKeysFlagName, completion.AutocompleteNone)
+
+		identityCAFlagName := "identity-ca"
+		createFlags.StringVar(
+			&cf.IdentityCA,
+			identityCAFlagName, "",
+			"Directory with the CA issuing an identity certificate to the container in /run/identity each time it starts",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(identityCAFlagName, completion.AutocompleteDefault)
+
+		identityTTLFlagName := "identity-ttl"
+		createFlags.StringVar(
+			&cf.IdentityTTL,
+			identityTTLFlagName, "",
+			"Validity of the identity certificate (default 24h)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(identityTTLFlagName, completion.AutocompleteNone)
+
 		passwdEntryName := "passwd-entry"
 		createFlags.StringVar(&cf.PasswdEntry, passwdEntryName, "", "Entry to write to /etc/passwd")
 		_ = cmd.RegisterFlagCompletionFunc(passwdEntryName, completion.AutocompleteNone)
@@ -617,6 +699,9 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		createFlags.StringVar(&cf.GroupEntry, groupEntryName, "", "Entry to write to /etc/group")
 		_ = cmd.RegisterFlagCompletionFunc(groupEntryName, completion.AutocompleteNone)
 
+		createFlags.BoolVar(&cf.NoPasswd, "no-passwd", false, "Do not add entries to /etc/passwd")
+		createFlags.BoolVar(&cf.NoGroup, "no-group", false, "Do not add entries to /etc/group")
+
 		decryptionKeysFlagName := "decryption-key"
 		createFlags.StringArrayVar(
 			&cf.DecryptionKeys,
@@ -88,6 +88,13 @@ func DefineNetFlags(cmd *cobra.Command) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
 
+	routeFlagName := "route"
+	netFlags.StringArray(
+		routeFlagName, nil,
+		"Add a static route to the container's network (destination,gateway[,metric])",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(routeFlagName, completion.AutocompleteNone)
+
 	netFlags.Bool(
 		"no-hosts", podmanConfig.ContainersConfDefaultsRO.Containers.NoHosts,
 		"Do not create /etc/hosts within the container, instead use the version from the image",
@@ -165,6 +172,20 @@ func NetFlagsToNetOptions(opts *entities.NetOptions, flags pflag.FlagSet) (*enti
 		opts.DNSSearch = dnsSearches
 	}
 
+	if flags.Changed("route") {
+		routes, err := flags.GetStringArray("route")
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range routes {
+			route, err := parse.Route(r)
+			if err != nil {
+				return nil, err
+			}
+			opts.Routes = append(opts.Routes, *route)
+		}
+	}
+
 	if flags.Changed("publish") {
 		inputPorts, err := flags.GetStringSlice("publish")
 		if err != nil {
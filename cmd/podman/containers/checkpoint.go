@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -40,6 +41,9 @@ var (
 	}
 )
 
+// checkpointStatsFile is the file the statistics are written to.
+var checkpointStatsFile string
+
 var checkpointOptions entities.CheckpointOptions
 
 type checkpointStatistics struct {
@@ -82,6 +86,10 @@ func init() {
 		"Display checkpoint statistics",
 	)
 
+	statsFileFlagName := "stats-file"
+	flags.StringVar(&checkpointStatsFile, statsFileFlagName, "", "Write checkpoint statistics as JSON to a file")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(statsFileFlagName, completion.AutocompleteDefault)
+
 	validate.AddLatestFlag(checkpointCommand, &checkpointOptions.Latest)
 }
 
@@ -122,6 +130,12 @@ func checkpoint(cmd *cobra.Command, args []string) error {
 	if (checkpointOptions.WithPrevious || checkpointOptions.PreCheckPoint) && !criu.MemTrack() {
 		return errors.New("system (architecture/kernel/CRIU) does not support memory tracking")
 	}
+	// The statistics written to the file are collected like the
+	// printed ones.
+	printStats := checkpointOptions.PrintStats
+	if checkpointStatsFile != "" {
+		checkpointOptions.PrintStats = true
+	}
 	responses, err := registry.ContainerEngine().ContainerCheckpoint(context.Background(), args, checkpointOptions)
 	if err != nil {
 		return err
@@ -131,11 +145,16 @@ func checkpoint(cmd *cobra.Command, args []string) error {
 	var statistics checkpointStatistics
 
 	for _, r := range responses {
-		switch {
-		case r.Err != nil:
+		if r.Err != nil {
 			errs = append(errs, r.Err)
-		case checkpointOptions.PrintStats:
+			continue
+		}
+		if checkpointOptions.PrintStats {
 			statistics.ContainerStatistics = append(statistics.ContainerStatistics, r)
+		}
+		switch {
+		case printStats:
+			// The statistics are printed instead.
 		case r.RawInput != "":
 			fmt.Println(r.RawInput)
 		default:
@@ -149,7 +168,14 @@ func checkpoint(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(j))
+		if printStats {
+			fmt.Println(string(j))
+		}
+		if checkpointStatsFile != "" {
+			if err := os.WriteFile(checkpointStatsFile, append(j, '\n'), 0o644); err != nil {
+				return fmt.Errorf("writing checkpoint statistics: %w", err)
+			}
+		}
 	}
 
 	return errs.PrintErrors()
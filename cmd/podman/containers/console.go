@@ -0,0 +1,66 @@
+package containers
+
+import (
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	consoleDescription = `Attach to the console of a running container which was created with --console.
+
+  Unlike podman attach, this works for containers started in the background without -i and -t, for example by a boot script, which helps to debug containers which fail to start properly.`
+	consoleCommand = &cobra.Command{
+		Use:               "console [options] CONTAINER",
+		Short:             "Attach to the console of a running container",
+		Long:              consoleDescription,
+		RunE:              console,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example:           `podman console ctrID`,
+	}
+
+	containerConsoleCommand = &cobra.Command{
+		Use:               consoleCommand.Use,
+		Short:             consoleCommand.Short,
+		Long:              consoleCommand.Long,
+		RunE:              consoleCommand.RunE,
+		Args:              consoleCommand.Args,
+		ValidArgsFunction: consoleCommand.ValidArgsFunction,
+		Example:           `podman container console ctrID`,
+	}
+)
+
+var consoleOpts entities.AttachOptions
+
+func consoleFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	detachKeysFlagName := "detach-keys"
+	flags.StringVar(&consoleOpts.DetachKeys, detachKeysFlagName, containerConfig.DetachKeys(), "Select the key sequence for detaching from the console. Format is a single character `[a-Z]` or a comma separated sequence of `ctrl-<value>`, where `<value>` is one of: `a-z`, `@`, `^`, `[`, `\\`, `]`, `^` or `_`")
+	_ = cmd.RegisterFlagCompletionFunc(detachKeysFlagName, common.AutocompleteDetachKeys)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: consoleCommand,
+	})
+	consoleFlags(consoleCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerConsoleCommand,
+		Parent:  containerCmd,
+	})
+	consoleFlags(containerConsoleCommand)
+}
+
+func console(cmd *cobra.Command, args []string) error {
+	// Signals are delivered to the container using the terminal.
+	consoleOpts.Stdin = os.Stdin
+	consoleOpts.Stdout = os.Stdout
+	return registry.ContainerEngine().ContainerConsole(registry.GetContext(), strings.TrimPrefix(args[0], "/"), consoleOpts)
+}
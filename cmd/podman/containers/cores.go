@@ -0,0 +1,162 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// podman container _cores_
+	coresCmd = &cobra.Command{
+		Use:   "cores",
+		Short: "Manage core dumps collected from containers",
+		Long:  "Manage core dumps collected from containers created with --collect-cores.",
+		RunE:  validate.SubCommandExists,
+	}
+
+	coresListCmd = &cobra.Command{
+		Use:               "list [options] CONTAINER",
+		Aliases:           []string{"ls"},
+		Short:             "List core dumps collected from a container",
+		Long:              "List the core dumps collected from the processes of a container created with --collect-cores.",
+		RunE:              coresList,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example:           `podman container cores list ctrID`,
+	}
+
+	coresExportCmd = &cobra.Command{
+		Use:               "export [options] CONTAINER NAME",
+		Short:             "Export a core dump collected from a container",
+		Long:              "Export a core dump collected from the processes of a container created with --collect-cores.",
+		RunE:              coresExport,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman container cores export ctrID httpd.1234.core > httpd.core
+  podman container cores export --output=httpd.core ctrID httpd.1234.core`,
+	}
+)
+
+var (
+	coresListFormat   string
+	coresListNoHeader bool
+	coresExportOutput string
+)
+
+type coreDumpReport struct {
+	define.CoreDump
+}
+
+func (c coreDumpReport) CreatedAt() string {
+	return units.HumanDuration(time.Since(c.Created)) + " ago"
+}
+
+func (c coreDumpReport) HumanSize() string {
+	return units.HumanSizeWithPrecision(float64(c.Size), 3)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: coresCmd,
+		Parent:  containerCmd,
+	})
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: coresListCmd,
+		Parent:  coresCmd,
+	})
+	listFlags := coresListCmd.Flags()
+	formatFlagName := "format"
+	listFlags.StringVar(&coresListFormat, formatFlagName, "{{range .}}{{.Name}}\t{{.HumanSize}}\t{{.CreatedAt}}\n{{end -}}", "Pretty-print core dumps using a Go template")
+	_ = coresListCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&coreDumpReport{}))
+	listFlags.BoolVarP(&coresListNoHeader, "noheading", "n", false, "Do not print headers")
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: coresExportCmd,
+		Parent:  coresCmd,
+	})
+	outputFlagName := "output"
+	coresExportCmd.Flags().StringVarP(&coresExportOutput, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
+	_ = coresExportCmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+}
+
+func coresList(cmd *cobra.Command, args []string) error {
+	cores, err := registry.ContainerEngine().ContainerCoresList(context.Background(), strings.TrimPrefix(args[0], "/"))
+	if err != nil {
+		return err
+	}
+	reports := make([]coreDumpReport, 0, len(cores))
+	for _, c := range cores {
+		reports = append(reports, coreDumpReport{c})
+	}
+
+	if report.IsJSON(coresListFormat) {
+		b, err := json.MarshalIndent(cores, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, coresListFormat)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, coresListFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !coresListNoHeader {
+		headers := report.Headers(coreDumpReport{}, map[string]string{
+			"CreatedAt": "CREATED",
+			"HumanSize": "SIZE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
+
+func coresExport(cmd *cobra.Command, args []string) error {
+	options := entities.ContainerCoresExportOptions{}
+	if len(coresExportOutput) == 0 {
+		file := os.Stdout
+		if term.IsTerminal(int(file.Fd())) {
+			return errors.New("refusing to export to terminal. Use -o flag or redirect")
+		}
+		options.Output = file
+	} else {
+		if err := parse.ValidateFileName(coresExportOutput); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(coresExportOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		options.Output = file
+	}
+	return registry.ContainerEngine().ContainerCoresExport(context.Background(), strings.TrimPrefix(args[0], "/"), args[1], options)
+}
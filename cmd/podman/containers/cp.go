@@ -50,14 +50,16 @@ var (
 )
 
 var (
-	cpOpts entities.ContainerCpOptions
-	chown  bool
+	cpOpts     entities.ContainerCpOptions
+	chown      bool
+	followLink bool
 )
 
 func cpFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 	flags.BoolVar(&cpOpts.OverwriteDirNonDir, "overwrite", false, "Allow to overwrite directories with non-directories and vice versa")
 	flags.BoolVarP(&chown, "archive", "a", true, `Chown copied files to the primary uid/gid of the destination container.`)
+	flags.BoolVarP(&followLink, "follow-link", "L", true, "Follow symbolic links in SRC_PATH, copy the link itself if false")
 
 	// Deprecated flags (both are NOPs): exist for backwards compat
 	flags.BoolVar(&cpOpts.Extract, "extract", false, "Deprecated...")
@@ -95,6 +97,12 @@ func cp(cmd *cobra.Command, args []string) error {
 	return copyToContainer(destContainerStr, destPath, sourcePath)
 }
 
+// sourceArchiveOptions returns the options for stat'ing and copying the
+// source path in a container.
+func sourceArchiveOptions() entities.ArchiveOptions {
+	return entities.ArchiveOptions{NoFollowLink: !followLink}
+}
+
 // containerMustExist returns an error if the specified container does not
 // exist.
 func containerMustExist(container string) error {
@@ -130,7 +138,7 @@ func copyContainerToContainer(sourceContainer string, sourcePath string, destCon
 		return err
 	}
 
-	sourceContainerInfo, err := registry.ContainerEngine().ContainerStat(registry.GetContext(), sourceContainer, sourcePath)
+	sourceContainerInfo, err := registry.ContainerEngine().ContainerStat(registry.GetContext(), sourceContainer, sourcePath, sourceArchiveOptions())
 	if err != nil {
 		return fmt.Errorf("%q could not be found on container %s: %w", sourcePath, sourceContainer, err)
 	}
@@ -166,7 +174,7 @@ func copyContainerToContainer(sourceContainer string, sourcePath string, destCon
 
 	sourceContainerCopy := func() error {
 		defer writer.Close()
-		copyFunc, err := registry.ContainerEngine().ContainerCopyToArchive(registry.GetContext(), sourceContainer, sourceContainerTarget, writer)
+		copyFunc, err := registry.ContainerEngine().ContainerCopyToArchive(registry.GetContext(), sourceContainer, sourceContainerTarget, writer, sourceArchiveOptions())
 		if err != nil {
 			return err
 		}
@@ -211,7 +219,7 @@ func copyFromContainer(container string, containerPath string, hostPath string)
 		hostPath = os.Stdout.Name()
 	}
 
-	containerInfo, err := registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath)
+	containerInfo, err := registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath, sourceArchiveOptions())
 	if err != nil {
 		return fmt.Errorf("%q could not be found on container %s: %w", containerPath, container, err)
 	}
@@ -321,7 +329,7 @@ func copyFromContainer(container string, containerPath string, hostPath string)
 
 	containerCopy := func() error {
 		defer writer.Close()
-		copyFunc, err := registry.ContainerEngine().ContainerCopyToArchive(registry.GetContext(), container, containerTarget, writer)
+		copyFunc, err := registry.ContainerEngine().ContainerCopyToArchive(registry.GetContext(), container, containerTarget, writer, sourceArchiveOptions())
 		if err != nil {
 			return err
 		}
@@ -346,7 +354,11 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 	}
 
 	// Make sure that host path exists.
-	hostInfo, err := copy.ResolveHostPath(hostPath)
+	resolveHostPath := copy.ResolveHostPath
+	if !followLink && !isStdin {
+		resolveHostPath = copy.ResolveHostPathNoFollow
+	}
+	hostInfo, err := resolveHostPath(hostPath)
 	if err != nil {
 		return fmt.Errorf("%q could not be found on the host: %w", hostPath, err)
 	}
@@ -420,6 +432,7 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 			// Unless the specified path points to ".", we want to
 			// copy the base directory.
 			KeepDirectoryNames: hostInfo.IsDir && filepath.Base(hostTarget) != ".",
+			NoDerefSymlinks:    !followLink,
 		}
 		if (!hostInfo.IsDir && !containerInfo.IsDir) || containerResolvedToParentDir {
 			// If we're having a file-to-file copy, make sure to
@@ -456,7 +469,7 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 // container.  If the path does not exist, it attempts to use the parent
 // directory.
 func resolvePathOnDestinationContainer(container string, containerPath string, isStdin bool) (baseName string, containerInfo *entities.ContainerStatReport, resolvedToParentDir bool, err error) {
-	containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath)
+	containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath, entities.ArchiveOptions{})
 	if err == nil {
 		baseName = filepath.Base(containerInfo.LinkTarget)
 		return //nolint: nilerr
@@ -488,7 +501,7 @@ func resolvePathOnDestinationContainer(container string, containerPath string, i
 		return
 	}
 
-	containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, parentDir)
+	containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, parentDir, entities.ArchiveOptions{})
 	if err != nil {
 		err = fmt.Errorf("%q could not be found on container %s: %w", containerPath, container, err)
 		return
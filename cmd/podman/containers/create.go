@@ -160,6 +160,7 @@ func create(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	s.RawImageName = rawImageName
+	s.RuntimeFlags = registry.PodmanConfig().RuntimeFlags
 
 	if err := createPodIfNecessary(cmd, s, cliVals.Net); err != nil {
 		return err
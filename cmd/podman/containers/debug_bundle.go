@@ -0,0 +1,69 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	debugBundleDescription = `Collects the logs, inspect output, statistics and platform specific state of a container into a gzip compressed tar archive which can be attached to bug reports.
+
+  On FreeBSD, the archive also contains the jail parameters, rctl usage and rules, pf state and mount table of the container.`
+
+	debugBundleCommand = &cobra.Command{
+		Use:               "debug-bundle [options] CONTAINER",
+		Short:             "Collect debugging information for a container",
+		Long:              debugBundleDescription,
+		RunE:              debugBundle,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman container debug-bundle ctrID > ctrID-debug.tar.gz
+  podman container debug-bundle --output=ctrID-debug.tar.gz ctrID`,
+	}
+)
+
+var debugBundleOutput string
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: debugBundleCommand,
+		Parent:  containerCmd,
+	})
+	flags := debugBundleCommand.Flags()
+
+	outputFlagName := "output"
+	flags.StringVarP(&debugBundleOutput, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
+	_ = debugBundleCommand.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+}
+
+func debugBundle(cmd *cobra.Command, args []string) error {
+	options := entities.ContainerDebugBundleOptions{}
+	if len(debugBundleOutput) == 0 {
+		file := os.Stdout
+		if term.IsTerminal(int(file.Fd())) {
+			return errors.New("refusing to write debug bundle to terminal. Use -o flag or redirect")
+		}
+		options.Output = file
+	} else {
+		if err := parse.ValidateFileName(debugBundleOutput); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(debugBundleOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		options.Output = file
+	}
+	return registry.ContainerEngine().ContainerDebugBundle(context.Background(), strings.TrimPrefix(args[0], "/"), options)
+}
@@ -57,6 +57,10 @@ func execFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
 	flags.SetInterspersed(false)
+	cpusFlagName := "cpus"
+	flags.Float64Var(&execOpts.CPUs, cpusFlagName, 0, "Number of CPUs the exec session may use (FreeBSD only)")
+	_ = cmd.RegisterFlagCompletionFunc(cpusFlagName, completion.AutocompleteNone)
+
 	flags.BoolVarP(&execDetach, "detach", "d", false, "Run the exec session in detached mode (backgrounded)")
 
 	detachKeysFlagName := "detach-keys"
@@ -79,6 +83,10 @@ func execFlags(cmd *cobra.Command) {
 	flags.StringVarP(&execOpts.User, userFlagName, "u", "", "Sets the username or UID used and optionally the groupname or GID for the specified command")
 	_ = cmd.RegisterFlagCompletionFunc(userFlagName, common.AutocompleteUserFlag)
 
+	niceFlagName := "nice"
+	flags.IntVar(&execOpts.Nice, niceFlagName, 0, "Nice value of the exec session's process, from -20 to 20")
+	_ = cmd.RegisterFlagCompletionFunc(niceFlagName, completion.AutocompleteNone)
+
 	preserveFdsFlagName := "preserve-fds"
 	flags.UintVar(&execOpts.PreserveFDs, preserveFdsFlagName, 0, "Pass N additional file descriptors to the container")
 	_ = cmd.RegisterFlagCompletionFunc(preserveFdsFlagName, completion.AutocompleteNone)
@@ -0,0 +1,174 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execLsDescription = `List the exec sessions of a container.
+
+  The CPU time and memory used by running exec sessions are recorded when they are listed.`
+
+	execLsCommand = &cobra.Command{
+		Use:               "ls [options] CONTAINER",
+		Short:             "List the exec sessions of a container",
+		Long:              execLsDescription,
+		RunE:              execLs,
+		Args:              validate.IDOrLatestArgs,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman container exec ls ctrID
+  podman container exec ls --format "{{.ID}} {{.CPUTime}}" myCtr`,
+	}
+)
+
+var (
+	execLsOpts entities.ExecListOptions
+	execLsFlag = struct {
+		format    string
+		noHeading bool
+		noTrunc   bool
+		quiet     bool
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execLsCommand,
+		Parent:  containerExecCommand,
+	})
+
+	flags := execLsCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&execLsFlag.format, formatFlagName, "{{range .}}{{.ID}}\t{{.Pid}}\t{{.Command}}\t{{.Started}}\t{{.Status}}\t{{.CPUTime}}\t{{.Memory}}\n{{end -}}", "Pretty-print exec sessions to JSON or using a Go template")
+	_ = execLsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&execSessionReporter{}))
+
+	flags.BoolVarP(&execLsFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVar(&execLsFlag.noTrunc, "no-trunc", false, "Do not truncate the output")
+	flags.BoolVarP(&execLsFlag.quiet, "quiet", "q", false, "Print exec session IDs only")
+
+	validate.AddLatestFlag(execLsCommand, &execLsOpts.Latest)
+}
+
+func execLs(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = strings.TrimPrefix(args[0], "/")
+	}
+	sessions, err := registry.ContainerEngine().ContainerExecList(context.Background(), nameOrID, execLsOpts)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(execLsFlag.format) {
+		b, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]execSessionReporter, 0, len(sessions))
+	for _, session := range sessions {
+		reporters = append(reporters, execSessionReporter{session})
+	}
+
+	if execLsFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, r := range reporters {
+			fmt.Println(r.ID())
+		}
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, execLsFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, execLsFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !execLsFlag.noHeading {
+		headers := report.Headers(execSessionReporter{}, map[string]string{
+			"ID":      "EXEC ID",
+			"Pid":     "PID",
+			"Command": "COMMAND",
+			"Started": "STARTED",
+			"Status":  "STATUS",
+			"CPUTime": "CPU TIME",
+			"Memory":  "MEM USAGE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
+
+// execSessionReporter is a struct for exec ls output
+type execSessionReporter struct {
+	*define.InspectExecSession
+}
+
+// ID returns the exec session ID, truncated unless --no-trunc is given
+func (e execSessionReporter) ID() string {
+	if execLsFlag.noTrunc || len(e.InspectExecSession.ID) < 12 {
+		return e.InspectExecSession.ID
+	}
+	return e.InspectExecSession.ID[0:12]
+}
+
+// Command returns the command of the exec session
+func (e execSessionReporter) Command() string {
+	command := append([]string{e.ProcessConfig.Entrypoint}, e.ProcessConfig.Arguments...)
+	return strings.Join(command, " ")
+}
+
+// Started returns a human readable time since the exec session was started
+func (e execSessionReporter) Started() string {
+	if e.StartedAt.IsZero() {
+		return ""
+	}
+	return units.HumanDuration(time.Since(e.StartedAt)) + " ago"
+}
+
+// Status returns whether the exec session is running or its exit code
+func (e execSessionReporter) Status() string {
+	switch {
+	case e.Running:
+		return "running"
+	case e.StartedAt.IsZero():
+		return "created"
+	default:
+		return "exited (" + strconv.Itoa(e.ExitCode) + ")"
+	}
+}
+
+// CPUTime returns the CPU time used by the exec session
+func (e execSessionReporter) CPUTime() string {
+	return time.Duration(e.InspectExecSession.CPUTime).String()
+}
+
+// Memory returns the memory used by the exec session in human readable format
+func (e execSessionReporter) Memory() string {
+	return units.BytesSize(float64(e.MemoryUsage))
+}
@@ -53,6 +53,10 @@ func exportFlags(cmd *cobra.Command) {
 	outputFlagName := "output"
 	flags.StringVarP(&outputFile, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
 	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	formatFlagName := "format"
+	flags.StringVar(&exportOpts.Format, formatFlagName, "tar", "Format of the export: tar or zfs (a zfs send stream)")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteExportFormat)
 }
 
 func init() {
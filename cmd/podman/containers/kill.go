@@ -2,7 +2,6 @@ package containers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -93,8 +92,8 @@ func kill(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if sig < 1 || sig > 64 {
-		return errors.New("valid signals are 1 through 64")
+	if sig < 1 || sig > signal.SIGRTMAX {
+		return fmt.Errorf("valid signals are 1 through %d", signal.SIGRTMAX)
 	}
 	for _, cidFile := range killCidFiles {
 		content, err := os.ReadFile(cidFile)
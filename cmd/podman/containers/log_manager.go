@@ -0,0 +1,33 @@
+package containers
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logManagerDescription = `Manages the log of a running container until it exits, i.e. rotates the log file or forwards the output to syslog. This command is used internally when starting containers.`
+
+	logManagerCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "log-manager CONTAINER",
+		Short:             "Manage the log of a running container",
+		Long:              logManagerDescription,
+		RunE:              logManager,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Hidden:            true,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Parent:  containerCmd,
+		Command: logManagerCommand,
+	})
+}
+
+func logManager(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerLogManager(registry.Context(), args[0])
+}
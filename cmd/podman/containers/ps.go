@@ -437,7 +437,7 @@ func (l psReporter) Ports() string {
 	if len(l.ListContainer.Ports) < 1 {
 		return ""
 	}
-	return portsToString(l.ListContainer.Ports)
+	return PortsToString(l.ListContainer.Ports)
 }
 
 // CreatedAt returns the container creation time in string format.  podman
@@ -486,10 +486,10 @@ func (l psReporter) UTS() string {
 	return l.Namespaces.UTS
 }
 
-// portsToString converts the ports used to a string of the from "port1, port2"
+// PortsToString converts the ports used to a string of the from "port1, port2"
 // and also groups a continuous list of ports into a readable format.
 // The format is IP:HostPort(-Range)->ContainerPort(-Range)/Proto
-func portsToString(ports []types.PortMapping) string {
+func PortsToString(ports []types.PortMapping) string {
 	if len(ports) == 0 {
 		return ""
 	}
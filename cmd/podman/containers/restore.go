@@ -3,6 +3,7 @@ package containers
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/containers/common/pkg/completion"
@@ -36,6 +37,9 @@ var (
 	}
 )
 
+// restoreStatsFile is the file the statistics are written to.
+var restoreStatsFile string
+
 var restoreOptions entities.RestoreOptions
 
 type restoreStatistics struct {
@@ -53,6 +57,7 @@ func init() {
 	flags.BoolVarP(&restoreOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
 	flags.BoolVar(&restoreOptions.TCPEstablished, "tcp-established", false, "Restore a container with established TCP connections")
 	flags.BoolVar(&restoreOptions.FileLocks, "file-locks", false, "Restore a container with file locks")
+	flags.BoolVar(&restoreOptions.TCPClose, "tcp-close", false, "Restore a container but close its established TCP connections")
 
 	importFlagName := "import"
 	flags.StringVarP(&restoreOptions.Import, importFlagName, "i", "", "Restore from exported checkpoint archive (tar.gz)")
@@ -87,6 +92,10 @@ func init() {
 		"Display restore statistics",
 	)
 
+	statsFileFlagName := "stats-file"
+	flags.StringVar(&restoreStatsFile, statsFileFlagName, "", "Write restore statistics as JSON to a file")
+	_ = restoreCommand.RegisterFlagCompletionFunc(statsFileFlagName, completion.AutocompleteDefault)
+
 	validate.AddLatestFlag(restoreCommand, &restoreOptions.Latest)
 }
 
@@ -142,6 +151,9 @@ func restore(cmd *cobra.Command, args []string) error {
 	if restoreOptions.Name != "" && restoreOptions.TCPEstablished {
 		return fmt.Errorf("--tcp-established cannot be used with --name")
 	}
+	if restoreOptions.TCPClose && restoreOptions.TCPEstablished {
+		return fmt.Errorf("--tcp-close cannot be used with --tcp-established")
+	}
 
 	inputPorts, err := cmd.Flags().GetStringSlice("publish")
 	if err != nil {
@@ -167,6 +179,12 @@ func restore(cmd *cobra.Command, args []string) error {
 	if argLen > 1 && restoreOptions.Name != "" {
 		return fmt.Errorf("--name can only be used with one checkpoint image")
 	}
+	// The statistics written to the file are collected like the
+	// printed ones.
+	printStats := restoreOptions.PrintStats
+	if restoreStatsFile != "" {
+		restoreOptions.PrintStats = true
+	}
 	responses, err := registry.ContainerEngine().ContainerRestore(context.Background(), args, restoreOptions)
 	if err != nil {
 		return err
@@ -176,11 +194,16 @@ func restore(cmd *cobra.Command, args []string) error {
 	var statistics restoreStatistics
 
 	for _, r := range responses {
-		switch {
-		case r.Err != nil:
+		if r.Err != nil {
 			errs = append(errs, r.Err)
-		case restoreOptions.PrintStats:
+			continue
+		}
+		if restoreOptions.PrintStats {
 			statistics.ContainerStatistics = append(statistics.ContainerStatistics, r)
+		}
+		switch {
+		case printStats:
+			// The statistics are printed instead.
 		case r.RawInput != "":
 			fmt.Println(r.RawInput)
 		default:
@@ -194,7 +217,14 @@ func restore(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(j))
+		if printStats {
+			fmt.Println(string(j))
+		}
+		if restoreStatsFile != "" {
+			if err := os.WriteFile(restoreStatsFile, append(j, '\n'), 0o644); err != nil {
+				return fmt.Errorf("writing restore statistics: %w", err)
+			}
+		}
 	}
 
 	return errs.PrintErrors()
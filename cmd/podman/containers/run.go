@@ -16,6 +16,7 @@ import (
 	"github.com/containers/podman/v5/pkg/rootless"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage/types"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -128,6 +129,10 @@ func run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if cliVals.Schedule != "" && cliVals.Rm {
+		return fmt.Errorf("the --schedule and --rm options conflict: %w", define.ErrInvalidArg)
+	}
+
 	runOpts.CIDFile = cliVals.CIDFile
 	runOpts.Rm = cliVals.Rm
 	cliVals, err := CreateInit(cmd, cliVals, false)
@@ -207,6 +212,7 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	s.RawImageName = rawImageName
+	s.RuntimeFlags = registry.PodmanConfig().RuntimeFlags
 	s.ImageOS = cliVals.OS
 	s.ImageArch = cliVals.Arch
 	s.ImageVariant = cliVals.Variant
@@ -217,6 +223,27 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// A scheduled container is only created here, the Podman service
+	// starts it each time its schedule is due.
+	if cliVals.Schedule != "" {
+		report, err := registry.ContainerEngine().ContainerCreate(registry.GetContext(), s)
+		if err != nil {
+			if err := rmPodIfNecessary(cmd, s); err != nil {
+				if !errors.Is(err, define.ErrNoSuchPod) {
+					logrus.Error(err.Error())
+				}
+			}
+			return err
+		}
+		if cliVals.CIDFile != "" {
+			if err := util.CreateIDFile(cliVals.CIDFile, report.Id); err != nil {
+				return err
+			}
+		}
+		fmt.Println(report.Id)
+		return nil
+	}
+
 	report, err := registry.ContainerEngine().ContainerRun(registry.GetContext(), runOpts)
 	// report.ExitCode is set by ContainerRun even it returns an error
 	if report != nil {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/utils"
@@ -64,6 +65,15 @@ func startFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&startOptions.All, "all", false, "Start all containers regardless of their state or configuration")
 
+	if !registry.IsRemote() {
+		flags.BoolVar(&startOptions.AllPreviouslyRunning, "all-previously-running", false, "Start the containers which were running before the host was shut down")
+		flags.BoolVar(&startOptions.Ordered, "ordered", false, "Start containers one at a time after their dependencies")
+
+		timeoutFlagName := "timeout"
+		flags.UintVarP(&startOptions.Timeout, timeoutFlagName, "t", 0, "Seconds to wait for each container to start when using --ordered")
+		_ = cmd.RegisterFlagCompletionFunc(timeoutFlagName, completion.AutocompleteNone)
+	}
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("sig-proxy")
 	}
@@ -84,6 +94,12 @@ func init() {
 }
 
 func validateStart(cmd *cobra.Command, args []string) error {
+	if startOptions.AllPreviouslyRunning {
+		if len(args) > 0 || startOptions.Latest || startOptions.All || len(filters) > 0 || startOptions.Attach {
+			return errors.New("--all-previously-running cannot be combined with containers or other selection options")
+		}
+		return nil
+	}
 	if len(args) == 0 && !startOptions.Latest && !startOptions.All && len(filters) < 1 {
 		return errors.New("start requires at least one argument")
 	}
@@ -102,6 +118,12 @@ func validateStart(cmd *cobra.Command, args []string) error {
 	if startOptions.Attach && startOptions.All {
 		return errors.New("you cannot start and attach all containers at once")
 	}
+	if startOptions.Attach && startOptions.Ordered {
+		return errors.New("--attach and --ordered cannot be used together")
+	}
+	if cmd.Flags().Changed("timeout") && !startOptions.Ordered {
+		return errors.New("--timeout can only be used with --ordered")
+	}
 	return nil
 }
 
@@ -135,10 +157,12 @@ func start(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	started := 0
 	for _, r := range responses {
 		switch {
 		case r.Err != nil:
 			errs = append(errs, r.Err)
+			continue
 		case startOptions.Attach:
 			// Implement the exitcode when the only one container is enabled attach
 			registry.SetExitCode(r.ExitCode)
@@ -147,6 +171,10 @@ func start(cmd *cobra.Command, args []string) error {
 		default:
 			fmt.Println(r.Id)
 		}
+		started++
+	}
+	if startOptions.Ordered || startOptions.AllPreviouslyRunning {
+		fmt.Fprintf(os.Stderr, "Started %d containers, %d failed\n", started, len(errs))
 	}
 	return errs.PrintErrors()
 }
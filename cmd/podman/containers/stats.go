@@ -150,6 +150,7 @@ func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 		"NetIO":         "NET IO",
 		"BlockIO":       "BLOCK IO",
 		"PIDS":          "PIDS",
+		"PIDsLimit":     "PIDS LIMIT",
 	})
 	if !statsOptions.NoReset {
 		tm.Clear()
@@ -0,0 +1,103 @@
+package containers
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	traceDescription = `Traces all processes in the jail of a running container with dtrace or ktrace.
+
+  With dtrace, the given probes are enabled for the processes of the container and the number of times each probe fires is counted per executable and function. By default, system calls are counted. With ktrace, the processes of the container are traced into a file on the host which can be read with kdump.
+
+  Tracing stops when the container exits, the duration has passed or on interrupt. This command is only supported on FreeBSD.`
+
+	traceCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "trace [options] CONTAINER",
+		Short:             "Trace the processes of a container",
+		Long:              traceDescription,
+		RunE:              trace,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman trace --duration 30s ctrID
+  podman trace --probe 'syscall::open*:entry' --probe 'syscall::read:entry' ctrID
+  podman trace --tool ktrace --output ctr.ktrace ctrID`,
+	}
+
+	containerTraceCommand = &cobra.Command{
+		Annotations:       traceCommand.Annotations,
+		Use:               traceCommand.Use,
+		Short:             traceCommand.Short,
+		Long:              traceCommand.Long,
+		RunE:              traceCommand.RunE,
+		Args:              traceCommand.Args,
+		ValidArgsFunction: traceCommand.ValidArgsFunction,
+		Example: `podman container trace --duration 30s ctrID
+  podman container trace --tool ktrace --output ctr.ktrace ctrID`,
+	}
+)
+
+var traceOptions entities.ContainerTraceOptions
+
+func traceFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	toolFlagName := "tool"
+	flags.StringVar(&traceOptions.Tool, toolFlagName, "dtrace", "Tracing tool to use (dtrace or ktrace)")
+	_ = cmd.RegisterFlagCompletionFunc(toolFlagName, common.AutocompleteTraceTool)
+
+	probeFlagName := "probe"
+	flags.StringArrayVar(&traceOptions.Probes, probeFlagName, []string{}, "DTrace probe description to enable (default syscall:::entry)")
+	_ = cmd.RegisterFlagCompletionFunc(probeFlagName, completion.AutocompleteNone)
+
+	durationFlagName := "duration"
+	flags.DurationVar(&traceOptions.Duration, durationFlagName, 0, "Stop tracing after the given duration")
+	_ = cmd.RegisterFlagCompletionFunc(durationFlagName, completion.AutocompleteNone)
+
+	outputFlagName := "output"
+	flags.StringVarP(&traceOptions.Output, outputFlagName, "o", "", "Write the trace to a file on the host (default: stdout for dtrace, ktrace.out for ktrace)")
+	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: traceCommand,
+	})
+	traceFlags(traceCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerTraceCommand,
+		Parent:  containerCmd,
+	})
+	traceFlags(containerTraceCommand)
+}
+
+func trace(cmd *cobra.Command, args []string) error {
+	if traceOptions.Tool == "ktrace" && traceOptions.Output == "" {
+		traceOptions.Output = "ktrace.out"
+	}
+	if traceOptions.Output != "" {
+		output, err := filepath.Abs(traceOptions.Output)
+		if err != nil {
+			return err
+		}
+		traceOptions.Output = output
+	}
+	traceOptions.Stdout = os.Stdout
+
+	// Stop tracing on interrupt, so that dtrace can print its results.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return registry.ContainerEngine().ContainerTrace(ctx, strings.TrimPrefix(args[0], "/"), traceOptions)
+}
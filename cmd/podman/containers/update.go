@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	updateDescription = `Updates the cgroup configuration of a given container`
+	updateDescription = `Updates the cgroup configuration or the labels of a given container`
 
 	updateCommand = &cobra.Command{
 		Use:               "update [options] CONTAINER",
@@ -38,12 +42,33 @@ var (
 	}
 )
 var (
-	updateOpts entities.ContainerCreateOptions
+	updateOpts        entities.ContainerCreateOptions
+	updateLabels      []string
+	updateUnsetLabels []string
+	updateMemoryLock  string
+	updateHostname    string
 )
 
 func updateFlags(cmd *cobra.Command) {
 	common.DefineCreateDefaults(&updateOpts)
 	common.DefineCreateFlags(cmd, &updateOpts, entities.UpdateMode)
+
+	flags := cmd.Flags()
+	labelFlagName := "label"
+	flags.StringArrayVarP(&updateLabels, labelFlagName, "l", []string{}, "Add or change a label of the container")
+	_ = cmd.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	labelRmFlagName := "label-rm"
+	flags.StringArrayVar(&updateUnsetLabels, labelRmFlagName, []string{}, "Remove a label from the container")
+	_ = cmd.RegisterFlagCompletionFunc(labelRmFlagName, completion.AutocompleteNone)
+
+	memoryLockFlagName := "memory-lock"
+	flags.StringVar(&updateMemoryLock, memoryLockFlagName, "", "Amount of memory the container may lock (experimental, FreeBSD only) (format: `<number>[<unit>]`, where unit = b (bytes), k (kibibytes), m (mebibytes), or g (gibibytes))")
+	_ = cmd.RegisterFlagCompletionFunc(memoryLockFlagName, completion.AutocompleteNone)
+
+	hostnameFlagName := "hostname"
+	flags.StringVar(&updateHostname, hostnameFlagName, "", "Change the hostname of the container")
+	_ = cmd.RegisterFlagCompletionFunc(hostnameFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -77,8 +102,33 @@ func update(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := &entities.ContainerUpdateOptions{
-		NameOrID: strings.TrimPrefix(args[0], "/"),
-		Specgen:  s,
+		NameOrID:    strings.TrimPrefix(args[0], "/"),
+		Specgen:     s,
+		UnsetLabels: updateUnsetLabels,
+		Hostname:    updateHostname,
+	}
+	if len(updateLabels) > 0 {
+		opts.Labels, err = parse.GetAllLabels([]string{}, updateLabels)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("memory-lock") {
+		memoryLock, err := units.RAMInBytes(updateMemoryLock)
+		if err != nil {
+			return fmt.Errorf("invalid value for memory lock: %w", err)
+		}
+		opts.MemoryLock = &memoryLock
+	}
+	// Only update the resource limits if any of their flags were given.
+	updateResources := false
+	cmd.LocalFlags().Visit(func(f *pflag.Flag) {
+		if f.Name != "label" && f.Name != "label-rm" && f.Name != "memory-lock" && f.Name != "hostname" {
+			updateResources = true
+		}
+	})
+	if !updateResources && (len(opts.Labels) > 0 || len(opts.UnsetLabels) > 0 || opts.MemoryLock != nil || opts.Hostname != "") {
+		opts.Specgen = nil
 	}
 	rep, err := registry.ContainerEngine().ContainerUpdate(context.Background(), opts)
 	if err != nil {
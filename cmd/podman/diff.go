@@ -40,6 +40,9 @@ func init() {
 	flags.StringVar(&diffOpts.Format, formatFlagName, "", "Change the output format (json)")
 	_ = diffCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
 
+	fileFlagsFlagName := "file-flags"
+	flags.BoolVar(&diffOpts.FileFlags, fileFlagsFlagName, false, "Also report changes to file flags (FreeBSD only)")
+
 	validate.AddLatestFlag(diffCmd, &diffOpts.Latest)
 }
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/containers/common/pkg/report"
 	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/storage/pkg/archive"
 	"github.com/spf13/cobra"
@@ -30,9 +31,10 @@ func Diff(_ *cobra.Command, args []string, options entities.DiffOptions) error {
 }
 
 type ChangesReportJSON struct {
-	Changed []string `json:"changed,omitempty"`
-	Added   []string `json:"added,omitempty"`
-	Deleted []string `json:"deleted,omitempty"`
+	Changed []string                `json:"changed,omitempty"`
+	Added   []string                `json:"added,omitempty"`
+	Deleted []string                `json:"deleted,omitempty"`
+	Flags   []define.FileFlagChange `json:"flags,omitempty"`
 }
 
 func changesToJSON(diffs *entities.DiffReport) error {
@@ -49,6 +51,7 @@ func changesToJSON(diffs *entities.DiffReport) error {
 			return fmt.Errorf("output kind %q not recognized", row.Kind)
 		}
 	}
+	body.Flags = diffs.FlagChanges
 
 	// Pull in configured json library
 	enc := json.NewEncoder(os.Stdout)
@@ -60,6 +63,9 @@ func changesToTable(diffs *entities.DiffReport) error {
 	for _, row := range diffs.Changes {
 		fmt.Fprintln(os.Stdout, row.String())
 	}
+	for _, row := range diffs.FlagChanges {
+		fmt.Fprintln(os.Stdout, row.String())
+	}
 	return nil
 }
 
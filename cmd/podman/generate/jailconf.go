@@ -0,0 +1,61 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jailConfDescription = `Generate a jail.conf(5) stanza equivalent to the jail Podman creates for a container.
+
+  The output can be used to review the jail parameters of a container or to start the container manually with jail(8).`
+	jailConfCmd = &cobra.Command{
+		Use:               "jail-conf [options] CONTAINER",
+		Short:             "Generate a jail.conf stanza for a container",
+		Long:              jailConfDescription,
+		RunE:              jailConf,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example:           `podman generate jail-conf ctrID`,
+	}
+)
+
+var (
+	jailConfOpts = &entities.GenerateJailConfOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: jailConfCmd,
+		Parent:  GenerateCmd,
+	})
+	flags := jailConfCmd.Flags()
+
+	filenameFlagName := "filename"
+	flags.StringVarP(&jailConfOpts.FileName, filenameFlagName, "f", "", "Write output to the specified path")
+	_ = jailConfCmd.RegisterFlagCompletionFunc(filenameFlagName, completion.AutocompleteDefault)
+}
+
+func jailConf(cmd *cobra.Command, args []string) error {
+	jailConfOpts.ID = args[0]
+	report, err := registry.ContainerEngine().GenerateJailConf(registry.GetContext(), jailConfOpts)
+	if err != nil {
+		return err
+	}
+
+	if len(jailConfOpts.FileName) > 0 {
+		if err := os.WriteFile(jailConfOpts.FileName, report.Data, 0o600); err != nil {
+			return err
+		}
+		fmt.Println(jailConfOpts.FileName)
+	} else {
+		fmt.Print(string(report.Data))
+	}
+	return nil
+}
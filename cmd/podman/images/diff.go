@@ -39,6 +39,9 @@ func diffFlags(flags *pflag.FlagSet) {
 	formatFlagName := "format"
 	flags.StringVar(&diffOpts.Format, formatFlagName, "", "Change the output format (json)")
 	_ = diffCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+
+	fileFlagsFlagName := "file-flags"
+	flags.BoolVar(&diffOpts.FileFlags, fileFlagsFlagName, false, "Also report changes to file flags (FreeBSD only)")
 }
 
 func diffRun(cmd *cobra.Command, args []string) error {
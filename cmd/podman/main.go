@@ -19,6 +19,7 @@ import (
 	_ "github.com/containers/podman/v5/cmd/podman/networks"
 	_ "github.com/containers/podman/v5/cmd/podman/pods"
 	"github.com/containers/podman/v5/cmd/podman/registry"
+	_ "github.com/containers/podman/v5/cmd/podman/schedule"
 	_ "github.com/containers/podman/v5/cmd/podman/secrets"
 	_ "github.com/containers/podman/v5/cmd/podman/system"
 	_ "github.com/containers/podman/v5/cmd/podman/system/connection"
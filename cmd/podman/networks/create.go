@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strconv"
 	"strings"
 
 	"github.com/containers/common/libnetwork/types"
@@ -183,7 +182,7 @@ func networkCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	for i := range networkCreateOptions.Routes {
-		route, err := parseRoute(networkCreateOptions.Routes[i])
+		route, err := parse.Route(networkCreateOptions.Routes[i])
 
 		if err != nil {
 			return err
@@ -204,46 +203,6 @@ func networkCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func parseRoute(routeStr string) (*types.Route, error) {
-	s := strings.Split(routeStr, ",")
-	var metric *uint32
-
-	if len(s) == 2 || len(s) == 3 {
-		dstStr := s[0]
-		gwStr := s[1]
-
-		destination, err := types.ParseCIDR(dstStr)
-		gateway := net.ParseIP(gwStr)
-
-		if err != nil {
-			return nil, fmt.Errorf("invalid route destination %s", dstStr)
-		}
-
-		if gateway == nil {
-			return nil, fmt.Errorf("invalid route gateway %s", gwStr)
-		}
-
-		if len(s) == 3 {
-			mtr, err := strconv.ParseUint(s[2], 10, 32)
-
-			if err != nil {
-				return nil, fmt.Errorf("invalid route metric %s", s[2])
-			}
-			x := uint32(mtr)
-			metric = &x
-		}
-
-		r := types.Route{
-			Destination: destination,
-			Gateway:     gateway,
-			Metric:      metric,
-		}
-
-		return &r, nil
-	}
-	return nil, fmt.Errorf("invalid route: %s\nFormat: --route <destination in CIDR>,<gateway>,<metric (optional)>", routeStr)
-}
-
 func parseRange(iprange string) (*types.LeaseRange, error) {
 	startIPString, endIPString, hasDash := strings.Cut(iprange, "-")
 	if hasDash {
@@ -21,11 +21,14 @@ var (
 		Long:        networkReloadDescription,
 		RunE:        networkReload,
 		Args: func(cmd *cobra.Command, args []string) error {
-			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
+			// --cleanup is enough on its own, containers are optional
+			cleanup, _ := cmd.Flags().GetBool("cleanup")
+			return validate.CheckAllLatestAndIDFile(cmd, args, cleanup, "")
 		},
 		ValidArgsFunction: common.AutocompleteContainers,
 		Example: `podman network reload 3c13ef6dd843
-  podman network reload test1 test2`,
+  podman network reload test1 test2
+  podman network reload --cleanup`,
 	}
 )
 
@@ -35,6 +38,7 @@ var (
 
 func reloadFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&reloadOptions.All, "all", "a", false, "Reload network configuration of all containers")
+	flags.BoolVar(&reloadOptions.Cleanup, "cleanup", false, "Remove the network jails and firewall anchors left behind by containers which did not clean up their network")
 }
 
 func init() {
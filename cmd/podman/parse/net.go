@@ -8,9 +8,11 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containers/common/libnetwork/etchosts"
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/storage/pkg/regexp"
 )
 
@@ -148,6 +150,48 @@ func parseEnvOrLabelFile(envOrLabel map[string]string, filename, configType stri
 	return scanner.Err()
 }
 
+// Route parses a static route in the form
+// <destination in CIDR>,<gateway>[,<metric>].
+func Route(routeStr string) (*types.Route, error) {
+	s := strings.Split(routeStr, ",")
+	var metric *uint32
+
+	if len(s) == 2 || len(s) == 3 {
+		dstStr := s[0]
+		gwStr := s[1]
+
+		destination, err := types.ParseCIDR(dstStr)
+		gateway := net.ParseIP(gwStr)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid route destination %s", dstStr)
+		}
+
+		if gateway == nil {
+			return nil, fmt.Errorf("invalid route gateway %s", gwStr)
+		}
+
+		if len(s) == 3 {
+			mtr, err := strconv.ParseUint(s[2], 10, 32)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid route metric %s", s[2])
+			}
+			x := uint32(mtr)
+			metric = &x
+		}
+
+		r := types.Route{
+			Destination: destination,
+			Gateway:     gateway,
+			Metric:      metric,
+		}
+
+		return &r, nil
+	}
+	return nil, fmt.Errorf("invalid route: %s\nFormat: --route <destination in CIDR>,<gateway>,<metric (optional)>", routeStr)
+}
+
 // ValidURL checks a string urlStr is a url or not
 func ValidURL(urlStr string) error {
 	url, err := url.ParseRequestURI(urlStr)
@@ -150,3 +150,23 @@ func TestGetAllLabelsFile(t *testing.T) {
 	result, _ := GetAllLabels(fileLabels, Var1)
 	assert.Equal(t, len(result), 3)
 }
+
+func TestRoute(t *testing.T) {
+	route, err := Route("10.10.0.0/16,192.168.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.10.0.0/16", route.Destination.String())
+	assert.Equal(t, "192.168.1.1", route.Gateway.String())
+	assert.Nil(t, route.Metric)
+
+	route, err = Route("fd00:1::/64,fd00::1,100")
+	assert.NoError(t, err)
+	assert.Equal(t, "fd00:1::/64", route.Destination.String())
+	if assert.NotNil(t, route.Metric) {
+		assert.Equal(t, uint32(100), *route.Metric)
+	}
+
+	for _, bad := range []string{"10.10.0.0/16", "10.10.0.0/16,gateway", "10.10.0.0,192.168.1.1", "10.10.0.0/16,192.168.1.1,-1", "a,b,c,d"} {
+		_, err := Route(bad)
+		assert.Error(t, err, bad)
+	}
+}
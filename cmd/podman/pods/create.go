@@ -79,6 +79,10 @@ func init() {
 	flags.StringVarP(&createOptions.ExitPolicy, policyFlag, "", string(containerConfig.Engine.PodExitPolicy), "Behaviour when the last container exits")
 	_ = createCommand.RegisterFlagCompletionFunc(policyFlag, common.AutocompletePodExitPolicy)
 
+	cpuPlacementFlagName := "cpu-placement"
+	flags.StringVar(&createOptions.CPUPlacement, cpuPlacementFlagName, "", "Choose the pod's cpuset to share as few CPUs with other pods as possible (domain, cpus)")
+	_ = createCommand.RegisterFlagCompletionFunc(cpuPlacementFlagName, common.AutocompletePodCPUPlacement)
+
 	infraImageFlagName := "infra-image"
 	var defInfraImage string
 	if !registry.IsRemote() {
@@ -13,6 +13,7 @@ import (
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/report"
 	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/containers"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/utils"
 	"github.com/containers/podman/v5/cmd/podman/validate"
@@ -149,6 +150,7 @@ func pods(cmd *cobra.Command, _ []string) error {
 			"Cgroup":             "CGROUP",
 			"Namespace":          "NAMESPACES",
 			"Restarts":           "RESTARTS",
+			"Ports":              "PORTS",
 		})
 
 		if err := rpt.Execute(headers); err != nil {
@@ -209,6 +211,11 @@ func (l ListPodReporter) Networks() string {
 	return strings.Join(l.ListPodsReport.Networks, ",")
 }
 
+// Ports returns the ports published by the pod in string format
+func (l ListPodReporter) Ports() string {
+	return containers.PortsToString(l.ListPodsReport.Ports)
+}
+
 // NumberOfContainers returns an int representation for
 // the number of containers belonging to the pod
 func (l ListPodReporter) NumberOfContainers() int {
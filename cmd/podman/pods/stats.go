@@ -50,6 +50,7 @@ func init() {
 
 	flags := statsCmd.Flags()
 	flags.BoolVarP(&statsOptions.All, "all", "a", false, "Provide stats for all pods")
+	flags.BoolVar(&statsOptions.Aggregate, "aggregate", false, "Provide one set of stats per pod, summed over its containers")
 
 	formatFlagName := "format"
 	flags.StringVar(&statsOptions.Format, formatFlagName, "", "Pretty-print container statistics to JSON or using a Go template")
@@ -0,0 +1,138 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/schedule"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lsCmd = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Short:             "List scheduled containers",
+		RunE:              ls,
+		Example:           "podman schedule ls",
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+// listReport describes a scheduled container.
+type listReport struct {
+	ID       string
+	Names    string
+	Schedule string
+	NextRun  string
+	LastRun  string
+	State    string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: lsCmd,
+		Parent:  scheduleCmd,
+	})
+
+	flags := lsCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.ID}}\t{{.Names}}\t{{.Schedule}}\t{{.NextRun}}\t{{.LastRun}}\t{{.State}}\n{{end -}}", "Format scheduled container output using Go template")
+	_ = lsCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&listReport{}))
+
+	noHeadingFlagName := "noheading"
+	flags.BoolVarP(&listFlag.noHeading, noHeadingFlagName, "n", false, "Do not print headers")
+
+	quietFlagName := "quiet"
+	flags.BoolVarP(&listFlag.quiet, quietFlagName, "q", false, "Print container IDs only")
+}
+
+// listScheduled returns the containers which have a schedule.
+func listScheduled() ([]entities.ListContainer, error) {
+	return registry.ContainerEngine().ContainerList(registry.Context(), entities.ContainerListOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {schedule.Label}},
+	})
+}
+
+func ls(cmd *cobra.Command, args []string) error {
+	ctrs, err := listScheduled()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	listed := make([]*listReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		expr := ctr.Labels[schedule.Label]
+		r := &listReport{
+			ID:       ctr.ID[:12],
+			Schedule: expr,
+			NextRun:  "never",
+			LastRun:  "never",
+			State:    ctr.State,
+		}
+		if len(ctr.Names) > 0 {
+			r.Names = ctr.Names[0]
+		}
+		if s, err := schedule.Parse(expr); err != nil {
+			r.NextRun = "invalid schedule"
+		} else if next := s.Next(now); !next.IsZero() {
+			r.NextRun = next.Format(time.DateTime)
+		}
+		if ctr.StartedAt > 0 {
+			r.LastRun = units.HumanDuration(now.Sub(time.Unix(ctr.StartedAt, 0))) + " ago"
+		}
+		listed = append(listed, r)
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, r := range listed {
+			fmt.Println(r.ID)
+		}
+		return nil
+	}
+
+	headers := report.Headers(listReport{}, map[string]string{
+		"ID":      "CONTAINER ID",
+		"NextRun": "NEXT RUN",
+		"LastRun": "LAST RUN",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(listed)
+}
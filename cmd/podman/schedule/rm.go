@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmCmd = &cobra.Command{
+		Use:               "rm [options] CONTAINER [CONTAINER...]",
+		Short:             "Remove one or more scheduled containers",
+		Long:              "Remove scheduled containers, so that they are no longer run",
+		RunE:              rm,
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example:           "podman schedule rm backup",
+	}
+	rmOptions = entities.RmOptions{}
+	rmAll     bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  scheduleCmd,
+	})
+	flags := rmCmd.Flags()
+	flags.BoolVarP(&rmAll, "all", "a", false, "Remove all scheduled containers")
+	flags.BoolVarP(&rmOptions.Force, "force", "f", false, "Stop and remove scheduled containers which are running")
+}
+
+func rm(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if (len(args) > 0 && rmAll) || (len(args) < 1 && !rmAll) {
+		return errors.New("`podman schedule rm` requires one argument, or the --all flag")
+	}
+
+	ctrs, err := listScheduled()
+	if err != nil {
+		return err
+	}
+	var ids []string
+	if rmAll {
+		for _, ctr := range ctrs {
+			ids = append(ids, ctr.ID)
+		}
+	} else {
+		// Only remove containers which are scheduled.
+	args:
+		for _, arg := range args {
+			for _, ctr := range ctrs {
+				if strings.HasPrefix(ctr.ID, arg) || (len(ctr.Names) > 0 && ctr.Names[0] == arg) {
+					ids = append(ids, ctr.ID)
+					continue args
+				}
+			}
+			errs = append(errs, fmt.Errorf("no scheduled container with name or ID %q found", arg))
+		}
+	}
+	if len(ids) == 0 {
+		return errs.PrintErrors()
+	}
+
+	responses, err := registry.ContainerEngine().ContainerRm(registry.Context(), ids, rmOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.RawInput)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
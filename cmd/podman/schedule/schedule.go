@@ -0,0 +1,23 @@
+package schedule
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _schedule_
+	scheduleCmd = &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage scheduled containers",
+		Long:  "Manage containers created with --schedule, which the Podman service runs periodically",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: scheduleCmd,
+	})
+}
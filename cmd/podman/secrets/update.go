@@ -0,0 +1,52 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateCmd = &cobra.Command{
+		Use:   "update [options] SECRET FILE|-",
+		Short: "Update the data of a secret",
+		Long:  "Replace the data of an existing secret, keeping its name, driver and labels. Containers using the secret as an environment variable receive the new data when they are next started.",
+		RunE:  update,
+		Args:  cobra.ExactArgs(2),
+		Example: `podman secret update mysecret /path/to/secret
+		printf "secretdata" | podman secret update mysecret -`,
+		ValidArgsFunction: common.AutocompleteSecretUpdate,
+	}
+)
+
+var (
+	updateEnv = false
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCmd,
+		Parent:  secretCmd,
+	})
+
+	flags := updateCmd.Flags()
+	flags.BoolVar(&updateEnv, "env", false, "Read secret data from environment variable")
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	reader, err := openSecretData(args[1], updateEnv)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	report, err := registry.ContainerEngine().SecretUpdate(context.Background(), args[0], reader)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ID)
+	return nil
+}
@@ -47,7 +47,9 @@ func init() {
 	flags := pruneCommand.Flags()
 	flags.BoolVarP(&force, "force", "f", false, "Do not prompt for confirmation.  The default is false")
 	flags.BoolVarP(&pruneOptions.All, "all", "a", false, "Remove all unused data")
+	flags.BoolVar(&pruneOptions.DryRun, "dry-run", false, "List resources left behind by removed containers without removing anything")
 	flags.BoolVar(&pruneOptions.External, "external", false, "Remove container data in storage not controlled by podman")
+	flags.BoolVar(&pruneOptions.Orphans, "orphans", false, "Remove resources left behind on the host by removed containers")
 	flags.BoolVar(&pruneOptions.Volume, "volumes", false, "Prune volumes")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filters, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
@@ -56,6 +58,14 @@ func init() {
 
 func prune(cmd *cobra.Command, args []string) error {
 	var err error
+	if pruneOptions.DryRun {
+		response, err := registry.ContainerEngine().SystemPrune(context.Background(), pruneOptions)
+		if err != nil {
+			return err
+		}
+		return utils.PrintOrphanPruneResults(response.OrphanPruneReports, false)
+	}
+
 	// Prompt for confirmation if --force is not set, unless --external
 	if !force && !pruneOptions.External {
 		reader := bufio.NewReader(os.Stdin)
@@ -64,6 +74,10 @@ func prune(cmd *cobra.Command, args []string) error {
 			volumeString = `
 	- all volumes not used by at least one container`
 		}
+		if pruneOptions.Orphans {
+			volumeString += `
+	- all jails, pf anchors and runtime directories left behind by removed containers`
+		}
 
 		fmt.Printf(createPruneWarningMessage(pruneOptions), volumeString, "Are you sure you want to continue? [y/N] ")
 
@@ -113,6 +127,11 @@ func prune(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	// Print orphaned resources prune results
+	err = utils.PrintOrphanPruneResults(response.OrphanPruneReports, true)
+	if err != nil {
+		return err
+	}
 
 	if !pruneOptions.External {
 		fmt.Printf("Total reclaimed space: %s\n", units.HumanSize((float64)(response.ReclaimedSpace)))
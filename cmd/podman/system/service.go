@@ -40,9 +40,11 @@ Enable a listening service for API access to Podman commands.
 	}
 
 	srvArgs = struct {
-		CorsHeaders string
-		PProfAddr   string
-		Timeout     uint
+		CorsHeaders           string
+		FirewallWatchInterval time.Duration
+		MetricsAddr           string
+		PProfAddr             string
+		Timeout               uint
 	}{}
 )
 
@@ -64,6 +66,16 @@ func init() {
 	flags.StringVarP(&srvArgs.CorsHeaders, "cors", "", "", "Set CORS Headers")
 	_ = srvCmd.RegisterFlagCompletionFunc("cors", completion.AutocompleteNone)
 
+	firewallWatchIntervalFlagName := "firewall-watch-interval"
+	flags.DurationVar(&srvArgs.FirewallWatchInterval, firewallWatchIntervalFlagName, 0,
+		"Interval between checks for flushed container firewall rules, which are then reloaded.  Use 0 to disable the checks")
+	_ = srvCmd.RegisterFlagCompletionFunc(firewallWatchIntervalFlagName, completion.AutocompleteNone)
+
+	metricsAddressFlagName := "metrics-address"
+	flags.StringVarP(&srvArgs.MetricsAddr, metricsAddressFlagName, "", "",
+		"Binding network address for the Prometheus metrics endpoint, default: do not expose metrics")
+	_ = srvCmd.RegisterFlagCompletionFunc(metricsAddressFlagName, completion.AutocompleteNone)
+
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
@@ -100,10 +112,12 @@ func service(cmd *cobra.Command, args []string) error {
 	}
 
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
-		CorsHeaders: srvArgs.CorsHeaders,
-		PProfAddr:   srvArgs.PProfAddr,
-		Timeout:     time.Duration(srvArgs.Timeout) * time.Second,
-		URI:         apiURI,
+		CorsHeaders:           srvArgs.CorsHeaders,
+		FirewallWatchInterval: srvArgs.FirewallWatchInterval,
+		MetricsAddr:           srvArgs.MetricsAddr,
+		PProfAddr:             srvArgs.PProfAddr,
+		Timeout:               time.Duration(srvArgs.Timeout) * time.Second,
+		URI:                   apiURI,
 	})
 }
 
@@ -125,6 +125,12 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 
 	maybeStartServiceReaper()
 	infra.StartWatcher(libpodRuntime)
+	infra.StartScheduler(registry.Context(), libpodRuntime, opts.Timeout)
+	if opts.FirewallWatchInterval > 0 {
+		if err := infra.StartFirewallWatcher(registry.Context(), libpodRuntime, opts.FirewallWatchInterval); err != nil {
+			return err
+		}
+	}
 	server, err := api.NewServerWithSettings(libpodRuntime, listener, opts)
 	if err != nil {
 		return err
@@ -104,6 +104,24 @@ func PrintNetworkPruneResults(networkPruneReport []*entities.NetworkPruneReport,
 	return errs.PrintErrors()
 }
 
+// PrintOrphanPruneResults prints the host resources left behind by removed
+// containers. With heading set they are listed as deleted, otherwise as only
+// found.
+func PrintOrphanPruneResults(orphanPruneReports []*entities.OrphanPruneReport, heading bool) error {
+	var errs OutputErrors
+	if heading && len(orphanPruneReports) > 0 {
+		fmt.Println("Deleted Orphaned Resources")
+	}
+	for _, r := range orphanPruneReports {
+		if r.Err == nil {
+			fmt.Printf("%s %s\n", r.Kind, r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
+
 // IsCheckpointImage returns true with no error only if all values in
 // namesOrIDs correspond to checkpoint images AND these images are
 // compatible with the container runtime that is currently in use,
@@ -0,0 +1,123 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"github.com/containers/common/pkg/chown"
+)
+
+// chownRecursive changes the ownership of path and everything below it to
+// uid and gid, without following symlinks. Directories are walked by a
+// bounded number of goroutines. Only entries whose ownership differs are
+// changed, so running it again over a tree which already has the ownership
+// only reads the tree.
+func chownRecursive(path string, uid, gid int) error {
+	isDangerous, err := chown.DangerousHostPath(path)
+	if err != nil {
+		return fmt.Errorf("failed to validate if host path is dangerous: %w", err)
+	}
+	if isDangerous {
+		return fmt.Errorf("chowning host path %q is not allowed. You can manually `chown -R %d:%d %s`", path, uid, gid, path)
+	}
+
+	st, err := os.Lstat(path)
+	if err != nil {
+		return fmt.Errorf("failed to get host path information: %w", err)
+	}
+	w := &chownWalker{
+		uid: uid,
+		gid: gid,
+		sem: make(chan struct{}, runtime.NumCPU()),
+	}
+	if err := w.chown(path, st); err != nil {
+		return err
+	}
+	if st.IsDir() {
+		w.walk(path)
+		w.wg.Wait()
+	}
+	if w.err != nil {
+		return fmt.Errorf("failed to chown recursively host path: %w", w.err)
+	}
+	return nil
+}
+
+type chownWalker struct {
+	uid, gid int
+	// sem limits the number of goroutines walking directories.
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	lock sync.Mutex
+	err  error
+}
+
+func (w *chownWalker) setErr(err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *chownWalker) failed() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err != nil
+}
+
+func (w *chownWalker) chown(path string, info os.FileInfo) error {
+	st := info.Sys().(*syscall.Stat_t)
+	if int(st.Uid) == w.uid && int(st.Gid) == w.gid {
+		return nil
+	}
+	return os.Lchown(path, w.uid, w.gid)
+}
+
+// walk changes the ownership of the entries of dir and descends into its
+// subdirectories, in a new goroutine if one is available.
+func (w *chownWalker) walk(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		w.setErr(err)
+		return
+	}
+	for _, entry := range entries {
+		if w.failed() {
+			return
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			w.setErr(err)
+			return
+		}
+		if err := w.chown(path, info); err != nil {
+			w.setErr(err)
+			return
+		}
+		if !entry.IsDir() {
+			continue
+		}
+		select {
+		case w.sem <- struct{}{}:
+			w.wg.Add(1)
+			go func() {
+				defer func() {
+					<-w.sem
+					w.wg.Done()
+				}()
+				w.walk(path)
+			}()
+		default:
+			w.walk(path)
+		}
+	}
+}
@@ -107,6 +107,12 @@ type Container struct {
 	// Functions called on a batched container will not lock or sync
 	batched bool
 
+	// saveDeferred is set while the container is being prepared and
+	// initialized for a start. Calls to save() only set stateDirty, and
+	// the state is written once when the start-time setup is done.
+	saveDeferred bool
+	stateDirty   bool
+
 	valid      bool
 	lock       lock.Locker
 	runtime    *Runtime
@@ -129,6 +135,15 @@ type Container struct {
 
 	slirp4netnsSubnet *net.IPNet
 	pastaResult       *pasta.SetupResult
+
+	// startTiming records the duration of the phases of starting the
+	// container while it is being started.
+	startTiming *startTiming
+
+	// releasedNetworkJail is the name of the FreeBSD network jail released
+	// while cleaning up the container's network, which is reaped once the
+	// container has been removed from the runtime.
+	releasedNetworkJail string
 }
 
 // ContainerState contains the current state of the container
@@ -175,6 +190,15 @@ type ContainerState struct {
 	LegacyExecSessions map[string]*legacyExecSession `json:"execSessions,omitempty"`
 	// NetNS is the path or name of the NetNS
 	NetNS string `json:"netns,omitempty"`
+	// JID is the ID of the jail of a running container. This is only
+	// used on FreeBSD.
+	JID int `json:"jid,omitempty"`
+	// NetJID is the ID of the vnet jail named by NetNS. This is only used
+	// on FreeBSD.
+	NetJID int `json:"netJid,omitempty"`
+	// PausedPIDs are the processes of a paused container which were
+	// stopped when pausing it. This is only used on FreeBSD.
+	PausedPIDs []int `json:"pausedPids,omitempty"`
 	// NetworkStatus contains the network Status for all networks
 	// the container is attached to. Only populated if we created a network
 	// namespace for the container, and the network namespace is currently
@@ -191,6 +215,10 @@ type ContainerState struct {
 	// StoppedByUser indicates whether the container was stopped by an
 	// explicit call to the Stop() API.
 	StoppedByUser bool `json:"stoppedByUser,omitempty"`
+	// PreviouslyRunning indicates whether the container was running when
+	// the running containers were last recorded before a shutdown of the
+	// host. It is cleared when the container is started.
+	PreviouslyRunning bool `json:"previouslyRunning,omitempty"`
 	// RestartPolicyMatch indicates whether the conditions for restart
 	// policy have been met.
 	RestartPolicyMatch bool `json:"restartPolicyMatch,omitempty"`
@@ -289,6 +317,10 @@ type ContainerSecret struct {
 	Mode uint32
 	// Secret target inside container
 	Target string
+	// Ephemeral secrets are not copied to the container's static dir when
+	// it is created. Their data is looked up every time the container is
+	// started and written to its run directory instead.
+	Ephemeral bool
 }
 
 // ContainerNetworkDescriptions describes the relationship between the CNI
@@ -753,6 +785,24 @@ func (c *Container) RestartCount() (uint, error) {
 	return c.state.RestartCount, nil
 }
 
+// ProcessCounts returns the number of open file descriptors and threads
+// across all processes in the container. Both are zero if the container is
+// not running or paused.
+func (c *Container) ProcessCounts() (openFiles, threads uint64, err error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
+		return 0, 0, nil
+	}
+	return c.getProcessCounts()
+}
+
 // Mounted returns whether the container is mounted and the path it is mounted
 // at (if it is mounted).
 // If the container is not mounted, no error is returned, and the mountpoint
@@ -853,6 +903,21 @@ func (c *Container) PID() (int, error) {
 	return c.state.PID, nil
 }
 
+// JailIDs returns the IDs of the container's jail and of its network jail.
+// Both are 0 if the container is not running or on platforms without jails.
+func (c *Container) JailIDs() (jid, netJID int, err error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return c.state.JID, c.state.NetJID, nil
+}
+
 // ConmonPID Returns the PID of the container's conmon process.
 // If the container is not running, a PID of 0 will be returned. No error will
 // occur.
@@ -979,6 +1044,21 @@ func (c *Container) StoppedByUser() (bool, error) {
 	return c.state.StoppedByUser, nil
 }
 
+// PreviouslyRunning returns whether the container was running before the
+// host was shut down and has not been started since.
+func (c *Container) PreviouslyRunning() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.state.PreviouslyRunning, nil
+}
+
 // StartupHCPassed returns whether the container's startup healthcheck passed.
 func (c *Container) StartupHCPassed() (bool, error) {
 	if !c.batched {
@@ -56,20 +56,22 @@ func (c *Container) Init(ctx context.Context, recursive bool) error {
 		}
 	}
 
-	if err := c.prepare(); err != nil {
-		if err2 := c.cleanup(ctx); err2 != nil {
-			logrus.Errorf("Cleaning up container %s: %v", c.ID(), err2)
+	return c.batchSaves(func() error {
+		if err := c.prepare(); err != nil {
+			if err2 := c.cleanup(ctx); err2 != nil {
+				logrus.Errorf("Cleaning up container %s: %v", c.ID(), err2)
+			}
+			return err
 		}
-		return err
-	}
 
-	if c.state.State == define.ContainerStateStopped {
-		// Reinitialize the container
-		return c.reinit(ctx, false)
-	}
+		if c.state.State == define.ContainerStateStopped {
+			// Reinitialize the container
+			return c.reinit(ctx, false)
+		}
 
-	// Initialize the container for the first time
-	return c.init(ctx, false)
+		// Initialize the container for the first time
+		return c.init(ctx, false)
+	})
 }
 
 // Start starts the given container.
@@ -127,6 +129,138 @@ func (c *Container) Update(res *spec.LinuxResources) error {
 	return c.update(res)
 }
 
+// UpdateLabels changes the labels of the container. The labels in remove are
+// removed first, then the labels in add are added or replace existing labels.
+// The new labels are persisted in the database and, if the container is
+// running, written to its metadata file.
+func (c *Container) UpdateLabels(add map[string]string, remove []string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	// Pull the latest config from the database, it may have been
+	// rewritten by another process.
+	newConf, err := c.runtime.state.GetContainerConfig(c.ID())
+	if err != nil {
+		return fmt.Errorf("retrieving container %s configuration from DB: %w", c.ID(), err)
+	}
+	labels := make(map[string]string, len(newConf.Labels)+len(add))
+	for key, value := range newConf.Labels {
+		labels[key] = value
+	}
+	for _, key := range remove {
+		delete(labels, key)
+	}
+	for key, value := range add {
+		labels[key] = value
+	}
+	newConf.Labels = labels
+
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", newConf); err != nil {
+		return fmt.Errorf("updating labels of container %s: %w", c.ID(), err)
+	}
+	c.config = newConf
+
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := c.updateMetadataFile(); err != nil {
+			return err
+		}
+	}
+
+	c.newContainerEvent(events.Update)
+	return nil
+}
+
+// UpdateMemoryLock changes the amount of memory in bytes the container may
+// lock. The new value is persisted in the database and, if the container is
+// running, applied to it.
+func (c *Container) UpdateMemoryLock(size int64) error {
+	if size < 0 {
+		return fmt.Errorf("memory lock cannot be negative: %w", define.ErrInvalidArg)
+	}
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	// Pull the latest config from the database, it may have been
+	// rewritten by another process.
+	newConf, err := c.runtime.state.GetContainerConfig(c.ID())
+	if err != nil {
+		return fmt.Errorf("retrieving container %s configuration from DB: %w", c.ID(), err)
+	}
+	oldConf := c.config
+	newConf.MemoryLock = size
+	c.config = newConf
+
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := c.updateMemoryLock(); err != nil {
+			c.config = oldConf
+			return err
+		}
+	}
+
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", newConf); err != nil {
+		return fmt.Errorf("updating memory lock of container %s: %w", c.ID(), err)
+	}
+
+	c.newContainerEvent(events.Update)
+	return nil
+}
+
+// UpdateHostname changes the hostname of the container. The new hostname is
+// persisted in the database and used from the next start of the container.
+// If the container is running, its /etc/hostname is updated as well and,
+// where the platform allows it, the hostname of the running container.
+func (c *Container) UpdateHostname(hostname string) error {
+	if !define.NameRegex.MatchString(hostname) {
+		return fmt.Errorf("invalid hostname %q: %w", hostname, define.ErrInvalidArg)
+	}
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.config.UTSNsCtr != "" || !c.hasPrivateUTS() {
+		return fmt.Errorf("container %s does not have a private UTS namespace, its hostname cannot be changed: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	// Pull the latest config from the database, it may have been
+	// rewritten by another process.
+	newConf, err := c.runtime.state.GetContainerConfig(c.ID())
+	if err != nil {
+		return fmt.Errorf("retrieving container %s configuration from DB: %w", c.ID(), err)
+	}
+	setConfigHostname(newConf, hostname)
+
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", newConf); err != nil {
+		return fmt.Errorf("updating hostname of container %s: %w", c.ID(), err)
+	}
+	c.config = newConf
+
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := c.updateRunningHostname(); err != nil {
+			return err
+		}
+	}
+
+	c.newContainerEvent(events.Update)
+	return nil
+}
+
 // StartAndAttach starts a container and attaches to it.
 // This acts as a combination of the Start and Attach APIs, ensuring proper
 // ordering of the two such that no output from the container is lost (e.g. the
@@ -173,7 +307,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 	go func() {
 		// Start resizing
 		if c.LogDriver() != define.PassthroughLogging && c.LogDriver() != define.PassthroughTTYLogging {
-			registerResizeFunc(resize, c.bundlePath())
+			registerResizeFunc(c, resize, c.bundlePath())
 		}
 
 		opts := new(AttachOptions)
@@ -340,7 +474,7 @@ func (c *Container) Attach(streams *define.AttachStreams, keys string, resize <-
 
 	// Start resizing
 	if c.LogDriver() != define.PassthroughLogging && c.LogDriver() != define.PassthroughTTYLogging {
-		registerResizeFunc(resize, c.bundlePath())
+		registerResizeFunc(c, resize, c.bundlePath())
 	}
 
 	opts := new(AttachOptions)
@@ -1020,6 +1154,9 @@ type ContainerCheckpointOptions struct {
 	// FileLocks tells the API to checkpoint/restore a container
 	// with file-locks
 	FileLocks bool
+	// TCPClose tells the API to restore a container with established
+	// TCP connections but close them
+	TCPClose bool
 }
 
 // Checkpoint checkpoints a container
@@ -1072,8 +1209,10 @@ func (c *Container) Restore(ctx context.Context, options ContainerCheckpointOpti
 			return nil, 0, err
 		}
 	}
-	defer c.newContainerEvent(events.Restore)
-	return c.restore(ctx, options)
+	start := time.Now()
+	criuStatistics, runtimeRestoreDuration, err := c.restore(ctx, options)
+	c.newCheckpointEvent(events.Restore, time.Since(start), runtimeRestoreDuration, criuStatistics)
+	return criuStatistics, runtimeRestoreDuration, err
 }
 
 // Indicate whether or not the container should restart
@@ -1105,9 +1244,17 @@ func (c *Container) CopyFromArchive(_ context.Context, containerPath string, cho
 	return c.copyFromArchive(containerPath, chown, noOverwriteDirNonDir, rename, tarStream)
 }
 
+// ContainerArchiveOptions are the options for stat'ing a path *inside* a
+// container and copying it from the container.
+type ContainerArchiveOptions struct {
+	// NoFollowLink describes and copies a symbolic link itself instead
+	// of its target.
+	NoFollowLink bool
+}
+
 // CopyToArchive copies the contents from the specified path *inside* the
 // container to the tarStream.
-func (c *Container) CopyToArchive(ctx context.Context, containerPath string, tarStream io.Writer) (func() error, error) {
+func (c *Container) CopyToArchive(ctx context.Context, containerPath string, options ContainerArchiveOptions, tarStream io.Writer) (func() error, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -1117,11 +1264,11 @@ func (c *Container) CopyToArchive(ctx context.Context, containerPath string, tar
 		}
 	}
 
-	return c.copyToArchive(containerPath, tarStream)
+	return c.copyToArchive(containerPath, options.NoFollowLink, tarStream)
 }
 
 // Stat the specified path *inside* the container and return a file info.
-func (c *Container) Stat(ctx context.Context, containerPath string) (*define.FileInfo, error) {
+func (c *Container) Stat(ctx context.Context, containerPath string, options ContainerArchiveOptions) (*define.FileInfo, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -1147,7 +1294,7 @@ func (c *Container) Stat(ctx context.Context, containerPath string) (*define.Fil
 		}()
 	}
 
-	info, _, _, err := c.stat(mountPoint, containerPath)
+	info, _, _, err := c.stat(mountPoint, containerPath, options.NoFollowLink)
 	return info, err
 }
 
@@ -166,6 +166,10 @@ type ContainerRootFSConfig struct {
 	// working directory if it does not exist. Some OCI runtimes do this by
 	// default, but others do not.
 	CreateWorkingDir bool `json:"createWorkingDir,omitempty"`
+	// CreateMountSources lists the host directories of bind mounts named
+	// with the %{name} or %{id} placeholders. Libpod creates those which do
+	// not exist when the container is initialized.
+	CreateMountSources []string `json:"createMountSources,omitempty"`
 	// Secrets lists secrets to mount into the container
 	Secrets []*ContainerSecret `json:"secrets,omitempty"`
 	// SecretPath is the secrets location in storage
@@ -181,6 +185,12 @@ type ContainerRootFSConfig struct {
 	// treated as root directories. Standard bind mounts will be mounted
 	// into paths relative to these directories.
 	ChrootDirs []string `json:"chroot_directories,omitempty"`
+	// CollectCores indicates that core dumps of the container's processes
+	// are written to a directory on the host.
+	CollectCores bool `json:"collectCores,omitempty"`
+	// Console indicates that the container gets a console in addition to
+	// its standard streams, which can be attached to with podman console.
+	Console bool `json:"console,omitempty"`
 }
 
 // ContainerSecurityConfig is an embedded sub-config providing security configuration
@@ -216,6 +226,13 @@ type ContainerSecurityConfig struct {
 	Groups []string `json:"groups,omitempty"`
 	// HostUsers are a list of host user accounts to add to /etc/passwd
 	HostUsers []string `json:"HostUsers,omitempty"`
+	// HostUserGroups indicates that the primary and supplementary groups
+	// of the HostUsers are added to /etc/group.
+	HostUserGroups bool `json:"hostUserGroups,omitempty"`
+	// NoPasswd and NoGroup indicate that Libpod does not add any entries
+	// to the container's /etc/passwd and /etc/group, respectively.
+	NoPasswd bool `json:"noPasswd,omitempty"`
+	NoGroup  bool `json:"noGroup,omitempty"`
 	// AddCurrentUserPasswdEntry indicates that Libpod should ensure that
 	// the container's /etc/passwd contains an entry for the user running
 	// Libpod - mostly used in rootless containers where the user running
@@ -286,6 +303,12 @@ type ContainerNetworkConfig struct {
 	// DNS options to be set in container resolv.conf
 	// With override options in host resolv if set
 	DNSOption []string `json:"dnsOption,omitempty"`
+	// Routes are static routes added to the container's network
+	// namespace, or vnet on FreeBSD, once its networks are set up.
+	Routes []types.Route `json:"routes,omitempty"`
+	// VnetDevfsRuleset is the devfs ruleset of the vnet jail of the
+	// container on FreeBSD, overriding the ruleset set by its networks.
+	VnetDevfsRuleset *int `json:"vnetDevfsRuleset,omitempty"`
 	// UseImageHosts indicates that /etc/hosts should not be
 	// bind-mounted inside the container.
 	// Conflicts with HostAdd.
@@ -347,8 +370,25 @@ type ContainerMiscConfig struct {
 	// Labels is a set of key-value pairs providing additional information
 	// about a container
 	Labels map[string]string `json:"labels,omitempty"`
+	// MetadataKeys selects the labels and annotations which are written
+	// to the container's metadata file
+	MetadataKeys []string `json:"metadataKeys,omitempty"`
+	// IdentityCA is the directory containing the CA which issues the
+	// container's identity certificate each time it starts. No
+	// certificate is issued if empty.
+	IdentityCA string `json:"identityCA,omitempty"`
+	// IdentityTTL is the validity of the container's identity
+	// certificate. DefaultIdentityTTL is used if zero.
+	IdentityTTL time.Duration `json:"identityTTL,omitempty"`
 	// StopSignal is the signal that will be used to stop the container
 	StopSignal uint `json:"stopSignal,omitempty"`
+	// IOPriority is the I/O priority class of the container
+	IOPriority *define.IOPriority `json:"ioPriority,omitempty"`
+	// MemoryLock is the amount of memory in bytes the container may lock
+	MemoryLock int64 `json:"memoryLock,omitempty"`
+	// MemoryReclaimSignal is the signal sent to the container when it
+	// exceeds its memory reservation
+	MemoryReclaimSignal uint `json:"memoryReclaimSignal,omitempty"`
 	// StopTimeout is the signal that will be used to stop the container
 	StopTimeout uint `json:"stopTimeout,omitempty"`
 	// Timeout is maximum time a container will run before getting the kill signal
@@ -376,8 +416,15 @@ type ContainerMiscConfig struct {
 	LogTag string `json:"logTag"`
 	// LogSize is the tag used for logging
 	LogSize int64 `json:"logSize"`
+	// LogMaxFiles is the number of log files kept when the log is
+	// rotated, including the current one. If it is 0 or 1, the log is
+	// truncated when it reaches LogSize.
+	LogMaxFiles uint `json:"logMaxFiles,omitempty"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
+	// LogOptions are options specific to the log driver, e.g. the
+	// address of the syslog server for the syslog driver.
+	LogOptions map[string]string `json:"logOptions,omitempty"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
 	// RestartPolicy indicates what action the container will take upon
@@ -397,6 +444,10 @@ type ContainerMiscConfig struct {
 	PostConfigureNetNS bool `json:"postConfigureNetNS"`
 	// OCIRuntime used to create the container
 	OCIRuntime string `json:"runtime,omitempty"`
+	// RuntimeFlags are additional flags passed to the OCI runtime every
+	// time it is invoked for this container. They are added after any
+	// global runtime flags the runtime was configured with.
+	RuntimeFlags []string `json:"runtimeFlags,omitempty"`
 	// IsInfra is a bool indicating whether this container is an infra container used for
 	// sharing kernel namespaces in a pod
 	IsInfra bool `json:"pause"`
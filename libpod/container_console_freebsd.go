@@ -0,0 +1,381 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/containers/common/pkg/resize"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/storage/pkg/reexec"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// consoleRelayCommand is the reexec key of the console relay. It is run with
+// the directory of the console socket as argument and the control side of the
+// console as extra file. It serves the console on the socket using the attach
+// protocol of conmon until the console is closed by the container.
+const consoleRelayCommand = "podman-console-relay"
+
+// consoleEnv is the environment variable which tells the container process
+// the file descriptor of its console.
+const consoleEnv = "PODMAN_CONSOLE_FD"
+
+// consoleBacklogSize is the amount of console output which is replayed to a
+// new attach session, so boot messages written while no one was attached are
+// not lost.
+const consoleBacklogSize = 64 * 1024
+
+// consoleBufSize must be kept in sync with the buffer used by
+// redirectResponseToOutputStreams.
+const consoleBufSize = 8192
+
+func init() {
+	reexec.Register(consoleRelayCommand, consoleRelayMain)
+}
+
+// consoleSocketPath returns the path of the socket served by the console
+// relay of the container.
+func (c *Container) consoleSocketPath() string {
+	return filepath.Join(c.state.RunDir, "console")
+}
+
+// consolePidPath returns the path of the pid file of the console relay.
+func (c *Container) consolePidPath() string {
+	return filepath.Join(c.state.RunDir, "console.pid")
+}
+
+// consoleTTYPath returns the path of the file which records the terminal
+// device of the console, which is used to resize it.
+func (c *Container) consoleTTYPath() string {
+	return filepath.Join(c.state.RunDir, "console.tty")
+}
+
+// consoleFD returns the file descriptor of the console in the container. It
+// follows the file descriptors preserved with --preserve-fds.
+func (c *Container) consoleFD() uint {
+	fds := c.config.PreserveFDs
+	for _, fd := range c.config.PreserveFD {
+		if fd-2 > fds {
+			fds = fd - 2
+		}
+	}
+	return 3 + fds
+}
+
+// addConsole tells the container process where to find its console.
+func (c *Container) addConsole(g *generate.Generator) error {
+	if !c.config.Console {
+		return nil
+	}
+	g.AddProcessEnv(consoleEnv, strconv.FormatUint(uint64(c.consoleFD()), 10))
+	return nil
+}
+
+// openConsolePty allocates a pseudo-terminal and returns its control and
+// terminal side.
+func openConsolePty() (*os.File, *os.File, error) {
+	fd, _, errno := unix.Syscall(unix.SYS_POSIX_OPENPT, uintptr(unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC), 0, 0)
+	if errno != 0 {
+		return nil, nil, fmt.Errorf("allocating pseudo-terminal: %w", errno)
+	}
+	control := os.NewFile(fd, "console")
+	n, err := unix.IoctlGetInt(int(fd), unix.TIOCGPTN)
+	if err != nil {
+		control.Close()
+		return nil, nil, fmt.Errorf("getting pseudo-terminal number: %w", err)
+	}
+	tty, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		control.Close()
+		return nil, nil, err
+	}
+	return control, tty, nil
+}
+
+// startConsole allocates the container's console and starts the relay
+// serving it. It returns the terminal side of the console, which must be
+// passed to the container, or nil if the container has no console.
+func (c *Container) startConsole() (*os.File, error) {
+	if !c.config.Console {
+		return nil, nil
+	}
+	// The relay of an earlier run is gone with the console it served.
+	if err := c.stopConsole(); err != nil {
+		return nil, err
+	}
+	control, tty, err := openConsolePty()
+	if err != nil {
+		return nil, fmt.Errorf("creating console for container %s: %w", c.ID(), err)
+	}
+	defer control.Close()
+
+	if err := os.WriteFile(c.consoleTTYPath(), []byte(tty.Name()), 0o600); err != nil {
+		tty.Close()
+		return nil, err
+	}
+	logFile, err := os.OpenFile(c.consoleSocketPath()+".log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+	defer logFile.Close()
+
+	cmd := reexec.Command(consoleRelayCommand, c.state.RunDir)
+	cmd.ExtraFiles = []*os.File{control}
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		tty.Close()
+		return nil, fmt.Errorf("starting console relay of container %s: %w", c.ID(), err)
+	}
+	// Reap the relay if it exits while we are still running, it is
+	// reparented to init otherwise.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	if err := os.WriteFile(c.consolePidPath(), []byte(strconv.Itoa(cmd.Process.Pid)), 0o600); err != nil {
+		_ = cmd.Process.Kill()
+		tty.Close()
+		return nil, err
+	}
+	logrus.Debugf("Started console relay %d for %s of container %s", cmd.Process.Pid, tty.Name(), c.ID())
+	return tty, nil
+}
+
+// stopConsole terminates the console relay of the container, if any.
+func (c *Container) stopConsole() error {
+	if !c.config.Console || c.state.RunDir == "" {
+		return nil
+	}
+	pidFile := c.consolePidPath()
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("parsing pid file %s: %w", pidFile, err)
+	}
+	if err := unix.Kill(pid, unix.SIGTERM); err != nil && !errors.Is(err, unix.ESRCH) {
+		return fmt.Errorf("stopping console relay %d: %w", pid, err)
+	}
+	logrus.Debugf("Stopped console relay %d of container %s", pid, c.ID())
+	for _, path := range []string{pidFile, c.consoleTTYPath(), c.consoleSocketPath(), c.consoleSocketPath() + ".log"} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// AttachConsole attaches to the console of a running container which was
+// created with a console.
+func (c *Container) AttachConsole(streams *define.AttachStreams, keys string, resizeCh <-chan resize.TerminalSize) error {
+	if !c.config.Console {
+		return fmt.Errorf("container %s does not have a console, create it with --console: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			c.lock.Unlock()
+			return err
+		}
+		// We are NOT holding the lock for the duration of the function.
+		c.lock.Unlock()
+	}
+	if !c.ensureState(define.ContainerStateRunning) {
+		return fmt.Errorf("can only attach to the console of running containers: %w", define.ErrCtrStateInvalid)
+	}
+
+	detachKeys, err := ctrDetachKeys(c, &keys)
+	if err != nil {
+		return err
+	}
+	tty, err := os.ReadFile(c.consoleTTYPath())
+	if err != nil {
+		return fmt.Errorf("looking up console of container %s: %w", c.ID(), err)
+	}
+	resize.HandleResizing(resizeCh, func(size resize.TerminalSize) {
+		if err := resizeConsole(string(tty), size); err != nil {
+			logrus.Debugf("Failed to resize console: %v", err)
+		}
+	})
+
+	conn, err := openUnixSocket(c.consoleSocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to connect to container's console socket: %v: %w", c.consoleSocketPath(), err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("Unable to close socket: %q", err)
+		}
+	}()
+
+	c.newContainerEvent(events.Attach)
+	receiveStdoutError, stdinDone := setupStdioChannels(streams, conn, detachKeys)
+	return readStdio(conn, streams, receiveStdoutError, stdinDone)
+}
+
+// resizeConsole sets the size of the console's terminal.
+func resizeConsole(tty string, size resize.TerminalSize) error {
+	f, err := os.OpenFile(tty, os.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return unix.IoctlSetWinsize(int(f.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: size.Height, Col: size.Width})
+}
+
+// consoleRelayMain is the main function of the console relay.
+func consoleRelayMain() {
+	if err := consoleRelayInner(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// consoleRelay forwards the output of a console to the attached sessions and
+// their input to the console.
+type consoleRelay struct {
+	control  *os.File
+	lock     sync.Mutex
+	sessions map[*net.UnixConn]struct{}
+	backlog  []byte
+}
+
+func consoleRelayInner() error {
+	if len(os.Args) != 2 {
+		return errors.New("internal error, need the socket directory as argument")
+	}
+	// Socket paths are limited in length, so listen relative to the
+	// directory.
+	if err := os.Chdir(os.Args[1]); err != nil {
+		return err
+	}
+	l, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: "console", Net: "unixpacket"})
+	if err != nil {
+		return fmt.Errorf("listening on console socket: %w", err)
+	}
+	defer l.Close()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, unix.SIGTERM, unix.SIGINT)
+	signal.Ignore(unix.SIGHUP)
+	go func() {
+		<-sigCh
+		l.Close()
+		os.Exit(0)
+	}()
+
+	relay := &consoleRelay{
+		// The first extra file is fd 3.
+		control:  os.NewFile(3, "console"),
+		sessions: make(map[*net.UnixConn]struct{}),
+	}
+	go relay.accept(l)
+	// The container closed the console when reading fails.
+	relay.forwardOutput()
+	return nil
+}
+
+// accept adds new attach sessions to the relay.
+func (r *consoleRelay) accept(l *net.UnixListener) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return
+		}
+		r.lock.Lock()
+		for backlog := r.backlog; len(backlog) > 0; {
+			n := len(backlog)
+			if n > consoleBufSize {
+				n = consoleBufSize
+			}
+			if err := writeConsolePacket(conn, backlog[:n]); err != nil {
+				break
+			}
+			backlog = backlog[n:]
+		}
+		r.sessions[conn] = struct{}{}
+		r.lock.Unlock()
+		go r.forwardInput(conn)
+	}
+}
+
+// forwardInput writes the input of an attach session to the console until
+// the session detaches.
+func (r *consoleRelay) forwardInput(conn *net.UnixConn) {
+	buf := make([]byte, consoleBufSize)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if _, err := r.control.Write(buf[:n]); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	r.lock.Lock()
+	delete(r.sessions, conn)
+	r.lock.Unlock()
+	conn.Close()
+}
+
+// forwardOutput sends the output of the console to all attach sessions and
+// keeps the most recent output for new sessions.
+func (r *consoleRelay) forwardOutput() {
+	buf := make([]byte, consoleBufSize)
+	for {
+		n, err := r.control.Read(buf)
+		if n > 0 {
+			r.lock.Lock()
+			r.backlog = append(r.backlog, buf[:n]...)
+			if len(r.backlog) > consoleBacklogSize {
+				r.backlog = r.backlog[len(r.backlog)-consoleBacklogSize:]
+			}
+			for conn := range r.sessions {
+				if err := writeConsolePacket(conn, buf[:n]); err != nil {
+					delete(r.sessions, conn)
+					conn.Close()
+				}
+			}
+			r.lock.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeConsolePacket sends console output to an attach session, framed like
+// the output of conmon.
+func writeConsolePacket(conn *net.UnixConn, data []byte) error {
+	packet := make([]byte, 0, len(data)+1)
+	packet = append(packet, AttachPipeStdout)
+	packet = append(packet, data...)
+	_, err := conn.Write(packet)
+	return err
+}
@@ -0,0 +1,38 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/resize"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/opencontainers/runtime-tools/generate"
+)
+
+// addConsole returns an error if the container has a console, which is only
+// supported on FreeBSD.
+func (c *Container) addConsole(g *generate.Generator) error {
+	if c.config.Console {
+		return fmt.Errorf("allocating a console: %w", define.ErrOSNotSupported)
+	}
+	return nil
+}
+
+// startConsole is a no-op on Linux, addConsole rejects containers with a
+// console.
+func (c *Container) startConsole() (*os.File, error) {
+	return nil, nil
+}
+
+// stopConsole is a no-op on Linux.
+func (c *Container) stopConsole() error {
+	return nil
+}
+
+// AttachConsole attaches to the console of a running container, which is
+// only supported on FreeBSD.
+func (c *Container) AttachConsole(streams *define.AttachStreams, keys string, resizeCh <-chan resize.TerminalSize) error {
+	return fmt.Errorf("attaching to the console: %w", define.ErrOSNotSupported)
+}
@@ -97,7 +97,7 @@ func (c *Container) copyFromArchive(path string, chown, noOverwriteDirNonDir boo
 	}, nil
 }
 
-func (c *Container) copyToArchive(path string, writer io.Writer) (func() error, error) {
+func (c *Container) copyToArchive(path string, noFollowLink bool, writer io.Writer) (func() error, error) {
 	var (
 		mountPoint string
 		unmount    func()
@@ -121,7 +121,7 @@ func (c *Container) copyToArchive(path string, writer io.Writer) (func() error,
 		}
 	}
 
-	statInfo, resolvedRoot, resolvedPath, err := c.stat(mountPoint, path)
+	statInfo, resolvedRoot, resolvedPath, err := c.stat(mountPoint, path, noFollowLink)
 	if err != nil {
 		unmount()
 		return nil, err
@@ -164,6 +164,7 @@ func (c *Container) copyToArchive(path string, writer io.Writer) (func() error,
 			// by the host's root and hence "nobody" inside the
 			// container's user namespace.
 			IgnoreUnreadable: rootless.IsRootless() && c.state.State == define.ContainerStateRunning,
+			NoDerefSymlinks:  noFollowLink,
 		}
 		return c.joinMountAndExec(
 			func() error {
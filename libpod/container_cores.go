@@ -0,0 +1,95 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"golang.org/x/sys/unix"
+)
+
+// coreDumpDir is the directory in the container where core dumps are
+// written when the container collects core dumps.
+const coreDumpDir = "/var/coredumps"
+
+// coreDumpHostDir returns the directory on the host which holds the core
+// dumps collected from the container.
+func (c *Container) coreDumpHostDir() string {
+	return filepath.Join(c.config.StaticDir, "cores")
+}
+
+// CoreDumps returns the core dumps collected from the container's processes,
+// oldest first.
+func (c *Container) CoreDumps() ([]define.CoreDump, error) {
+	if !c.config.CollectCores {
+		return nil, fmt.Errorf("container %s does not collect core dumps: %w", c.ID(), define.ErrInvalidArg)
+	}
+	entries, err := os.ReadDir(c.coreDumpHostDir())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cores := make([]define.CoreDump, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		cores = append(cores, define.CoreDump{
+			Name:    entry.Name(),
+			Size:    info.Size(),
+			Created: info.ModTime(),
+		})
+	}
+	sort.Slice(cores, func(i, j int) bool {
+		return cores[i].Created.Before(cores[j].Created)
+	})
+	return cores, nil
+}
+
+// OpenCoreDump opens a core dump collected from the container for reading.
+// The core dump directory is writable from inside the container, so the file
+// is opened without following symbolic links and checked to be a regular file
+// after it is opened, rather than checking the path first.
+func (c *Container) OpenCoreDump(name string) (*os.File, error) {
+	if !c.config.CollectCores {
+		return nil, fmt.Errorf("container %s does not collect core dumps: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid core dump name %q: %w", name, define.ErrInvalidArg)
+	}
+	path := filepath.Join(c.coreDumpHostDir(), name)
+	// O_NONBLOCK keeps the open from blocking on a FIFO.
+	f, err := os.OpenFile(path, os.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		// Linux fails with ELOOP and FreeBSD with EMLINK on a symbolic link.
+		if errors.Is(err, unix.ELOOP) || errors.Is(err, unix.EMLINK) {
+			return nil, fmt.Errorf("core dump %s of container %s is not a regular file: %w", name, c.ID(), define.ErrInvalidArg)
+		}
+		return nil, fmt.Errorf("core dump %s of container %s: %w", name, c.ID(), err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("core dump %s of container %s: %w", name, c.ID(), err)
+	}
+	if !st.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("core dump %s of container %s is not a regular file: %w", name, c.ID(), define.ErrInvalidArg)
+	}
+	return f, nil
+}
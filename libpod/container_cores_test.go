@@ -0,0 +1,55 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestOpenCoreDump(t *testing.T) {
+	c := &Container{
+		config: &ContainerConfig{
+			ID: "0123abcd",
+			ContainerRootFSConfig: ContainerRootFSConfig{
+				StaticDir:    t.TempDir(),
+				CollectCores: true,
+			},
+		},
+	}
+	dir := c.coreDumpHostDir()
+	require.NoError(t, os.Mkdir(dir, 0o755))
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("secret"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "core.1"), []byte("core"), 0o600))
+	require.NoError(t, os.Symlink(secret, filepath.Join(dir, "core.2")))
+	require.NoError(t, unix.Mkfifo(filepath.Join(dir, "core.3"), 0o600))
+
+	f, err := c.OpenCoreDump("core.1")
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	f.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "core", string(data))
+
+	// A symbolic link placed by the container must not be followed.
+	_, err = c.OpenCoreDump("core.2")
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	// A FIFO must neither block nor be read.
+	_, err = c.OpenCoreDump("core.3")
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	for _, name := range []string{"", ".", "..", "../secret", "missing"} {
+		_, err = c.OpenCoreDump(name)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,97 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// debugFile is a file in a debug bundle. If collecting the file fails, the
+// error is written to the bundle instead of its contents.
+type debugFile struct {
+	name    string
+	collect func() ([]byte, error)
+}
+
+// DebugBundle writes a gzip compressed tar archive with information which
+// helps debugging problems with the container, suitable for attaching to bug
+// reports. Collecting the information is best effort, errors are recorded in
+// the archive.
+func (c *Container) DebugBundle(w io.Writer) error {
+	files := []debugFile{
+		{"inspect.json", func() ([]byte, error) {
+			data, err := c.Inspect(false)
+			if err != nil {
+				return nil, err
+			}
+			if data.Config != nil {
+				data.Config.Env = debugRedactEnv(data.Config.Env)
+			}
+			return json.MarshalIndent(data, "", "    ")
+		}},
+		{"stats.json", func() ([]byte, error) {
+			stats, err := c.GetContainerStats(&define.ContainerStats{})
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(stats, "", "    ")
+		}},
+		{"container.log", c.debugLogs},
+	}
+	files = append(files, c.platformDebugFiles()...)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, f := range files {
+		data, err := f.collect()
+		if err != nil {
+			data = []byte(fmt.Sprintf("error collecting %s: %v\n", f.name, err))
+		}
+		hdr := &tar.Header{
+			Name:    c.Name() + "/" + f.name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// debugRedactEnv replaces the values of environment variables, which often
+// hold credentials such as the values of secrets, for a debug bundle.
+func debugRedactEnv(env []string) []string {
+	redacted := make([]string, 0, len(env))
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		redacted = append(redacted, name+"=<redacted>")
+	}
+	return redacted
+}
+
+// debugLogs returns the contents of the container's log file.
+func (c *Container) debugLogs() ([]byte, error) {
+	switch c.LogDriver() {
+	case define.KubernetesLogging, define.JSONLogging:
+		return os.ReadFile(c.LogPath())
+	default:
+		return nil, fmt.Errorf("logs from log driver %q are not included, use podman logs", c.LogDriver())
+	}
+}
@@ -0,0 +1,159 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/rctl"
+)
+
+const (
+	// pfNatTable is the pf table which holds the addresses of
+	// containers using NAT.
+	pfNatTable = "cni-nat"
+	// pfRdrAnchor is the pf anchor which holds port forwarding rules
+	// for each network.
+	pfRdrAnchor = "cni-rdr"
+)
+
+// platformDebugFiles returns the FreeBSD specific files for a debug bundle.
+func (c *Container) platformDebugFiles() []debugFile {
+	return []debugFile{
+		{"jail.conf", c.debugJailConf},
+		{"jls.txt", func() ([]byte, error) {
+			name, err := c.debugJailName()
+			if err != nil {
+				return nil, err
+			}
+			return debugCommand("jls", "-n", "-j", name)
+		}},
+		{"rctl.txt", c.debugRctl},
+		{"pf.txt", debugPf},
+		{"mounts.txt", c.debugMounts},
+	}
+}
+
+// debugJailConf returns the jail.conf of the container with the values of
+// its environment variables replaced, as they often hold credentials.
+func (c *Container) debugJailConf() ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return nil, err
+	}
+	return c.jailConf(true)
+}
+
+// debugJailName returns the name of the container's jail.
+func (c *Container) debugJailName() (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return "", err
+	}
+	return c.jailName()
+}
+
+// debugCommand runs a command and returns its combined output.
+func debugCommand(name string, args ...string) ([]byte, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %v: %s", name, strings.Join(args, " "), err, bytes.TrimSpace(out))
+	}
+	return out, nil
+}
+
+// debugRctl returns the resource usage and rctl rules of the container's
+// jail.
+func (c *Container) debugRctl() ([]byte, error) {
+	name, err := c.debugJailName()
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	usage, err := rctl.GetRacct("jail:" + name)
+	if err != nil {
+		fmt.Fprintf(&b, "# usage: %v\n", err)
+	} else {
+		keys := make([]string, 0, len(usage))
+		for key := range usage {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("# usage\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s=%d\n", key, usage[key])
+		}
+	}
+	rules, err := debugCommand("rctl", "jail:"+name)
+	if err != nil {
+		fmt.Fprintf(&b, "# rules: %v\n", err)
+	} else {
+		b.WriteString("# rules\n")
+		b.Write(rules)
+	}
+	return b.Bytes(), nil
+}
+
+// debugPf returns the pf state used for container networking.
+func debugPf() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# table %s\n", pfNatTable)
+	if out, err := debugCommand("pfctl", "-t", pfNatTable, "-T", "show"); err != nil {
+		fmt.Fprintf(&b, "%v\n", err)
+	} else {
+		b.Write(out)
+	}
+	anchors, err := debugCommand("pfctl", "-a", pfRdrAnchor, "-s", "Anchors")
+	if err != nil {
+		fmt.Fprintf(&b, "# anchor %s\n%v\n", pfRdrAnchor, err)
+		return b.Bytes(), nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(anchors))
+	for scanner.Scan() {
+		anchor := strings.TrimSpace(scanner.Text())
+		if anchor == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "# anchor %s\n", anchor)
+		if out, err := debugCommand("pfctl", "-a", anchor, "-s", "nat"); err != nil {
+			fmt.Fprintf(&b, "%v\n", err)
+		} else {
+			b.Write(out)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// debugMounts returns the host mounts which belong to the container.
+func (c *Container) debugMounts() ([]byte, error) {
+	c.lock.Lock()
+	if err := c.syncContainer(); err != nil {
+		c.lock.Unlock()
+		return nil, err
+	}
+	mountPoint := c.state.Mountpoint
+	runDir := c.state.RunDir
+	c.lock.Unlock()
+
+	out, err := debugCommand("mount", "-p")
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if (mountPoint != "" && strings.Contains(line, mountPoint)) || (runDir != "" && strings.Contains(line, runDir)) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.Bytes(), nil
+}
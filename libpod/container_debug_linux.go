@@ -0,0 +1,24 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+)
+
+// platformDebugFiles returns the Linux specific files for a debug bundle.
+func (c *Container) platformDebugFiles() []debugFile {
+	return []debugFile{
+		{"mountinfo", func() ([]byte, error) {
+			pid, err := c.PID()
+			if err != nil {
+				return nil, err
+			}
+			if pid == 0 {
+				return nil, fmt.Errorf("container %s is not running", c.ID())
+			}
+			return os.ReadFile(fmt.Sprintf("/proc/%d/mountinfo", pid))
+		}},
+	}
+}
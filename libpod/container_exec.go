@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
@@ -79,6 +80,12 @@ type ExecConfig struct {
 	// exiting, and the exit command being executed. If set to 0, there is
 	// no delay. If set, ExitCommand must also be set.
 	ExitCommandDelay uint `json:"exitCommandDelay,omitempty"`
+	// CPUs is the number of CPUs the first process of the exec session
+	// may use. If set to 0, the process is only limited by the limits of
+	// the container.
+	CPUs float64 `json:"cpus,omitempty"`
+	// Nice is the nice value of the first process of the exec session.
+	Nice int `json:"nice,omitempty"`
 }
 
 // ExecSession contains information on a single exec session attached to a given
@@ -99,6 +106,14 @@ type ExecSession struct {
 	PID int `json:"pid,omitempty"`
 	// ExitCode is the exit code of the exec session, if it has exited.
 	ExitCode int `json:"exitCode,omitempty"`
+	// StartedAt is the time the exec session was started.
+	StartedAt time.Time `json:"startedAt,omitempty"`
+	// CPUTime is the CPU time used by the process of the exec session,
+	// as last recorded while it was running.
+	CPUTime time.Duration `json:"cpuTime,omitempty"`
+	// MemoryUsage is the memory in bytes used by the process of the exec
+	// session, as last recorded while it was running.
+	MemoryUsage uint64 `json:"memoryUsage,omitempty"`
 
 	// Config is the configuration of this exec session.
 	// Cannot be empty.
@@ -135,6 +150,9 @@ func (e *ExecSession) Inspect() (*define.InspectExecSession, error) {
 	output.OpenStdout = e.Config.AttachStdout
 	output.Running = e.State == define.ExecStateRunning
 	output.Pid = e.PID
+	output.StartedAt = e.StartedAt
+	output.CPUTime = uint64(e.CPUTime)
+	output.MemoryUsage = e.MemoryUsage
 	output.ProcessConfig = new(define.InspectExecProcess)
 	if len(e.Config.Command) > 0 {
 		output.ProcessConfig.Entrypoint = e.Config.Command[0]
@@ -146,6 +164,8 @@ func (e *ExecSession) Inspect() (*define.InspectExecSession, error) {
 	output.ProcessConfig.Privileged = e.Config.Privileged
 	output.ProcessConfig.Tty = e.Config.Terminal
 	output.ProcessConfig.User = e.Config.User
+	output.ProcessConfig.CPUs = e.Config.CPUs
+	output.ProcessConfig.Nice = e.Config.Nice
 
 	return output, nil
 }
@@ -180,6 +200,15 @@ func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
 	if config.ExitCommandDelay > 0 && len(config.ExitCommand) == 0 {
 		return "", fmt.Errorf("must provide a non-empty exit command if giving an exit command delay: %w", define.ErrInvalidArg)
 	}
+	if config.Nice < -20 || config.Nice > 20 {
+		return "", fmt.Errorf("nice value %d must be between -20 and 20: %w", config.Nice, define.ErrInvalidArg)
+	}
+	if config.CPUs < 0 {
+		return "", fmt.Errorf("CPU limit %g must not be negative: %w", config.CPUs, define.ErrInvalidArg)
+	}
+	if err := checkExecLimits(config); err != nil {
+		return "", err
+	}
 
 	// Verify that we are in a good state to continue
 	if !c.ensureState(define.ContainerStateRunning) {
@@ -278,8 +307,7 @@ func (c *Container) ExecStart(sessionID string) error {
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	// Update and save session to reflect PID/running
-	session.PID = pid
-	session.State = define.ExecStateRunning
+	c.execSessionStarted(session, pid)
 
 	return c.save()
 }
@@ -335,8 +363,7 @@ func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachS
 	var lastErr error
 
 	// Update and save session to reflect PID/running
-	session.PID = pid
-	session.State = define.ExecStateRunning
+	c.execSessionStarted(session, pid)
 
 	if err := c.save(); err != nil {
 		lastErr = err
@@ -505,8 +532,7 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 
 	var lastErr error
 
-	session.PID = pid
-	session.State = define.ExecStateRunning
+	c.execSessionStarted(session, pid)
 
 	if err := c.save(); err != nil {
 		lastErr = err
@@ -764,11 +790,17 @@ func (c *Container) Exec(config *ExecConfig, streams *define.AttachStreams, resi
 // Exec emulates the old Libpod exec API, providing a single call to create,
 // run, and remove an exec session. Returns exit code and error. Exit code is
 // not guaranteed to be set sanely if error is not nil.
-func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resizeChan <-chan resize.TerminalSize, isHealthcheck bool) (exitCode int, retErr error) {
+func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resizeChan <-chan resize.TerminalSize, isHealthcheck bool) (int, error) {
 	sessionID, err := c.ExecCreate(config)
 	if err != nil {
 		return -1, err
 	}
+	return c.execSession(sessionID, streams, resizeChan, isHealthcheck)
+}
+
+// execSession starts the created exec session, waits for it to exit and
+// removes it, returning its exit code.
+func (c *Container) execSession(sessionID string, streams *define.AttachStreams, resizeChan <-chan resize.TerminalSize, isHealthcheck bool) (exitCode int, retErr error) {
 	defer func() {
 		if err := c.ExecRemove(sessionID, false); err != nil {
 			if retErr == nil && !errors.Is(err, define.ErrNoSuchExecSession) {
@@ -1052,6 +1084,67 @@ func (c *Container) getActiveExecSessions() ([]string, error) {
 	return activeSessions, lastErr
 }
 
+// execSessionStarted records that the process of the exec session was started
+// and applies its resource limits. The session must be saved by the caller.
+func (c *Container) execSessionStarted(session *ExecSession, pid int) {
+	session.PID = pid
+	session.State = define.ExecStateRunning
+	session.StartedAt = time.Now()
+	if err := applyExecLimits(session); err != nil {
+		logrus.Errorf("Limiting resources of container %s exec session %s: %v", c.ID(), session.ID(), err)
+	}
+}
+
+// ListExecSessions returns the exec sessions of the container, sorted by the
+// time they were started. Sessions which have exited are marked as stopped,
+// and the resource usage of running sessions is recorded.
+func (c *Container) ListExecSessions() ([]*ExecSession, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if _, err := c.getActiveExecSessions(); err != nil {
+			return nil, err
+		}
+	}
+
+	needSave := false
+	sessions := make([]*ExecSession, 0, len(c.state.ExecSessions))
+	for _, session := range c.state.ExecSessions {
+		if session.State == define.ExecStateRunning && session.PID > 0 {
+			cpuTime, memory, err := execSessionUsage(session.PID)
+			if err != nil {
+				logrus.Debugf("Reading resource usage of container %s exec session %s: %v", c.ID(), session.ID(), err)
+			} else {
+				session.CPUTime = cpuTime
+				session.MemoryUsage = memory
+				needSave = true
+			}
+		}
+		returnSession := new(ExecSession)
+		if err := JSONDeepCopy(session, returnSession); err != nil {
+			return nil, fmt.Errorf("copying contents of container %s exec session %s: %w", c.ID(), session.ID(), err)
+		}
+		sessions = append(sessions, returnSession)
+	}
+	if needSave {
+		if err := c.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
 // removeAllExecSessions stops and removes all the container's exec sessions
 func (c *Container) removeAllExecSessions() error {
 	knownSessions := c.getKnownExecSessions()
@@ -0,0 +1,46 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/rctl"
+	"golang.org/x/sys/unix"
+)
+
+// checkExecLimits checks that the resource limits of an exec session can be
+// applied. Both limits are supported on FreeBSD.
+func checkExecLimits(config *ExecConfig) error {
+	return nil
+}
+
+// applyExecLimits limits the resources of the process of an exec session.
+// The CPU limit is an rctl rule for the process, which is inherited by its
+// children and removed by the kernel when the process exits.
+func applyExecLimits(session *ExecSession) error {
+	if session.Config.Nice != 0 {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, session.PID, session.Config.Nice); err != nil {
+			return fmt.Errorf("setting nice value %d of process %d: %w", session.Config.Nice, session.PID, err)
+		}
+	}
+	if session.Config.CPUs > 0 {
+		pcpu := int(math.Ceil(session.Config.CPUs * 100))
+		if err := rctl.AddRule(fmt.Sprintf("process:%d:pcpu:deny=%d", session.PID, pcpu)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSessionUsage returns the CPU time and memory used by the process of an
+// exec session, as accounted by rctl.
+func execSessionUsage(pid int) (time.Duration, uint64, error) {
+	usage, err := rctl.GetRacct(fmt.Sprintf("process:%d", pid))
+	if err != nil {
+		return 0, 0, err
+	}
+	return time.Duration(usage["cputime"]) * time.Second, usage["memoryuse"], nil
+}
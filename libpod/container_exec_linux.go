@@ -0,0 +1,82 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"golang.org/x/sys/unix"
+)
+
+// userHZ is the unit of the CPU times in /proc/PID/stat, which the kernel
+// always reports in USER_HZ ticks.
+const userHZ = 100
+
+// checkExecLimits checks that the resource limits of an exec session can be
+// applied. The CPU limit would need a cgroup for the exec session, which is
+// not supported.
+func checkExecLimits(config *ExecConfig) error {
+	if config.CPUs > 0 {
+		return fmt.Errorf("limiting the CPUs of an exec session: %w", define.ErrOSNotSupported)
+	}
+	return nil
+}
+
+// applyExecLimits limits the resources of the process of an exec session.
+func applyExecLimits(session *ExecSession) error {
+	if session.Config.Nice != 0 {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, session.PID, session.Config.Nice); err != nil {
+			return fmt.Errorf("setting nice value %d of process %d: %w", session.Config.Nice, session.PID, err)
+		}
+	}
+	return nil
+}
+
+// execSessionUsage returns the CPU time and resident memory of the process of
+// an exec session.
+func execSessionUsage(pid int) (time.Duration, uint64, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0, err
+	}
+	// The command name may contain spaces, the fields after it can be
+	// split safely.
+	_, after, ok := strings.Cut(string(stat), ") ")
+	if !ok {
+		return 0, 0, fmt.Errorf("parsing /proc/%d/stat: missing command name", pid)
+	}
+	fields := strings.Fields(after)
+	// utime and stime are fields 14 and 15, the fields after the
+	// command name start at field 3.
+	if len(fields) < 13 {
+		return 0, 0, fmt.Errorf("parsing /proc/%d/stat: too few fields", pid)
+	}
+	var ticks uint64
+	for _, field := range fields[11:13] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("parsing /proc/%d/stat: %w", pid, err)
+		}
+		ticks += value
+	}
+
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return 0, 0, err
+	}
+	fields = strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, 0, fmt.Errorf("parsing /proc/%d/statm: too few fields", pid)
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing /proc/%d/statm: %w", pid, err)
+	}
+
+	return time.Duration(ticks) * time.Second / userHZ, pages * uint64(os.Getpagesize()), nil
+}
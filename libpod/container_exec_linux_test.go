@@ -0,0 +1,27 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecSessionUsage(t *testing.T) {
+	cpuTime, memory, err := execSessionUsage(os.Getpid())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, cpuTime.Nanoseconds(), int64(0))
+	assert.Greater(t, memory, uint64(0))
+
+	_, _, err = execSessionUsage(-1)
+	assert.Error(t, err)
+}
+
+func TestCheckExecLimits(t *testing.T) {
+	assert.NoError(t, checkExecLimits(&ExecConfig{Nice: 10}))
+	assert.ErrorIs(t, checkExecLimits(&ExecConfig{CPUs: 0.5}), define.ErrOSNotSupported)
+}
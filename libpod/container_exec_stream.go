@@ -0,0 +1,153 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// ExecStreamOptions are the options of ExecStream.
+type ExecStreamOptions struct {
+	// Input, if set, is copied to the standard input of the exec session.
+	Input io.Reader
+	// CombinedOutput sends the standard error of the exec session to
+	// Stdout, in the order it was written, and leaves Stderr unset.
+	CombinedOutput bool
+}
+
+// ExecStream is an exec session started by ExecStream whose output is read
+// while it runs.
+type ExecStream struct {
+	// Stdout and Stderr return the output of the exec session. Both must be
+	// drained, otherwise the session blocks once it has written more than
+	// the reader consumed. They return io.EOF once the session has exited
+	// and all of its output has been read.
+	Stdout io.Reader
+	Stderr io.Reader
+
+	done     chan struct{}
+	exitCode int
+	err      error
+}
+
+// Wait waits for the exec session to exit and returns its exit code. The
+// exit code is not guaranteed to be set sanely if the error is not nil.
+func (s *ExecStream) Wait() (int, error) {
+	<-s.done
+	return s.exitCode, s.err
+}
+
+// Output reads all the output of the exec session and waits for it to exit.
+func (s *ExecStream) Output() (stdout, stderr []byte, exitCode int, err error) {
+	var errBuf bytes.Buffer
+	errDone := make(chan error, 1)
+	go func() {
+		var err error
+		if s.Stderr != nil {
+			_, err = io.Copy(&errBuf, s.Stderr)
+		}
+		errDone <- err
+	}()
+	stdout, readErr := io.ReadAll(s.Stdout)
+	if err := <-errDone; readErr == nil {
+		readErr = err
+	}
+	exitCode, err = s.Wait()
+	if err == nil && readErr != nil {
+		err = fmt.Errorf("reading exec session output: %w", readErr)
+	}
+	return stdout, errBuf.Bytes(), exitCode, err
+}
+
+// ExecStream runs a command in the container like Exec, returning as soon as
+// the exec session has been created so that its output can be streamed. The
+// exec session is removed once it has exited.
+// If ctx is done before the command exits, the exec session is stopped and
+// Wait returns the error of the context.
+func (c *Container) ExecStream(ctx context.Context, config *ExecConfig, options ExecStreamOptions) (*ExecStream, error) {
+	return c.execStream(ctx, config, options, false)
+}
+
+func (c *Container) execStream(ctx context.Context, config *ExecConfig, options ExecStreamOptions, isHealthcheck bool) (*ExecStream, error) {
+	sessionID, err := c.ExecCreate(config)
+	if err != nil {
+		return nil, err
+	}
+
+	stdoutR, stdoutW := io.Pipe()
+	streams := new(define.AttachStreams)
+	streams.OutputStream = stdoutW
+	streams.ErrorStream = stdoutW
+	streams.AttachOutput = true
+	streams.AttachError = true
+	stream := &ExecStream{
+		Stdout: stdoutR,
+		done:   make(chan struct{}),
+	}
+	var stderrW *io.PipeWriter
+	if !options.CombinedOutput {
+		var stderrR *io.PipeReader
+		stderrR, stderrW = io.Pipe()
+		streams.ErrorStream = stderrW
+		stream.Stderr = stderrR
+	}
+	if options.Input != nil {
+		streams.InputStream = bufio.NewReader(options.Input)
+		streams.AttachInput = true
+	}
+
+	exited := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stopped)
+			timeout := uint(0)
+			if err := c.ExecStop(sessionID, &timeout); err != nil && !errors.Is(err, define.ErrNoSuchExecSession) {
+				logrus.Warnf("Stopping container %s exec session %s: %v", c.ID(), sessionID, err)
+			}
+		case <-exited:
+		}
+	}()
+
+	go func() {
+		defer close(stream.done)
+		stream.exitCode, stream.err = c.execSession(sessionID, streams, nil, isHealthcheck)
+		close(exited)
+		select {
+		case <-stopped:
+			if stream.err == nil {
+				stream.exitCode = -1
+				stream.err = fmt.Errorf("container %s exec session %s: %w", c.ID(), sessionID, ctx.Err())
+			}
+		default:
+		}
+		// The attach has finished, all the output of the session has
+		// been written.
+		stdoutW.Close()
+		if stderrW != nil {
+			stderrW.Close()
+		}
+	}()
+
+	return stream, nil
+}
+
+// scanLines splits the output of an exec session into lines, without their
+// line endings.
+func scanLines(output []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
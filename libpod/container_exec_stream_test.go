@@ -0,0 +1,63 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecStreamOutput(t *testing.T) {
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+	stream := &ExecStream{
+		Stdout: stdoutR,
+		Stderr: stderrR,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(stream.done)
+		// Interleave the writes, the reads must not wait for one stream
+		// to be closed before draining the other.
+		_, _ = stdoutW.Write([]byte("out 1\n"))
+		_, _ = stderrW.Write([]byte("err 1\n"))
+		_, _ = stdoutW.Write([]byte("out 2\n"))
+		stream.exitCode = 3
+		stdoutW.Close()
+		stderrW.Close()
+	}()
+
+	stdout, stderr, exitCode, err := stream.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "out 1\nout 2\n", string(stdout))
+	assert.Equal(t, "err 1\n", string(stderr))
+	assert.Equal(t, 3, exitCode)
+}
+
+func TestExecStreamOutputError(t *testing.T) {
+	stdoutR, stdoutW := io.Pipe()
+	stream := &ExecStream{
+		Stdout: stdoutR,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(stream.done)
+		_, _ = stdoutW.Write([]byte("partial"))
+		stdoutW.CloseWithError(errors.New("broken"))
+	}()
+
+	stdout, stderr, _, err := stream.Output()
+	assert.ErrorContains(t, err, "broken")
+	assert.Equal(t, "partial", string(stdout))
+	assert.Empty(t, stderr)
+}
+
+func TestScanLines(t *testing.T) {
+	assert.Equal(t, []string{}, scanLines(nil))
+	assert.Equal(t, []string{"a", "", "b"}, scanLines([]byte("a\n\nb")))
+	assert.Equal(t, []string{"a", "b"}, scanLines([]byte("a\r\nb\n")))
+}
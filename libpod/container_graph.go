@@ -93,6 +93,66 @@ func BuildContainerGraph(ctrs []*Container) (*ContainerGraph, error) {
 	return graph, nil
 }
 
+// SortContainersByDependencies returns the containers ordered such that every
+// container comes after the containers it depends on. Containers in a pod are
+// also placed after the pod's infra container. Dependencies which are not part
+// of the given containers are ignored. Containers which do not depend on each
+// other keep their relative order from the input.
+func SortContainersByDependencies(ctrs []*Container) ([]*Container, error) {
+	index := make(map[string]int, len(ctrs))
+	infra := make(map[string]string)
+	for i, ctr := range ctrs {
+		index[ctr.ID()] = i
+		if ctr.IsInfra() && ctr.PodID() != "" {
+			infra[ctr.PodID()] = ctr.ID()
+		}
+	}
+
+	// Count the dependencies of each container and remember the
+	// containers depending on it
+	pending := make([]int, len(ctrs))
+	dependedOn := make([][]int, len(ctrs))
+	for i, ctr := range ctrs {
+		deps := ctr.Dependencies()
+		if infraID, ok := infra[ctr.PodID()]; ok && infraID != ctr.ID() {
+			deps = append(deps, infraID)
+		}
+		seen := make(map[int]bool, len(deps))
+		for _, dep := range deps {
+			j, ok := index[dep]
+			if !ok || seen[j] {
+				continue
+			}
+			seen[j] = true
+			pending[i]++
+			dependedOn[j] = append(dependedOn[j], i)
+		}
+	}
+
+	// Repeatedly pick the first container in input order whose
+	// dependencies have all been placed
+	sorted := make([]*Container, 0, len(ctrs))
+	placed := make([]bool, len(ctrs))
+	for len(sorted) < len(ctrs) {
+		next := -1
+		for i := range ctrs {
+			if !placed[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("cycle found in container dependency graph: %w", define.ErrInternal)
+		}
+		placed[next] = true
+		sorted = append(sorted, ctrs[next])
+		for _, i := range dependedOn[next] {
+			pending[i]--
+		}
+	}
+	return sorted, nil
+}
+
 // Detect cycles in a container graph using Tarjan's strongly connected
 // components algorithm
 // Return true if a cycle is found, false otherwise
@@ -311,3 +311,52 @@ func TestBuildContainerGraphFourContainersNoneInCycle(t *testing.T) {
 	assert.Equal(t, 2, len(graph.noDepNodes))
 	assert.Equal(t, 2, len(graph.notDependedOnNodes))
 }
+
+func TestSortContainersByDependencies(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr1, err := getTestCtr1(manager)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	assert.NoError(t, err)
+	ctr3, err := getTestCtrN("3", manager)
+	assert.NoError(t, err)
+	ctr4, err := getTestCtrN("4", manager)
+	assert.NoError(t, err)
+	ctr5, err := getTestCtrN("5", manager)
+	assert.NoError(t, err)
+
+	// ctr5 is not being sorted and is ignored
+	ctr1.config.NetNsCtr = ctr2.config.ID
+	ctr2.config.Dependencies = []string{ctr4.config.ID, ctr5.config.ID}
+
+	// ctr3 is in a pod with ctr4 as its infra container
+	ctr3.config.Pod = "pod"
+	ctr4.config.Pod = "pod"
+	ctr4.config.IsInfra = true
+
+	sorted, err := SortContainersByDependencies([]*Container{ctr1, ctr2, ctr3, ctr4})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Container{ctr4, ctr2, ctr1, ctr3}, sorted)
+}
+
+func TestSortContainersByDependenciesCycle(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr1, err := getTestCtr1(manager)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	assert.NoError(t, err)
+
+	ctr1.config.NetNsCtr = ctr2.config.ID
+	ctr2.config.Dependencies = []string{ctr1.config.ID}
+
+	_, err = SortContainersByDependencies([]*Container{ctr1, ctr2})
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// identityDir is the name of the directory in /run which contains the
+	// container's identity certificate and key.
+	identityDir = "identity"
+	// identityCACertFile and identityCAKeyFile are the names of the
+	// certificate and key of the identity CA in the CA directory.
+	identityCACertFile = "ca.crt"
+	identityCAKeyFile  = "ca.key"
+	// DefaultIdentityTTL is the validity of identity certificates if none
+	// is configured.
+	DefaultIdentityTTL = 24 * time.Hour
+)
+
+// identityCA is a local certificate authority issuing identity certificates.
+type identityCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// loadIdentityCA loads the certificate and key of the identity CA from the
+// given directory.
+func loadIdentityCA(dir string) (*identityCA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, identityCACertFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading identity CA certificate: %w", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in %s", filepath.Join(dir, identityCACertFile))
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing identity CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("identity CA certificate %s is not a CA certificate", filepath.Join(dir, identityCACertFile))
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(dir, identityCAKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading identity CA key: %w", err)
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no private key found in %s", filepath.Join(dir, identityCAKeyFile))
+	}
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("parsing identity CA key: %w", err)
+	}
+	return &identityCA{cert: cert, key: key}, nil
+}
+
+// parsePrivateKey parses a PKCS #8, EC or PKCS #1 private key.
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+}
+
+// issue creates a key pair and a certificate for it, valid for ttl, with the
+// given common name, DNS names and IP addresses. It returns the certificate
+// followed by the CA certificate and the key, PEM encoded.
+func (ca *identityCA) issue(commonName string, dnsNames []string, ips []net.IP, ttl time.Duration) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating identity key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating identity certificate serial number: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+		// Allow for some clock skew between the host and the peers.
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(ttl),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	if template.NotAfter.After(ca.cert.NotAfter) {
+		template.NotAfter = ca.cert.NotAfter
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating identity certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding identity key: %w", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})...)
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// identityNames returns the DNS names and IP addresses of the container to
+// include in its identity certificate.
+func (c *Container) identityNames() ([]string, []net.IP) {
+	dnsNames := []string{c.Name()}
+	if hostname := c.Hostname(); hostname != c.Name() {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	var ips []net.IP
+	for _, status := range c.getNetworkStatus() {
+		for _, netInt := range status.Interfaces {
+			for _, netAddress := range netInt.Subnets {
+				ips = append(ips, netAddress.IPNet.IP)
+			}
+		}
+	}
+	return dnsNames, ips
+}
+
+// makeIdentity issues a new identity certificate for the container and
+// writes it, its key and the CA certificate to the identity directory in the
+// container's run directory. The certificate is replaced each time the
+// container is started. It returns the path of the directory.
+func (c *Container) makeIdentity() (string, error) {
+	ca, err := loadIdentityCA(c.config.IdentityCA)
+	if err != nil {
+		return "", err
+	}
+	ttl := c.config.IdentityTTL
+	if ttl == 0 {
+		ttl = DefaultIdentityTTL
+	}
+	dnsNames, ips := c.identityNames()
+	certPEM, keyPEM, err := ca.issue(c.Name(), dnsNames, ips, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(c.state.RunDir, identityDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("removing identity directory: %w", err)
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating identity directory: %w", err)
+	}
+	if err := os.Chown(dir, c.RootUID(), c.RootGID()); err != nil {
+		return "", err
+	}
+	if err := label.Relabel(dir, c.config.MountLabel, false); err != nil && !errors.Is(err, unix.ENOTSUP) {
+		return "", err
+	}
+	files := []struct {
+		name     string
+		contents []byte
+	}{
+		{"cert.pem", certPEM},
+		{"key.pem", keyPEM},
+		{"ca.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})},
+	}
+	for _, file := range files {
+		if err := writeStringToPath(filepath.Join(dir, file.name), string(file.contents), c.config.MountLabel, c.RootUID(), c.RootGID()); err != nil {
+			return "", err
+		}
+	}
+	if err := os.Chmod(filepath.Join(dir, "key.pem"), 0o600); err != nil {
+		return "", err
+	}
+	logrus.Debugf("Issued identity certificate for container %s valid until %s", c.ID(), time.Now().Add(ttl).Format(time.RFC3339))
+	return dir, nil
+}
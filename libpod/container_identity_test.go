@@ -0,0 +1,92 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestIdentityCA(t *testing.T, dir string, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, identityCACertFile), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, identityCAKeyFile), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	require.NoError(t, err)
+}
+
+func TestIdentityCAIssue(t *testing.T) {
+	dir := t.TempDir()
+	caNotAfter := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	writeTestIdentityCA(t, dir, caNotAfter)
+
+	ca, err := loadIdentityCA(dir)
+	require.NoError(t, err)
+
+	certPEM, keyPEM, err := ca.issue("web", []string{"web", "web.example"}, []net.IP{net.ParseIP("10.88.0.2")}, time.Hour)
+	require.NoError(t, err)
+
+	block, rest := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	// The certificate is followed by the CA certificate.
+	caBlock, _ := pem.Decode(rest)
+	require.NotNil(t, caBlock)
+	assert.Equal(t, ca.cert.Raw, caBlock.Bytes)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "web.example", Roots: roots})
+	assert.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "10.88.0.2", Roots: roots})
+	assert.NoError(t, err)
+	assert.Equal(t, "web", cert.Subject.CommonName)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.NotAfter, time.Minute)
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	require.NotNil(t, keyBlock)
+	key, err := parsePrivateKey(keyBlock)
+	require.NoError(t, err)
+	assert.True(t, key.Public().(*ecdsa.PublicKey).Equal(cert.PublicKey))
+
+	// The certificate does not outlive the CA.
+	certPEM, _, err = ca.issue("web", nil, nil, 72*time.Hour)
+	require.NoError(t, err)
+	block, _ = pem.Decode(certPEM)
+	cert, err = x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, caNotAfter.UTC(), cert.NotAfter.UTC())
+}
+
+func TestLoadIdentityCAMissing(t *testing.T) {
+	_, err := loadIdentityCA(t.TempDir())
+	assert.ErrorContains(t, err, "reading identity CA certificate")
+}
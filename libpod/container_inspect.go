@@ -5,6 +5,7 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
@@ -117,6 +118,19 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		}
 	}
 
+	var openFiles, threads uint64
+	var racct map[string]uint64
+	if runtimeInfo.State == define.ContainerStateRunning || runtimeInfo.State == define.ContainerStatePaused {
+		openFiles, threads, err = c.getProcessCounts()
+		if err != nil {
+			logrus.Debugf("Getting process counts for container %s: %v", c.ID(), err)
+		}
+		racct, err = c.racctUsage()
+		if err != nil {
+			logrus.Debugf("Getting resource usage for container %s: %v", c.ID(), err)
+		}
+	}
+
 	data := &define.InspectContainerData{
 		ID:      config.ID,
 		Created: config.CreatedTime,
@@ -144,6 +158,11 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			CheckpointLog:  runtimeInfo.CheckpointLog,
 			RestoreLog:     runtimeInfo.RestoreLog,
 			StoppedByUser:  c.state.StoppedByUser,
+			OpenFiles:      openFiles,
+			Threads:        threads,
+			Jid:            c.state.JID,
+			NetJid:         c.state.NetJID,
+			Racct:          racct,
 		},
 		Image:                   config.RootfsImageID,
 		ImageName:               config.RootfsImageName,
@@ -462,6 +481,15 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	logConfig.Path = c.config.LogPath
 	logConfig.Size = units.HumanSize(float64(c.config.LogSize))
 	logConfig.Tag = c.config.LogTag
+	if len(c.config.LogOptions) > 0 || c.config.LogMaxFiles > 0 {
+		logConfig.Config = make(map[string]string, len(c.config.LogOptions)+1)
+		for key, val := range c.config.LogOptions {
+			logConfig.Config[key] = val
+		}
+		if c.config.LogMaxFiles > 0 {
+			logConfig.Config["max-file"] = strconv.FormatUint(uint64(c.config.LogMaxFiles), 10)
+		}
+	}
 
 	hostConfig.LogConfig = logConfig
 
@@ -3,6 +3,10 @@
 package libpod
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/containers/podman/v5/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -15,5 +19,44 @@ func (c *Container) platformInspectContainerHostConfig(ctrSpec *spec.Spec, hostC
 	// UTS namespace mode
 	hostConfig.UTSMode = c.NamespaceMode(spec.UTSNamespace, ctrSpec)
 
+	// SysV IPC is shared by inheriting the objects of the parent jail
+	switch {
+	case c.config.IPCNsCtr != "":
+		hostConfig.IpcMode = fmt.Sprintf("container:%s", c.config.IPCNsCtr)
+	case c.IsInfra(), ctrSpec.Annotations["org.freebsd.jail.sysvshm"] == "new":
+		hostConfig.IpcMode = "shareable"
+	default:
+		hostConfig.IpcMode = "private"
+	}
+
+	// The pids limit is applied using rctl
+	if ctrSpec.Linux != nil && ctrSpec.Linux.Resources != nil && ctrSpec.Linux.Resources.Pids != nil {
+		hostConfig.PidsLimit = ctrSpec.Linux.Resources.Pids.Limit
+	}
+	if ctrSpec.Linux != nil && ctrSpec.Linux.Resources != nil && ctrSpec.Linux.Resources.Memory != nil && ctrSpec.Linux.Resources.Memory.Reservation != nil {
+		hostConfig.MemoryReservation = *ctrSpec.Linux.Resources.Memory.Reservation
+	}
+	hostConfig.MemoryReclaimSignal = c.config.MemoryReclaimSignal
+	hostConfig.MemoryLock = c.config.MemoryLock
+	if c.config.IOPriority != nil {
+		hostConfig.IOPriority = c.config.IOPriority.Class
+	}
+
+	// Jail parameters which affect what the container can see
+	if enforceStatfs, ok := ctrSpec.Annotations["org.freebsd.jail.enforce_statfs"]; ok {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "enforce_statfs="+enforceStatfs)
+	}
+	if securelevel, ok := ctrSpec.Annotations["org.freebsd.jail.securelevel"]; ok {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "securelevel="+securelevel)
+	}
+	allow := make([]string, 0, len(ctrSpec.Annotations))
+	for key, value := range ctrSpec.Annotations {
+		if param, ok := strings.CutPrefix(key, "org.freebsd.jail.allow."); ok {
+			allow = append(allow, "allow."+param+"="+value)
+		}
+	}
+	sort.Strings(allow)
+	hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, allow...)
+
 	return nil
 }
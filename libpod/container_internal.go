@@ -314,21 +314,9 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 		return false, err
 	}
 
-	if err := c.prepare(); err != nil {
+	if err := c.prepareAndInit(ctx, true); err != nil {
 		return false, err
 	}
-
-	if c.state.State == define.ContainerStateStopped {
-		// Reinitialize the container if we need to
-		if err := c.reinit(ctx, true); err != nil {
-			return false, err
-		}
-	} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
-		// Initialize the container
-		if err := c.init(ctx, true); err != nil {
-			return false, err
-		}
-	}
 	if err := c.start(ctx); err != nil {
 		return false, err
 	}
@@ -352,6 +340,12 @@ func (c *Container) ensureState(states ...define.ContainerStatus) bool {
 // This function should suffice to ensure a container's state is accurate and
 // it is valid for use.
 func (c *Container) syncContainer() error {
+	// Don't lose deferred changes when reloading the state
+	if c.stateDirty {
+		if err := c.writeState(); err != nil {
+			return err
+		}
+	}
 	if err := c.runtime.state.UpdateContainer(c); err != nil {
 		return err
 	}
@@ -364,8 +358,10 @@ func (c *Container) syncContainer() error {
 			return err
 		}
 
+		jailIDsChanged := c.syncJailIDs()
+
 		// Only save back to DB if state changed
-		if c.state.State != oldState {
+		if c.state.State != oldState || jailIDsChanged {
 			// Check for a restart policy match
 			if c.config.RestartPolicy != define.RestartPolicyNone && c.config.RestartPolicy != define.RestartPolicyNo &&
 				(oldState == define.ContainerStateRunning || oldState == define.ContainerStatePaused) &&
@@ -627,6 +623,9 @@ func (c *Container) teardownStorage() error {
 func resetContainerState(state *ContainerState) {
 	state.PID = 0
 	state.ConmonPID = 0
+	state.JID = 0
+	state.NetJID = 0
+	state.PausedPIDs = nil
 	state.Mountpoint = ""
 	state.Mounted = false
 	// Reset state.
@@ -634,6 +633,12 @@ func resetContainerState(state *ContainerState) {
 	// except ContainerStateRemoving which is preserved.
 	switch state.State {
 	case define.ContainerStateStopped, define.ContainerStateExited, define.ContainerStateStopping, define.ContainerStateRunning, define.ContainerStatePaused:
+		// Containers still running were not stopped before the host
+		// went down, remember them as if their state had been
+		// recorded.
+		if state.State == define.ContainerStateRunning || state.State == define.ContainerStatePaused {
+			state.PreviouslyRunning = true
+		}
 		// All containers that ran at any point during the last boot
 		// must be placed in the Exited state.
 		state.State = define.ContainerStateExited
@@ -738,6 +743,26 @@ func (c *Container) refresh() error {
 	return nil
 }
 
+// recordRunning sets whether the container is currently running or paused as
+// its state before a shutdown of the host.
+func (c *Container) recordRunning() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	running := c.state.State == define.ContainerStateRunning || c.state.State == define.ContainerStatePaused
+	if c.state.PreviouslyRunning == running {
+		return nil
+	}
+	c.state.PreviouslyRunning = running
+	return c.save()
+}
+
 // Remove conmon attach socket and terminal resize FIFO
 // This is necessary for restarting containers
 func (c *Container) removeConmonFiles() error {
@@ -812,13 +837,71 @@ func (c *Container) getArtifactPath(name string) string {
 }
 
 // save container state to the database
+// If saves are being deferred by batchSaves(), the state is only marked as
+// dirty and is written when the batch ends.
 func (c *Container) save() error {
+	if c.saveDeferred {
+		c.stateDirty = true
+		return nil
+	}
+	return c.writeState()
+}
+
+// writeState writes the container state to the database immediately, along
+// with any changes whose save has been deferred.
+func (c *Container) writeState() error {
 	if err := c.runtime.state.SaveContainer(c); err != nil {
 		return fmt.Errorf("saving container %s state: %w", c.ID(), err)
 	}
+	c.stateDirty = false
 	return nil
 }
 
+// batchSaves calls fn with saves of the container state deferred, so that
+// all the changes fn makes to the state are written to the database in a
+// single transaction. The state is written even if fn fails. Changes which
+// record resources that must be cleaned up if Podman is killed before the
+// batch ends, such as the network namespace and mounts, are written at once
+// with writeState() instead.
+func (c *Container) batchSaves(fn func() error) error {
+	if c.saveDeferred {
+		return fn()
+	}
+	c.saveDeferred = true
+	err := fn()
+	c.saveDeferred = false
+	if c.stateDirty {
+		if saveErr := c.writeState(); saveErr != nil {
+			if err == nil {
+				return saveErr
+			}
+			logrus.Errorf("Saving container %s state: %v", c.ID(), saveErr)
+		}
+	}
+	return err
+}
+
+// prepareAndInit prepares the container and then initializes it in the OCI
+// runtime if it has not been created there already. prepare() writes the
+// resources it sets up to the database immediately, the saves made while
+// (re)initializing are written together when the container is created in
+// the OCI runtime.
+func (c *Container) prepareAndInit(ctx context.Context, retainRetries bool) error {
+	return c.batchSaves(func() error {
+		if err := c.prepare(); err != nil {
+			return err
+		}
+		if c.state.State == define.ContainerStateStopped {
+			// Reinitialize the container if we need to
+			return c.reinit(ctx, retainRetries)
+		} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
+			// Or initialize it if necessary
+			return c.init(ctx, retainRetries)
+		}
+		return nil
+	})
+}
+
 // Checks the container is in the right state, then initializes the container in preparation to start the container.
 // If recursive is true, each of the container's dependencies will be started.
 // Otherwise, this function will return with error if there are dependencies of this container that aren't running.
@@ -846,22 +929,8 @@ func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr
 		}
 	}()
 
-	if err := c.prepare(); err != nil {
-		return err
-	}
-
-	if c.state.State == define.ContainerStateStopped {
-		// Reinitialize the container if we need to
-		if err := c.reinit(ctx, false); err != nil {
-			return err
-		}
-	} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
-		// Or initialize it if necessary
-		if err := c.init(ctx, false); err != nil {
-			return err
-		}
-	}
-	return nil
+	c.startTiming = newStartTiming(c.ID())
+	return c.prepareAndInit(ctx, false)
 }
 
 // checks dependencies are running and prints a helpful message
@@ -916,9 +985,11 @@ func (c *Container) startDependencies(ctx context.Context) error {
 	ctrErrors := make(map[string]error)
 	ctrsVisited := make(map[string]bool)
 
-	// Traverse the graph beginning at nodes with no dependencies
+	// Traverse the graph beginning at nodes with no dependencies.
+	// Dependencies which are already running are left alone, the others
+	// are started once all of their own dependencies are running.
 	for _, node := range graph.noDepNodes {
-		startNode(ctx, node, false, ctrErrors, ctrsVisited, true)
+		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
 	}
 
 	if len(ctrErrors) > 0 {
@@ -1038,12 +1109,19 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		return err
 	}
 
+	// Create the host directories of mounts named by placeholders
+	if err := c.createMountSources(); err != nil {
+		return err
+	}
+
 	// Generate the OCI newSpec
+	specStart := time.Now()
 	newSpec, cleanupFunc, err := c.generateSpec(ctx)
 	if err != nil {
 		return err
 	}
 	defer cleanupFunc()
+	c.startTiming.record(startPhaseSpec, specStart)
 
 	// Make sure the workdir exists while initializing container
 	if err := c.resolveWorkDir(); err != nil {
@@ -1082,12 +1160,27 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	}
 
 	// With the spec complete, do an OCI create
+	createStart := time.Now()
 	if _, err = c.ociRuntime.CreateContainer(c, nil); err != nil {
 		return err
 	}
+	c.startTiming.record(startPhaseRuntimeCreate, createStart)
+	c.runtime.recordJail(c.ID())
 
 	logrus.Debugf("Created container %s in OCI runtime", c.ID())
 
+	c.syncJailIDs()
+
+	if err := c.prestartHooks(ctx, newSpec.Annotations); err != nil {
+		return fmt.Errorf("container %s: %w", c.ID(), err)
+	}
+
+	limitsStart := time.Now()
+	if err := c.setupResourceLimits(); err != nil {
+		return err
+	}
+	c.startTiming.record(startPhaseResourceLimits, limitsStart)
+
 	// Remove any exec sessions leftover from a potential prior run.
 	if len(c.state.ExecSessions) > 0 {
 		if err := c.runtime.state.RemoveContainerExecSessions(c); err != nil {
@@ -1125,7 +1218,10 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		}
 	}
 
-	if err := c.save(); err != nil {
+	// Write the state now, together with any changes deferred while
+	// preparing the container, so Conmon's PID is recorded before
+	// shutdown signals are allowed again.
+	if err := c.writeState(); err != nil {
 		return err
 	}
 
@@ -1232,24 +1328,11 @@ func (c *Container) initAndStart(ctx context.Context) (retErr error) {
 		}
 	}()
 
-	if err := c.prepare(); err != nil {
+	c.startTiming = newStartTiming(c.ID())
+	if err := c.prepareAndInit(ctx, false); err != nil {
 		return err
 	}
 
-	// If we are ContainerStateStopped we need to remove from runtime
-	// And reset to ContainerStateConfigured
-	if c.state.State == define.ContainerStateStopped {
-		logrus.Debugf("Recreating container %s in OCI runtime", c.ID())
-
-		if err := c.reinit(ctx, false); err != nil {
-			return err
-		}
-	} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
-		if err := c.init(ctx, false); err != nil {
-			return err
-		}
-	}
-
 	// Now start the container
 	return c.start(ctx)
 }
@@ -1260,12 +1343,17 @@ func (c *Container) start(ctx context.Context) error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	runtimeStart := time.Now()
 	if err := c.ociRuntime.StartContainer(c); err != nil {
 		return err
 	}
+	c.startTiming.record(startPhaseRuntimeStart, runtimeStart)
 	logrus.Debugf("Started container %s", c.ID())
+	c.startTiming.finish()
+	c.startTiming = nil
 
 	c.state.State = define.ContainerStateRunning
+	c.state.PreviouslyRunning = false
 
 	// Unless being ignored, set the MAINPID to conmon.
 	if c.config.SdNotifyMode != define.SdNotifyModeIgnore {
@@ -1294,6 +1382,12 @@ func (c *Container) start(ctx context.Context) error {
 		}
 	}
 
+	if c.needsLogManager() {
+		if err := c.startLogManager(); err != nil {
+			logrus.Errorf("Managing log of container %s: %v", c.ID(), err)
+		}
+	}
+
 	c.newContainerEvent(events.Start)
 
 	if err := c.save(); err != nil {
@@ -1457,22 +1551,7 @@ func (c *Container) waitForConmonToExitAndSave() error {
 
 // Internal, non-locking function to pause a container
 func (c *Container) pause() error {
-	if c.config.NoCgroups {
-		return fmt.Errorf("cannot pause without using Cgroups: %w", define.ErrNoCgroups)
-	}
-
-	if rootless.IsRootless() {
-		cgroupv2, err := cgroups.IsCgroup2UnifiedMode()
-		if err != nil {
-			return fmt.Errorf("failed to determine cgroupversion: %w", err)
-		}
-		if !cgroupv2 {
-			return fmt.Errorf("can not pause containers on rootless containers with cgroup V1: %w", define.ErrNoCgroups)
-		}
-	}
-
-	if err := c.ociRuntime.PauseContainer(c); err != nil {
-		// TODO when using docker-py there is some sort of race/incompatibility here
+	if err := c.pauseProcesses(); err != nil {
 		return err
 	}
 
@@ -1485,12 +1564,7 @@ func (c *Container) pause() error {
 
 // Internal, non-locking function to unpause a container
 func (c *Container) unpause() error {
-	if c.config.NoCgroups {
-		return fmt.Errorf("cannot unpause without using Cgroups: %w", define.ErrNoCgroups)
-	}
-
-	if err := c.ociRuntime.UnpauseContainer(c); err != nil {
-		// TODO when using docker-py there is some sort of race/incompatibility here
+	if err := c.unpauseProcesses(); err != nil {
 		return err
 	}
 
@@ -1551,22 +1625,10 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 			}
 		}
 	}()
-	if err := c.prepare(); err != nil {
+	c.startTiming = newStartTiming(c.ID())
+	if err := c.prepareAndInit(ctx, false); err != nil {
 		return err
 	}
-
-	if c.state.State == define.ContainerStateStopped {
-		// Reinitialize the container if we need to
-		if err := c.reinit(ctx, false); err != nil {
-			return err
-		}
-	} else if c.state.State == define.ContainerStateConfigured ||
-		c.state.State == define.ContainerStateExited {
-		// Initialize the container
-		if err := c.init(ctx, false); err != nil {
-			return err
-		}
-	}
 	return c.start(ctx)
 }
 
@@ -1729,10 +1791,25 @@ func (c *Container) mountStorage() (_ string, deferredErr error) {
 	}
 
 	tz := c.Timezone()
+	if tz == "local" {
+		// A host without /etc/localtime uses UTC, which is the
+		// default on FreeBSD.
+		if _, err := os.Stat("/etc/localtime"); errors.Is(err, os.ErrNotExist) {
+			tz = "UTC"
+		}
+	}
 	localTimePath, err := timezone.ConfigureContainerTimeZone(tz, c.state.RunDir, mountPoint, etcInTheContainerPath, c.ID())
 	if err != nil {
 		return "", fmt.Errorf("configuring timezone for container %s: %w", c.ID(), err)
 	}
+	if localTimePath != "" && c.config.Spec.Annotations[define.TimezoneCopyAnnotation] == "true" {
+		// The image lacks the zone file, copy it into the container
+		// instead of bind mounting it over /etc/localtime.
+		if err := copyLocaltime(localTimePath, etcInTheContainerFd, rootUID, rootGID); err != nil {
+			return "", fmt.Errorf("configuring timezone for container %s: %w", c.ID(), err)
+		}
+		localTimePath = ""
+	}
 	if localTimePath != "" {
 		if err := c.relabel(localTimePath, c.config.MountLabel, false); err != nil {
 			return "", err
@@ -1764,6 +1841,29 @@ func (c *Container) mountStorage() (_ string, deferredErr error) {
 	return mountPoint, nil
 }
 
+// copyLocaltime copies the zone file src to /etc/localtime in the container,
+// given by the file descriptor of its /etc directory, owned by the
+// container's root user.
+func copyLocaltime(src string, etcFd, uid, gid int) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	fd, err := unix.Openat(etcFd, "localtime", unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0o644)
+	if err != nil {
+		return fmt.Errorf("creating /etc/localtime: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "localtime")
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing /etc/localtime: %w", err)
+	}
+	if err := f.Chown(uid, gid); err != nil {
+		return fmt.Errorf("chown /etc/localtime: %w", err)
+	}
+	return nil
+}
+
 // Mount a single named volume into the container.
 // If necessary, copy up image contents into the volume.
 // Does not verify that the name volume given is actually present in container
@@ -1929,6 +2029,10 @@ func (c *Container) cleanupStorage() error {
 		}
 	}
 
+	if err := c.cleanupPlatformMounts(); err != nil {
+		reportErrorf("unmounting container %s: %w", c.ID(), err)
+	}
+
 	if err := c.cleanupOverlayMounts(); err != nil {
 		// If the container can't remove content report the error
 		reportErrorf("failed to clean up overlay mounts for %s: %w", c.ID(), err)
@@ -1991,6 +2095,13 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Remove resource limits which are not tied to the lifetime of the
+	// container. On FreeBSD, this must happen before the network is
+	// cleaned up since the jail name depends on the network namespace.
+	if err := c.cleanupResourceLimits(); err != nil {
+		logrus.Errorf("Removing container %s resource limits: %v", c.ID(), err)
+	}
+
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = fmt.Errorf("removing container %s network: %w", c.ID(), err)
@@ -2026,6 +2137,33 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Ephemeral secrets must not outlive the container's run.
+	if err := c.removeEphemeralSecrets(); err != nil {
+		if lastError != nil {
+			logrus.Errorf("Removing container %s ephemeral secrets: %v", c.ID(), err)
+		} else {
+			lastError = err
+		}
+	}
+
+	if err := c.stopConsole(); err != nil {
+		if lastError != nil {
+			logrus.Errorf("Stopping container %s console: %v", c.ID(), err)
+		} else {
+			lastError = err
+		}
+	}
+
+	// Make sure the network jail released above is gone now that the
+	// container is out of the runtime.
+	if err := c.reapNetworkJail(); err != nil {
+		if lastError != nil {
+			logrus.Errorf("Removing container %s network jail: %v", c.ID(), err)
+		} else {
+			lastError = err
+		}
+	}
+
 	// Unmount storage
 	if err := c.cleanupStorage(); err != nil {
 		if lastError != nil {
@@ -2044,6 +2182,7 @@ func (c *Container) cleanup(ctx context.Context) error {
 				continue
 			}
 			logrus.Errorf("Unmounting image volume %q:%q :%v", v.Source, v.Dest, err)
+			continue
 		}
 		if err := img.Unmount(false); err != nil {
 			if lastError == nil {
@@ -2125,6 +2264,61 @@ func (c *Container) delete(ctx context.Context) error {
 	return nil
 }
 
+// prestartHooks runs the prestart and createRuntime hooks which the OCI
+// runtime does not run itself (if any). They are run after the runtime created
+// the container and before it is started, as specified by the OCI Runtime
+// Specification. The hooks are passed the annotations of the generated spec,
+// which include some only known when the container is initialized, e.g. the
+// name of its jail on FreeBSD. A failing hook fails the container's
+// initialization.
+func (c *Container) prestartHooks(ctx context.Context, annotations map[string]string) error {
+	if c.state.ExtensionStageHooks == nil {
+		return nil
+	}
+	state, err := json.Marshal(spec.State{
+		Version:     spec.Version,
+		ID:          c.ID(),
+		Status:      spec.StateCreated,
+		Pid:         c.state.PID,
+		Bundle:      c.bundlePath(),
+		Annotations: annotations,
+	})
+	if err != nil {
+		return err
+	}
+	for _, stage := range []string{"prestart", "createRuntime"} {
+		for i, hook := range c.state.ExtensionStageHooks[stage] {
+			hook := hook
+			logrus.Debugf("container %s: invoke %s hook %d, path %s", c.ID(), stage, i, hook.Path)
+			var stderr, stdout bytes.Buffer
+			hookErr, err := exec.RunWithOptions(
+				ctx,
+				exec.RunOptions{
+					Hook:            &hook,
+					Dir:             c.bundlePath(),
+					State:           state,
+					Stdout:          &stdout,
+					Stderr:          &stderr,
+					PostKillTimeout: exec.DefaultPostKillTimeout,
+				},
+			)
+			if err != nil {
+				if hookErr != err {
+					logrus.Debugf("container %s: %s hook %d (hook error): %v", c.ID(), stage, i, hookErr)
+				}
+				if stdoutString := stdout.String(); stdoutString != "" {
+					logrus.Debugf("container %s: %s hook %d: stdout:\n%s", c.ID(), stage, i, stdoutString)
+				}
+				if stderrString := stderr.String(); stderrString != "" {
+					logrus.Debugf("container %s: %s hook %d: stderr:\n%s", c.ID(), stage, i, stderrString)
+				}
+				return fmt.Errorf("%s hook %s: %w", stage, hook.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
 // postDeleteHooks runs the poststop hooks (if any) as specified by
 // the OCI Runtime Specification (which requires them to run
 // post-delete, despite the stage name).
@@ -2249,13 +2443,24 @@ func (c *Container) saveSpec(spec *spec.Spec) error {
 
 // Warning: precreate hooks may alter 'config' in place.
 func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[string][]spec.Hook, error) {
+	// Hooks of the stages which the OCI runtime does not run itself but
+	// which can be run from the host once the container was created are
+	// run by libpod, see prestartHooks.
+	extensionStages := []string{"precreate", "poststop"}
+	for _, stage := range []string{"prestart", "createRuntime"} {
+		if !c.ociRuntime.SupportsHook(stage) {
+			extensionStages = append(extensionStages, stage)
+		}
+	}
+
 	allHooks := make(map[string][]spec.Hook)
-	if len(c.runtime.config.Engine.HooksDir.Get()) == 0 {
+	hooksDirs := platformHooksDirs(c.runtime.config.Engine.HooksDir.Get())
+	if len(hooksDirs) == 0 {
 		if rootless.IsRootless() {
 			return nil, nil
 		}
-		for _, hDir := range []string{hooks.DefaultDir, hooks.OverrideDir} {
-			manager, err := hooks.New(ctx, []string{hDir}, []string{"precreate", "poststop"})
+		for _, hDir := range implicitHooksDirs {
+			manager, err := hooks.New(ctx, []string{hDir}, extensionStages)
 			if err != nil {
 				if os.IsNotExist(err) {
 					continue
@@ -2274,7 +2479,7 @@ func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[s
 			}
 		}
 	} else {
-		manager, err := hooks.New(ctx, c.runtime.config.Engine.HooksDir.Get(), []string{"precreate", "poststop"})
+		manager, err := hooks.New(ctx, hooksDirs, extensionStages)
 		if err != nil {
 			return nil, err
 		}
@@ -2284,6 +2489,17 @@ func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[s
 			return nil, err
 		}
 	}
+	if config.Hooks != nil {
+		for stage, stageHooks := range map[string][]spec.Hook{
+			"createContainer": config.Hooks.CreateContainer,
+			"startContainer":  config.Hooks.StartContainer,
+			"poststart":       config.Hooks.Poststart,
+		} {
+			if len(stageHooks) > 0 && !c.ociRuntime.SupportsHook(stage) {
+				logrus.Warnf("Container %s: OCI runtime %s does not support %s hooks, %d hooks will not run", c.ID(), c.ociRuntime.Name(), stage, len(stageHooks))
+			}
+		}
+	}
 
 	hookErr, err := exec.RuntimeConfigFilterWithOptions(
 		ctx,
@@ -2470,6 +2686,26 @@ func (c *Container) checkExitFile() error {
 	return c.handleExitFile(exitFile, info)
 }
 
+// hasExitFile returns true if the container is running according to its state
+// but Conmon has written its exit file, so its state needs to be synced.
+func (c *Container) hasExitFile() (bool, error) {
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping) {
+		return false, nil
+	}
+
+	exitFile, err := c.exitFilePath()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(exitFile); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("running stat on container %s exit file: %w", c.ID(), err)
+	}
+	return true, nil
+}
+
 func (c *Container) hasNamespace(namespace spec.LinuxNamespaceType) bool {
 	if c.config.Spec == nil || c.config.Spec.Linux == nil {
 		return false
@@ -2482,7 +2718,65 @@ func (c *Container) hasNamespace(namespace spec.LinuxNamespaceType) bool {
 	return false
 }
 
-// extractSecretToStorage copies a secret's data from the secrets manager to the container's static dir
+// secretPath returns the path of the file holding a secret's data on the
+// host. Ephemeral secrets are kept in the container's run directory, the
+// others in its static dir.
+func (c *Container) secretPath(secr *ContainerSecret) string {
+	if secr.Ephemeral {
+		return filepath.Join(c.ephemeralSecretsDir(), secr.Name)
+	}
+	return filepath.Join(c.config.SecretsPath, secr.Name)
+}
+
+// ephemeralSecretsDir returns the directory holding the data of the
+// container's ephemeral secrets while it runs.
+func (c *Container) ephemeralSecretsDir() string {
+	return filepath.Join(c.state.RunDir, "secrets")
+}
+
+// mountEphemeralSecretsDir creates the directory for the ephemeral secrets of
+// the container. Unless it is on tmpfs already, as the run directory usually
+// is on Linux but not on FreeBSD, a tmpfs is mounted on it so that the data of
+// the secrets is never written to disk.
+func (c *Container) mountEphemeralSecretsDir() error {
+	dir := c.ephemeralSecretsDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	onTmpfs, err := isTmpfs(dir)
+	if err != nil || onTmpfs {
+		return err
+	}
+	if rootless.IsRootless() {
+		return fmt.Errorf("ephemeral secrets need the run directory %s to be on tmpfs: %w", c.state.RunDir, define.ErrInvalidArg)
+	}
+	if err := mount.Mount("tmpfs", dir, "tmpfs", "mode=0700"); err != nil {
+		return fmt.Errorf("mounting tmpfs for ephemeral secrets on %s: %w", dir, err)
+	}
+	return nil
+}
+
+// removeEphemeralSecrets removes the data of the container's ephemeral
+// secrets, which is looked up again when the container is started.
+func (c *Container) removeEphemeralSecrets() error {
+	if c.state.RunDir == "" {
+		return nil
+	}
+	dir := c.ephemeralSecretsDir()
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := mount.Unmount(dir); err != nil {
+		return fmt.Errorf("unmounting ephemeral secrets of container %s: %w", c.ID(), err)
+	}
+	return os.RemoveAll(dir)
+}
+
+// extractSecretToStorage copies a secret's data from the secrets manager to the container's static dir,
+// or to its run directory for ephemeral secrets
 func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 	manager, err := c.runtime.SecretsManager()
 	if err != nil {
@@ -2492,13 +2786,20 @@ func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 	if err != nil {
 		return err
 	}
-	secretFile := filepath.Join(c.config.SecretsPath, secr.Name)
+	secretFile := c.secretPath(secr)
+	if secr.Ephemeral {
+		if err := c.mountEphemeralSecretsDir(); err != nil {
+			return err
+		}
+	}
 
 	hostUID, hostGID, err := butil.GetHostIDs(util.IDtoolsToRuntimeSpec(c.config.IDMappings.UIDMap), util.IDtoolsToRuntimeSpec(c.config.IDMappings.GIDMap), secr.UID, secr.GID)
 	if err != nil {
 		return fmt.Errorf("unable to extract secret: %w", err)
 	}
-	err = os.WriteFile(secretFile, data, 0644)
+	// Only make the secret accessible to others once it has the requested
+	// owner and mode.
+	err = os.WriteFile(secretFile, data, 0600)
 	if err != nil {
 		return fmt.Errorf("unable to create %s: %w", secretFile, err)
 	}
@@ -440,7 +440,7 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		g.AddMount(overlayMount)
 	}
 
-	// Add image volumes as overlay mounts
+	// Add image volumes
 	for _, volume := range c.config.ImageVolumes {
 		// Mount the specified image.
 		img, _, err := c.runtime.LibimageRuntime().LookupImage(volume.Source, nil)
@@ -457,16 +457,13 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 			return nil, nil, fmt.Errorf("failed to create TempDir in the %s directory: %w", c.config.StaticDir, err)
 		}
 
-		var overlayMount spec.Mount
-		if volume.ReadWrite {
-			overlayMount, err = overlay.Mount(contentDir, mountPoint, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
-		} else {
-			overlayMount, err = overlay.MountReadOnly(contentDir, mountPoint, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
-		}
+		imageMounts, err := c.imageVolumeMounts(contentDir, mountPoint, volume)
 		if err != nil {
-			return nil, nil, fmt.Errorf("creating overlay mount for image %q failed: %w", volume.Source, err)
+			return nil, nil, fmt.Errorf("creating mount for image %q failed: %w", volume.Source, err)
+		}
+		for _, m := range imageMounts {
+			g.AddMount(m)
 		}
-		g.AddMount(overlayMount)
 	}
 
 	err = c.setHomeEnvIfNeeded()
@@ -504,6 +501,18 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		return nil, nil, err
 	}
 
+	if err := c.addMemoryLock(&g); err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.addCoreDumpMount(&g); err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.addConsole(&g); err != nil {
+		return nil, nil, err
+	}
+
 	// Look up and add groups the user belongs to, if a group wasn't directly specified
 	if !strings.Contains(c.config.User, ":") {
 		// the gidMappings that are present inside the container user namespace
@@ -551,7 +560,9 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		return nil, nil, err
 	}
 
-	g.SetRootPath(c.state.Mountpoint)
+	if err := c.setRootPath(&g); err != nil {
+		return nil, nil, err
+	}
 	g.AddAnnotation("org.opencontainers.image.stopSignal", strconv.FormatUint(uint64(c.config.StopSignal), 10))
 
 	if _, exists := g.Config.Annotations[annotations.ContainerManager]; !exists {
@@ -574,6 +585,9 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		if err != nil {
 			return nil, nil, fmt.Errorf("setting up CDI devices: %w", err)
 		}
+		if err := util.ConvertCDIEdits(g.Config); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Mounts need to be sorted so paths will not cover other paths
@@ -600,6 +614,10 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		return nil, nil, err
 	}
 
+	if err := c.addJailNameAnnotation(&g); err != nil {
+		return nil, nil, err
+	}
+
 	// Warning: precreate hooks may alter g.Config in place.
 	if c.state.ExtensionStageHooks, err = c.setupOCIHooks(ctx, g.Config); err != nil {
 		return nil, nil, fmt.Errorf("setting up OCI Hooks: %w", err)
@@ -630,6 +648,12 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		}
 	}
 
+	if c.config.Timezone != "" && c.config.Spec.Annotations[define.TimezoneEnvAnnotation] == "true" {
+		if err := c.addTimezoneEnv(&g); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// setup rlimits
 	nofileSet := false
 	nprocSet := false
@@ -742,13 +766,25 @@ func (c *Container) isWorkDirSymlink(resolvedPath string) bool {
 func (c *Container) resolveWorkDir() error {
 	workdir := c.WorkingDir()
 
-	// If the specified workdir is a subdir of a volume or mount,
-	// we don't need to do anything.  The runtime is taking care of
-	// that.
-	if isPathOnVolume(c, workdir) || isPathOnMount(c, workdir) {
-		logrus.Debugf("Workdir %q resolved to a volume or mount", workdir)
+	// If the specified workdir is a subdir of a mount, we don't need to
+	// do anything.  The runtime is taking care of that.
+	if isPathOnMount(c, workdir) {
+		logrus.Debugf("Workdir %q resolved to a mount", workdir)
 		return nil
 	}
+	// Named volumes are mounted by now, so a missing workdir below a
+	// volume is created inside of the volume, whether it was given by the
+	// user or comes from the image. Volumes which only mount a subpath are
+	// left to the runtime.
+	createWorkDir := c.config.CreateWorkingDir
+	if isPathOnVolume(c, workdir) {
+		vol := namedVolumeForPath(c, workdir)
+		if vol == nil || vol.SubPath != "" {
+			logrus.Debugf("Workdir %q resolved to a volume", workdir)
+			return nil
+		}
+		createWorkDir = true
+	}
 
 	_, resolvedWorkdir, err := c.resolvePath(c.state.Mountpoint, workdir)
 	if err != nil {
@@ -763,7 +799,7 @@ func (c *Container) resolveWorkDir() error {
 		}
 		return nil
 	}
-	if !c.config.CreateWorkingDir {
+	if !createWorkDir {
 		// No need to create it (e.g., `--workdir=/foo`), so let's make sure
 		// the path exists on the container.
 		if err != nil {
@@ -793,13 +829,31 @@ func (c *Container) resolveWorkDir() error {
 	if err != nil {
 		return fmt.Errorf("looking up %s inside of the container %s: %w", c.User(), c.ID(), err)
 	}
-	if err := idtools.SafeChown(resolvedWorkdir, int(uid), int(gid)); err != nil {
+	hostUID, hostGID, err := c.hostIDs(int(uid), int(gid))
+	if err != nil {
+		return err
+	}
+	if err := idtools.SafeChown(resolvedWorkdir, hostUID, hostGID); err != nil {
 		return fmt.Errorf("chowning container %s workdir to container root: %w", c.ID(), err)
 	}
 
 	return nil
 }
 
+// hostIDs maps a UID and GID of the container to the host, using the ID
+// mappings of the container.
+func (c *Container) hostIDs(uid, gid int) (int, int, error) {
+	if c.config.IDMappings.UIDMap == nil {
+		return uid, gid, nil
+	}
+	mappings := idtools.NewIDMappingsFromMaps(c.config.IDMappings.UIDMap, c.config.IDMappings.GIDMap)
+	pair, err := mappings.ToHost(idtools.IDPair{UID: uid, GID: gid})
+	if err != nil {
+		return 0, 0, fmt.Errorf("mapping user %d:%d: %w", uid, gid, err)
+	}
+	return pair.UID, pair.GID, nil
+}
+
 func (c *Container) getUserOverrides() *lookup.Overrides {
 	var hasPasswdFile, hasGroupFile bool
 	overrides := lookup.Overrides{}
@@ -1150,18 +1204,40 @@ func (c *Container) exportCheckpoint(options ContainerCheckpointOptions) error {
 	return nil
 }
 
-func (c *Container) checkpointRestoreSupported(version int) error {
+func (c *Container) checkpointRestoreSupported(version int, options ContainerCheckpointOptions) error {
 	if err := criu.CheckForCriu(version); err != nil {
 		return err
 	}
 	if !c.ociRuntime.SupportsCheckpoint() {
 		return errors.New("configured runtime does not support checkpoint/restore")
 	}
+	for _, option := range options.runtimeOptions() {
+		if !c.ociRuntime.SupportsCheckpointOption(option) {
+			return fmt.Errorf("configured runtime does not support checkpoint/restore with --%s: %w", option, define.ErrOSNotSupported)
+		}
+	}
 	return nil
 }
 
+// runtimeOptions returns the names of the checkpoint and restore options of
+// the OCI runtime which are requested.
+func (options ContainerCheckpointOptions) runtimeOptions() []string {
+	var names []string
+	if options.TCPEstablished {
+		names = append(names, "tcp-established")
+	}
+	if options.FileLocks {
+		names = append(names, "file-locks")
+	}
+	if options.TCPClose {
+		names = append(names, "tcp-close")
+	}
+	return names
+}
+
 func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointOptions) (*define.CRIUCheckpointRestoreStatistics, int64, error) {
-	if err := c.checkpointRestoreSupported(criu.MinCriuVersion); err != nil {
+	start := time.Now()
+	if err := c.checkpointRestoreSupported(criu.MinCriuVersion, options); err != nil {
 		return nil, 0, err
 	}
 
@@ -1221,7 +1297,10 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		return nil, 0, err
 	}
 
-	defer c.newContainerEvent(events.Checkpoint)
+	var criuStatistics *define.CRIUCheckpointRestoreStatistics
+	defer func() {
+		c.newCheckpointEvent(events.Checkpoint, time.Since(start), runtimeCheckpointDuration, criuStatistics)
+	}()
 
 	// There is a bug from criu: https://github.com/checkpoint-restore/criu/issues/116
 	// We have to change the symbolic link from absolute path to relative path
@@ -1257,7 +1336,7 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		}
 	}
 
-	criuStatistics, err := func() (*define.CRIUCheckpointRestoreStatistics, error) {
+	criuStatistics, err = func() (*define.CRIUCheckpointRestoreStatistics, error) {
 		if !options.PrintStats {
 			return nil, nil
 		}
@@ -1390,7 +1469,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		}
 		return criu.PodCriuVersion
 	}()
-	if err := c.checkpointRestoreSupported(minCriuVersion); err != nil {
+	if err := c.checkpointRestoreSupported(minCriuVersion, options); err != nil {
 		return nil, 0, err
 	}
 
@@ -1473,24 +1552,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 
 		netOpts := make(map[string]types.PerNetworkOptions, len(netStatus))
 		for network, perNetOpts := range networkOpts {
-			// unset mac and ips before we start adding the ones from the status
-			perNetOpts.StaticMAC = nil
-			perNetOpts.StaticIPs = nil
-			for name, netInt := range netStatus[network].Interfaces {
-				perNetOpts.InterfaceName = name
-				if !options.IgnoreStaticMAC {
-					perNetOpts.StaticMAC = netInt.MacAddress
-				}
-				if !options.IgnoreStaticIP {
-					for _, netAddress := range netInt.Subnets {
-						perNetOpts.StaticIPs = append(perNetOpts.StaticIPs, netAddress.IPNet.IP)
-					}
-				}
-				// Normally interfaces have a length of 1, only for some special cni configs we could get more.
-				// For now just use the first interface to get the ips this should be good enough for most cases.
-				break
-			}
-			netOpts[network] = perNetOpts
+			netOpts[network] = statusNetworkOptions(perNetOpts, netStatus[network], !options.IgnoreStaticIP, !options.IgnoreStaticMAC)
 		}
 		c.perNetworkOpts = netOpts
 	}
@@ -1700,6 +1762,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 	if err != nil {
 		return nil, 0, err
 	}
+	c.runtime.recordJail(c.ID())
 
 	criuStatistics, err = func() (*define.CRIUCheckpointRestoreStatistics, error) {
 		if !options.PrintStats {
@@ -1816,6 +1879,16 @@ func (c *Container) mountIntoRootDirs(mountName string, mountPath string) error
 	return nil
 }
 
+// removeFromRootDirs removes a standard bind mount added by mountIntoRootDirs
+// from all root directories.
+func (c *Container) removeFromRootDirs(mountName string) {
+	delete(c.state.BindMounts, mountName)
+
+	for _, chrootDir := range c.config.ChrootDirs {
+		delete(c.state.BindMounts, filepath.Join(chrootDir, mountName))
+	}
+}
+
 // Make standard bind mounts to include in the container
 func (c *Container) makeBindMounts() error {
 	if err := idtools.SafeChown(c.state.RunDir, c.RootUID(), c.RootGID()); err != nil {
@@ -1839,17 +1912,18 @@ func (c *Container) makeBindMounts() error {
 				if err := os.Remove(resolvePath); err != nil && !os.IsNotExist(err) {
 					return fmt.Errorf("container %s: %w", c.ID(), err)
 				}
-				delete(c.state.BindMounts, resolvconf.DefaultResolvConf)
+				c.removeFromRootDirs(resolvconf.DefaultResolvConf)
 			}
 			if hostsPath, ok := c.state.BindMounts[config.DefaultHostsFile]; ok {
 				if err := os.Remove(hostsPath); err != nil && !os.IsNotExist(err) {
 					return fmt.Errorf("container %s: %w", c.ID(), err)
 				}
-				delete(c.state.BindMounts, config.DefaultHostsFile)
+				c.removeFromRootDirs(config.DefaultHostsFile)
 			}
 		}
 
-		if c.config.NetNsCtr != "" && (!c.config.UseImageResolvConf || !c.config.UseImageHosts) {
+		useImageResolvConf := c.useImageResolvConf()
+		if c.config.NetNsCtr != "" && (!useImageResolvConf || !c.config.UseImageHosts) {
 			// We share a net namespace.
 			// We want /etc/resolv.conf and /etc/hosts from the
 			// other container. Unless we're not creating both of
@@ -1868,7 +1942,7 @@ func (c *Container) makeBindMounts() error {
 			// The other container may not have a resolv.conf or /etc/hosts
 			// If it doesn't, don't copy them
 			resolvPath, exists := bindMounts[resolvconf.DefaultResolvConf]
-			if !c.config.UseImageResolvConf && exists {
+			if !useImageResolvConf && exists {
 				err := c.mountIntoRootDirs(resolvconf.DefaultResolvConf, resolvPath)
 
 				if err != nil {
@@ -1911,7 +1985,7 @@ func (c *Container) makeBindMounts() error {
 				}
 			}
 		} else {
-			if !c.config.UseImageResolvConf {
+			if !useImageResolvConf {
 				if err := c.createResolvConf(); err != nil {
 					return fmt.Errorf("creating resolv.conf for container %s: %w", c.ID(), err)
 				}
@@ -1971,40 +2045,26 @@ func (c *Container) makeBindMounts() error {
 	}
 	containerenvPath := filepath.Join(runPath, ".containerenv")
 
-	_, hasRunContainerenv := c.state.BindMounts[containerenvPath]
-	if !hasRunContainerenv {
-	Loop:
-		// check in the spec mounts
-		for _, m := range c.config.Spec.Mounts {
-			switch {
-			case m.Destination == containerenvPath:
-				hasRunContainerenv = true
-				break Loop
-			case m.Destination == runPath && m.Type != define.TypeTmpfs:
-				hasRunContainerenv = true
-				break Loop
-			}
+	hasRunContainerenv := false
+Loop:
+	// check in the spec mounts
+	for _, m := range c.config.Spec.Mounts {
+		switch {
+		case m.Destination == containerenvPath:
+			hasRunContainerenv = true
+			break Loop
+		case m.Destination == runPath && m.Type != define.TypeTmpfs:
+			hasRunContainerenv = true
+			break Loop
 		}
 	}
 
-	// Make .containerenv if it does not exist
+	// Make .containerenv unless the user provides it. It is rewritten on
+	// every start, the container may have been renamed.
 	if !hasRunContainerenv {
-		containerenv := c.runtime.graphRootMountedFlag(c.config.Spec.Mounts)
-		isRootless := 0
-		if rootless.IsRootless() {
-			isRootless = 1
-		}
-		imageID, imageName := c.Image()
-
-		if c.Privileged() {
-			// Populate the .containerenv with container information
-			containerenv = fmt.Sprintf(`engine="podman-%s"
-name=%q
-id=%q
-image=%q
-imageid=%q
-rootless=%d
-%s`, version.Version.String(), c.Name(), c.ID(), imageName, imageID, isRootless, containerenv)
+		containerenv, err := c.containerenvContents()
+		if err != nil {
+			return err
 		}
 		containerenvHostPath, err := c.writeStringToRundir(".containerenv", containerenv)
 		if err != nil {
@@ -2013,16 +2073,37 @@ rootless=%d
 		c.state.BindMounts[containerenvPath] = containerenvHostPath
 	}
 
+	// Make the metadata file with the selected labels and annotations
+	if len(c.config.MetadataKeys) > 0 {
+		metadataHostPath, err := c.writeStringToRundir(containerMetadataFile, c.metadataFileContents())
+		if err != nil {
+			return fmt.Errorf("creating metadata file for container %s: %w", c.ID(), err)
+		}
+		c.state.BindMounts[filepath.Join(runPath, containerMetadataFile)] = metadataHostPath
+	}
+
+	// Issue a new identity certificate each time the container starts
+	if c.config.IdentityCA != "" {
+		identityHostPath, err := c.makeIdentity()
+		if err != nil {
+			return fmt.Errorf("creating identity for container %s: %w", c.ID(), err)
+		}
+		c.state.BindMounts[filepath.Join(runPath, identityDir)] = identityHostPath
+	}
+
 	// Add Subscription Mounts
-	subscriptionMounts := subscriptions.MountsWithUIDGID(c.config.MountLabel, c.state.RunDir, c.runtime.config.Containers.DefaultMountsFile, c.state.Mountpoint, c.RootUID(), c.RootGID(), rootless.IsRootless(), false)
-	for _, mount := range subscriptionMounts {
-		if _, ok := c.state.BindMounts[mount.Destination]; !ok {
-			c.state.BindMounts[mount.Destination] = mount.Source
+	if mountsFile, ok := c.subscriptionMountsFile(); ok {
+		subscriptionMounts := subscriptions.MountsWithUIDGID(c.config.MountLabel, c.state.RunDir, mountsFile, c.state.Mountpoint, c.RootUID(), c.RootGID(), rootless.IsRootless(), !fipsSubscription)
+		for _, mount := range subscriptionMounts {
+			if _, ok := c.state.BindMounts[mount.Destination]; !ok {
+				c.state.BindMounts[mount.Destination] = mount.Source
+			}
 		}
 	}
 
 	// Secrets are mounted by getting the secret data from the secrets manager,
-	// copying the data into the container's static dir,
+	// copying the data into the container's static dir (or, for ephemeral
+	// secrets, its run directory on every start),
 	// then mounting the copied dir into /run/secrets.
 	// The secrets mounting must come after subscription mounts, since subscription mounts
 	// creates the /run/secrets dir in the container where we mount as well.
@@ -2041,13 +2122,140 @@ rootless=%d
 					base = ""
 				}
 			}
-			src := filepath.Join(c.config.SecretsPath, secret.Name)
+			if secret.Ephemeral {
+				if err := c.extractSecretToCtrStorage(secret); err != nil {
+					return fmt.Errorf("extracting secret %s: %w", secret.Name, err)
+				}
+			}
+			src := c.secretPath(secret)
 			dest := filepath.Join(base, secretFileName)
 			c.state.BindMounts[dest] = src
 		}
 	}
 
-	return c.makePlatformBindMounts()
+	// Make /etc/hostname. Unlike the other files it is not shared with
+	// another container, but it is rewritten on every start as the
+	// hostname may have been changed by podman update or podman rename.
+	hostnamePath, err := c.writeStringToRundir("hostname", c.Hostname())
+	if err != nil {
+		return fmt.Errorf("creating hostname file for container %s: %w", c.ID(), err)
+	}
+	return c.mountIntoRootDirs("/etc/hostname", hostnamePath)
+}
+
+// containerenvContents returns the contents of the .containerenv file. The
+// details about the container are only included for privileged containers,
+// or for all containers with the containerenv-full annotation.
+func (c *Container) containerenvContents() (string, error) {
+	containerenv := c.runtime.graphRootMountedFlag(c.config.Spec.Mounts)
+	if !c.Privileged() && c.config.Spec.Annotations[define.ContainerenvFullAnnotation] != "true" {
+		return containerenv, nil
+	}
+	isRootless := 0
+	if rootless.IsRootless() {
+		isRootless = 1
+	}
+	imageID, imageName := c.Image()
+	platformFields, err := c.platformContainerenv()
+	if err != nil {
+		return "", err
+	}
+
+	// Populate the .containerenv with container information
+	return fmt.Sprintf(`engine="podman-%s"
+name=%q
+id=%q
+image=%q
+imageid=%q
+rootless=%d
+%s%s`, version.Version.String(), c.Name(), c.ID(), imageName, imageID, isRootless, platformFields, containerenv), nil
+}
+
+// setConfigHostname sets the hostname in the given container configuration.
+// The HOSTNAME environment variable, which is added to the spec when the
+// container is created with a hostname, follows the hostname unless the
+// user set it to something else.
+func setConfigHostname(conf *ContainerConfig, hostname string) {
+	oldHostname := conf.Spec.Hostname
+	conf.Spec.Hostname = hostname
+	if conf.Spec.Process == nil {
+		return
+	}
+	for i, env := range conf.Spec.Process.Env {
+		if key, value, _ := strings.Cut(env, "="); key == "HOSTNAME" && value == oldHostname {
+			conf.Spec.Process.Env[i] = "HOSTNAME=" + hostname
+		}
+	}
+}
+
+// updateRunningHostname applies a change of the hostname to the running
+// container. The hostname file is written in place so that the change is
+// seen through the existing bind mount of /etc/hostname.
+func (c *Container) updateRunningHostname() error {
+	if hostnamePath, ok := c.state.BindMounts["/etc/hostname"]; ok {
+		if err := os.WriteFile(hostnamePath, []byte(c.Hostname()), 0o644); err != nil {
+			return fmt.Errorf("updating hostname file of container %s: %w", c.ID(), err)
+		}
+	}
+	return c.setRunningHostname()
+}
+
+// subscriptionMountsFile returns the mounts.conf file to read the container's
+// subscription mounts from, or "" to use the default files. It returns false
+// if subscription mounts were disabled with the mounts-file annotation.
+func (c *Container) subscriptionMountsFile() (string, bool) {
+	// Set by the hidden --default-mounts-file option for testing.
+	if c.runtime.config.Containers.DefaultMountsFile != "" {
+		return c.runtime.config.Containers.DefaultMountsFile, true
+	}
+	if c.config.Spec != nil {
+		if file, ok := c.config.Spec.Annotations[define.MountsFileAnnotation]; ok {
+			if file == "none" {
+				return "", false
+			}
+			return file, true
+		}
+	}
+	return platformMountsFile(), true
+}
+
+// addTimezoneEnv sets the TZ environment variable to the name of the
+// container's timezone, unless it is already set.
+func (c *Container) addTimezoneEnv(g *generate.Generator) error {
+	for _, env := range g.Config.Process.Env {
+		if strings.HasPrefix(env, "TZ=") {
+			return nil
+		}
+	}
+	tz := c.Timezone()
+	if tz == "local" {
+		name, err := localTimezone()
+		if err != nil {
+			return fmt.Errorf("finding local timezone: %w", err)
+		}
+		if name == "" {
+			logrus.Debugf("Name of the local timezone is unknown, not setting TZ for container %s", c.ID())
+			return nil
+		}
+		tz = name
+	}
+	g.AddProcessEnv("TZ", tz)
+	return nil
+}
+
+// useImageResolvConf returns whether the container keeps the resolv.conf of
+// its image instead of a generated one. Besides --dns=none, this is the case
+// when containers.conf sets dns_servers to "none" and the container has no
+// DNS settings of its own.
+func (c *Container) useImageResolvConf() bool {
+	if c.config.UseImageResolvConf {
+		return true
+	}
+	if len(c.config.DNSServer) > 0 || len(c.config.DNSSearch) > 0 || len(c.config.DNSOption) > 0 {
+		return false
+	}
+	servers := c.runtime.config.Containers.DNSServers.Get()
+	return len(servers) == 1 && servers[0] == "none"
 }
 
 // createResolvConf create the resolv.conf file and bind mount it
@@ -2101,7 +2309,12 @@ func (c *Container) addResolvConf() error {
 	// Exception: Populate `/etc/resolv.conf` if container is not connected to any network
 	// with dns enabled then we do not get any nameservers back.
 	if networkBackend != string(types.Netavark) || len(networkNameServers) == 0 {
-		nameservers = append(nameservers, c.runtime.config.Containers.DNSServers.Get()...)
+		for _, server := range c.runtime.config.Containers.DNSServers.Get() {
+			// "none" only disables resolv.conf generation, see useImageResolvConf.
+			if server != "none" {
+				nameservers = append(nameservers, server)
+			}
+		}
 		for _, ip := range c.config.DNSServer {
 			nameservers = append(nameservers, ip.String())
 		}
@@ -2290,7 +2503,7 @@ func (c *Container) addHosts() error {
 		ExtraHosts:   c.config.HostAdd,
 		ContainerIPs: containerIPsEntries,
 		HostContainersInternalIP: etchosts.GetHostContainersInternalIPExcluding(
-			c.runtime.config, c.state.NetworkStatus, c.runtime.network, exclude),
+			c.runtime.config, c.state.NetworkStatus, c.runtime.Network(), exclude),
 		TargetFile: targetFile,
 	})
 }
@@ -2341,10 +2554,77 @@ func (c *Container) generateGroupEntry() (string, error) {
 		}
 		groupString += entry
 	}
+	if c.config.HostUserGroups {
+		entry, err := c.generateHostUserGroupEntries(addedGID)
+		if err != nil {
+			return "", err
+		}
+		groupString += entry
+	}
 
 	return groupString, nil
 }
 
+// generateHostUserGroupEntries makes entries in /etc/group for the primary
+// and supplementary groups of the host users added to /etc/passwd, listing
+// the host users which are members. Groups which exist in the image are
+// skipped.
+func (c *Container) generateHostUserGroupEntries(addedGID int) (string, error) {
+	var gids []string
+	members := make(map[string][]string)
+	for _, userid := range c.config.HostUsers {
+		u, err := util.LookupUser(userid)
+		if err != nil {
+			return "", err
+		}
+		groupIDs, err := util.UserGroupIDs(u)
+		if err != nil {
+			return "", fmt.Errorf("looking up groups of host user %s: %w", u.Username, err)
+		}
+		if !slices.Contains(groupIDs, u.Gid) {
+			groupIDs = append([]string{u.Gid}, groupIDs...)
+		}
+		for _, gid := range groupIDs {
+			if _, ok := members[gid]; !ok {
+				gids = append(gids, gid)
+			}
+			members[gid] = append(members[gid], u.Username)
+		}
+	}
+
+	entries := ""
+	for _, gid := range gids {
+		if gid == strconv.Itoa(addedGID) {
+			continue
+		}
+		g, err := util.LookupGroup(gid)
+		if err != nil {
+			return "", fmt.Errorf("looking up host group %s: %w", gid, err)
+		}
+		entry, err := c.hostGroupEntry(g, members[gid])
+		if err != nil {
+			return "", err
+		}
+		entries += entry
+	}
+	return entries, nil
+}
+
+// hostGroupEntry returns the /etc/group entry for the given host group, or
+// an empty string if the group exists in the image.
+func (c *Container) hostGroupEntry(g *user.Group, members []string) (string, error) {
+	// Look up the group name and GID to see if it exists in the image.
+	for _, name := range []string{g.Name, g.Gid} {
+		if _, err := lookup.GetGroup(c.state.Mountpoint, name); err != runcuser.ErrNoGroupEntries {
+			return "", err
+		}
+	}
+	if c.config.GroupEntry != "" {
+		return c.groupEntry(g.Name, g.Gid, members), nil
+	}
+	return fmt.Sprintf("%s:x:%s:%s\n", g.Name, g.Gid, strings.Join(members, ",")), nil
+}
+
 // Make an entry in /etc/group for the group of the user running podman iff we
 // are rootless.
 func (c *Container) generateCurrentUserGroupEntry() (string, int, error) {
@@ -2353,7 +2633,7 @@ func (c *Container) generateCurrentUserGroupEntry() (string, int, error) {
 		return "", 0, nil
 	}
 
-	g, err := user.LookupGroupId(strconv.Itoa(gid))
+	g, err := util.LookupGroup(strconv.Itoa(gid))
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to get current group: %w", err)
 	}
@@ -2375,7 +2655,7 @@ func (c *Container) generateCurrentUserGroupEntry() (string, int, error) {
 	username := ""
 	uid := rootless.GetRootlessUID()
 	if uid != 0 {
-		u, err := user.LookupId(strconv.Itoa(uid))
+		u, err := util.LookupUser(strconv.Itoa(uid))
 		if err != nil {
 			return "", 0, fmt.Errorf("failed to get current user to make group entry: %w", err)
 		}
@@ -2409,13 +2689,13 @@ func (c *Container) generateUserGroupEntry(addedGID int) (string, error) {
 	}
 
 	// Check if the group already exists
-	g, err := lookup.GetGroup(c.state.Mountpoint, group)
+	_, err = lookup.GetGroup(c.state.Mountpoint, group)
 	if err != runcuser.ErrNoGroupEntries {
 		return "", err
 	}
 
 	if c.config.GroupEntry != "" {
-		return c.groupEntry(g.Name, strconv.Itoa(g.Gid), g.List), nil
+		return c.groupEntry(group, group, []string{splitUser[0]}), nil
 	}
 
 	return fmt.Sprintf("%d:x:%d:%s\n", gid, gid, splitUser[0]), nil
@@ -2486,7 +2766,7 @@ func (c *Container) generateCurrentUserPasswdEntry() (string, int, int, error) {
 		return "", 0, 0, nil
 	}
 
-	u, err := user.LookupId(strconv.Itoa(uid))
+	u, err := util.LookupUser(strconv.Itoa(uid))
 	if err != nil {
 		return "", 0, 0, fmt.Errorf("failed to get current user: %w", err)
 	}
@@ -2660,8 +2940,8 @@ func (c *Container) generatePasswdAndGroup() (string, string, error) {
 		return "", "", nil
 	}
 
-	needPasswd := true
-	needGroup := true
+	needPasswd := !c.config.NoPasswd
+	needGroup := !c.config.NoGroup
 
 	// First, check if there's a mount at /etc/passwd or group, we don't
 	// want to interfere with user mounts.
@@ -2839,21 +3119,9 @@ func (c *Container) fixVolumePermissions(v *ContainerNamedVolume) error {
 	if vol.state.NeedsChown && (!vol.UsesVolumeDriver() && vol.config.Driver != "image") {
 		vol.state.NeedsChown = false
 
-		uid := int(c.config.Spec.Process.User.UID)
-		gid := int(c.config.Spec.Process.User.GID)
-
-		if c.config.IDMappings.UIDMap != nil {
-			p := idtools.IDPair{
-				UID: uid,
-				GID: gid,
-			}
-			mappings := idtools.NewIDMappingsFromMaps(c.config.IDMappings.UIDMap, c.config.IDMappings.GIDMap)
-			newPair, err := mappings.ToHost(p)
-			if err != nil {
-				return fmt.Errorf("mapping user %d:%d: %w", uid, gid, err)
-			}
-			uid = newPair.UID
-			gid = newPair.GID
+		uid, gid, err := c.hostIDs(int(c.config.Spec.Process.User.UID), int(c.config.Spec.Process.User.GID))
+		if err != nil {
+			return err
 		}
 
 		vol.state.UIDChowned = uid
@@ -2945,6 +3213,9 @@ func (c *Container) ChangeHostPathOwnership(src string, recurse bool, uid, gid i
 			return nil
 		}
 	}
+	if recurse {
+		return chownRecursive(src, uid, gid)
+	}
 	return chown.ChangeHostPathOwnership(src, recurse, uid, gid)
 }
 
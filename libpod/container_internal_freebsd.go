@@ -3,19 +3,29 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/containers/buildah/pkg/jail"
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/rctl"
 	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/storage/pkg/idtools"
+	"github.com/containers/storage/pkg/mount"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	"golang.org/x/sys/unix"
 )
 
@@ -47,6 +57,7 @@ func (c *Container) prepare() error {
 
 	go func() {
 		defer wg.Done()
+		defer c.startTiming.record(startPhaseNetwork, time.Now())
 		// Set up network namespace if not already set up
 		noNetNS := c.state.NetNS == ""
 		if c.config.CreateNetNS && noNetNS && !c.config.PostConfigureNetNS {
@@ -66,6 +77,7 @@ func (c *Container) prepare() error {
 	// Mount storage if not mounted
 	go func() {
 		defer wg.Done()
+		defer c.startTiming.record(startPhaseStorage, time.Now())
 		mountPoint, mountStorageErr = c.mountStorage()
 
 		if mountStorageErr != nil {
@@ -115,12 +127,10 @@ func (c *Container) prepare() error {
 		return createErr
 	}
 
-	// Save changes to container state
-	if err := c.save(); err != nil {
-		return err
-	}
-
-	return nil
+	// Write the changes to the container state now, even when saves are
+	// batched: the network namespace and mounts must be recorded in case
+	// Podman is killed before the batch ends, so they can be cleaned up.
+	return c.writeState()
 }
 
 // cleanupNetwork unmounts and cleans up the container's network
@@ -167,13 +177,49 @@ func (c *Container) addNetworkContainer(g *generate.Generator, ctr string) error
 	if err != nil {
 		return fmt.Errorf("retrieving dependency %s of container %s from state: %w", ctr, c.ID(), err)
 	}
-	c.runtime.state.UpdateContainer(nsCtr)
-	if nsCtr.state.NetNS != "" {
-		g.AddAnnotation("org.freebsd.parentJail", nsCtr.state.NetNS)
+	if err := c.runtime.state.UpdateContainer(nsCtr); err != nil {
+		return fmt.Errorf("updating state of dependency %s of container %s: %w", ctr, c.ID(), err)
+	}
+	if nsCtr.state.NetNS == "" {
+		// Without its network jail, the container would run on the
+		// host's network instead of the network of the pod, where
+		// its ports are published.
+		if nsCtr.config.CreateNetNS {
+			return fmt.Errorf("network jail of container %s is not running, cannot join it from container %s: %w", ctr, c.ID(), define.ErrCtrStopped)
+		}
+		return nil
 	}
+	g.AddAnnotation("org.freebsd.parentJail", nsCtr.state.NetNS)
 	return nil
 }
 
+// sysvIPCParams are the jail parameters which control the visibility of SysV
+// IPC objects in a jail.
+var sysvIPCParams = []string{"sysvmsg", "sysvsem", "sysvshm"}
+
+// setSysvIPC sets the SysV IPC jail parameters of the container to the given
+// value, unless they were set explicitly.
+func setSysvIPC(g *generate.Generator, value string) {
+	for _, param := range sysvIPCParams {
+		key := "org.freebsd.jail." + param
+		if _, ok := g.Config.Annotations[key]; !ok {
+			g.AddAnnotation(key, value)
+		}
+	}
+}
+
+// Share the SysV IPC objects of an existing container. A jail can only
+// inherit them from its parent, so this is only possible for containers which
+// also run in the jail owning the other container's network, such as the
+// members of a pod.
+func (c *Container) addIPCContainer(g *generate.Generator, ctr string) {
+	if ctr != c.config.NetNsCtr || g.Config.Annotations["org.freebsd.parentJail"] == "" {
+		logrus.Warnf("Container %s cannot share SysV IPC with container %s on FreeBSD unless it also joins its network jail", c.ID(), ctr)
+		return
+	}
+	setSysvIPC(g, "inherit")
+}
+
 func isRootlessCgroupSet(cgroup string) bool {
 	return false
 }
@@ -202,23 +248,168 @@ func (c *Container) addNetworkNamespace(g *generate.Generator) error {
 		// child of the jail owning the vnet.
 		if c.config.PostConfigureNetNS {
 			g.AddAnnotation("org.freebsd.jail.vnet", "new")
+			if c.IsInfra() {
+				// The other members of the pod are created as
+				// children of the infra container's jail.
+				g.AddAnnotation("org.freebsd.jail.children.max", strconv.Itoa(networkJailChildrenMax(c)))
+				setSysvIPC(g, "new")
+			}
 		} else {
 			g.AddAnnotation("org.freebsd.parentJail", c.state.NetNS)
+			if c.IsInfra() {
+				// The vnet jail owns the SysV IPC objects of
+				// the pod.
+				setSysvIPC(g, "inherit")
+			}
 		}
 	}
 	return nil
 }
 
+// fipsSubscription is whether the FIPS mode of the host is passed on to
+// containers with a subscription mount. FreeBSD has no FIPS mode.
+const fipsSubscription = false
+
+// mountsFiles are the mounts.conf files on FreeBSD, in order of precedence.
+var mountsFiles = []string{
+	"/usr/local/etc/containers/mounts.conf",
+	"/usr/local/share/containers/mounts.conf",
+}
+
+// platformMountsFile returns the first of mountsFiles which exists, as the
+// default files of the subscriptions package are the Linux locations. If none
+// exist, the first is returned so that no subscription mounts are added.
+func platformMountsFile() string {
+	for _, file := range mountsFiles {
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	return mountsFiles[0]
+}
+
+// addJailNameAnnotation records the name of the container's jail in the
+// spec, where OCI hooks can find it.
+func (c *Container) addJailNameAnnotation(g *generate.Generator) error {
+	name, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	g.AddAnnotation(define.JailNameAnnotation, name)
+	return nil
+}
+
+// implicitHooksDirs are the OCI hooks directories which are used if no
+// hooks directories are configured. Packages install hooks below
+// /usr/local on FreeBSD.
+var implicitHooksDirs = []string{
+	"/usr/local/share/containers/oci/hooks.d",
+	"/usr/local/etc/containers/oci/hooks.d",
+}
+
+// platformHooksDirs replaces the default hooks directories of
+// containers.conf, which are the Linux locations, with implicitHooksDirs.
+// Directories which were configured explicitly are returned unchanged.
+func platformHooksDirs(dirs []string) []string {
+	if slices.Equal(dirs, config.DefaultHooksDirs) {
+		return implicitHooksDirs
+	}
+	return dirs
+}
+
 func (c *Container) addSystemdMounts(g *generate.Generator) error {
 	return nil
 }
 
+// addMemoryLock allows the processes of the container to lock up to
+// MemoryLock bytes of memory, e.g. using mlockall(2), by enabling the
+// allow.mlock jail parameter and raising the memlock rlimit unless it was
+// set explicitly. The total amount of memory locked by the jail is limited
+// with an rctl(8) rule, see rctlRules.
+func (c *Container) addMemoryLock(g *generate.Generator) error {
+	if c.config.MemoryLock == 0 {
+		return nil
+	}
+	g.AddAnnotation(jailAnnotationPrefix+"allow.mlock", "true")
+	for _, rlimit := range g.Config.Process.Rlimits {
+		if rlimit.Type == "RLIMIT_MEMLOCK" {
+			return nil
+		}
+	}
+	g.AddProcessRlimits("RLIMIT_MEMLOCK", uint64(c.config.MemoryLock), uint64(c.config.MemoryLock))
+	return nil
+}
+
+// updateMemoryLock applies a changed MemoryLock to the running container's
+// jail. The memlock rlimit of processes which are already running is not
+// changed.
+func (c *Container) updateMemoryLock() error {
+	jailName, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	j, err := jail.FindByName(jailName)
+	if err != nil {
+		return fmt.Errorf("finding jail %s: %w", jailName, err)
+	}
+	jconf := jail.NewConfig()
+	jconf.Set("allow.mlock", c.config.MemoryLock > 0)
+	if err := j.Set(jconf); err != nil {
+		return fmt.Errorf("setting allow.mlock for jail %s: %w", jailName, err)
+	}
+	if err := rctl.RemoveRule("jail:" + jailName + ":memorylocked"); err != nil && !errors.Is(err, unix.ESRCH) {
+		return fmt.Errorf("removing memory lock limit for container %s: %w", c.ID(), err)
+	}
+	if c.config.MemoryLock > 0 {
+		if err := rctl.AddRule(fmt.Sprintf("jail:%s:memorylocked:deny=%d", jailName, c.config.MemoryLock)); err != nil {
+			return fmt.Errorf("setting memory lock limit for container %s: %w", c.ID(), err)
+		}
+	}
+	return nil
+}
+
+// addCoreDumpMount mounts the container's core dump directory on the host
+// over coreDumpDir in the container. The kern.corefile sysctl is global but
+// is interpreted relative to the root of the jail, so core dumps are only
+// collected if it names a file in coreDumpDir.
+func (c *Container) addCoreDumpMount(g *generate.Generator) error {
+	if !c.config.CollectCores {
+		return nil
+	}
+	hostDir := c.coreDumpHostDir()
+	if err := os.MkdirAll(hostDir, 0o755); err != nil {
+		return fmt.Errorf("creating core dump directory for container %s: %w", c.ID(), err)
+	}
+	// Processes in the container may write core dumps as any user.
+	if err := os.Chmod(hostDir, 0o777|os.ModeSticky); err != nil {
+		return fmt.Errorf("setting permissions of core dump directory for container %s: %w", c.ID(), err)
+	}
+	g.AddMount(spec.Mount{
+		Destination: coreDumpDir,
+		Type:        define.TypeBind,
+		Source:      hostDir,
+		Options:     []string{"rw", "nosuid", "noexec"},
+	})
+
+	corefile, err := unix.Sysctl("kern.corefile")
+	if err != nil {
+		return fmt.Errorf("reading kern.corefile: %w", err)
+	}
+	if !strings.HasPrefix(corefile, coreDumpDir+"/") {
+		logrus.Warnf("kern.corefile is %q, core dumps of container %s are only collected if it is set to a file in %s, e.g. %s/%%N.%%P.core", corefile, c.ID(), coreDumpDir, coreDumpDir)
+	}
+	return nil
+}
+
 func (c *Container) addSharedNamespaces(g *generate.Generator) error {
 	if c.config.NetNsCtr != "" {
 		if err := c.addNetworkContainer(g, c.config.NetNsCtr); err != nil {
 			return err
 		}
 	}
+	if c.config.IPCNsCtr != "" {
+		c.addIPCContainer(g, c.config.IPCNsCtr)
+	}
 
 	availableUIDs, availableGIDs, err := rootless.GetAvailableIDMaps()
 	if err != nil {
@@ -265,6 +456,8 @@ func (c *Container) addSharedNamespaces(g *generate.Generator) error {
 	return nil
 }
 
+// addRootPropagation does nothing on FreeBSD, mount propagation is not
+// supported by nullfs.
 func (c *Container) addRootPropagation(g *generate.Generator, mounts []spec.Mount) error {
 	return nil
 }
@@ -301,7 +494,31 @@ func setVolumeAtime(mountPoint string, st os.FileInfo) error {
 	return nil
 }
 
-func (c *Container) makePlatformBindMounts() error {
+// platformContainerenv returns the FreeBSD specific fields of .containerenv,
+// the name of the container's jail.
+func (c *Container) platformContainerenv() (string, error) {
+	jailName, err := c.jailName()
+	if err != nil {
+		return "", fmt.Errorf("getting jail name: %w", err)
+	}
+	return fmt.Sprintf("jail=%q\n", jailName), nil
+}
+
+// setRunningHostname changes the hostname of the container's jail.
+func (c *Container) setRunningHostname() error {
+	jailName, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	j, err := jail.FindByName(jailName)
+	if err != nil {
+		return fmt.Errorf("finding jail %s: %w", jailName, err)
+	}
+	jconf := jail.NewConfig()
+	jconf.Set("host.hostname", c.Hostname())
+	if err := j.Set(jconf); err != nil {
+		return fmt.Errorf("setting hostname of jail %s: %w", jailName, err)
+	}
 	return nil
 }
 
@@ -344,6 +561,55 @@ func (c *Container) jailName() (string, error) {
 	}
 }
 
+// syncJailIDs updates the IDs of the container's jail and network jail in
+// the container state. The IDs are cleared if the container is not running
+// and looked up by name if they are missing, e.g. after the container was
+// started by an older version of Podman. It returns whether the state was
+// changed.
+func (c *Container) syncJailIDs() bool {
+	// Use the network jail name of the container sharing its network, if
+	// any, instead of calling jailName which locks the infra container.
+	netNS := func() (string, error) {
+		netNS, _, err := getContainerNetNS(c)
+		return netNS, err
+	}
+	return c.updateJailIDs(netNS, jailID)
+}
+
+// updateJailIDs implements syncJailIDs, using netNS to find the name of the
+// container's network jail and lookup to find the ID of a jail by name.
+func (c *Container) updateJailIDs(netNS func() (string, error), lookup func(string) (int, error)) bool {
+	jid, netJID := c.state.JID, c.state.NetJID
+
+	if !c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping) {
+		c.state.JID = 0
+	} else if c.state.JID == 0 {
+		netNS, err := netNS()
+		if err != nil {
+			logrus.Debugf("Getting network jail of container %s: %v", c.ID(), err)
+		} else {
+			name := c.ID()
+			if netNS != "" {
+				name = netNS + "." + c.ID()
+			}
+			if c.state.JID, err = lookup(name); err != nil {
+				logrus.Debugf("Getting jail ID of container %s: %v", c.ID(), err)
+			}
+		}
+	}
+
+	if c.state.NetNS == "" {
+		c.state.NetJID = 0
+	} else if c.state.NetJID == 0 {
+		var err error
+		if c.state.NetJID, err = lookup(c.state.NetNS); err != nil {
+			logrus.Debugf("Getting jail ID of network jail %s: %v", c.state.NetNS, err)
+		}
+	}
+
+	return c.state.JID != jid || c.state.NetJID != netJID
+}
+
 type safeMountInfo struct {
 	// mountPoint is the mount point.
 	mountPoint string
@@ -398,3 +664,346 @@ func (c *Container) hasPrivateUTS() bool {
 	// specification.
 	return true
 }
+
+// readOnlyRootPath returns the path where the read-only view of the
+// container's root filesystem is mounted.
+func (c *Container) readOnlyRootPath() string {
+	return filepath.Join(c.state.RunDir, "rootfs-ro")
+}
+
+// setRootPath sets the root path in the OCI spec. For read-only containers,
+// the root filesystem is presented to the jail using a read-only nullfs mount
+// of the container's storage. The storage mount itself stays writable so that
+// we can still manage files like /etc/passwd and /etc/hosts from the host.
+func (c *Container) setRootPath(g *generate.Generator) error {
+	if !c.IsReadOnly() {
+		g.SetRootPath(c.state.Mountpoint)
+		return nil
+	}
+	roPath := c.readOnlyRootPath()
+	if err := os.MkdirAll(roPath, 0o755); err != nil {
+		return fmt.Errorf("creating read-only root directory for container %s: %w", c.ID(), err)
+	}
+	if err := mount.Mount(c.state.Mountpoint, roPath, "nullfs", "bind,ro"); err != nil {
+		return fmt.Errorf("mounting read-only root filesystem for container %s: %w", c.ID(), err)
+	}
+	g.SetRootPath(roPath)
+	return nil
+}
+
+// cleanupPlatformMounts removes any mounts created by setRootPath. It must be
+// called before the container's storage is unmounted.
+func (c *Container) cleanupPlatformMounts() error {
+	if !c.IsReadOnly() || c.state.RunDir == "" {
+		return nil
+	}
+	roPath := c.readOnlyRootPath()
+	mounted, err := mount.Mounted(roPath)
+	if err != nil || !mounted {
+		return nil
+	}
+	if err := unix.Unmount(roPath, unix.MNT_FORCE); err != nil {
+		return fmt.Errorf("unmounting read-only root filesystem for container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// imageVolumeMounts returns the mounts used to expose an image volume in the
+// container. The image is always mounted read-only using nullfs. For
+// read-write image volumes, a unionfs mount backed by the upper directory in
+// contentDir is stacked on top so that writes do not modify the image.
+func (c *Container) imageVolumeMounts(contentDir, mountPoint string, volume *ContainerImageVolume) ([]spec.Mount, error) {
+	mounts := []spec.Mount{{
+		Type:        "nullfs",
+		Source:      mountPoint,
+		Destination: volume.Dest,
+		Options:     []string{"ro"},
+	}}
+	if !volume.ReadWrite {
+		return mounts, nil
+	}
+	upperDir := filepath.Join(contentDir, "upper")
+	if err := idtools.MkdirAllAs(upperDir, 0o700, c.RootUID(), c.RootGID()); err != nil {
+		return nil, fmt.Errorf("creating upper directory for image volume %q: %w", volume.Dest, err)
+	}
+	mounts = append(mounts, spec.Mount{
+		Type:        "unionfs",
+		Source:      upperDir,
+		Destination: volume.Dest,
+		Options:     []string{"rw"},
+	})
+	return mounts, nil
+}
+
+// rctlRules returns the rctl(8) rules, without the subject, which implement
+// the container's resource limits.
+func (c *Container) rctlRules() []string {
+	var rules []string
+	resources := c.LinuxResources()
+	if resources != nil && resources.Pids != nil && resources.Pids.Limit > 0 {
+		rules = append(rules, fmt.Sprintf("maxproc:deny=%d", resources.Pids.Limit))
+	}
+	if resources != nil && resources.Memory != nil && resources.Memory.Limit != nil && *resources.Memory.Limit > 0 {
+		rules = append(rules, fmt.Sprintf("memoryuse:deny=%d", *resources.Memory.Limit))
+	}
+	if resources != nil && resources.CPU != nil && resources.CPU.Quota != nil && *resources.CPU.Quota > 0 &&
+		resources.CPU.Period != nil && *resources.CPU.Period > 0 {
+		// pcpu is a percentage of a single CPU, rounded up so that a
+		// small quota still allows the container to run.
+		period := int64(*resources.CPU.Period)
+		rules = append(rules, fmt.Sprintf("pcpu:deny=%d", (*resources.CPU.Quota*100+period-1)/period))
+	}
+	if c.config.MemoryLock > 0 {
+		rules = append(rules, fmt.Sprintf("memorylocked:deny=%d", c.config.MemoryLock))
+	}
+	if p := c.config.IOPriority; p != nil {
+		for _, throttle := range []struct {
+			resource string
+			value    uint64
+		}{
+			{"readbps", p.ReadBps},
+			{"writebps", p.WriteBps},
+			{"readiops", p.ReadIOPS},
+			{"writeiops", p.WriteIOPS},
+		} {
+			if throttle.value > 0 {
+				rules = append(rules, fmt.Sprintf("%s:throttle=%d", throttle.resource, throttle.value))
+			}
+		}
+	}
+	// The kernel checks the memory use of the jail periodically and
+	// sends the reclaim signal while it exceeds the reservation, instead
+	// of denying allocations.
+	if c.config.MemoryReclaimSignal != 0 && resources != nil && resources.Memory != nil && resources.Memory.Reservation != nil && *resources.Memory.Reservation > 0 {
+		if name := unix.SignalName(syscall.Signal(c.config.MemoryReclaimSignal)); name != "" {
+			rules = append(rules, fmt.Sprintf("memoryuse:%s=%d", strings.ToLower(name), *resources.Memory.Reservation))
+		}
+	}
+	return rules
+}
+
+// setupResourceLimits adds rctl rules for the container's jail. This must be
+// called after the jail has been created by the OCI runtime.
+func (c *Container) setupResourceLimits() error {
+	if err := c.setupCPUSet(); err != nil {
+		return err
+	}
+	if err := c.setupNice(); err != nil {
+		return err
+	}
+	rules := c.rctlRules()
+	if len(rules) == 0 {
+		return nil
+	}
+	// Rules for a jail are kept by name after the jail is removed so
+	// remove any left over from a previous run of the container.
+	if err := c.cleanupResourceLimits(); err != nil {
+		return err
+	}
+	jailName, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	for _, rule := range rules {
+		if err := rctl.AddRule("jail:" + jailName + ":" + rule); err != nil {
+			return fmt.Errorf("setting resource limits for container %s: %w", c.ID(), err)
+		}
+	}
+	return nil
+}
+
+// cpuSet returns the CPUs the container is restricted to, which are those of
+// its pod unless the container sets its own.
+func (c *Container) cpuSet() (string, error) {
+	if resources := c.LinuxResources(); resources != nil && resources.CPU != nil && resources.CPU.Cpus != "" {
+		return resources.CPU.Cpus, nil
+	}
+	if c.config.Pod == "" {
+		return "", nil
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		return "", fmt.Errorf("looking up pod of container %s: %w", c.ID(), err)
+	}
+	if cpu := pod.config.ResourceLimits.CPU; cpu != nil {
+		return cpu.Cpus, nil
+	}
+	return "", nil
+}
+
+// setupCPUSet restricts the container's jail to its cpuset using cpuset(1).
+func (c *Container) setupCPUSet() error {
+	cpus, err := c.cpuSet()
+	if err != nil || cpus == "" {
+		return err
+	}
+	jailName, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	if out, err := exec.Command("cpuset", "-l", cpus, "-j", jailName).CombinedOutput(); err != nil {
+		return fmt.Errorf("setting cpuset %s for container %s: %w: %s", cpus, c.ID(), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// setupNice sets the nice value of the container's I/O priority class for
+// the container's process, which is inherited by the processes it starts.
+func (c *Container) setupNice() error {
+	if c.config.IOPriority == nil || c.config.IOPriority.Nice == 0 || c.state.PID == 0 {
+		return nil
+	}
+	if err := unix.Setpriority(unix.PRIO_PROCESS, c.state.PID, c.config.IOPriority.Nice); err != nil {
+		return fmt.Errorf("setting nice value of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// cleanupResourceLimits removes the rctl rules added by setupResourceLimits.
+func (c *Container) cleanupResourceLimits() error {
+	rules := c.rctlRules()
+	if len(rules) == 0 {
+		return nil
+	}
+	jailName, err := c.jailName()
+	if err != nil {
+		return fmt.Errorf("getting jail name: %w", err)
+	}
+	for _, rule := range rules {
+		resource, _, _ := strings.Cut(rule, ":")
+		if err := rctl.RemoveRule("jail:" + jailName + ":" + resource); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("removing resource limits for container %s: %w", c.ID(), err)
+		}
+	}
+	return nil
+}
+
+// maxPauseAttempts bounds the number of times the processes of a jail are
+// listed while pausing it, in case a process keeps forking.
+const maxPauseAttempts = 10
+
+// jailProcess is a process in a container's jail as listed by ps(1).
+type jailProcess struct {
+	pid   int
+	state string
+}
+
+// parseJailProcesses parses the output of `ps -o pid=,state=`.
+func parseJailProcesses(out []byte) ([]jailProcess, error) {
+	procs := []jailProcess{}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected ps(1) output %q", line)
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("parsing pid in ps(1) output %q: %w", line, err)
+		}
+		procs = append(procs, jailProcess{pid: pid, state: fields[1]})
+	}
+	return procs, nil
+}
+
+// jailProcesses lists the processes in the container's jail.
+func (c *Container) jailProcesses() ([]jailProcess, error) {
+	jailName, err := c.jailName()
+	if err != nil {
+		return nil, fmt.Errorf("getting jail name: %w", err)
+	}
+	out, err := exec.Command("ps", "-o", "pid=,state=", "-J", jailName).Output()
+	if err != nil {
+		// ps(1) fails when no process matches.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 {
+			return []jailProcess{}, nil
+		}
+		return nil, fmt.Errorf("listing processes in jail %s: %w", jailName, err)
+	}
+	return parseJailProcesses(out)
+}
+
+// pauseProcesses stops the processes in the container's jail with SIGSTOP.
+// The jail is listed again after stopping its processes to catch those
+// forked in the meantime, until none of them is left running. The stopped
+// processes are recorded in the container's state, so that unpausing does
+// not resume processes which were already stopped, e.g. by a debugger.
+func (c *Container) pauseProcesses() error {
+	c.state.PausedPIDs = nil
+	for i := 0; i < maxPauseAttempts; i++ {
+		procs, err := c.jailProcesses()
+		if err == nil {
+			var running int
+			c.state.PausedPIDs, running, err = stopJailProcesses(procs, c.state.PausedPIDs, unix.Kill)
+			if err == nil && running == 0 {
+				return nil
+			}
+		}
+		if err != nil {
+			if err2 := c.unpauseProcesses(); err2 != nil {
+				logrus.Errorf("Resuming container %s: %v", c.ID(), err2)
+			}
+			return fmt.Errorf("stopping processes of container %s: %w", c.ID(), err)
+		}
+	}
+	if err := c.unpauseProcesses(); err != nil {
+		logrus.Errorf("Resuming container %s: %v", c.ID(), err)
+	}
+	return fmt.Errorf("processes of container %s are still running after %d attempts to stop them", c.ID(), maxPauseAttempts)
+}
+
+// unpauseProcesses resumes the processes stopped by pauseProcesses.
+func (c *Container) unpauseProcesses() error {
+	procs, err := c.jailProcesses()
+	if err != nil {
+		return err
+	}
+	if err := resumeJailProcesses(procs, c.state.PausedPIDs, unix.Kill); err != nil {
+		return fmt.Errorf("resuming processes of container %s: %w", c.ID(), err)
+	}
+	c.state.PausedPIDs = nil
+	return nil
+}
+
+// stopJailProcesses sends SIGSTOP with kill to the processes in procs which
+// are neither stopped nor zombies. It returns stopped with the PIDs of the
+// processes it stopped appended, and the number of processes which were
+// running.
+func stopJailProcesses(procs []jailProcess, stopped []int, kill func(int, unix.Signal) error) ([]int, int, error) {
+	running := 0
+	for _, proc := range procs {
+		// Stopped and zombie processes cannot run anymore
+		if strings.HasPrefix(proc.state, "T") || strings.HasPrefix(proc.state, "Z") {
+			continue
+		}
+		running++
+		if err := kill(proc.pid, unix.SIGSTOP); err != nil {
+			if errors.Is(err, unix.ESRCH) {
+				continue
+			}
+			return stopped, running, fmt.Errorf("stopping process %d: %w", proc.pid, err)
+		}
+		if !slices.Contains(stopped, proc.pid) {
+			stopped = append(stopped, proc.pid)
+		}
+	}
+	return stopped, running, nil
+}
+
+// resumeJailProcesses sends SIGCONT with kill to the processes in stopped
+// which are still in procs. A stopped process which left the jail is gone,
+// and its PID may have been reused by another process.
+func resumeJailProcesses(procs []jailProcess, stopped []int, kill func(int, unix.Signal) error) error {
+	for _, proc := range procs {
+		if !slices.Contains(stopped, proc.pid) {
+			continue
+		}
+		if err := kill(proc.pid, unix.SIGCONT); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("resuming process %d: %w", proc.pid, err)
+		}
+	}
+	return nil
+}
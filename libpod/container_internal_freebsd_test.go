@@ -0,0 +1,89 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestAddIPCContainer(t *testing.T) {
+	newGenerator := func() *generate.Generator {
+		return &generate.Generator{Config: &spec.Spec{Annotations: map[string]string{}}}
+	}
+
+	// A member of a pod runs in the jail owning the pod's network
+	ctr := &Container{config: &ContainerConfig{}}
+	ctr.config.NetNsCtr = "infra"
+	g := newGenerator()
+	g.AddAnnotation("org.freebsd.parentJail", "infra")
+	g.AddAnnotation("org.freebsd.jail.sysvmsg", "disable")
+	ctr.addIPCContainer(g, "infra")
+	assert.Equal(t, "disable", g.Config.Annotations["org.freebsd.jail.sysvmsg"])
+	assert.Equal(t, "inherit", g.Config.Annotations["org.freebsd.jail.sysvsem"])
+	assert.Equal(t, "inherit", g.Config.Annotations["org.freebsd.jail.sysvshm"])
+
+	// The IPC objects of another container can't be inherited
+	g = newGenerator()
+	g.AddAnnotation("org.freebsd.parentJail", "infra")
+	ctr.addIPCContainer(g, "other")
+	assert.NotContains(t, g.Config.Annotations, "org.freebsd.jail.sysvshm")
+
+	// Neither can those of a container without a network jail
+	g = newGenerator()
+	ctr.addIPCContainer(g, "infra")
+	assert.NotContains(t, g.Config.Annotations, "org.freebsd.jail.sysvshm")
+}
+
+func TestParseJailProcesses(t *testing.T) {
+	procs, err := parseJailProcesses([]byte("  1 Ss\n 42 T\n\n103 R+\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []jailProcess{{1, "Ss"}, {42, "T"}, {103, "R+"}}, procs)
+
+	procs, err = parseJailProcesses(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, procs)
+
+	_, err = parseJailProcesses([]byte("pid\n"))
+	assert.Error(t, err)
+	_, err = parseJailProcesses([]byte("x S\n"))
+	assert.Error(t, err)
+}
+
+func TestPauseJailProcesses(t *testing.T) {
+	signals := map[int]unix.Signal{}
+	kill := func(pid int, sig unix.Signal) error {
+		if pid == 7 {
+			return unix.ESRCH
+		}
+		signals[pid] = sig
+		return nil
+	}
+
+	// A process stopped before pausing is not recorded, nor is one
+	// which exited in the meantime.
+	stopped, running, err := stopJailProcesses([]jailProcess{{1, "Ss"}, {5, "T"}, {6, "Z"}, {7, "R"}}, nil, kill)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, running)
+	assert.Equal(t, []int{1}, stopped)
+
+	// A process forked in the meantime is stopped on the next attempt.
+	stopped, running, err = stopJailProcesses([]jailProcess{{1, "T"}, {5, "T"}, {9, "R"}}, stopped, kill)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, running)
+	assert.Equal(t, []int{1, 9}, stopped)
+	assert.Equal(t, map[int]unix.Signal{1: unix.SIGSTOP, 9: unix.SIGSTOP}, signals)
+
+	// Only the recorded processes still in the jail are resumed.
+	signals = map[int]unix.Signal{}
+	err = resumeJailProcesses([]jailProcess{{1, "T"}, {5, "T"}, {6, "Z"}}, stopped, kill)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]unix.Signal{1: unix.SIGCONT}, signals)
+
+	_, _, err = stopJailProcesses([]jailProcess{{2, "S"}}, nil, func(int, unix.Signal) error { return unix.EPERM })
+	assert.ErrorIs(t, err, unix.EPERM)
+}
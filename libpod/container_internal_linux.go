@@ -14,10 +14,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/containers/buildah/pkg/overlay"
 	"github.com/containers/common/libnetwork/slirp4netns"
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/hooks"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/rootless"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -71,6 +73,7 @@ func (c *Container) prepare() error {
 
 	go func() {
 		defer wg.Done()
+		defer c.startTiming.record(startPhaseNetwork, time.Now())
 		// Set up network namespace if not already set up
 		noNetNS := c.state.NetNS == ""
 		if c.config.CreateNetNS && noNetNS && !c.config.PostConfigureNetNS {
@@ -90,6 +93,7 @@ func (c *Container) prepare() error {
 	// Mount storage if not mounted
 	go func() {
 		defer wg.Done()
+		defer c.startTiming.record(startPhaseStorage, time.Now())
 		mountPoint, mountStorageErr = c.mountStorage()
 
 		if mountStorageErr != nil {
@@ -143,12 +147,10 @@ func (c *Container) prepare() error {
 		return createErr
 	}
 
-	// Save changes to container state
-	if err := c.save(); err != nil {
-		return err
-	}
-
-	return nil
+	// Write the changes to the container state now, even when saves are
+	// batched: the network namespace and mounts must be recorded in case
+	// Podman is killed before the batch ends, so they can be cleaned up.
+	return c.writeState()
 }
 
 // cleanupNetwork unmounts and cleans up the container's network
@@ -432,6 +434,30 @@ func (c *Container) addNetworkNamespace(g *generate.Generator) error {
 	return nil
 }
 
+// fipsSubscription is whether the FIPS mode of the host is passed on to
+// containers with a subscription mount.
+const fipsSubscription = true
+
+// platformMountsFile returns "" to use the default mounts.conf files, which
+// are in the Linux locations.
+func platformMountsFile() string {
+	return ""
+}
+
+// addJailNameAnnotation does nothing on Linux.
+func (c *Container) addJailNameAnnotation(g *generate.Generator) error {
+	return nil
+}
+
+// implicitHooksDirs are the OCI hooks directories which are used if no
+// hooks directories are configured.
+var implicitHooksDirs = []string{hooks.DefaultDir, hooks.OverrideDir}
+
+// platformHooksDirs returns the configured OCI hooks directories unchanged.
+func platformHooksDirs(dirs []string) []string {
+	return dirs
+}
+
 func (c *Container) addSystemdMounts(g *generate.Generator) error {
 	if c.Systemd() {
 		if err := c.setupSystemd(g.Mounts(), *g); err != nil {
@@ -660,16 +686,16 @@ func setVolumeAtime(mountPoint string, st os.FileInfo) error {
 	return nil
 }
 
-func (c *Container) makePlatformBindMounts() error {
-	// Make /etc/hostname
-	// This should never change, so no need to recreate if it exists
-	if _, ok := c.state.BindMounts["/etc/hostname"]; !ok {
-		hostnamePath, err := c.writeStringToRundir("hostname", c.Hostname())
-		if err != nil {
-			return fmt.Errorf("creating hostname file for container %s: %w", c.ID(), err)
-		}
-		c.state.BindMounts["/etc/hostname"] = hostnamePath
-	}
+// platformContainerenv returns the Linux specific fields of .containerenv.
+// There are none.
+func (c *Container) platformContainerenv() (string, error) {
+	return "", nil
+}
+
+// setRunningHostname does nothing on Linux, the hostname of the UTS
+// namespace cannot be changed from the outside. The new hostname is used
+// when the container is started the next time.
+func (c *Container) setRunningHostname() error {
 	return nil
 }
 
@@ -805,3 +831,101 @@ func (c *Container) hasPrivateUTS() bool {
 	}
 	return privateUTS
 }
+
+func (c *Container) setRootPath(g *generate.Generator) error {
+	g.SetRootPath(c.state.Mountpoint)
+	return nil
+}
+
+func (c *Container) cleanupPlatformMounts() error {
+	return nil
+}
+
+// syncJailIDs does nothing on Linux, which has no jails.
+func (c *Container) syncJailIDs() bool {
+	return false
+}
+
+// setupResourceLimits does nothing on Linux, resource limits are applied by
+// the OCI runtime using cgroups.
+func (c *Container) setupResourceLimits() error {
+	return nil
+}
+
+func (c *Container) cleanupResourceLimits() error {
+	return nil
+}
+
+// imageVolumeMounts returns the mounts used to expose an image volume in the
+// container. Image volumes are mounted using overlay so that writes to
+// read-write image volumes do not modify the image.
+func (c *Container) imageVolumeMounts(contentDir, mountPoint string, volume *ContainerImageVolume) ([]spec.Mount, error) {
+	var (
+		overlayMount spec.Mount
+		err          error
+	)
+	if volume.ReadWrite {
+		overlayMount, err = overlay.Mount(contentDir, mountPoint, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
+	} else {
+		overlayMount, err = overlay.MountReadOnly(contentDir, mountPoint, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []spec.Mount{overlayMount}, nil
+}
+
+// addMemoryLock returns an error if the container may lock memory, which is
+// only supported on FreeBSD.
+func (c *Container) addMemoryLock(g *generate.Generator) error {
+	if c.config.MemoryLock != 0 {
+		return fmt.Errorf("locking memory: %w", define.ErrOSNotSupported)
+	}
+	return nil
+}
+
+// updateMemoryLock returns an error as locking memory is only supported on
+// FreeBSD.
+func (c *Container) updateMemoryLock() error {
+	return fmt.Errorf("locking memory: %w", define.ErrOSNotSupported)
+}
+
+// addCoreDumpMount returns an error if the container collects core dumps.
+// On Linux, core dumps are handled by the host's kernel.core_pattern.
+func (c *Container) addCoreDumpMount(g *generate.Generator) error {
+	if c.config.CollectCores {
+		return fmt.Errorf("collecting core dumps: %w", define.ErrOSNotSupported)
+	}
+	return nil
+}
+
+// pauseProcesses freezes the processes of the container with the OCI runtime,
+// which needs the container's cgroup.
+func (c *Container) pauseProcesses() error {
+	if c.config.NoCgroups {
+		return fmt.Errorf("cannot pause without using Cgroups: %w", define.ErrNoCgroups)
+	}
+
+	if rootless.IsRootless() {
+		cgroupv2, err := cgroups.IsCgroup2UnifiedMode()
+		if err != nil {
+			return fmt.Errorf("failed to determine cgroupversion: %w", err)
+		}
+		if !cgroupv2 {
+			return fmt.Errorf("can not pause containers on rootless containers with cgroup V1: %w", define.ErrNoCgroups)
+		}
+	}
+
+	// TODO when using docker-py there is some sort of race/incompatibility here
+	return c.ociRuntime.PauseContainer(c)
+}
+
+// unpauseProcesses thaws the processes frozen by pauseProcesses.
+func (c *Container) unpauseProcesses() error {
+	if c.config.NoCgroups {
+		return fmt.Errorf("cannot unpause without using Cgroups: %w", define.ErrNoCgroups)
+	}
+
+	// TODO when using docker-py there is some sort of race/incompatibility here
+	return c.ociRuntime.UnpauseContainer(c)
+}
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"os/user"
 	"testing"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -59,4 +60,30 @@ func TestGenerateUserGroupEntry(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, group, "567890:x:567890:567890\n")
+
+	c.config.User = "123456:456789"
+	c.config.GroupEntry = "$GROUPNAME:x:$GID:$USERLIST,extra"
+	group, err = c.generateUserGroupEntry(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, group, "456789:x:456789:123456,extra\n")
+}
+
+func TestHostGroupEntry(t *testing.T) {
+	c := Container{
+		config: &ContainerConfig{Spec: &spec.Spec{}},
+		state: &ContainerState{
+			Mountpoint: "/does/not/exist/tmp/",
+		},
+	}
+	g := &user.Group{Gid: "4242", Name: "devs"}
+	entry, err := c.hostGroupEntry(g, []string{"alice", "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, "devs:x:4242:alice,bob\n", entry)
+
+	c.config.GroupEntry = "$GROUPNAME:*:$GID:$USERLIST"
+	entry, err = c.hostGroupEntry(g, []string{"alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, "devs:*:4242:alice\n", entry)
 }
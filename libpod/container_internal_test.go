@@ -11,10 +11,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/storage/pkg/idtools"
 	stypes "github.com/containers/storage/types"
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // hookPath is the path to an example hook executable.
@@ -142,6 +145,105 @@ func TestParseIDMapMountOption(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestResetContainerStatePreviouslyRunning(t *testing.T) {
+	tests := []struct {
+		state             define.ContainerStatus
+		previouslyRunning bool
+	}{
+		{define.ContainerStateRunning, true},
+		{define.ContainerStatePaused, true},
+		{define.ContainerStateExited, false},
+		{define.ContainerStateStopped, false},
+		{define.ContainerStateConfigured, false},
+	}
+	for _, tt := range tests {
+		state := &ContainerState{State: tt.state}
+		resetContainerState(state)
+		assert.Equal(t, tt.previouslyRunning, state.PreviouslyRunning, tt.state.String())
+	}
+
+	// A recorded state is kept across the reset.
+	state := &ContainerState{State: define.ContainerStateExited, PreviouslyRunning: true}
+	resetContainerState(state)
+	assert.True(t, state.PreviouslyRunning)
+}
+
+func TestMountIntoRootDirs(t *testing.T) {
+	c := &Container{
+		config: &ContainerConfig{ContainerRootFSConfig: ContainerRootFSConfig{ChrootDirs: []string{"/chroot", "/var/lib/jail"}}},
+		state:  &ContainerState{BindMounts: map[string]string{"/etc/passwd": "/run/passwd"}},
+	}
+	assert.NoError(t, c.mountIntoRootDirs("/etc/hosts", "/run/hosts"))
+	assert.Equal(t, map[string]string{
+		"/etc/passwd":             "/run/passwd",
+		"/etc/hosts":              "/run/hosts",
+		"/chroot/etc/hosts":       "/run/hosts",
+		"/var/lib/jail/etc/hosts": "/run/hosts",
+	}, c.state.BindMounts)
+
+	c.removeFromRootDirs("/etc/hosts")
+	assert.Equal(t, map[string]string{"/etc/passwd": "/run/passwd"}, c.state.BindMounts)
+}
+
+func TestUseImageResolvConf(t *testing.T) {
+	rtConfig := &config.Config{}
+	c := &Container{
+		runtime: &Runtime{config: rtConfig},
+		config:  &ContainerConfig{},
+	}
+	assert.False(t, c.useImageResolvConf())
+
+	c.config.UseImageResolvConf = true
+	assert.True(t, c.useImageResolvConf())
+
+	c.config.UseImageResolvConf = false
+	rtConfig.Containers.DNSServers.Set([]string{"none"})
+	assert.True(t, c.useImageResolvConf())
+
+	c.config.DNSSearch = []string{"example.com"}
+	assert.False(t, c.useImageResolvConf())
+
+	c.config.DNSSearch = nil
+	rtConfig.Containers.DNSServers.Set([]string{"none", "1.1.1.1"})
+	assert.False(t, c.useImageResolvConf())
+}
+
+func TestSetConfigHostname(t *testing.T) {
+	conf := &ContainerConfig{Spec: &rspec.Spec{
+		Hostname: "web",
+		Process:  &rspec.Process{Env: []string{"PATH=/bin", "HOSTNAME=web"}},
+	}}
+	setConfigHostname(conf, "web01")
+	assert.Equal(t, "web01", conf.Spec.Hostname)
+	assert.Equal(t, []string{"PATH=/bin", "HOSTNAME=web01"}, conf.Spec.Process.Env)
+
+	// A HOSTNAME set by the user is kept.
+	conf.Spec.Process.Env = []string{"HOSTNAME=custom"}
+	setConfigHostname(conf, "web02")
+	assert.Equal(t, "web02", conf.Spec.Hostname)
+	assert.Equal(t, []string{"HOSTNAME=custom"}, conf.Spec.Process.Env)
+}
+
+func TestHostIDs(t *testing.T) {
+	c := &Container{config: &ContainerConfig{}}
+	uid, gid, err := c.hostIDs(1000, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, uid)
+	assert.Equal(t, 100, gid)
+
+	c.config.IDMappings = stypes.IDMappingOptions{
+		UIDMap: []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		GIDMap: []idtools.IDMap{{ContainerID: 0, HostID: 200000, Size: 65536}},
+	}
+	uid, gid, err = c.hostIDs(1000, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 101000, uid)
+	assert.Equal(t, 200100, gid)
+
+	_, _, err = c.hostIDs(70000, 0)
+	assert.Error(t, err)
+}
+
 func TestPostDeleteHooks(t *testing.T) {
 	ctx := context.Background()
 	dir := t.TempDir()
@@ -213,3 +315,70 @@ func init() {
 		panic("we need a reliable executable path on Windows")
 	}
 }
+
+func TestBatchSaves(t *testing.T) {
+	state, path, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.runtime = &Runtime{state: state}
+	require.NoError(t, state.AddContainer(ctr))
+
+	savedPID := func() int {
+		dbCtr, err := state.Container(ctr.ID())
+		require.NoError(t, err)
+		require.NoError(t, state.UpdateContainer(dbCtr))
+		return dbCtr.state.PID
+	}
+	initialPID := savedPID()
+
+	err = ctr.batchSaves(func() error {
+		ctr.state.PID = initialPID + 1
+		if err := ctr.save(); err != nil {
+			return err
+		}
+		ctr.state.PID = initialPID + 2
+		if err := ctr.save(); err != nil {
+			return err
+		}
+		// Nothing is written until the batch ends
+		assert.Equal(t, initialPID, savedPID())
+		assert.True(t, ctr.stateDirty)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, ctr.saveDeferred)
+	assert.False(t, ctr.stateDirty)
+	assert.Equal(t, initialPID+2, savedPID())
+
+	// Changes are written even when the batch fails
+	err = ctr.batchSaves(func() error {
+		ctr.state.PID = initialPID + 3
+		if err := ctr.save(); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed")
+	})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, initialPID+3, savedPID())
+
+	// writeState writes immediately in a batch
+	err = ctr.batchSaves(func() error {
+		ctr.state.PID = initialPID + 5
+		if err := ctr.writeState(); err != nil {
+			return err
+		}
+		assert.Equal(t, initialPID+5, savedPID())
+		assert.False(t, ctr.stateDirty)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Outside of a batch, saves are written immediately
+	ctr.state.PID = initialPID + 6
+	require.NoError(t, ctr.save())
+	assert.Equal(t, initialPID+6, savedPID())
+}
@@ -0,0 +1,214 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/common/pkg/secrets"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const jailAnnotationPrefix = "org.freebsd.jail."
+
+// JailConf returns a jail.conf(5) stanza which is equivalent to the jail
+// created for the container. Podman does not use jail.conf itself, the output
+// is intended for reviewing the jail parameters and for starting the
+// container manually, e.g. when Podman is not available. Environment
+// variables set from secrets are left out.
+func (c *Container) JailConf() ([]byte, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.jailConf(false)
+}
+
+// jailConf returns the jail.conf(5) stanza of the container. With redactEnv,
+// the values of all environment variables are replaced, for output which is
+// shared with others.
+func (c *Container) jailConf(redactEnv bool) ([]byte, error) {
+	ctrSpec, err := c.specFromState()
+	if err != nil {
+		return nil, err
+	}
+	name, err := c.jailName()
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by podman for container %s (%s)\n", c.Name(), c.ID())
+	fmt.Fprintf(&b, "%s {\n", jailConfQuote(name))
+
+	rootPath := c.state.Mountpoint
+	if ctrSpec.Root != nil && ctrSpec.Root.Path != "" {
+		rootPath = ctrSpec.Root.Path
+	}
+	if rootPath == "" {
+		b.WriteString("\t# The container storage is not mounted, use podman mount to mount it.\n")
+	} else {
+		writeJailParam(&b, "path", rootPath)
+	}
+	if ctrSpec.Hostname != "" {
+		writeJailParam(&b, "host.hostname", ctrSpec.Hostname)
+	}
+
+	// Networking
+	switch {
+	case ctrSpec.Annotations[jailAnnotationPrefix+"vnet"] == "new":
+		writeJailParam(&b, "vnet", "true")
+	case ctrSpec.Annotations["org.freebsd.parentJail"] != "":
+		fmt.Fprintf(&b, "\t# The network stack is owned by the parent jail %s.\n", ctrSpec.Annotations["org.freebsd.parentJail"])
+	default:
+		writeJailParam(&b, "ip4", "inherit")
+		writeJailParam(&b, "ip6", "inherit")
+	}
+	networks := make([]string, 0, len(c.state.NetworkStatus))
+	for netName := range c.state.NetworkStatus {
+		networks = append(networks, netName)
+	}
+	sort.Strings(networks)
+	for _, netName := range networks {
+		for ifName, netInt := range c.state.NetworkStatus[netName].Interfaces {
+			for _, subnet := range netInt.Subnets {
+				fmt.Fprintf(&b, "\t# Network %s: %s %s\n", netName, ifName, subnet.IPNet.String())
+			}
+		}
+	}
+
+	// Jail parameters passed to the OCI runtime
+	params := make([]string, 0, len(ctrSpec.Annotations))
+	for key := range ctrSpec.Annotations {
+		if strings.HasPrefix(key, jailAnnotationPrefix) && key != jailAnnotationPrefix+"vnet" {
+			params = append(params, key)
+		}
+	}
+	sort.Strings(params)
+	for _, key := range params {
+		writeJailParam(&b, strings.TrimPrefix(key, jailAnnotationPrefix), ctrSpec.Annotations[key])
+	}
+
+	// Mounts
+	for _, m := range ctrSpec.Mounts {
+		if m.Type == "devfs" && m.Destination == "/dev" {
+			writeJailParam(&b, "mount.devfs", "true")
+			for _, o := range m.Options {
+				if ruleset, ok := strings.CutPrefix(o, "ruleset="); ok {
+					writeJailParam(&b, "devfs_ruleset", ruleset)
+				}
+			}
+			continue
+		}
+		writeJailMount(&b, rootPath, m)
+	}
+
+	// Process
+	if ctrSpec.Process != nil {
+		process := *ctrSpec.Process
+		var secretEnv []string
+		process.Env, secretEnv = jailConfEnv(process.Env, c.config.EnvSecrets)
+		if redactEnv {
+			process.Env = debugRedactEnv(process.Env)
+		}
+		if len(secretEnv) > 0 {
+			fmt.Fprintf(&b, "\t# The secret environment variables %s are left out of exec.start.\n", strings.Join(secretEnv, ", "))
+		}
+		writeJailParam(&b, "exec.start", jailExecCommand(&process))
+		if ctrSpec.Process.User.UID != 0 {
+			writeJailParam(&b, "exec.jail_user", fmt.Sprintf("%d", ctrSpec.Process.User.UID))
+		}
+	}
+	writeJailParam(&b, "exec.clean", "true")
+	writeJailParam(&b, "persist", "true")
+	b.WriteString("}\n")
+
+	return []byte(b.String()), nil
+}
+
+// jailConfQuote quotes a string for use in jail.conf. Newlines are escaped so
+// that every parameter stays on a single line.
+func jailConfQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// writeJailParam writes a single jail parameter. Boolean parameters which
+// are true are written without a value.
+func writeJailParam(b *strings.Builder, name, value string) {
+	switch value {
+	case "true":
+		fmt.Fprintf(b, "\t%s;\n", name)
+	case "false":
+		fmt.Fprintf(b, "\t%s = false;\n", name)
+	default:
+		fmt.Fprintf(b, "\t%s = %s;\n", name, jailConfQuote(value))
+	}
+}
+
+// writeJailMount writes a mount parameter in fstab(5) format.
+func writeJailMount(b *strings.Builder, rootPath string, m spec.Mount) {
+	options := "rw"
+	if len(m.Options) > 0 {
+		options = strings.Join(m.Options, ",")
+	}
+	source := m.Source
+	if source == "" {
+		source = m.Type
+	}
+	dest := filepath.Join(rootPath, m.Destination)
+	fmt.Fprintf(b, "\tmount += %s;\n", jailConfQuote(fmt.Sprintf("%s %s %s %s 0 0", fstabEscape(source), fstabEscape(dest), m.Type, options)))
+}
+
+// fstabEscape escapes the spaces and tabs in a field of an fstab(5) line.
+func fstabEscape(s string) string {
+	r := strings.NewReplacer(" ", `\040`, "\t", `\011`)
+	return r.Replace(s)
+}
+
+// jailConfEnv returns the environment for exec.start without the variables
+// set from secrets, whose names are returned as well.
+func jailConfEnv(env []string, envSecrets map[string]*secrets.Secret) ([]string, []string) {
+	var kept, secretEnv []string
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		if envSecrets[name] != nil {
+			secretEnv = append(secretEnv, name)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	sort.Strings(secretEnv)
+	return kept, secretEnv
+}
+
+// jailExecCommand returns a shell command which runs the container process
+// with its working directory and environment.
+func jailExecCommand(p *spec.Process) string {
+	shellQuote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	var cmd []string
+	if p.Cwd != "" {
+		cmd = append(cmd, "cd", shellQuote(p.Cwd), "&&")
+	}
+	cmd = append(cmd, "exec")
+	if len(p.Env) > 0 {
+		cmd = append(cmd, "env")
+		for _, e := range p.Env {
+			cmd = append(cmd, shellQuote(e))
+		}
+	}
+	for _, arg := range p.Args {
+		cmd = append(cmd, shellQuote(arg))
+	}
+	return strings.Join(cmd, " ")
+}
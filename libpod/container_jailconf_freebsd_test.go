@@ -0,0 +1,138 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/containers/common/pkg/secrets"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJailConfQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", `"plain"`},
+		{"with space", `"with space"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\dir`, `"C:\\dir"`},
+		{"$HOME ${PATH}", `"\$HOME \${PATH}"`},
+		{"two\nlines", `"two\nlines"`},
+		{`\"$`, `"\\\"\$"`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, jailConfQuote(tt.in), tt.in)
+	}
+}
+
+func TestWriteJailMount(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount spec.Mount
+		want  string
+	}{
+		{
+			name:  "nullfs",
+			mount: spec.Mount{Type: "nullfs", Source: "/data", Destination: "/data", Options: []string{"ro"}},
+			want:  "\tmount += \"/data /root/data nullfs ro 0 0\";\n",
+		},
+		{
+			name:  "no source or options",
+			mount: spec.Mount{Type: "tmpfs", Destination: "/tmp"},
+			want:  "\tmount += \"tmpfs /root/tmp tmpfs rw 0 0\";\n",
+		},
+		{
+			name:  "spaces and tabs",
+			mount: spec.Mount{Type: "nullfs", Source: "/my data", Destination: "/in\tside"},
+			want:  "\tmount += \"/my\\\\040data /root/in\\\\011side nullfs rw 0 0\";\n",
+		},
+		{
+			name:  "quotes and dollars",
+			mount: spec.Mount{Type: "nullfs", Source: `/a"$b`, Destination: "/c"},
+			want:  "\tmount += \"/a\\\"\\$b /root/c nullfs rw 0 0\";\n",
+		},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		writeJailMount(&b, "/root", tt.mount)
+		assert.Equal(t, tt.want, b.String(), tt.name)
+	}
+}
+
+func TestJailExecCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		process spec.Process
+		want    string
+	}{
+		{
+			name:    "args",
+			process: spec.Process{Args: []string{"/bin/sh", "-c", "echo hi"}},
+			want:    `exec '/bin/sh' '-c' 'echo hi'`,
+		},
+		{
+			name:    "cwd and env",
+			process: spec.Process{Cwd: "/work dir", Env: []string{"A=1", "B=x y"}, Args: []string{"run"}},
+			want:    `cd '/work dir' && exec env 'A=1' 'B=x y' 'run'`,
+		},
+		{
+			name:    "single quotes",
+			process: spec.Process{Args: []string{"echo", "it's"}},
+			want:    `exec 'echo' 'it'\''s'`,
+		},
+		{
+			name:    "shell characters",
+			process: spec.Process{Args: []string{"echo", `$HOME "x" \n`, "a\nb"}},
+			want:    "exec 'echo' '$HOME \"x\" \\n' 'a\nb'",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, jailExecCommand(&tt.process), tt.name)
+	}
+}
+
+func TestWriteJailParamExecStart(t *testing.T) {
+	var b strings.Builder
+	writeJailParam(&b, "exec.start", jailExecCommand(&spec.Process{Args: []string{"echo", `"$x"`, "a\nb"}}))
+	assert.Equal(t, "\texec.start = \"exec 'echo' '\\\"\\$x\\\"' 'a\\nb'\";\n", b.String())
+}
+
+func TestJailConfSecretEnv(t *testing.T) {
+	c := &Container{
+		config: &ContainerConfig{
+			ID:   "abc",
+			Name: "web",
+			Spec: &spec.Spec{
+				Process: &spec.Process{
+					Env:  []string{"PATH=/bin", "TOKEN=s3cr3t-token", "PASSWORD=s3cr3t-password"},
+					Args: []string{"/bin/sh"},
+				},
+			},
+			ContainerMiscConfig: ContainerMiscConfig{
+				EnvSecrets: map[string]*secrets.Secret{
+					"TOKEN":    {Name: "token"},
+					"PASSWORD": {Name: "password"},
+				},
+			},
+		},
+		state:   &ContainerState{},
+		batched: true,
+	}
+
+	conf, err := c.JailConf()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(conf), "s3cr3t")
+	assert.Contains(t, string(conf), "# The secret environment variables PASSWORD, TOKEN are left out of exec.start.")
+	assert.Contains(t, string(conf), `'PATH=/bin'`)
+
+	// The debug bundle leaves out the values of all variables.
+	conf, err = c.jailConf(true)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(conf), "s3cr3t")
+	assert.NotContains(t, string(conf), "/bin'")
+	assert.Contains(t, string(conf), `'PATH=<redacted>'`)
+}
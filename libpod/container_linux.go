@@ -3,6 +3,9 @@
 package libpod
 
 import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -19,3 +22,8 @@ func networkDisabled(c *Container) (bool, error) {
 	}
 	return false, nil
 }
+
+// JailConf is only supported on FreeBSD.
+func (c *Container) JailConf() ([]byte, error) {
+	return nil, fmt.Errorf("generating jail.conf is only supported on FreeBSD: %w", define.ErrOSNotSupported)
+}
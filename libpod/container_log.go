@@ -3,10 +3,13 @@
 package libpod
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
@@ -25,16 +28,83 @@ func init() {
 	logDrivers = append(logDrivers, define.KubernetesLogging, define.NoLogging, define.PassthroughLogging)
 }
 
+// expandLogTag returns the container's log tag with the template expanded
+// using the container's inspect data. The container must be locked.
+func (c *Container) expandLogTag() (string, error) {
+	logTag := c.LogTag()
+	if logTag == "" {
+		return "", nil
+	}
+	data, err := c.inspectLocked(false)
+	if err != nil {
+		// FIXME: this error should probably be returned
+		return "", nil //nolint: nilerr
+	}
+	tmpl, err := template.New("container").Parse(logTag)
+	if err != nil {
+		return "", fmt.Errorf("template parsing error %s: %w", logTag, err)
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// logMergeWindow is how long a log line of one container is held back
+// waiting for older lines of other containers when reading several logs.
+const logMergeWindow = 500 * time.Millisecond
+
 // Log is a runtime function that can read one or more container logs.
+// The lines of multiple containers are written ordered by their timestamps.
 func (r *Runtime) Log(ctx context.Context, containers []*Container, options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	if len(containers) < 2 {
+		for c, ctr := range containers {
+			if err := ctr.ReadLog(ctx, options, logChannel, int64(c)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Read every log into a channel of its own, which is closed once the
+	// reader is done, and merge them into logChannel.
+	inputs := make([]chan *logs.LogLine, 0, len(containers))
 	for c, ctr := range containers {
-		if err := ctr.ReadLog(ctx, options, logChannel, int64(c)); err != nil {
+		ctrOptions := *options
+		ctrOptions.WaitGroup = new(sync.WaitGroup)
+		input := make(chan *logs.LogLine, 1)
+		if err := ctr.ReadLog(ctx, &ctrOptions, input, int64(c)); err != nil {
+			// Let the readers that were already started finish.
+			discardLogInputs(inputs)
 			return err
 		}
+		inputs = append(inputs, input)
+		go func() {
+			ctrOptions.WaitGroup.Wait()
+			close(input)
+		}()
 	}
+	options.WaitGroup.Add(1)
+	go func() {
+		defer options.WaitGroup.Done()
+		logs.MergeLogLines(inputs, logChannel, logMergeWindow)
+	}()
 	return nil
 }
 
+// discardLogInputs discards the lines of the given log inputs until their
+// readers are done.
+func discardLogInputs(inputs []chan *logs.LogLine) {
+	for _, input := range inputs {
+		go func(input chan *logs.LogLine) {
+			for range input {
+			}
+		}(input)
+	}
+}
+
 // ReadLog reads a container's log based on the input options and returns log lines over a channel.
 func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
 	switch c.LogDriver() {
@@ -52,7 +122,7 @@ func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logCh
 		// TODO provide a separate implementation of this when Conmon
 		// has support.
 		fallthrough
-	case define.KubernetesLogging, "":
+	case define.KubernetesLogging, define.SyslogLogging, "":
 		return c.readFromLogFile(ctx, options, logChannel, colorID)
 	default:
 		return fmt.Errorf("unrecognized log driver %q, cannot read logs: %w", c.LogDriver(), define.ErrInternal)
@@ -68,6 +138,13 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 		}
 		return fmt.Errorf("unable to read log file %s for %s : %w", c.ID(), c.LogPath(), err)
 	}
+	// Include the rotated log files when reading the whole log.
+	if options.Tail < 0 && c.config.LogMaxFiles > 1 {
+		tailLog, err = readRotatedLogs(c.LogPath(), c.config.LogMaxFiles)
+		if err != nil {
+			return fmt.Errorf("unable to read rotated log files for %s: %w", c.ID(), err)
+		}
+	}
 	options.WaitGroup.Add(1)
 	go func() {
 		if options.Until.After(time.Now()) {
@@ -0,0 +1,180 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/sirupsen/logrus"
+)
+
+// logRotateInterval is the interval at which the log manager checks the size
+// of the container's log file.
+const logRotateInterval = time.Second
+
+// logSizeMax returns the size at which the container's log is truncated or
+// rotated, or 0 if the size is not limited.
+func (c *Container) logSizeMax() int64 {
+	if c.config.LogSize > 0 {
+		return c.config.LogSize
+	}
+	if c.runtime.config.Containers.LogSizeMax > 0 {
+		return c.runtime.config.Containers.LogSizeMax
+	}
+	return 0
+}
+
+// rotatesLogs returns true if the container's log is rotated by the log
+// manager instead of being truncated by conmon.
+func (c *Container) rotatesLogs() bool {
+	return c.config.LogMaxFiles > 1 && c.logSizeMax() > 0
+}
+
+// needsLogManager returns true if the container needs a log manager process
+// while it is running.
+func (c *Container) needsLogManager() bool {
+	return c.config.LogDriver == define.SyslogLogging || c.rotatesLogs()
+}
+
+// startLogManager starts a Podman process which manages the log of the
+// container until the container exits.
+func (c *Container) startLogManager() error {
+	args, err := specgenutil.CreateLogManagerCommandArgs(c.runtime.storageConfig, c.runtime.config, c.ID())
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	logrus.Debugf("Starting log manager for container %s: %v", c.ID(), args)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting log manager: %w", err)
+	}
+	// The log manager outlives a Podman client process, a long running
+	// service has to reap it.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
+
+// ManageLogs manages the log of the current run of the container and returns
+// once the container has exited. It rotates the log file when it reaches its
+// maximum size if more than one log file is kept, and forwards the output to
+// syslog for the syslog log driver.
+func (c *Container) ManageLogs(ctx context.Context) error {
+	if !c.needsLogManager() {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	if c.rotatesLogs() {
+		rotateCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			_, _ = c.Wait(rotateCtx)
+			cancel()
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.rotateLogsUntilDone(rotateCtx)
+		}()
+	}
+
+	var err error
+	if c.config.LogDriver == define.SyslogLogging {
+		err = c.forwardLogsToSyslog(ctx)
+	}
+	wg.Wait()
+	return err
+}
+
+// rotateLogsUntilDone rotates the log file whenever it reaches its maximum
+// size until ctx is cancelled.
+func (c *Container) rotateLogsUntilDone(ctx context.Context) {
+	maxSize := c.logSizeMax()
+	ticker := time.NewTicker(logRotateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(c.LogPath())
+		if err != nil || info.Size() < maxSize {
+			continue
+		}
+		if err := rotateLogFile(c.LogPath(), c.config.LogMaxFiles); err != nil {
+			logrus.Errorf("Rotating log of container %s: %v", c.ID(), err)
+			continue
+		}
+		if err := c.ociRuntime.ReopenContainerLog(c); err != nil {
+			logrus.Errorf("Reopening log of container %s: %v", c.ID(), err)
+		}
+	}
+}
+
+// rotatedLogPath returns the path of the nth rotated log file.
+func rotatedLogPath(path string, n uint) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// rotateLogFile renames the log file to path.1 and shifts the existing
+// rotated files, so that at most maxFiles files including the current one
+// are kept.
+func rotateLogFile(path string, maxFiles uint) error {
+	if err := os.Remove(rotatedLogPath(path, maxFiles-1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for i := maxFiles - 2; i >= 1; i-- {
+		if err := os.Rename(rotatedLogPath(path, i), rotatedLogPath(path, i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(path, rotatedLogPath(path, 1))
+}
+
+// readRotatedLogs returns the lines of the rotated log files, oldest first.
+func readRotatedLogs(path string, maxFiles uint) ([]*logs.LogLine, error) {
+	var lines []*logs.LogLine
+	for i := maxFiles - 1; i >= 1; i-- {
+		f, err := os.Open(rotatedLogPath(path, i))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			nll, err := logs.NewLogLine(scanner.Text())
+			if err != nil {
+				logrus.Errorf("Getting new log line: %v", err)
+				continue
+			}
+			lines = append(lines, nll)
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,41 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateLogFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctr.log")
+	line := func(msg string) string {
+		return "2024-05-15T10:17:30.000000000+00:00 stdout F " + msg + "\n"
+	}
+
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		require.NoError(t, os.WriteFile(path, []byte(line(msg)), 0o600))
+		require.NoError(t, rotateLogFile(path, 3))
+	}
+	require.NoError(t, os.WriteFile(path, []byte(line("five")), 0o600))
+
+	// Only two rotated files are kept besides the current one.
+	_, err := os.Stat(rotatedLogPath(path, 3))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	data, err := os.ReadFile(rotatedLogPath(path, 1))
+	require.NoError(t, err)
+	assert.Equal(t, line("four"), string(data))
+
+	lines, err := readRotatedLogs(path, 3)
+	require.NoError(t, err)
+	msgs := make([]string, 0, len(lines))
+	for _, l := range lines {
+		msgs = append(msgs, l.Msg)
+	}
+	assert.Equal(t, []string{"three", "four"}, msgs)
+}
@@ -0,0 +1,170 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// syslogAddressOption is the log option for the address of the syslog
+	// server, e.g. udp://loghost:514 or unix:///var/run/log.
+	syslogAddressOption = "syslog-address"
+	// syslogFacilityOption is the log option for the syslog facility.
+	syslogFacilityOption = "syslog-facility"
+)
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+func init() {
+	logDrivers = append(logDrivers, define.SyslogLogging)
+}
+
+// parseSyslogOptions returns the network and address of the syslog server
+// and the facility from the options of the syslog log driver. An empty
+// network and address select the local syslog daemon.
+func parseSyslogOptions(options map[string]string) (string, string, syslog.Priority, error) {
+	var network, raddr string
+	facility := syslog.LOG_DAEMON
+	for key, val := range options {
+		switch key {
+		case syslogAddressOption:
+			u, err := url.Parse(val)
+			if err != nil {
+				return "", "", 0, fmt.Errorf("invalid syslog address %q: %w", val, err)
+			}
+			switch u.Scheme {
+			case "udp", "tcp":
+				if u.Host == "" {
+					return "", "", 0, fmt.Errorf("syslog address %q has no host: %w", val, define.ErrInvalidArg)
+				}
+				raddr = u.Host
+				if u.Port() == "" {
+					raddr = net.JoinHostPort(u.Hostname(), "514")
+				}
+			case "unix", "unixgram":
+				if u.Path == "" {
+					return "", "", 0, fmt.Errorf("syslog address %q has no path: %w", val, define.ErrInvalidArg)
+				}
+				raddr = u.Path
+			default:
+				return "", "", 0, fmt.Errorf("syslog address %q must use udp, tcp, unix or unixgram: %w", val, define.ErrInvalidArg)
+			}
+			network = u.Scheme
+		case syslogFacilityOption:
+			f, ok := syslogFacilities[val]
+			if !ok {
+				return "", "", 0, fmt.Errorf("unknown syslog facility %q: %w", val, define.ErrInvalidArg)
+			}
+			facility = f
+		default:
+			return "", "", 0, fmt.Errorf("log option %q is not supported by the %s log driver: %w", key, define.SyslogLogging, define.ErrInvalidArg)
+		}
+	}
+	return network, raddr, facility, nil
+}
+
+// forwardLogsToSyslog sends the output of the current run of the container to
+// syslog. Stdout is logged with the info and stderr with the err severity,
+// using the container's log tag or its short ID as the syslog tag. It returns
+// once the container has exited and its log has been forwarded.
+func (c *Container) forwardLogsToSyslog(ctx context.Context) error {
+	network, raddr, facility, err := parseSyslogOptions(c.config.LogOptions)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	if err := c.syncContainer(); err != nil {
+		c.lock.Unlock()
+		return err
+	}
+	tag, err := c.expandLogTag()
+	since := c.state.StartedTime
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if tag == "" {
+		tag = c.ID()[:12]
+	}
+
+	writer, err := syslog.Dial(network, raddr, facility|syslog.LOG_INFO, tag)
+	if err != nil {
+		return fmt.Errorf("connecting to syslog: %w", err)
+	}
+	defer writer.Close()
+	write := func(device, msg string) {
+		var err error
+		if device == "stderr" {
+			err = writer.Err(msg)
+		} else {
+			err = writer.Info(msg)
+		}
+		if err != nil {
+			logrus.Errorf("Writing log of container %s to syslog: %v", c.ID(), err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	logChannel := make(chan *logs.LogLine)
+	options := &logs.LogOptions{
+		Follow:    true,
+		Since:     since,
+		Tail:      -1,
+		WaitGroup: &wg,
+	}
+	if err := c.ReadLog(ctx, options, logChannel, 0); err != nil {
+		return err
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+
+	// Lines longer than the conmon buffer are split into partial lines,
+	// join them before sending them.
+	partial := make(map[string]string)
+	for line := range logChannel {
+		if line.Partial() {
+			partial[line.Device] += line.Msg
+			continue
+		}
+		write(line.Device, partial[line.Device]+line.Msg)
+		delete(partial, line.Device)
+	}
+	for device, msg := range partial {
+		write(device, msg)
+	}
+	return nil
+}
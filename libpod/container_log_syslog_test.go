@@ -0,0 +1,42 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"log/syslog"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSyslogOptions(t *testing.T) {
+	tests := []struct {
+		options  map[string]string
+		network  string
+		raddr    string
+		facility syslog.Priority
+	}{
+		{nil, "", "", syslog.LOG_DAEMON},
+		{map[string]string{"syslog-address": "udp://loghost"}, "udp", "loghost:514", syslog.LOG_DAEMON},
+		{map[string]string{"syslog-address": "tcp://10.0.0.1:601", "syslog-facility": "local3"}, "tcp", "10.0.0.1:601", syslog.LOG_LOCAL3},
+		{map[string]string{"syslog-address": "unix:///var/run/log"}, "unix", "/var/run/log", syslog.LOG_DAEMON},
+	}
+	for _, tt := range tests {
+		network, raddr, facility, err := parseSyslogOptions(tt.options)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.network, network)
+		assert.Equal(t, tt.raddr, raddr)
+		assert.Equal(t, tt.facility, facility)
+	}
+
+	for _, options := range []map[string]string{
+		{"syslog-address": "http://loghost"},
+		{"syslog-address": "udp://"},
+		{"syslog-facility": "bogus"},
+		{"max-file": "3"},
+	} {
+		_, _, _, err := parseSyslogOptions(options)
+		assert.ErrorIs(t, err, define.ErrInvalidArg)
+	}
+}
@@ -0,0 +1,81 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// containerMetadataFile is the name of the file in /run which contains the
+// labels and annotations selected with --metadata-keys. It allows agents
+// running in the container, e.g. monitoring daemons, to identify the
+// container they are running in.
+const containerMetadataFile = ".containermeta"
+
+// metadataFileContents returns the contents of the container's metadata file.
+// Each line has the form key="value", labels use a label. prefix and
+// annotations an annotation. prefix.
+func (c *Container) metadataFileContents() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "name=%q\n", c.Name())
+	fmt.Fprintf(&b, "id=%q\n", c.ID())
+
+	var annotations map[string]string
+	if c.config.Spec != nil {
+		annotations = c.config.Spec.Annotations
+	}
+	for _, prefix := range []string{"label", "annotation"} {
+		values := c.config.Labels
+		if prefix == "annotation" {
+			values = annotations
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			if matchMetadataKey(c.config.MetadataKeys, key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s.%s=%q\n", prefix, key, values[key])
+		}
+	}
+	return b.String()
+}
+
+// updateMetadataFile rewrites the metadata file of a running container. The
+// file is written in place so that the change is visible through the bind
+// mount in the container.
+func (c *Container) updateMetadataFile() error {
+	if len(c.config.MetadataKeys) == 0 || c.state.RunDir == "" {
+		return nil
+	}
+	metaPath := filepath.Join(c.state.RunDir, containerMetadataFile)
+	if _, err := os.Stat(metaPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := os.WriteFile(metaPath, []byte(c.metadataFileContents()), 0o644); err != nil {
+		return fmt.Errorf("updating container %s metadata file: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// matchMetadataKey returns true if key matches one of the patterns.
+func matchMetadataKey(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/lock"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataFileContents(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr, err := getTestCtr1(manager)
+	assert.NoError(t, err)
+	ctr.config.Labels = map[string]string{
+		"com.example.tier":  "frontend",
+		"com.example.owner": "ops",
+		"other":             "ignored",
+	}
+	ctr.config.Spec = &spec.Spec{Annotations: map[string]string{
+		"io.example.region": "eu",
+		"io.other":          "ignored",
+	}}
+	ctr.config.MetadataKeys = []string{"com.example.*", "io.example.region"}
+
+	expected := `name="test1"
+id="11111111111111111111111111111111"
+label.com.example.owner="ops"
+label.com.example.tier="frontend"
+annotation.io.example.region="eu"
+`
+	assert.Equal(t, expected, ctr.metadataFileContents())
+}
@@ -0,0 +1,71 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage/pkg/idtools"
+	"github.com/sirupsen/logrus"
+)
+
+// expandMountTemplate replaces the %{name} and %{id} placeholders in s with
+// the name and ID of the container. It returns whether s contained any.
+func expandMountTemplate(s, name, id string) (string, bool) {
+	if !strings.Contains(s, "%{") {
+		return s, false
+	}
+	expanded := strings.NewReplacer("%{name}", name, "%{id}", id).Replace(s)
+	return expanded, expanded != s
+}
+
+// expandMountTemplates expands the container name and ID placeholders in the
+// host paths of bind mounts and in the driver options of named volumes. Host
+// directories named by a placeholder are recorded, to be created by
+// createMountSources when the container is initialized, so that each
+// container gets its own directory for e.g. logs or spool files. Nothing is
+// created on the host if creating the container fails. This must be called
+// once the name of the container is set.
+func (c *Container) expandMountTemplates() error {
+	for i := range c.config.Spec.Mounts {
+		m := &c.config.Spec.Mounts[i]
+		source, ok := expandMountTemplate(m.Source, c.Name(), c.ID())
+		if !ok {
+			continue
+		}
+		if !filepath.IsAbs(source) {
+			return fmt.Errorf("mount source %q for %s must be an absolute path", m.Source, m.Destination)
+		}
+		m.Source = source
+		c.config.CreateMountSources = append(c.config.CreateMountSources, source)
+	}
+
+	for _, vol := range c.config.NamedVolumes {
+		for i, opt := range vol.Options {
+			if strings.HasPrefix(opt, "volume-opt") {
+				vol.Options[i], _ = expandMountTemplate(opt, c.Name(), c.ID())
+			}
+		}
+	}
+	return nil
+}
+
+// createMountSources creates the host directories of bind mounts named by a
+// placeholder which do not exist, owned by the container's root user.
+func (c *Container) createMountSources() error {
+	for _, source := range c.config.CreateMountSources {
+		if _, err := os.Stat(source); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("checking mount source %s: %w", source, err)
+		}
+		logrus.Debugf("Creating host directory %s for container %s", source, c.ID())
+		if err := idtools.MkdirAllAs(source, 0o755, c.RootUID(), c.RootGID()); err != nil {
+			return fmt.Errorf("creating mount source %s: %w", source, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMountTemplate(t *testing.T) {
+	tests := []struct {
+		in       string
+		out      string
+		expanded bool
+	}{
+		{"/srv/logs", "/srv/logs", false},
+		{"/srv/%{name}/logs", "/srv/web/logs", true},
+		{"/srv/%{id}", "/srv/0123abcd", true},
+		{"/srv/%{name}-%{id}", "/srv/web-0123abcd", true},
+		{"volume-opt=device=/data/%{name}", "volume-opt=device=/data/web", true},
+		{"/srv/%{unknown}", "/srv/%{unknown}", false},
+	}
+	for _, tt := range tests {
+		out, expanded := expandMountTemplate(tt.in, "web", "0123abcd")
+		assert.Equal(t, tt.out, out, tt.in)
+		assert.Equal(t, tt.expanded, expanded, tt.in)
+	}
+}
+
+func TestExpandMountTemplates(t *testing.T) {
+	dir := t.TempDir()
+	c := &Container{
+		config: &ContainerConfig{
+			ID:   "0123abcd",
+			Name: "web",
+			Spec: &spec.Spec{
+				Mounts: []spec.Mount{
+					{Source: filepath.Join(dir, "logs", "%{name}"), Destination: "/var/log"},
+					{Source: filepath.Join(dir, "data"), Destination: "/data"},
+				},
+			},
+		},
+	}
+	require.NoError(t, c.expandMountTemplates())
+	source := filepath.Join(dir, "logs", "web")
+	assert.Equal(t, source, c.config.Spec.Mounts[0].Source)
+	assert.Equal(t, []string{source}, c.config.CreateMountSources)
+
+	// Nothing is created until the container is initialized
+	assert.NoDirExists(t, source)
+
+	relative := &Container{config: &ContainerConfig{
+		ID:   "0123abcd",
+		Name: "web",
+		Spec: &spec.Spec{Mounts: []spec.Mount{{Source: "logs/%{id}", Destination: "/var/log"}}},
+	}}
+	assert.Error(t, relative.expandMountTemplates())
+
+	if os.Geteuid() != 0 {
+		t.Skip("creating the directories requires root")
+	}
+	require.NoError(t, c.createMountSources())
+	assert.DirExists(t, source)
+	assert.NoDirExists(t, filepath.Join(dir, "data"))
+}
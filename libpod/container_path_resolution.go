@@ -153,6 +153,23 @@ func isPathOnVolume(c *Container, containerPath string) bool {
 	return false
 }
 
+// namedVolumeForPath returns the named volume whose destination contains the
+// specified containerPath, preferring the innermost one, or nil.
+func namedVolumeForPath(c *Container, containerPath string) *ContainerNamedVolume {
+	cleanedContainerPath := filepath.Clean(containerPath)
+	var found *ContainerNamedVolume
+	for _, vol := range c.config.NamedVolumes {
+		cleanedDestination := filepath.Clean(vol.Dest)
+		if cleanedContainerPath != cleanedDestination && !isSubDir(cleanedContainerPath, cleanedDestination) {
+			continue
+		}
+		if found == nil || len(cleanedDestination) > len(filepath.Clean(found.Dest)) {
+			found = vol
+		}
+	}
+	return found
+}
+
 // findBindMounts checks if the specified containerPath matches the destination
 // path of a Mount.  Returns a matching Mount or nil.
 func findBindMount(c *Container, containerPath string) *specs.Mount {
@@ -28,3 +28,17 @@ func TestIsSubDir(t *testing.T) {
 	assert.False(t, isSubDir("//", "..//"))
 	assert.True(t, isSubDir("/foo/bar/baz/../../", "/foo/"))
 }
+
+func TestNamedVolumeForPath(t *testing.T) {
+	outer := &ContainerNamedVolume{Name: "outer", Dest: "/vol"}
+	inner := &ContainerNamedVolume{Name: "inner", Dest: "/vol/data/"}
+	c := &Container{config: &ContainerConfig{ContainerRootFSConfig: ContainerRootFSConfig{
+		NamedVolumes: []*ContainerNamedVolume{inner, outer},
+	}}}
+
+	assert.Equal(t, outer, namedVolumeForPath(c, "/vol"))
+	assert.Equal(t, outer, namedVolumeForPath(c, "/vol/subdir"))
+	assert.Equal(t, inner, namedVolumeForPath(c, "/vol/data/subdir/"))
+	assert.Nil(t, namedVolumeForPath(c, "/"))
+	assert.Nil(t, namedVolumeForPath(c, "/volume"))
+}
@@ -0,0 +1,81 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Phases of starting a container which are timed by startTiming. On FreeBSD,
+// the network phase includes creating the vnet jail and the runtime create
+// phase creating the container's jail.
+const (
+	startPhaseStorage        = "storage-mount"
+	startPhaseNetwork        = "network-setup"
+	startPhaseSpec           = "spec-generation"
+	startPhaseRuntimeCreate  = "runtime-create"
+	startPhaseResourceLimits = "resource-limits"
+	startPhaseRuntimeStart   = "runtime-start"
+)
+
+// startTiming records how long the phases of starting a container take, so
+// that regressions in start latency can be pinpointed. Each phase is logged
+// at trace level when it finishes and a summary of all phases at debug
+// level once the container has started. A nil startTiming records nothing,
+// e.g. when a container is only initialized.
+type startTiming struct {
+	ctrID  string
+	begin  time.Time
+	lock   sync.Mutex
+	phases []startPhase
+}
+
+type startPhase struct {
+	name     string
+	duration time.Duration
+}
+
+func newStartTiming(ctrID string) *startTiming {
+	return &startTiming{ctrID: ctrID, begin: time.Now()}
+}
+
+// record records that the named phase, which began at start, has finished.
+// Phases may run concurrently.
+func (t *startTiming) record(name string, start time.Time) {
+	if t == nil {
+		return
+	}
+	duration := time.Since(start)
+	logrus.WithFields(logrus.Fields{
+		"container": t.ctrID,
+		"phase":     name,
+		"duration":  duration,
+	}).Trace("Container start phase finished")
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.phases = append(t.phases, startPhase{name: name, duration: duration})
+}
+
+// summary returns the duration of each phase in the order they finished.
+func (t *startTiming) summary() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	parts := make([]string, 0, len(t.phases))
+	for _, phase := range t.phases {
+		parts = append(parts, phase.name+"="+phase.duration.Round(time.Microsecond).String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// finish logs the summary of all phases.
+func (t *startTiming) finish() {
+	if t == nil {
+		return
+	}
+	logrus.Debugf("Started container %s in %s: %s", t.ctrID, time.Since(t.begin).Round(time.Microsecond), t.summary())
+}
@@ -0,0 +1,22 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartTiming(t *testing.T) {
+	var nilTiming *startTiming
+	nilTiming.record(startPhaseStorage, time.Now())
+	nilTiming.finish()
+
+	timing := newStartTiming("abc")
+	timing.record(startPhaseStorage, time.Now().Add(-1500*time.Microsecond))
+	timing.record(startPhaseNetwork, time.Now().Add(-time.Second))
+	summary := timing.summary()
+	assert.Regexp(t, `^storage-mount=1\.5\d*ms network-setup=1(\.\d+)?s$`, summary)
+}
@@ -30,7 +30,7 @@ func (c *Container) statOnHost(mountPoint string, containerPath string) (*copier
 	return statInfo, resolvedRoot, resolvedPath, err
 }
 
-func (c *Container) stat(containerMountPoint string, containerPath string) (*define.FileInfo, string, string, error) {
+func (c *Container) stat(containerMountPoint string, containerPath string, noFollowLink bool) (*define.FileInfo, string, string, error) {
 	var (
 		resolvedRoot     string
 		resolvedPath     string
@@ -66,8 +66,15 @@ func (c *Container) stat(containerMountPoint string, containerPath string) (*def
 		}
 	}
 
+	// A link which is not followed is described by itself, so a dangling
+	// link can be copied.
+	linkItself := noFollowLink && statInfo.IsSymlink
+	if linkItself {
+		statErr = nil
+	}
+
 	switch {
-	case statInfo.IsSymlink:
+	case statInfo.IsSymlink && !linkItself:
 		// Symlinks are already evaluated and always relative to the
 		// container's mount point.
 		absContainerPath = statInfo.ImmediateTarget
@@ -96,6 +103,10 @@ func (c *Container) stat(containerMountPoint string, containerPath string) (*def
 		ModTime:    statInfo.ModTime,
 		LinkTarget: absContainerPath,
 	}
+	if linkItself {
+		info.IsDir = false
+		info.Mode = os.ModeSymlink | 0o777
+	}
 
 	return info, resolvedRoot, resolvedPath, statErr
 }
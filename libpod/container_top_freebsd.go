@@ -3,12 +3,10 @@
 package libpod
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
-	"sync"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/util"
@@ -18,6 +16,13 @@ import (
 
 var isDescriptor = map[string]bool{}
 
+// psKeywords maps descriptors which are named differently by ps(1) to
+// the corresponding ps keyword.
+var psKeywords = map[string]string{
+	"emulation": "emul",
+	"jailname":  "jail",
+}
+
 func init() {
 	allDescriptors, err := util.GetContainerPidInformationDescriptors()
 	if err != nil {
@@ -49,15 +54,8 @@ func (c *Container) Top(descriptors []string) ([]string, error) {
 	// If everything in descriptors is a supported AIX format
 	// descriptor, we use 'ps -ao <descriptors>', otherwise we pass
 	// everything straight through to ps.
-	supportedDescriptors := true
-	for _, d := range descriptors {
-		if _, ok := isDescriptor[d]; !ok {
-			supportedDescriptors = false
-			break
-		}
-	}
-	if supportedDescriptors {
-		descriptors = []string{"-ao", strings.Join(descriptors, ",")}
+	if keywords, err := psKeywordList(descriptors); err == nil {
+		descriptors = []string{"-ao", keywords}
 	}
 
 	// Note that the descriptors to ps(1) must be shlexed (see #12452).
@@ -93,43 +91,38 @@ func (c *Container) Top(descriptors []string) ([]string, error) {
 	return output, nil
 }
 
-func execPS(args []string) ([]string, error) {
-	cmd := exec.Command("ps", args...)
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, err
-	}
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return nil, err
+// psKeywordList returns the ps(1) keyword list for the given descriptors,
+// which may also be comma-separated. It fails if any of them is not a
+// supported descriptor.
+func psKeywordList(descriptors []string) (string, error) {
+	keywords := []string{}
+	for _, d := range descriptors {
+		for _, s := range strings.Split(d, ",") {
+			if s == "" {
+				continue
+			}
+			if !isDescriptor[s] {
+				return "", fmt.Errorf("unknown descriptor: %s", s)
+			}
+			if keyword, ok := psKeywords[s]; ok {
+				s = keyword
+			}
+			keywords = append(keywords, s)
+		}
 	}
+	return strings.Join(keywords, ","), nil
+}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
-	stdout := []string{}
-	go func() {
-		scanner := bufio.NewScanner(stdoutPipe)
-		for scanner.Scan() {
-			stdout = append(stdout, scanner.Text())
-		}
-		wg.Done()
-	}()
-	stderr := []string{}
-	go func() {
-		scanner := bufio.NewScanner(stderrPipe)
-		for scanner.Scan() {
-			stderr = append(stderr, scanner.Text())
+// execPS runs ps(1) on the host with the given arguments and returns its
+// output lines.
+func execPS(args []string) ([]string, error) {
+	output, err := exec.Command("ps", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("ps(1) command failed: %w, output: %s", err, strings.Join(scanLines(exitErr.Stderr), " "))
 		}
-		wg.Done()
-	}()
-
-	if err := cmd.Start(); err != nil {
 		return nil, err
 	}
-	wg.Wait()
-	if err := cmd.Wait(); err != nil {
-		return nil, fmt.Errorf("ps(1) command failed: %w, output: %s", err, strings.Join(stderr, " "))
-	}
-
-	return stdout, nil
+	return scanLines(output), nil
 }
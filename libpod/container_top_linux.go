@@ -5,6 +5,7 @@ package libpod
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -358,51 +359,24 @@ func (c *Container) execPS(psArgs []string) ([]string, bool, error) {
 // execPS executes ps(1) with the specified args in the container via exec session.
 // This should be a bit safer then execPS() but it requires ps(1) to be installed in the container.
 func (c *Container) execPSinContainer(args []string) ([]string, error) {
-	rPipe, wPipe, err := os.Pipe()
+	config := new(ExecConfig)
+	config.Command = append([]string{"ps"}, args...)
+	stream, err := c.ExecStream(context.Background(), config, ExecStreamOptions{})
 	if err != nil {
 		return nil, err
 	}
-	defer rPipe.Close()
-
-	var errBuf bytes.Buffer
-	streams := new(define.AttachStreams)
-	streams.OutputStream = wPipe
-	streams.ErrorStream = &errBuf
-	streams.AttachOutput = true
-	streams.AttachError = true
-
-	outErrChan := make(chan error)
-	stdout := []string{}
-	go func() {
-		defer close(outErrChan)
-		scanner := bufio.NewScanner(rPipe)
-		for scanner.Scan() {
-			stdout = append(stdout, scanner.Text())
-		}
-		if err := scanner.Err(); err != nil {
-			outErrChan <- err
-		}
-	}()
-
-	cmd := append([]string{"ps"}, args...)
-	config := new(ExecConfig)
-	config.Command = cmd
-	ec, err := c.Exec(config, streams, nil)
-	wPipe.Close()
+	stdout, stderr, ec, err := stream.Output()
 	if err != nil {
 		return nil, err
 	} else if ec != 0 {
-		return nil, fmt.Errorf("runtime failed with exit status: %d and output: %s", ec, errBuf.String())
+		return nil, fmt.Errorf("runtime failed with exit status: %d and output: %s", ec, stderr)
 	}
 
 	if logrus.GetLevel() >= logrus.DebugLevel {
 		// If we're running in debug mode or higher, we might want to have a
 		// look at stderr which includes debug logs from conmon.
-		logrus.Debugf(errBuf.String())
+		logrus.Debugf(string(stderr))
 	}
 
-	if err := <-outErrChan; err != nil {
-		return nil, fmt.Errorf("failed to read ps stdout: %w", err)
-	}
-	return stdout, nil
+	return scanLines(stdout), nil
 }
@@ -0,0 +1,27 @@
+//go:build !remote
+
+package libpod
+
+import "io"
+
+const (
+	// TraceToolDTrace traces the container with dtrace(1).
+	TraceToolDTrace = "dtrace"
+	// TraceToolKTrace traces the container with ktrace(1).
+	TraceToolKTrace = "ktrace"
+)
+
+// TraceOptions are the options for tracing the processes of a container.
+type TraceOptions struct {
+	// Tool is the tracing tool, either TraceToolDTrace or TraceToolKTrace.
+	Tool string
+	// Probes are the DTrace probe descriptions to enable. By default,
+	// system calls made by the container are counted.
+	Probes []string
+	// Output is the file on the host which receives the trace. It is
+	// required for ktrace. If it is not set for dtrace, the output is
+	// written to Stdout.
+	Output string
+	// Stdout receives the output of dtrace if Output is not set.
+	Stdout io.Writer
+}
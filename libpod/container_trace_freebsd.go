@@ -0,0 +1,127 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultTraceProbe is the DTrace probe enabled if no probes are given.
+const defaultTraceProbe = "syscall:::entry"
+
+// Trace traces the processes of the container until ctx is cancelled or the
+// container exits.
+//
+// With dtrace, the given probes are enabled for all processes in the
+// container's jail and the number of times each probe fires is counted per
+// executable and function. The counts are written when tracing stops.
+//
+// With ktrace, the processes running in the jail when tracing starts and
+// their descendants are traced. Processes started later with podman exec
+// are not traced. The trace file can be read with kdump(1).
+func (c *Container) Trace(ctx context.Context, options *TraceOptions) error {
+	c.lock.Lock()
+	if err := c.syncContainer(); err != nil {
+		c.lock.Unlock()
+		return err
+	}
+	if c.state.State != define.ContainerStateRunning {
+		c.lock.Unlock()
+		return fmt.Errorf("container %s is not running, cannot trace it: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	jailName, err := c.jailName()
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if _, err := c.Wait(ctx); err == nil {
+			logrus.Debugf("Container %s exited, stopping trace", c.ID())
+		}
+		cancel()
+	}()
+
+	switch options.Tool {
+	case TraceToolDTrace:
+		return traceDTrace(ctx, jailName, options)
+	case TraceToolKTrace:
+		return traceKTrace(ctx, jailName, options)
+	default:
+		return fmt.Errorf("unknown trace tool %q, must be %s or %s: %w", options.Tool, TraceToolDTrace, TraceToolKTrace, define.ErrInvalidArg)
+	}
+}
+
+// traceDTrace runs dtrace until ctx is cancelled. It is interrupted with
+// SIGINT, which makes it print its aggregations before exiting.
+func traceDTrace(ctx context.Context, jailName string, options *TraceOptions) error {
+	probes := options.Probes
+	if len(probes) == 0 {
+		probes = []string{defaultTraceProbe}
+	}
+	args := []string{"-q"}
+	for _, probe := range probes {
+		args = append(args, "-n", fmt.Sprintf("%s /jailname == %q/ { @[execname, probefunc] = count(); }", probe, jailName))
+	}
+	if options.Output != "" {
+		args = append(args, "-o", options.Output)
+	}
+
+	cmd := exec.CommandContext(ctx, "dtrace", args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = 10 * time.Second
+	cmd.Stdout = options.Stdout
+	cmd.Stderr = os.Stderr
+	logrus.Debugf("Running dtrace %s", strings.Join(args, " "))
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("running dtrace: %w", err)
+	}
+	return nil
+}
+
+// traceKTrace enables ktrace for the processes in the jail until ctx is
+// cancelled.
+func traceKTrace(ctx context.Context, jailName string, options *TraceOptions) error {
+	if options.Output == "" {
+		return fmt.Errorf("ktrace requires an output file: %w", define.ErrInvalidArg)
+	}
+	out, err := exec.Command("ps", "-o", "pid=", "-J", jailName).Output()
+	if err != nil {
+		return fmt.Errorf("listing processes in jail %s: %w", jailName, err)
+	}
+	pids := strings.Fields(string(out))
+	if len(pids) == 0 {
+		return fmt.Errorf("no processes are running in jail %s", jailName)
+	}
+
+	defer func() {
+		if out, err := exec.Command("ktrace", "-c", "-f", options.Output).CombinedOutput(); err != nil {
+			logrus.Errorf("Disabling ktrace: %v: %s", err, strings.TrimSpace(string(out)))
+		}
+	}()
+	for i, pid := range pids {
+		args := []string{"-i", "-f", options.Output, "-p", pid}
+		// The first call truncates the trace file.
+		if i > 0 {
+			args = append([]string{"-a"}, args...)
+		}
+		if out, err := exec.Command("ktrace", args...).CombinedOutput(); err != nil {
+			// The process may have exited in the meantime.
+			logrus.Warnf("Tracing process %s: %v: %s", pid, err, strings.TrimSpace(string(out)))
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
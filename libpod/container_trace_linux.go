@@ -0,0 +1,16 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// Trace traces the processes of the container. It is only supported on
+// FreeBSD.
+func (c *Container) Trace(ctx context.Context, options *TraceOptions) error {
+	return fmt.Errorf("tracing containers with %s: %w", options.Tool, define.ErrOSNotSupported)
+}
@@ -156,6 +156,88 @@ const (
 	// IDs optionally with colon separated mount options.
 	VolumesFromAnnotation = "io.podman.annotations.volumes-from"
 
+	// JailProfileAnnotation is used on FreeBSD to select the jail
+	// parameter profile for a container. It can be set in the annotations
+	// field of containers.conf to choose a default profile for all
+	// containers. The --jail-profile option takes precedence.
+	JailProfileAnnotation = "io.podman.annotations.jail-profile"
+
+	// IOPriorityAnnotation is used on FreeBSD to select the I/O priority
+	// class of a container. It can be set in the annotations field of
+	// containers.conf to choose a default class for all containers. The
+	// --io-priority option takes precedence.
+	IOPriorityAnnotation = "io.podman.annotations.io-priority"
+
+	// IOPriorityClassAnnotationPrefix is the prefix of annotations which
+	// define or redefine an I/O priority class, e.g.
+	// io.podman.annotations.io-priority.batch=nice=10,writeiops=100.
+	// They are usually set in the annotations field of containers.conf.
+	IOPriorityClassAnnotationPrefix = "io.podman.annotations.io-priority."
+
+	// VnetSysctlsAnnotation is used on FreeBSD to pass the net.* sysctls
+	// which are set inside the container's vnet after the network is
+	// configured. It is a comma-separated list of key=value pairs.
+	VnetSysctlsAnnotation = "io.podman.annotations.vnet-sysctls"
+
+	// VnetDevfsRulesetAnnotation sets the devfs ruleset of the vnet jails
+	// of containers on FreeBSD. It is usually set in the annotations field
+	// of containers.conf as a default which the networks of a container
+	// and --security-opt vnet_devfs_ruleset override.
+	VnetDevfsRulesetAnnotation = "io.podman.annotations.vnet-devfs-ruleset"
+
+	// VnetDevfsRulesetLabel is the network label setting the devfs ruleset
+	// of the vnet jails of the containers joining the network on FreeBSD.
+	VnetDevfsRulesetLabel = "io.podman.network.vnet-devfs-ruleset"
+
+	// NetworkDHCPLabel marks a bridge network on FreeBSD whose containers
+	// get their address from a DHCP server on the network by running
+	// dhclient in their vnet jail. podman network create --ipam-driver
+	// dhcp sets it, as the network backend has no DHCP support there.
+	NetworkDHCPLabel = "io.podman.network.dhcp"
+
+	// NetworkIngressRateLabel is the network label limiting the rate of
+	// the traffic received by each container of the network, unless the
+	// container sets its own. FreeBSD only.
+	NetworkIngressRateLabel = "io.podman.network.ingress-rate"
+
+	// NetworkEgressRateLabel is the network label limiting the rate of
+	// the traffic sent by each container of the network, unless the
+	// container sets its own. FreeBSD only.
+	NetworkEgressRateLabel = "io.podman.network.egress-rate"
+
+	// MountsFileAnnotation selects the mounts.conf file listing the host
+	// files and directories which are copied into a container, overriding
+	// the default files. The value "none" disables these subscription
+	// mounts. It can be set in the annotations field of containers.conf to
+	// apply to all containers.
+	MountsFileAnnotation = "io.podman.annotations.mounts-file"
+
+	// ContainerenvFullAnnotation, if set to "true", adds the details about
+	// the container, like its name, ID and image, to /run/.containerenv,
+	// which are otherwise only included for privileged containers. It can
+	// be set in the annotations field of containers.conf to apply to all
+	// containers.
+	ContainerenvFullAnnotation = "io.podman.annotations.containerenv-full"
+
+	// TimezoneEnvAnnotation, if set to "true", makes --tz also set the TZ
+	// environment variable of the container to the name of the timezone,
+	// unless TZ is already set. It can be set in the annotations field of
+	// containers.conf to apply to all containers.
+	TimezoneEnvAnnotation = "io.podman.annotations.timezone-env"
+
+	// TimezoneCopyAnnotation, if set to "true", makes --tz copy the zone
+	// file of the host to /etc/localtime in the container's root
+	// filesystem when the image has no zone file for the timezone. By
+	// default the zone file is bind mounted over /etc/localtime. It can be
+	// set in the annotations field of containers.conf to apply to all
+	// containers.
+	TimezoneCopyAnnotation = "io.podman.annotations.timezone-copy"
+
+	// JailNameAnnotation is set by Podman on FreeBSD in the OCI spec of a
+	// container to the name of the container's jail, so that OCI hooks
+	// can find the jail, e.g. with jls(8) or jexec(8).
+	JailNameAnnotation = "io.podman.annotations.jail-name"
+
 	// KubeHealthCheckAnnotation is used by kube play to tell podman that any health checks should follow
 	// the k8s behavior of waiting for the intialDelaySeconds to be over before updating the status
 	KubeHealthCheckAnnotation = "io.podman.annotations.kube.health.check"
@@ -168,7 +250,7 @@ const (
 // already reserved annotation that Podman sets during container creation.
 func IsReservedAnnotation(value string) bool {
 	switch value {
-	case InspectAnnotationCIDFile, InspectAnnotationAutoremove, InspectAnnotationPrivileged, InspectAnnotationPublishAll, InspectAnnotationInit, InspectAnnotationLabel, InspectAnnotationSeccomp, InspectAnnotationApparmor, InspectResponseTrue, InspectResponseFalse, VolumesFromAnnotation:
+	case InspectAnnotationCIDFile, InspectAnnotationAutoremove, InspectAnnotationPrivileged, InspectAnnotationPublishAll, InspectAnnotationInit, InspectAnnotationLabel, InspectAnnotationSeccomp, InspectAnnotationApparmor, InspectResponseTrue, InspectResponseFalse, VolumesFromAnnotation, VnetSysctlsAnnotation:
 		return true
 
 	default:
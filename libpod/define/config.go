@@ -85,8 +85,34 @@ const PassthroughLogging = "passthrough"
 // PassthroughTTYLogging is the string conmon expects when specifying to use the passthrough driver even on a tty.
 const PassthroughTTYLogging = "passthrough-tty"
 
+// SyslogLogging is the log driver which forwards the container's output to
+// syslog. Conmon writes the output to a k8s-file log which is forwarded by
+// Podman.
+const SyslogLogging = "syslog"
+
 // DefaultRlimitValue is the value set by default for nofile and nproc
 const RLimitDefaultValue = uint64(1048576)
 
 // BindMountPrefix distinguishes its annotations from others
 const BindMountPrefix = "bind-mount-options"
+
+// BridgePortHandlerOption is the option of the bridge network mode selecting
+// how the ports of a container are published, it is kept in the network
+// options of the container under the "bridge" key.
+const BridgePortHandlerOption = "port_handler"
+
+// FirewallPortHandler publishes ports with the firewall rules of the network
+// backend. This is the default.
+const FirewallPortHandler = "firewall"
+
+// ProxyPortHandler publishes ports with host sockets opened by Podman, whose
+// connections are forwarded to the container by a userspace proxy.
+const ProxyPortHandler = "proxy"
+
+// BridgeIngressRateOption is the option of the bridge network mode limiting
+// the rate of the traffic received by the container on each network.
+const BridgeIngressRateOption = "ingress_rate"
+
+// BridgeEgressRateOption is the option of the bridge network mode limiting
+// the rate of the traffic sent by the container on each network.
+const BridgeEgressRateOption = "egress_rate"
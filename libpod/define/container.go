@@ -1,5 +1,7 @@
 package define
 
+import "time"
+
 // Valid restart policy types.
 const (
 	// RestartPolicyNone indicates that no restart policy has been requested
@@ -48,3 +50,13 @@ const (
 	// A DaemonSet kube yaml spec
 	K8sKindDaemonSet = "daemonset"
 )
+
+// CoreDump describes a core dump collected from a container process.
+type CoreDump struct {
+	// Name is the file name of the core dump.
+	Name string `json:"name"`
+	// Size is the size of the core dump in bytes.
+	Size int64 `json:"size"`
+	// Created is the time the core dump was written.
+	Created time.Time `json:"created"`
+}
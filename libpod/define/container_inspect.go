@@ -278,6 +278,18 @@ type InspectContainerState struct {
 	RestoreLog     string              `json:"RestoreLog,omitempty"`
 	Restored       bool                `json:"Restored,omitempty"`
 	StoppedByUser  bool                `json:"StoppedByUser,omitempty"`
+	OpenFiles      uint64              `json:"OpenFiles,omitempty"`
+	Threads        uint64              `json:"Threads,omitempty"`
+	// Jid is the ID of the container's jail. This is only reported on
+	// FreeBSD.
+	Jid int `json:"Jid,omitempty"`
+	// NetJid is the ID of the container's network (vnet) jail. This is
+	// only reported on FreeBSD.
+	NetJid int `json:"NetJid,omitempty"`
+	// Racct is a snapshot of the resource usage of the running
+	// container's jail, keyed by rctl(8) resource name, e.g. cputime,
+	// memoryuse, openfiles and nthr. This is only reported on FreeBSD.
+	Racct map[string]uint64 `json:"Racct,omitempty"`
 }
 
 // Healthcheck returns the HealthCheckResults. This is used for old podman compat
@@ -587,6 +599,15 @@ type InspectContainerHostConfig struct {
 	// MemoryReservation is the reservation (soft limit) of memory available
 	// to the container. Soft limits are warnings only and can be exceeded.
 	MemoryReservation int64 `json:"MemoryReservation"`
+	// IOPriority is the name of the I/O priority class of the container.
+	// This is only supported on FreeBSD.
+	IOPriority string `json:"IOPriority,omitempty"`
+	// MemoryLock is the amount of memory in bytes the container may lock
+	// to protect it from paging. This is only supported on FreeBSD.
+	MemoryLock int64 `json:"MemoryLock,omitempty"`
+	// MemoryReclaimSignal is the signal sent to the container when it
+	// exceeds its memory reservation. This is only supported on FreeBSD.
+	MemoryReclaimSignal uint `json:"MemoryReclaimSignal,omitempty"`
 	// MemorySwap is the total limit for all memory available to the
 	// container, including swap. 0 indicates that there is no limit to the
 	// amount of memory available.
@@ -679,6 +700,18 @@ type InspectAdditionalNetwork struct {
 	Links []string `json:"Links"`
 	// Aliases are any network aliases the container has in this network.
 	Aliases []string `json:"Aliases,omitempty"`
+	// BridgeInterface is the host bridge of this network. FreeBSD only.
+	BridgeInterface string `json:"BridgeInterface,omitempty"`
+	// HostInterface is the host end of the epair connecting the container
+	// to the bridge of this network, if it could be determined. FreeBSD
+	// only.
+	HostInterface string `json:"HostInterface,omitempty"`
+	// IngressRate is the rate limit, in bits per second, of the traffic
+	// received by the container in this network. FreeBSD only.
+	IngressRate uint64 `json:"IngressRate,omitempty"`
+	// EgressRate is the rate limit, in bits per second, of the traffic
+	// sent by the container in this network. FreeBSD only.
+	EgressRate uint64 `json:"EgressRate,omitempty"`
 }
 
 // InspectNetworkSettings holds information about the network settings of the
@@ -699,6 +732,42 @@ type InspectNetworkSettings struct {
 	// container has joined.
 	// It is a map of network name to network information.
 	Networks map[string]*InspectAdditionalNetwork `json:"Networks,omitempty"`
+	// NetworkJail is the name of the vnet jail holding the network stack
+	// of the container. FreeBSD only.
+	NetworkJail string `json:"NetworkJail,omitempty"`
+	// PFAnchors are the pf anchors holding the port forwarding and traffic
+	// shaping rules of the container. FreeBSD only.
+	PFAnchors []string `json:"PFAnchors,omitempty"`
+}
+
+// InspectNetworkHost holds the host side details of the networks of a
+// running container, which correlate them with the output of ifconfig and
+// pfctl. They are only known on FreeBSD.
+type InspectNetworkHost struct {
+	// NetworkJail is the name of the vnet jail holding the network stack
+	// of the container.
+	NetworkJail string
+	// PFAnchors are the pf anchors holding the port forwarding and traffic
+	// shaping rules of the container.
+	PFAnchors []string
+	// Interfaces maps the name of each network of the container to the
+	// host interfaces connecting it to that network.
+	Interfaces map[string]InspectNetworkHostInterface
+}
+
+// InspectNetworkHostInterface holds the host interfaces connecting a
+// container to a network.
+type InspectNetworkHostInterface struct {
+	// Bridge is the host bridge of the network.
+	Bridge string
+	// Interface is the host end of the epair connecting the container to
+	// the bridge, if it could be determined.
+	Interface string
+	// IngressRate and EgressRate are the rate limits, in bits per second,
+	// of the traffic received and sent by the container. Zero means
+	// unlimited.
+	IngressRate uint64
+	EgressRate  uint64
 }
 
 // InspectContainerData provides a detailed record of a container's configuration
@@ -780,6 +849,14 @@ type InspectExecSession struct {
 	// Pid is the PID of the exec session's process.
 	// Will be set to 0 if the exec session is not running.
 	Pid int `json:"Pid"`
+	// StartedAt is the time the exec session was started.
+	StartedAt time.Time `json:"StartedAt"`
+	// CPUTime is the CPU time in nanoseconds used by the exec session's
+	// process, as last recorded while it was running.
+	CPUTime uint64 `json:"CPUTime,omitempty"`
+	// MemoryUsage is the memory in bytes used by the exec session's
+	// process, as last recorded while it was running.
+	MemoryUsage uint64 `json:"MemoryUsage,omitempty"`
 	// ProcessConfig contains information about the exec session's process.
 	ProcessConfig *InspectExecProcess `json:"ProcessConfig"`
 }
@@ -800,6 +877,11 @@ type InspectExecProcess struct {
 	Tty bool `json:"tty"`
 	// User is the user the exec session was started as.
 	User string `json:"user"`
+	// CPUs is the number of CPUs the exec session's process is limited
+	// to. Zero means the process is not limited.
+	CPUs float64 `json:"cpus,omitempty"`
+	// Nice is the nice value of the exec session's process.
+	Nice int `json:"nice,omitempty"`
 }
 
 // DriverData handles the data for a storage driver
@@ -148,6 +148,15 @@ type ContainerStats struct {
 	PIDs        uint64
 	UpTime      time.Duration
 	Duration    uint64
+	// PIDsLimit is the maximum number of processes, zero if unlimited.
+	// This is only reported on FreeBSD.
+	PIDsLimit uint64
+	// OpenFiles is the number of open file descriptors in the container.
+	// This is only reported on FreeBSD.
+	OpenFiles uint64
+	// Threads is the number of threads in the container.
+	// This is only reported on FreeBSD.
+	Threads uint64
 }
 
 // Statistics for an individual container network interface
@@ -0,0 +1,10 @@
+package define
+
+const (
+	// CPUPlacementDomain places a pod on the CPUs of the NUMA domain used
+	// by the fewest other pods.
+	CPUPlacementDomain = "domain"
+	// CPUPlacementCPUs places a pod on the CPUs used by the fewest other
+	// pods, preferring CPUs of a single NUMA domain.
+	CPUPlacementCPUs = "cpus"
+)
@@ -1,5 +1,7 @@
 package define
 
+import "strings"
+
 // extra type to use as enum
 type DiffType uint8
 
@@ -24,3 +26,22 @@ func (d DiffType) String() string {
 		return "unknown"
 	}
 }
+
+// FileFlagChange describes a change to the file flags (see chflags(1)) of a
+// file. File flags are only reported on FreeBSD.
+type FileFlagChange struct {
+	Path    string   `json:"path"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+func (c FileFlagChange) String() string {
+	flags := make([]string, 0, len(c.Added)+len(c.Removed))
+	for _, f := range c.Added {
+		flags = append(flags, "+"+f)
+	}
+	for _, f := range c.Removed {
+		flags = append(flags, "-"+f)
+	}
+	return "F " + c.Path + " " + strings.Join(flags, ",")
+}
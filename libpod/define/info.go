@@ -26,6 +26,26 @@ type SecurityInfo struct {
 	SELinuxEnabled      bool   `json:"selinuxEnabled"`
 }
 
+// JailInfo describes the jail, resource accounting, MAC and firewall
+// support of a FreeBSD host
+type JailInfo struct {
+	// Jailed is true if podman itself is running in a jail
+	Jailed bool `json:"jailed"`
+	// ChildrenMax and ChildrenCur are the maximum and current number of
+	// jails which may be created from the jail podman is running in
+	ChildrenMax int `json:"childrenMax"`
+	ChildrenCur int `json:"childrenCur"`
+	// RacctEnabled is true if resource accounting is enabled, which is
+	// required for resource limits using rctl(8)
+	RacctEnabled bool `json:"racctEnabled"`
+	// MACModules are the MAC policy modules loaded in the kernel
+	MACModules []string `json:"macModules"`
+	// PF and IPFW are the status of the pf and ipfw firewalls, one of
+	// "enabled", "disabled", "not loaded" or "unknown"
+	PF   string `json:"pf"`
+	IPFW string `json:"ipfw"`
+}
+
 // HostInfo describes the libpod host
 type HostInfo struct {
 	Arch               string            `json:"arch"`
@@ -42,6 +62,7 @@ type HostInfo struct {
 	FreeLocks          *uint32           `json:"freeLocks,omitempty"`
 	Hostname           string            `json:"hostname"`
 	IDMappings         IDMappings        `json:"idMappings,omitempty"`
+	Jail               *JailInfo         `json:"jail,omitempty"`
 	Kernel             string            `json:"kernel"`
 	LogDriver          string            `json:"logDriver"`
 	MemFree            int64             `json:"memFree"`
@@ -98,6 +119,9 @@ type DistributionInfo struct {
 	Variant      string `json:"variant,omitempty"`
 	Version      string `json:"version"`
 	Codename     string `json:"codename,omitempty"`
+	// PatchLevel is the patch level of the distribution, e.g. p5 for
+	// FreeBSD 14.1-RELEASE-p5, if known.
+	PatchLevel string `json:"patchLevel,omitempty"`
 }
 
 // ConmonInfo describes the conmon executable being used
@@ -0,0 +1,30 @@
+package define
+
+const (
+	// IOPriorityCritical is the I/O priority class for latency critical
+	// containers.
+	IOPriorityCritical = "critical"
+	// IOPriorityNormal is the default I/O priority class.
+	IOPriorityNormal = "normal"
+	// IOPriorityBatch is the I/O priority class for batch jobs which
+	// should not disturb other containers.
+	IOPriorityBatch = "batch"
+)
+
+// IOPriority describes the I/O priority class of a container. On FreeBSD it
+// is implemented using rctl(8) throttle rules for the container's jail and
+// the nice value of the container's processes.
+type IOPriority struct {
+	// Class is the name of the class.
+	Class string `json:"class"`
+	// Nice is the nice value of the container's processes.
+	Nice int `json:"nice,omitempty"`
+	// ReadBps and WriteBps throttle the bytes read and written per
+	// second, zero means no limit.
+	ReadBps  uint64 `json:"readBps,omitempty"`
+	WriteBps uint64 `json:"writeBps,omitempty"`
+	// ReadIOPS and WriteIOPS throttle the read and write operations per
+	// second, zero means no limit.
+	ReadIOPS  uint64 `json:"readIOPS,omitempty"`
+	WriteIOPS uint64 `json:"writeIOPS,omitempty"`
+}
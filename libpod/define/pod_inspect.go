@@ -21,6 +21,8 @@ type InspectPodData struct {
 	CreateCommand []string `json:"CreateCommand,omitempty"`
 	// ExitPolicy of the pod.
 	ExitPolicy string `json:"ExitPolicy,omitempty"`
+	// CPUPlacement is the hint used to choose the pod's cpuset.
+	CPUPlacement string `json:"CPUPlacement,omitempty"`
 	// State represents the current state of the pod.
 	State string `json:"State"`
 	// Hostname is the hostname that the pod will set.
@@ -136,6 +138,8 @@ type InspectPodInfraConfig struct {
 	CPUSetCPUs string `json:"cpuset_cpus,omitempty"`
 	// Pid is the PID namespace mode of the pod's infra container
 	PidNS string `json:"pid_ns,omitempty"`
+	// IpcNS is the IPC namespace mode of the pod's infra container
+	IpcNS string `json:"ipc_ns,omitempty"`
 	// UserNS is the usernamespace that all the containers in the pod will join.
 	UserNS string `json:"userns,omitempty"`
 	// UtsNS is the uts namespace that all containers in the pod will join
@@ -19,6 +19,8 @@ var initInodes = map[string]bool{
 	"/run":                   true,
 	"/run/notify":            true,
 	"/run/.containerenv":     true,
+	"/run/.containermeta":    true,
+	"/run/identity":          true,
 	"/run/secrets":           true,
 	define.ContainerInitPath: true,
 	"/sys":                   true,
@@ -27,17 +29,10 @@ var initInodes = map[string]bool{
 
 // GetDiff returns the differences between the two images, layers, or containers
 func (r *Runtime) GetDiff(from, to string, diffType define.DiffType) ([]archive.Change, error) {
-	toLayer, err := r.getLayerID(to, diffType)
+	fromLayer, toLayer, err := r.getDiffLayers(from, to, diffType)
 	if err != nil {
 		return nil, err
 	}
-	fromLayer := ""
-	if from != "" {
-		fromLayer, err = r.getLayerID(from, diffType)
-		if err != nil {
-			return nil, err
-		}
-	}
 	var rchanges []archive.Change
 	changes, err := r.store.Changes(fromLayer, toLayer)
 	if err == nil {
@@ -51,6 +46,37 @@ func (r *Runtime) GetDiff(from, to string, diffType define.DiffType) ([]archive.
 	return rchanges, err
 }
 
+// GetFileFlagDiff returns the changes to file flags between the two images,
+// layers, or containers.
+func (r *Runtime) GetFileFlagDiff(from, to string, diffType define.DiffType) ([]define.FileFlagChange, error) {
+	fromLayer, toLayer, err := r.getDiffLayers(from, to, diffType)
+	if err != nil {
+		return nil, err
+	}
+	return r.fileFlagChanges(fromLayer, toLayer)
+}
+
+// getDiffLayers returns the layer IDs for the two sides of a diff. When
+// comparing containers, the first argument may also be an image so that a
+// container can be compared with any image.
+func (r *Runtime) getDiffLayers(from, to string, diffType define.DiffType) (string, string, error) {
+	toLayer, err := r.getLayerID(to, diffType)
+	if err != nil {
+		return "", "", err
+	}
+	fromLayer := ""
+	if from != "" {
+		fromLayer, err = r.getLayerID(from, diffType)
+		if err != nil && diffType == define.DiffContainer {
+			fromLayer, err = r.getLayerID(from, define.DiffImage)
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return fromLayer, toLayer, nil
+}
+
 // GetLayerID gets a full layer id given a full or partial id
 // If the id matches a container or image, the id of the top layer is returned
 // If the id matches a layer, the top layer id is returned
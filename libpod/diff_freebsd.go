@@ -0,0 +1,240 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// fileFlagNames maps file flags from <sys/stat.h> to the names used by
+// chflags(1).
+var fileFlagNames = []struct {
+	flag uint32
+	name string
+}{
+	{0x00000001, "nodump"},
+	{0x00000002, "uchg"},
+	{0x00000004, "uappnd"},
+	{0x00000008, "opaque"},
+	{0x00000010, "uunlnk"},
+	{0x00000080, "usystem"},
+	{0x00000100, "usparse"},
+	{0x00000200, "uoffline"},
+	{0x00000400, "ureparse"},
+	{0x00000800, "uarch"},
+	{0x00001000, "urdonly"},
+	{0x00008000, "uhidden"},
+	{0x00010000, "arch"},
+	{0x00020000, "schg"},
+	{0x00040000, "sappnd"},
+	{0x00100000, "sunlnk"},
+	{0x00200000, "snapshot"},
+}
+
+// fileFlagChanges returns the changes to file flags between two layers. If
+// fromLayer is empty, the layer is compared with its parent.
+func (r *Runtime) fileFlagChanges(fromLayer, toLayer string) ([]define.FileFlagChange, error) {
+	if fromLayer == "" {
+		layer, err := r.store.Layer(toLayer)
+		if err != nil {
+			return nil, err
+		}
+		fromLayer = layer.Parent
+	}
+
+	toMount, err := r.mountDiffLayer(toLayer)
+	if err != nil {
+		return nil, err
+	}
+	defer r.unmountDiffLayer(toLayer)
+	fromMount := ""
+	if fromLayer != "" {
+		fromMount, err = r.mountDiffLayer(fromLayer)
+		if err != nil {
+			return nil, err
+		}
+		defer r.unmountDiffLayer(fromLayer)
+	}
+
+	paths, err := r.zfsDiffPaths(fromLayer, toLayer, toMount)
+	if err != nil {
+		logrus.Debugf("Not using zfs diff to compare file flags: %v", err)
+		paths, err = layerPaths(toMount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []define.FileFlagChange
+	for _, path := range paths {
+		if initInodes[path] {
+			continue
+		}
+		toFlags, err := fileFlags(filepath.Join(toMount, path))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		var fromFlags uint32
+		if fromMount != "" {
+			fromFlags, err = fileFlags(filepath.Join(fromMount, path))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+		if fromFlags == toFlags {
+			continue
+		}
+		changes = append(changes, define.FileFlagChange{
+			Path:    path,
+			Added:   fileFlagsToNames(toFlags &^ fromFlags),
+			Removed: fileFlagsToNames(fromFlags &^ toFlags),
+		})
+	}
+	return changes, nil
+}
+
+func (r *Runtime) mountDiffLayer(layer string) (string, error) {
+	mountPoint, err := r.store.Mount(layer, "")
+	if err != nil {
+		return "", fmt.Errorf("mounting layer %s: %w", layer, err)
+	}
+	return mountPoint, nil
+}
+
+func (r *Runtime) unmountDiffLayer(layer string) {
+	if _, err := r.store.Unmount(layer, false); err != nil {
+		logrus.Errorf("Unmounting layer %s: %v", layer, err)
+	}
+}
+
+// zfsDiffPaths uses zfs diff to list the paths which were modified in a
+// layer. This only works with the zfs storage driver when comparing a layer
+// with its parent, since the layer's dataset is a clone of a snapshot of its
+// parent.
+func (r *Runtime) zfsDiffPaths(fromLayer, toLayer, toMount string) ([]string, error) {
+	if r.store.GraphDriverName() != "zfs" {
+		return nil, errors.New("storage driver is not zfs")
+	}
+	layer, err := r.store.Layer(toLayer)
+	if err != nil {
+		return nil, err
+	}
+	if fromLayer == "" || layer.Parent != fromLayer {
+		return nil, fmt.Errorf("layer %s is not the parent of %s", fromLayer, toLayer)
+	}
+
+	out, err := exec.Command("zfs", "list", "-H", "-o", "name,origin", toMount).Output()
+	if err != nil {
+		return nil, fmt.Errorf("finding dataset for %s: %w", toMount, err)
+	}
+	fields := strings.Split(strings.TrimSpace(string(out)), "\t")
+	if len(fields) != 2 || fields[1] == "-" {
+		return nil, fmt.Errorf("dataset for %s is not a clone", toMount)
+	}
+	out, err = exec.Command("zfs", "diff", "-H", fields[1], fields[0]).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running zfs diff %s %s: %w", fields[1], fields[0], err)
+	}
+
+	var paths []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		// Removed files can't have changed flags and for renamed
+		// files, the new name is the last field.
+		if len(fields) < 2 || fields[0] == "-" {
+			continue
+		}
+		path, err := unescapeZfsPath(fields[len(fields)-1])
+		if err != nil {
+			return nil, err
+		}
+		rel, ok := strings.CutPrefix(path, toMount)
+		if !ok {
+			return nil, fmt.Errorf("unexpected path %q in zfs diff output", path)
+		}
+		if rel == "" {
+			rel = "/"
+		}
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// unescapeZfsPath decodes the octal escapes which zfs diff uses for
+// non-printable characters and spaces in paths, e.g. \0040 for a space.
+func unescapeZfsPath(path string) (string, error) {
+	if !strings.Contains(path, `\`) {
+		return path, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+5 <= len(path) {
+			c, err := strconv.ParseUint(path[i+1:i+5], 8, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid escape in zfs diff path %q: %w", path, err)
+			}
+			b.WriteByte(byte(c))
+			i += 4
+			continue
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String(), nil
+}
+
+// layerPaths returns the paths of all files in a mounted layer.
+func layerPaths(root string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.Join("/", rel))
+		return nil
+	})
+	return paths, err
+}
+
+// fileFlags returns the file flags of a file without following symlinks.
+func fileFlags(path string) (uint32, error) {
+	var st unix.Stat_t
+	if err := unix.Lstat(path, &st); err != nil {
+		return 0, &fs.PathError{Op: "lstat", Path: path, Err: err}
+	}
+	return st.Flags, nil
+}
+
+// fileFlagsToNames returns the chflags(1) names of the given flags. Unknown
+// flags are returned in hexadecimal.
+func fileFlagsToNames(flags uint32) []string {
+	var names []string
+	for _, f := range fileFlagNames {
+		if flags&f.flag != 0 {
+			names = append(names, f.name)
+			flags &^= f.flag
+		}
+	}
+	if flags != 0 {
+		names = append(names, fmt.Sprintf("%#x", flags))
+	}
+	return names
+}
@@ -0,0 +1,28 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileFlagsToNames(t *testing.T) {
+	assert.Nil(t, fileFlagsToNames(0))
+	assert.Equal(t, []string{"nodump", "schg"}, fileFlagsToNames(0x00020001))
+	assert.Equal(t, []string{"uchg", "0x80000000"}, fileFlagsToNames(0x80000002))
+}
+
+func TestUnescapeZfsPath(t *testing.T) {
+	path, err := unescapeZfsPath(`/var/db/foo\0040bar`)
+	assert.NoError(t, err)
+	assert.Equal(t, "/var/db/foo bar", path)
+
+	path, err = unescapeZfsPath("/etc/rc.conf")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/rc.conf", path)
+
+	_, err = unescapeZfsPath(`/tmp/\09zz`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,11 @@
+//go:build !remote
+
+package libpod
+
+import "github.com/containers/podman/v5/libpod/define"
+
+// fileFlagChanges returns the changes to file flags between two layers. File
+// flags are not supported on Linux.
+func (r *Runtime) fileFlagChanges(fromLayer, toLayer string) ([]define.FileFlagChange, error) {
+	return nil, nil
+}
@@ -0,0 +1,230 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containers/storage/pkg/directory"
+	"github.com/containers/storage/pkg/mount"
+)
+
+// ImageDiskUsage is the space used on disk by an image.
+type ImageDiskUsage struct {
+	// Size is the space used by all layers of the image.
+	Size int64
+	// SharedSize is the space used by layers shared with other images.
+	SharedSize int64
+	// UniqueSize is the space used by layers only used by this image,
+	// which is freed when the image is removed.
+	UniqueSize int64
+}
+
+// GraphDriverDiskUsage is the space used on disk by images and containers
+// as reported by the graph driver.
+type GraphDriverDiskUsage struct {
+	// Images maps image IDs to the space used by them.
+	Images map[string]ImageDiskUsage
+	// ImagesSize is the space used by all images, counting shared layers
+	// once.
+	ImagesSize int64
+	// Containers maps container IDs to the space used by their writable
+	// layer.
+	Containers map[string]int64
+}
+
+// zfsUsage is the space used by a ZFS dataset, in bytes.
+type zfsUsage struct {
+	dataset   int64 // usedbydataset
+	snapshots int64 // usedbysnapshots
+}
+
+func (u zfsUsage) total() int64 {
+	return u.dataset + u.snapshots
+}
+
+// GraphDriverDiskUsage returns the space used on disk by images and
+// containers for graph drivers where it differs significantly from the size
+// of the layer contents, which libimage and c/storage report. With the zfs
+// graph driver, layers are clones of the snapshot of their parent, so a
+// layer only uses the space of the blocks which differ from its parent, and
+// compression reduces the space used further. It returns nil for other
+// graph drivers.
+func (r *Runtime) GraphDriverDiskUsage() (*GraphDriverDiskUsage, error) {
+	if r.store == nil || r.store.GraphDriverName() != "zfs" {
+		return nil, nil
+	}
+	parent, err := r.zfsParentDataset()
+	if err != nil {
+		return nil, err
+	}
+	usage, err := zfsDatasetsUsage(parent)
+	if err != nil {
+		return nil, err
+	}
+	// The dataset of a layer is named after the layer's ID.
+	layerUsage := make(map[string]zfsUsage, len(usage))
+	for name, u := range usage {
+		if id, ok := strings.CutPrefix(name, parent+"/"); ok {
+			layerUsage[id] = u
+		}
+	}
+
+	layers, err := r.store.Layers()
+	if err != nil {
+		return nil, err
+	}
+	layerParents := make(map[string]string, len(layers))
+	for _, layer := range layers {
+		layerParents[layer.ID] = layer.Parent
+	}
+	images, err := r.store.Images()
+	if err != nil {
+		return nil, err
+	}
+	imageLayers := make(map[string][]string, len(images))
+	for _, image := range images {
+		var chain []string
+		for id := image.TopLayer; id != ""; id = layerParents[id] {
+			chain = append(chain, id)
+		}
+		imageLayers[image.ID] = chain
+	}
+
+	du := &GraphDriverDiskUsage{Containers: make(map[string]int64)}
+	du.Images, du.ImagesSize = imagesDiskUsage(imageLayers, layerUsage)
+
+	containers, err := r.store.Containers()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range containers {
+		if u, ok := layerUsage[ctr.LayerID]; ok {
+			du.Containers[ctr.ID] = u.total()
+		}
+	}
+	return du, nil
+}
+
+// zfsParentDataset returns the dataset which holds the datasets of all layers
+// when using the zfs graph driver.
+func (r *Runtime) zfsParentDataset() (string, error) {
+	status, err := r.store.Status()
+	if err != nil {
+		return "", err
+	}
+	for _, pair := range status {
+		if pair[0] == "Parent Dataset" {
+			return pair[1], nil
+		}
+	}
+	return "", fmt.Errorf("getting the parent dataset of the zfs graph driver")
+}
+
+// imagesDiskUsage computes the space used by images from the layers of each
+// image and the space used by each layer. It also returns the space used by
+// all images, counting layers shared by several images once.
+func imagesDiskUsage(imageLayers map[string][]string, usage map[string]zfsUsage) (map[string]ImageDiskUsage, int64) {
+	users := make(map[string]int)
+	for _, layers := range imageLayers {
+		for _, layer := range layers {
+			users[layer]++
+		}
+	}
+	var total int64
+	for layer := range users {
+		total += usage[layer].total()
+	}
+
+	images := make(map[string]ImageDiskUsage, len(imageLayers))
+	for id, layers := range imageLayers {
+		var du ImageDiskUsage
+		for _, layer := range layers {
+			size := usage[layer].total()
+			du.Size += size
+			if users[layer] > 1 {
+				du.SharedSize += size
+			}
+		}
+		du.UniqueSize = du.Size - du.SharedSize
+		images[id] = du
+	}
+	return images, total
+}
+
+// zfsDatasetsUsage returns the space used by the given dataset and its
+// children, keyed by dataset name, using a single zfs get command.
+func zfsDatasetsUsage(dataset string) (map[string]zfsUsage, error) {
+	out, err := exec.Command("zfs", "get", "-Hp", "-r", "-d", "1", "-t", "filesystem", "-o", "name,property,value", "usedbydataset,usedbysnapshots", dataset).Output()
+	if err != nil {
+		return nil, fmt.Errorf("getting space used by dataset %s: %w", dataset, err)
+	}
+	return parseZfsUsage(out)
+}
+
+// parseZfsUsage parses the output of zfs get -Hp -o name,property,value for
+// the usedbydataset and usedbysnapshots properties.
+func parseZfsUsage(out []byte) (map[string]zfsUsage, error) {
+	usage := make(map[string]zfsUsage)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected zfs get output %q", scanner.Text())
+		}
+		name := fields[0]
+		value, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s of dataset %s: %w", fields[1], fields[0], err)
+		}
+		u := usage[name]
+		switch fields[1] {
+		case "usedbydataset":
+			u.dataset = value
+		case "usedbysnapshots":
+			u.snapshots = value
+		}
+		usage[name] = u
+	}
+	return usage, scanner.Err()
+}
+
+// DiskUsage returns the space used on disk by the volume. If the volume is a
+// ZFS dataset, the space used by the dataset and its snapshots is returned,
+// otherwise the size of the files in the volume.
+func (v *Volume) DiskUsage() (int64, error) {
+	mountPoint, err := v.MountPoint()
+	if err != nil {
+		return 0, err
+	}
+	if dataset, ok := zfsDatasetMountedAt(mountPoint); ok {
+		usage, err := zfsDatasetsUsage(dataset)
+		if err != nil {
+			return 0, err
+		}
+		return usage[dataset].total(), nil
+	}
+	return directory.Size(mountPoint)
+}
+
+// zfsDatasetMountedAt returns the name of the ZFS dataset mounted at path,
+// if any.
+func zfsDatasetMountedAt(path string) (string, bool) {
+	mounts, err := mount.GetMounts()
+	if err != nil {
+		return "", false
+	}
+	path = filepath.Clean(path)
+	for _, m := range mounts {
+		if m.Mountpoint == path && m.FSType == "zfs" {
+			return m.Source, true
+		}
+	}
+	return "", false
+}
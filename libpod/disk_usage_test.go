@@ -0,0 +1,43 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZfsUsage(t *testing.T) {
+	out := "zroot/containers\tusedbydataset\t98304\n" +
+		"zroot/containers\tusedbysnapshots\t0\n" +
+		"zroot/containers/abc\tusedbydataset\t1048576\n" +
+		"zroot/containers/abc\tusedbysnapshots\t4096\n"
+	usage, err := parseZfsUsage([]byte(out))
+	require.NoError(t, err)
+	assert.Equal(t, zfsUsage{dataset: 98304}, usage["zroot/containers"])
+	assert.Equal(t, int64(1052672), usage["zroot/containers/abc"].total())
+
+	_, err = parseZfsUsage([]byte("zroot/containers\tusedbydataset\n"))
+	assert.Error(t, err)
+	_, err = parseZfsUsage([]byte("zroot/containers\tusedbydataset\t-\n"))
+	assert.Error(t, err)
+}
+
+func TestImagesDiskUsage(t *testing.T) {
+	usage := map[string]zfsUsage{
+		"base": {dataset: 100, snapshots: 10},
+		"app1": {dataset: 20},
+		"app2": {dataset: 30},
+	}
+	images, total := imagesDiskUsage(map[string][]string{
+		"image1": {"app1", "base"},
+		"image2": {"app2", "base"},
+		"image3": {"base"},
+	}, usage)
+	assert.Equal(t, int64(160), total)
+	assert.Equal(t, ImageDiskUsage{Size: 130, SharedSize: 110, UniqueSize: 20}, images["image1"])
+	assert.Equal(t, ImageDiskUsage{Size: 140, SharedSize: 110, UniqueSize: 30}, images["image2"])
+	assert.Equal(t, ImageDiskUsage{Size: 110, SharedSize: 110}, images["image3"])
+}
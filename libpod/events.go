@@ -6,8 +6,11 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
 	"github.com/sirupsen/logrus"
 )
@@ -106,6 +109,79 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newStopTimeoutEvent creates a new event for a container which did not stop
+// with its stop signal within its stop timeout and is about to be killed.
+func (c *Container) newStopTimeoutEvent(signal string, timeout uint) {
+	e := events.NewEvent(events.StopTimeout)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := c.Labels()
+	attributes[events.SignalAttribute] = signal
+	attributes[events.TimeoutAttribute] = strconv.FormatUint(uint64(timeout), 10)
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write %s event: %q", events.StopTimeout, err)
+	}
+}
+
+// newCheckpointEvent creates a new checkpoint or restore event which holds the
+// durations of the operation along with the labels of the container.
+func (c *Container) newCheckpointEvent(status events.Status, duration time.Duration, runtimeDuration int64, criuStatistics *define.CRIUCheckpointRestoreStatistics) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := c.Labels()
+	for key, value := range checkpointEventAttributes(duration, runtimeDuration, criuStatistics) {
+		attributes[key] = value
+	}
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write %s event: %q", status, err)
+	}
+}
+
+// checkpointEventAttributes returns the event attributes holding the durations
+// of a checkpoint or restore in microseconds. The durations of the runtime and
+// of CRIU are only known when statistics were requested.
+func checkpointEventAttributes(duration time.Duration, runtimeDuration int64, criuStatistics *define.CRIUCheckpointRestoreStatistics) map[string]string {
+	attributes := map[string]string{
+		events.DurationAttribute: strconv.FormatInt(duration.Microseconds(), 10),
+	}
+	if runtimeDuration > 0 {
+		attributes[events.RuntimeDurationAttribute] = strconv.FormatInt(runtimeDuration, 10)
+	}
+	if criuStatistics == nil {
+		return attributes
+	}
+	for key, value := range map[string]uint32{
+		"criu_freezing_time": criuStatistics.FreezingTime,
+		"criu_frozen_time":   criuStatistics.FrozenTime,
+		"criu_memdump_time":  criuStatistics.MemdumpTime,
+		"criu_memwrite_time": criuStatistics.MemwriteTime,
+		"criu_forking_time":  criuStatistics.ForkingTime,
+		"criu_restore_time":  criuStatistics.RestoreTime,
+	} {
+		if value > 0 {
+			attributes[key] = strconv.FormatUint(uint64(value), 10)
+		}
+	}
+	return attributes
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e := events.NewEvent(events.ExecDied)
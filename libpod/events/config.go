@@ -18,6 +18,9 @@ const (
 	Null EventerType = iota
 	// Memory indicates the event logger will hold events in memory
 	Memory EventerType = iota
+	// Syslog indicates events should be sent to syslog, in addition to
+	// the log file which is used for reading events
+	Syslog EventerType = iota
 )
 
 // Event describes the attributes of a libpod event
@@ -99,6 +102,27 @@ type ReadOptions struct {
 	Until string
 }
 
+// NetworkJailAttribute is the attribute of network events holding the name
+// of the container's vnet jail on FreeBSD.
+const NetworkJailAttribute = "jail"
+
+// DurationAttribute is the attribute of checkpoint and restore events holding
+// the time the operation took in microseconds.
+const DurationAttribute = "duration"
+
+// RuntimeDurationAttribute is the attribute of checkpoint and restore events
+// holding the time the OCI runtime took in microseconds. It is only set when
+// statistics were requested.
+const RuntimeDurationAttribute = "runtime_duration"
+
+// SignalAttribute is the attribute of stop-timeout events holding the stop
+// signal the container did not stop with.
+const SignalAttribute = "signal"
+
+// TimeoutAttribute is the attribute of stop-timeout events holding the stop
+// timeout of the container in seconds.
+const TimeoutAttribute = "timeout"
+
 // Type of event that occurred (container, volume, image, pod, etc)
 type Type string
 
@@ -166,6 +190,19 @@ const (
 	NetworkConnect Status = "connect"
 	// NetworkDisconnect
 	NetworkDisconnect Status = "disconnect"
+	// NetworkFirewallReload is the reload of the network of a container
+	// whose firewall rules were flushed
+	NetworkFirewallReload Status = "firewall-reload"
+	// NetworkJailCreate is the creation of the vnet jail of a container
+	NetworkJailCreate Status = "netjail-create"
+	// NetworkJailCleanup is the removal of a vnet jail left behind by a
+	// container which did not clean up its network
+	NetworkJailCleanup Status = "netjail-cleanup"
+	// NetworkJailRemove is the release of the vnet jail of a container
+	NetworkJailRemove Status = "netjail-remove"
+	// NetworkSetupError is a failure to configure the networks of a
+	// container, including installing its firewall rules
+	NetworkSetupError Status = "network-setup-error"
 	// Pause ...
 	Pause Status = "pause"
 	// Prune ...
@@ -198,6 +235,9 @@ const (
 	Start Status = "start"
 	// Stop ...
 	Stop Status = "stop"
+	// StopTimeout is the status of a container which did not stop within
+	// its stop timeout and is killed with SIGKILL.
+	StopTimeout Status = "stop-timeout"
 	// Sync ...
 	Sync Status = "sync"
 	// Tag ...
@@ -208,6 +248,8 @@ const (
 	Unpause Status = "unpause"
 	// Untag ...
 	Untag Status = "untag"
+	// Update indicates that the configuration of a container was updated
+	Update Status = "update"
 )
 
 // EventFilter for filtering events
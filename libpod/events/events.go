@@ -24,6 +24,8 @@ func (et EventerType) String() string {
 		return "memory"
 	case Null:
 		return "none"
+	case Syslog:
+		return "syslog"
 	default:
 		return "invalid"
 	}
@@ -40,6 +42,8 @@ func IsValidEventer(eventer string) bool {
 		return true
 	case Null.String():
 		return true
+	case Syslog.String():
+		return true
 	default:
 		return false
 	}
@@ -87,7 +91,14 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		}
 		humanFormat += ")"
 	case Network:
-		humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, name=%s)", e.Time, e.Type, e.Status, id, id, e.Network)
+		humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, name=%s", e.Time, e.Type, e.Status, id, id, e.Network)
+		if jail, ok := e.Attributes[NetworkJailAttribute]; ok {
+			humanFormat += fmt.Sprintf(", jail=%s", jail)
+		}
+		humanFormat += ")"
+		if e.Error != "" {
+			humanFormat += " " + e.Error
+		}
 	case Image:
 		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, id, e.Name)
 		if e.Error != "" {
@@ -191,6 +202,16 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkConnect, nil
 	case NetworkDisconnect.String():
 		return NetworkDisconnect, nil
+	case NetworkJailCreate.String():
+		return NetworkJailCreate, nil
+	case NetworkJailCleanup.String():
+		return NetworkJailCleanup, nil
+	case NetworkFirewallReload.String():
+		return NetworkFirewallReload, nil
+	case NetworkJailRemove.String():
+		return NetworkJailRemove, nil
+	case NetworkSetupError.String():
+		return NetworkSetupError, nil
 	case Pause.String():
 		return Pause, nil
 	case Prune.String():
@@ -221,6 +242,8 @@ func StringToStatus(name string) (Status, error) {
 		return Start, nil
 	case Stop.String():
 		return Stop, nil
+	case StopTimeout.String():
+		return StopTimeout, nil
 	case Sync.String():
 		return Sync, nil
 	case Tag.String():
@@ -231,6 +254,8 @@ func StringToStatus(name string) (Status, error) {
 		return Unpause, nil
 	case Untag.String():
 		return Untag, nil
+	case Update.String():
+		return Update, nil
 	}
 	return "", fmt.Errorf("unknown event status %q", name)
 }
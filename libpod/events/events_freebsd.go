@@ -12,7 +12,9 @@ func NewEventer(options EventerOptions) (Eventer, error) {
 	logrus.Debugf("Initializing event backend %s", options.EventerType)
 	switch strings.ToUpper(options.EventerType) {
 	case strings.ToUpper(LogFile.String()):
-		return EventLogFile{options}, nil
+		return newLogFileEventer(options)
+	case strings.ToUpper(Syslog.String()):
+		return newSyslogEventer(options)
 	case strings.ToUpper(Null.String()):
 		return newNullEventer(), nil
 	case strings.ToUpper(Memory.String()):
@@ -19,6 +19,8 @@ func NewEventer(options EventerOptions) (Eventer, error) {
 		return eventer, nil
 	case strings.ToUpper(LogFile.String()):
 		return newLogFileEventer(options)
+	case strings.ToUpper(Syslog.String()):
+		return newSyslogEventer(options)
 	case strings.ToUpper(Null.String()):
 		return newNullEventer(), nil
 	case strings.ToUpper(Memory.String()):
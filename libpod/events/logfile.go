@@ -56,11 +56,27 @@ func (e EventLogFile) Write(ee Event) error {
 		return err
 	}
 
-	if _, err := rotateLog(e.options.LogFilePath, eventJSONString, e.options.LogFileMaxSize); err != nil {
+	rotated, err := rotateLog(e.options.LogFilePath, eventJSONString, e.options.LogFileMaxSize)
+	if err != nil {
 		return err
 	}
+	if rotated {
+		if err := removeLogIndex(e.options.LogFilePath); err != nil {
+			logrus.Debugf("Removing events log index: %v", err)
+		}
+	}
 
-	return e.writeString(eventJSONString)
+	var offset int64
+	if info, err := os.Stat(e.options.LogFilePath); err == nil {
+		offset = info.Size()
+	}
+	if err := e.writeString(eventJSONString); err != nil {
+		return err
+	}
+	if err := updateLogIndex(e.options.LogFilePath, offset, ee.Time); err != nil {
+		logrus.Debugf("Updating events log index: %v", err)
+	}
+	return nil
 }
 
 func (e EventLogFile) writeString(s string) error {
@@ -79,7 +95,19 @@ func writeToFile(s string, f *os.File) error {
 func (e EventLogFile) getTail(options ReadOptions) (*tail.Tail, error) {
 	seek := tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}
 	if options.FromStart || !options.Stream {
-		seek.Whence = 0
+		seek.Whence = io.SeekStart
+		// Use the index to skip the events before the requested time
+		if len(options.Since) > 0 {
+			since, err := util.ParseInputTime(options.Since, true)
+			if err != nil {
+				return nil, err
+			}
+			offset, err := logIndexOffset(e.options.LogFilePath, since)
+			if err != nil {
+				logrus.Debugf("Reading events log index: %v", err)
+			}
+			seek.Offset = offset
+		}
 	}
 	stream := options.Stream
 	return tail.TailFile(e.options.LogFilePath, tail.Config{ReOpen: stream, Follow: stream, Location: &seek, Logger: tail.DiscardingLogger, Poll: true})
@@ -117,17 +145,20 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 	if err != nil {
 		return err
 	}
+	var untilTime time.Time
 	if len(options.Until) > 0 {
-		untilTime, err := util.ParseInputTime(options.Until, false)
+		untilTime, err = util.ParseInputTime(options.Until, false)
 		if err != nil {
 			return err
 		}
-		go func() {
-			time.Sleep(time.Until(untilTime))
-			if err := t.Stop(); err != nil {
-				logrus.Errorf("Stopping logger: %v", err)
-			}
-		}()
+		if options.Stream {
+			go func() {
+				time.Sleep(time.Until(untilTime))
+				if err := t.Stop(); err != nil {
+					logrus.Errorf("Stopping logger: %v", err)
+				}
+			}()
+		}
 	}
 	logrus.Debugf("Reading events from file %q", e.options.LogFilePath)
 
@@ -194,6 +225,14 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 		if skipRotate {
 			continue
 		}
+		// When not streaming, stop once the events are safely past the
+		// requested time instead of reading the rest of the file
+		if !options.Stream && !untilTime.IsZero() && event.Time.After(untilTime.Add(logIndexMargin)) {
+			if err := t.Stop(); err != nil {
+				logrus.Errorf("Stopping logger: %v", err)
+			}
+			return nil
+		}
 		if applyFilters(event, filterMap) {
 			options.EventChannel <- event
 		}
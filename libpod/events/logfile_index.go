@@ -0,0 +1,133 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// The index of the events log file records the time and offset of an event
+// at most every logIndexInterval bytes, so that reading the events since a
+// given time does not need to scan the whole log file. Each entry consists of
+// the event time in nanoseconds since the epoch followed by the offset of the
+// event in the log file, both as big-endian 64-bit integers.
+const (
+	logIndexInterval  = 64 * 1024
+	logIndexEntrySize = 16
+	// logIndexMargin accounts for events being written to the log file in
+	// a slightly different order than their timestamps.
+	logIndexMargin = time.Minute
+)
+
+type logIndexEntry struct {
+	time   int64
+	offset int64
+}
+
+// logIndexPath returns the path of the index for the given log file.
+func logIndexPath(logFilePath string) string {
+	return logFilePath + ".idx"
+}
+
+// updateLogIndex records an event written at the given offset in the log
+// file, if the last entry in the index is at least logIndexInterval bytes
+// before it. The events lock must be held.
+func updateLogIndex(logFilePath string, offset int64, eventTime time.Time) error {
+	f, err := os.OpenFile(logIndexPath(logFilePath), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, logIndexEntrySize)
+	size := info.Size() - info.Size()%logIndexEntrySize
+	if size > 0 {
+		if _, err := f.ReadAt(buf, size-logIndexEntrySize); err != nil {
+			return err
+		}
+		last := decodeLogIndexEntry(buf)
+		switch {
+		case offset < last.offset:
+			// The log file was replaced, start a new index
+			size = 0
+		case offset-last.offset < logIndexInterval:
+			return nil
+		}
+	}
+	if err := f.Truncate(size); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint64(buf[0:8], uint64(eventTime.UnixNano()))
+	binary.BigEndian.PutUint64(buf[8:16], uint64(offset))
+	_, err = f.WriteAt(buf, size)
+	return err
+}
+
+func decodeLogIndexEntry(buf []byte) logIndexEntry {
+	return logIndexEntry{
+		time:   int64(binary.BigEndian.Uint64(buf[0:8])),
+		offset: int64(binary.BigEndian.Uint64(buf[8:16])),
+	}
+}
+
+// removeLogIndex removes the index, e.g. after the log file was rotated.
+func removeLogIndex(logFilePath string) error {
+	if err := os.Remove(logIndexPath(logFilePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// logIndexOffset returns the offset in the log file from which events since
+// the given time must be read. Zero is returned if the index does not exist
+// or does not match the log file.
+func logIndexOffset(logFilePath string, since time.Time) (int64, error) {
+	data, err := os.ReadFile(logIndexPath(logFilePath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	entries := make([]logIndexEntry, 0, len(data)/logIndexEntrySize)
+	for i := 0; i+logIndexEntrySize <= len(data); i += logIndexEntrySize {
+		entries = append(entries, decodeLogIndexEntry(data[i:i+logIndexEntrySize]))
+	}
+
+	// Find the last entry which is safely before the requested time
+	target := since.Add(-logIndexMargin).UnixNano()
+	i := sort.Search(len(entries), func(i int) bool {
+		return entries[i].time > target
+	})
+	if i == 0 {
+		return 0, nil
+	}
+	offset := entries[i-1].offset
+
+	// Make sure the offset is at the start of a line in the log file
+	f, err := os.Open(logFilePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	buf := make([]byte, 1)
+	if _, err := f.ReadAt(buf, offset-1); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if buf[0] != '\n' {
+		return 0, nil
+	}
+	return offset, nil
+}
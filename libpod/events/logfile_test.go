@@ -1,9 +1,12 @@
 package events
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -135,3 +138,52 @@ func TestRenameLog(t *testing.T) {
 	require.NoError(t, os.Remove(target.Name()))
 	require.Equal(t, beforeRename, afterRename)
 }
+
+func TestLogIndex(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "events.log")
+	eventer, err := newLogFileEventer(EventerOptions{LogFilePath: logFile})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2000; i++ {
+		event := NewEvent(Start)
+		event.Type = Container
+		event.ID = strings.Repeat("a", 64)
+		event.Time = start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, eventer.Write(event))
+	}
+
+	// The start of the log is always indexed
+	offset, err := logIndexOffset(logFile, start)
+	require.NoError(t, err)
+	require.Zero(t, offset)
+
+	since := start.Add(1500 * time.Minute)
+	offset, err = logIndexOffset(logFile, since)
+	require.NoError(t, err)
+	require.Positive(t, offset)
+
+	ch := make(chan *Event)
+	go func() {
+		err := eventer.Read(context.Background(), ReadOptions{
+			EventChannel: ch,
+			Since:        since.Add(-time.Second).Format(time.RFC3339),
+			Until:        since.Add(9*time.Minute + time.Second).Format(time.RFC3339),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+	count := 0
+	for event := range ch {
+		require.False(t, event.Time.Before(since))
+		count++
+	}
+	require.Equal(t, 10, count)
+
+	// Rotating the log removes the index
+	require.NoError(t, removeLogIndex(logFile))
+	offset, err = logIndexOffset(logFile, since)
+	require.NoError(t, err)
+	require.Zero(t, offset)
+}
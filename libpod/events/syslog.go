@@ -0,0 +1,52 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+)
+
+// EventSyslog sends events to syslog(3). Since events can not be read back
+// from syslog, they are also written to the events log file, which is used
+// for reading events.
+type EventSyslog struct {
+	file   *EventLogFile
+	writer *syslog.Writer
+}
+
+// newSyslogEventer creates a new EventSyslog eventer
+func newSyslogEventer(options EventerOptions) (*EventSyslog, error) {
+	file, err := newLogFileEventer(options)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "podman")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to syslog: %w", err)
+	}
+	return &EventSyslog{file: file, writer: writer}, nil
+}
+
+// Write writes the event to the log file and to syslog
+func (e *EventSyslog) Write(ee Event) error {
+	if err := e.file.Write(ee); err != nil {
+		return err
+	}
+	eventJSONString, err := ee.ToJSONString()
+	if err != nil {
+		return err
+	}
+	return e.writer.Info(eventJSONString)
+}
+
+// Read reads events from the log file
+func (e *EventSyslog) Read(ctx context.Context, options ReadOptions) error {
+	return e.file.Read(ctx, options)
+}
+
+// String returns a string representation of the logger
+func (e *EventSyslog) String() string {
+	return Syslog.String()
+}
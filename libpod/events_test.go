@@ -0,0 +1,66 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointEventAttributes(t *testing.T) {
+	assert.Equal(t, map[string]string{"duration": "1500"}, checkpointEventAttributes(1500*time.Microsecond, 0, nil))
+
+	stats := &define.CRIUCheckpointRestoreStatistics{FrozenTime: 300, MemdumpTime: 40, PagesWritten: 12}
+	assert.Equal(t, map[string]string{
+		"duration":          "2000000",
+		"runtime_duration":  "900000",
+		"criu_frozen_time":  "300",
+		"criu_memdump_time": "40",
+	}, checkpointEventAttributes(2*time.Second, 900000, stats))
+}
+
+// recordingEventer keeps the events written to it.
+type recordingEventer struct {
+	events []events.Event
+}
+
+func (e *recordingEventer) Write(event events.Event) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+func (e *recordingEventer) Read(ctx context.Context, options events.ReadOptions) error {
+	return nil
+}
+
+func (e *recordingEventer) String() string {
+	return "recording"
+}
+
+func TestNewStopTimeoutEvent(t *testing.T) {
+	eventer := &recordingEventer{}
+	ctr := &Container{
+		config: &ContainerConfig{
+			ID:   "0123abcd",
+			Name: "web",
+			ContainerMiscConfig: ContainerMiscConfig{
+				Labels: map[string]string{"app": "web"},
+			},
+		},
+		runtime: &Runtime{eventer: eventer},
+	}
+	ctr.newStopTimeoutEvent("SIGTERM", 10)
+	if assert.Len(t, eventer.events, 1) {
+		e := eventer.events[0]
+		assert.Equal(t, events.StopTimeout, e.Status)
+		assert.Equal(t, events.Container, e.Type)
+		assert.Equal(t, map[string]string{"app": "web", "signal": "SIGTERM", "timeout": "10"}, e.Attributes)
+	}
+	// The container's labels are not modified
+	assert.Equal(t, map[string]string{"app": "web"}, ctr.config.Labels)
+}
@@ -0,0 +1,37 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// ReloadFlushedFirewallRules reloads the network of the running containers
+// whose firewall rules are gone, e.g. after the firewall was reloaded, and
+// returns them. Containers which could not be checked or reloaded are logged
+// and skipped.
+func (r *Runtime) ReloadFlushedFirewallRules() ([]*Container, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if err := firewallWatchSupported(); err != nil {
+		return nil, err
+	}
+
+	ctrs, err := r.GetRunningContainers()
+	if err != nil {
+		return nil, err
+	}
+	var reloaded []*Container
+	for _, ctr := range ctrs {
+		flushed, err := ctr.reloadFlushedFirewallRules()
+		if err != nil {
+			logrus.Errorf("Reloading firewall rules of container %s: %v", ctr.ID(), err)
+		}
+		if flushed {
+			reloaded = append(reloaded, ctr)
+		}
+	}
+	return reloaded, nil
+}
@@ -0,0 +1,194 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/sirupsen/logrus"
+)
+
+// firewallWatchSupported reports whether flushed firewall rules can be
+// detected. Container networks only install pf rules on FreeBSD.
+func firewallWatchSupported() error {
+	return nil
+}
+
+// reloadFlushedFirewallRules reloads the network of the container if its pf
+// rules are gone and reports whether it did so. An event is emitted for every
+// network of the reloaded container.
+func (c *Container) reloadFlushedFirewallRules() (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+	if c.state.State != define.ContainerStateRunning || c.state.NetNS == "" || len(c.state.NetworkStatus) == 0 {
+		return false, nil
+	}
+	flushed, err := c.firewallRulesFlushed()
+	if err != nil || !flushed {
+		return false, err
+	}
+
+	logrus.Infof("Firewall rules of container %s were flushed, reloading its network", c.ID())
+	jailName := c.state.NetNS
+	err = c.reloadNetwork()
+	for netName := range c.state.NetworkStatus {
+		c.newNetworkJailEvent(events.NetworkFirewallReload, netName, jailName, err)
+	}
+	return true, err
+}
+
+// firewallRulesFlushed reports whether the port forwarding rules or the NAT
+// table entries of the container are missing from pf. Nothing is checked while
+// pf is disabled as no rules are enforced then.
+func (c *Container) firewallRulesFlushed() (bool, error) {
+	out, err := exec.Command("pfctl", "-s", "Running").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "Enabled" {
+		return false, nil
+	}
+
+	backendPorts, hostIPPorts, _ := c.publishedPorts()
+	var anchors []string
+	if len(backendPorts) > 0 {
+		anchors = append(anchors, pfRdrAnchor+"/"+c.ID())
+	}
+	if len(hostIPPorts) > 0 {
+		anchors = append(anchors, hostIPAnchor(c.ID()))
+	}
+	for _, anchor := range anchors {
+		out, err := pfctlShow("-a", anchor, "-s", "nat")
+		if err != nil {
+			return false, err
+		}
+		if len(bytes.TrimSpace(out)) == 0 {
+			logrus.Debugf("Port forwarding rules of container %s are missing from pf anchor %s", c.ID(), anchor)
+			return true, nil
+		}
+	}
+	if c.usesTrafficShaping() {
+		anchor := shapingAnchor(c.ID())
+		out, err := pfctlShow("-a", anchor, "-s", "rules")
+		if err != nil {
+			return false, err
+		}
+		if len(bytes.TrimSpace(out)) == 0 {
+			logrus.Debugf("Traffic shaping rules of container %s are missing from pf anchor %s", c.ID(), anchor)
+			return true, nil
+		}
+	}
+
+	addrs, err := c.natAddresses()
+	if err != nil || len(addrs) == 0 {
+		return false, err
+	}
+	out, err = pfctlShow("-t", pfNatTable, "-T", "show")
+	if err != nil {
+		return false, err
+	}
+	if missing := missingNatAddresses(out, addrs); len(missing) > 0 {
+		logrus.Debugf("Addresses %v of container %s are missing from pf table %s", missing, c.ID(), pfNatTable)
+		return true, nil
+	}
+	return false, nil
+}
+
+// pfctlShow runs pfctl(8) to list rules or table entries and returns its
+// output. An anchor or a table which does not exist, as is the case once
+// their rules are flushed, is listed as empty. Other failures are returned.
+func pfctlShow(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("pfctl", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if pfctlNotFound(stderr.String()) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("running pfctl %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// pfctlNotFound reports whether the error output of pfctl(8) says that the
+// anchor or table it was asked about does not exist.
+func pfctlNotFound(stderr string) bool {
+	// pfctl reports a missing anchor with the EINVAL of the ioctl
+	// listing its rules.
+	return strings.Contains(stderr, "Table does not exist") ||
+		strings.Contains(stderr, "Invalid argument")
+}
+
+// natAddresses returns the IPv4 addresses of the container in the networks
+// whose traffic is masqueraded by pf, i.e. every network which is not
+// internal.
+func (c *Container) natAddresses() ([]net.IP, error) {
+	var addrs []net.IP
+	for netName, status := range c.state.NetworkStatus {
+		network, err := c.runtime.Network().NetworkInspect(netName)
+		if err != nil {
+			return nil, fmt.Errorf("inspecting network %s: %w", netName, err)
+		}
+		if network.Internal {
+			continue
+		}
+		addrs = append(addrs, statusIPv4Addresses(status)...)
+	}
+	return addrs, nil
+}
+
+// statusIPv4Addresses returns the IPv4 addresses of all interfaces in the
+// given network status.
+func statusIPv4Addresses(status types.StatusBlock) []net.IP {
+	var addrs []net.IP
+	for _, iface := range status.Interfaces {
+		for _, subnet := range iface.Subnets {
+			if ip := subnet.IPNet.IP.To4(); ip != nil {
+				addrs = append(addrs, ip)
+			}
+		}
+	}
+	return addrs
+}
+
+// missingNatAddresses returns the given addresses which are not in the pf NAT
+// table, as listed by pfctl(8).
+func missingNatAddresses(pfctlOutput []byte, addrs []net.IP) []net.IP {
+	var table []*net.IPNet
+	for _, entry := range strings.Fields(string(pfctlOutput)) {
+		if !strings.Contains(entry, "/") {
+			if strings.Contains(entry, ":") {
+				entry += "/128"
+			} else {
+				entry += "/32"
+			}
+		}
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			table = append(table, ipNet)
+		}
+	}
+
+	var missing []net.IP
+	for _, addr := range addrs {
+		found := false
+		for _, ipNet := range table {
+			if ipNet.Contains(addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, addr)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,47 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingNatAddresses(t *testing.T) {
+	out := []byte("   10.88.0.2\n   10.89.0.0/24\n   fd00::2\n")
+	addrs := []net.IP{
+		net.ParseIP("10.88.0.2"),
+		net.ParseIP("10.88.0.3"),
+		net.ParseIP("10.89.0.7"),
+	}
+	assert.Equal(t, []net.IP{net.ParseIP("10.88.0.3")}, missingNatAddresses(out, addrs))
+	assert.Len(t, missingNatAddresses(nil, addrs), 3)
+	assert.Empty(t, missingNatAddresses(out, nil))
+}
+
+func TestStatusIPv4Addresses(t *testing.T) {
+	status := types.StatusBlock{
+		Interfaces: map[string]types.NetInterface{
+			"eth0": {
+				Subnets: []types.NetAddress{
+					{IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.88.0.2"), Mask: net.CIDRMask(16, 32)}}},
+					{IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP("fd00::2"), Mask: net.CIDRMask(64, 128)}}},
+				},
+			},
+		},
+	}
+	addrs := statusIPv4Addresses(status)
+	if assert.Len(t, addrs, 1) {
+		assert.True(t, addrs[0].Equal(net.ParseIP("10.88.0.2")))
+	}
+}
+
+func TestPfctlNotFound(t *testing.T) {
+	assert.True(t, pfctlNotFound("pfctl: Table does not exist.\n"))
+	assert.True(t, pfctlNotFound("pfctl: DIOCGETRULES: Invalid argument\n"))
+	assert.False(t, pfctlNotFound("pfctl: /dev/pf: Permission denied\n"))
+	assert.False(t, pfctlNotFound(""))
+}
@@ -0,0 +1,20 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// firewallWatchSupported reports whether flushed firewall rules can be
+// detected. The rules of netavark are restored through firewalld instead.
+func firewallWatchSupported() error {
+	return fmt.Errorf("watching firewall rules: %w", define.ErrOSNotSupported)
+}
+
+// reloadFlushedFirewallRules is a no-op on Linux.
+func (c *Container) reloadFlushedFirewallRules() (bool, error) {
+	return false, nil
+}
@@ -3,7 +3,6 @@
 package libpod
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -87,36 +86,30 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	if len(newCommand) < 1 || newCommand[0] == "" {
 		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
 	}
-	rPipe, wPipe, err := os.Pipe()
-	if err != nil {
-		return define.HealthCheckInternalError, "", fmt.Errorf("unable to create pipe for healthcheck session: %w", err)
-	}
-	defer wPipe.Close()
-	defer rPipe.Close()
-
-	streams := new(define.AttachStreams)
-
-	streams.InputStream = bufio.NewReader(os.Stdin)
-	streams.OutputStream = wPipe
-	streams.ErrorStream = wPipe
-	streams.AttachOutput = true
-	streams.AttachError = true
-	streams.AttachInput = true
-
-	stdout := []string{}
-	go func() {
-		scanner := bufio.NewScanner(rPipe)
-		for scanner.Scan() {
-			stdout = append(stdout, scanner.Text())
-		}
-	}()
-
 	logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
 	timeStart := time.Now()
 	hcResult := define.HealthCheckSuccess
 	config := new(ExecConfig)
 	config.Command = newCommand
-	exitCode, hcErr := c.exec(config, streams, nil, true)
+	hcCtx := ctx
+	if timeout := c.HealthCheckConfig().Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		hcCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var (
+		stdout   []string
+		exitCode int
+	)
+	stream, hcErr := c.execStream(hcCtx, config, ExecStreamOptions{
+		Input:          os.Stdin,
+		CombinedOutput: true,
+	}, true)
+	if hcErr == nil {
+		var output []byte
+		output, _, exitCode, hcErr = stream.Output()
+		stdout = scanLines(output)
+	}
 	if hcErr != nil {
 		hcResult = define.HealthCheckFailure
 		if errors.Is(hcErr, define.ErrOCIRuntimeNotFound) ||
@@ -71,7 +71,7 @@ func (r *Runtime) info() (*define.Info, error) {
 		volumePlugins = append(volumePlugins, plugin)
 	}
 	info.Plugins.Volume = volumePlugins
-	info.Plugins.Network = r.network.Drivers()
+	info.Plugins.Network = r.Network().Drivers()
 	info.Plugins.Log = logDrivers
 
 	info.Registries = registries
@@ -124,7 +124,7 @@ func (r *Runtime) hostInfo() (*define.HostInfo, error) {
 		MemFree:            mi.MemFree,
 		MemTotal:           mi.MemTotal,
 		NetworkBackend:     r.config.Network.NetworkBackend,
-		NetworkBackendInfo: r.network.NetworkInfo(),
+		NetworkBackendInfo: r.Network().NetworkInfo(),
 		OS:                 runtime.GOOS,
 		SwapFree:           mi.SwapFree,
 		SwapTotal:          mi.SwapTotal,
@@ -142,6 +142,7 @@ func (r *Runtime) hostInfo() (*define.HostInfo, error) {
 	if err != nil {
 		logrus.Errorf("Getting info on OCI runtime %s: %v", r.defaultOCIRuntime.Name(), err)
 	} else {
+		setPlatformOCIRuntimeInfo(ociruntimeInfo)
 		info.Conmon = conmonInfo
 		info.OCIRuntime = ociruntimeInfo
 	}
@@ -278,13 +279,16 @@ func (r *Runtime) storeInfo() (*define.StoreInfo, error) {
 }
 
 // GetHostDistributionInfo returns a map containing the host's distribution and version
-func (r *Runtime) GetHostDistributionInfo() define.DistributionInfo {
+func (r *Runtime) GetHostDistributionInfo() (dist define.DistributionInfo) {
 	// Populate values in case we cannot find the values
 	// or the file
-	dist := define.DistributionInfo{
+	dist = define.DistributionInfo{
 		Distribution: "unknown",
 		Version:      "unknown",
 	}
+	// Fill in what os-release is missing once it has been read.
+	defer setPlatformDistributionInfo(&dist)
+
 	f, err := os.Open("/etc/os-release")
 	if err != nil {
 		return dist
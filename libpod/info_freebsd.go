@@ -3,17 +3,201 @@
 package libpod
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 	"unsafe"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
+const (
+	firewallEnabled   = "enabled"
+	firewallDisabled  = "disabled"
+	firewallNotLoaded = "not loaded"
+	firewallUnknown   = "unknown"
+)
+
 func (r *Runtime) setPlatformHostInfo(info *define.HostInfo) error {
+	jailInfo, err := getJailInfo()
+	if err != nil {
+		return err
+	}
+	info.Jail = jailInfo
 	return nil
 }
 
+// getJailInfo returns the jail, resource accounting, MAC and firewall
+// support of the host.
+func getJailInfo() (*define.JailInfo, error) {
+	jailed, err := unix.SysctlUint32("security.jail.jailed")
+	if err != nil {
+		return nil, fmt.Errorf("reading sysctl security.jail.jailed: %w", err)
+	}
+	childrenMax, err := unix.SysctlUint32("security.jail.children.max")
+	if err != nil {
+		return nil, fmt.Errorf("reading sysctl security.jail.children.max: %w", err)
+	}
+	childrenCur, err := unix.SysctlUint32("security.jail.children.cur")
+	if err != nil {
+		return nil, fmt.Errorf("reading sysctl security.jail.children.cur: %w", err)
+	}
+	info := &define.JailInfo{
+		Jailed:      jailed != 0,
+		ChildrenMax: int(childrenMax),
+		ChildrenCur: int(childrenCur),
+		PF:          pfStatus(),
+		IPFW:        ipfwStatus(),
+	}
+	// The sysctl does not exist if the kernel was built without RACCT.
+	if racct, err := unix.SysctlUint32("kern.racct.enable"); err == nil {
+		info.RacctEnabled = racct != 0
+	}
+	modules, err := kernelModules()
+	if err != nil {
+		return nil, err
+	}
+	info.MACModules = []string{}
+	for _, module := range modules {
+		if strings.HasPrefix(module, "mac_") {
+			info.MACModules = append(info.MACModules, module)
+		}
+	}
+	sort.Strings(info.MACModules)
+	return info, nil
+}
+
+// moduleStat is struct module_stat from <sys/module.h>.
+type moduleStat struct {
+	version int32
+	name    [32]byte
+	refs    int32
+	id      int32
+	data    uintptr
+}
+
+// kernelModules returns the names of the modules in the kernel, including
+// the ones compiled into it.
+func kernelModules() ([]string, error) {
+	var modules []string
+	id, _, errno := unix.Syscall(unix.SYS_MODNEXT, 0, 0, 0)
+	for errno == 0 && id != 0 {
+		stat := moduleStat{}
+		stat.version = int32(unsafe.Sizeof(stat))
+		// The module may have been unloaded since it was listed.
+		if _, _, err := unix.Syscall(unix.SYS_MODSTAT, id, uintptr(unsafe.Pointer(&stat)), 0); err == 0 {
+			modules = append(modules, unix.ByteSliceToString(stat.name[:]))
+		}
+		id, _, errno = unix.Syscall(unix.SYS_MODNEXT, id, 0, 0)
+	}
+	if errno != 0 && errno != unix.ENOENT {
+		return nil, fmt.Errorf("listing kernel modules: %w", errno)
+	}
+	return modules, nil
+}
+
+// pfStatus returns whether pf is loaded and enabled.
+func pfStatus() string {
+	if _, err := os.Stat("/dev/pf"); errors.Is(err, os.ErrNotExist) {
+		return firewallNotLoaded
+	}
+	out, err := exec.Command("pfctl", "-s", "info").Output()
+	if err != nil {
+		logrus.Debugf("Getting pf status: %v", err)
+		return firewallUnknown
+	}
+	firstLine, _, _ := bytes.Cut(out, []byte("\n"))
+	if bytes.HasPrefix(firstLine, []byte("Status: Enabled")) {
+		return firewallEnabled
+	}
+	return firewallDisabled
+}
+
+// ipfwStatus returns whether ipfw is loaded and enabled.
+func ipfwStatus() string {
+	enabled, err := unix.SysctlUint32("net.inet.ip.fw.enable")
+	if err != nil {
+		return firewallNotLoaded
+	}
+	if enabled != 0 {
+		return firewallEnabled
+	}
+	return firewallDisabled
+}
+
+// setPlatformDistributionInfo fills in the distribution and version from
+// the kern.ostype sysctl and freebsd-version(1) if /etc/os-release is
+// missing, and adds the patch level, which os-release does not include.
+func setPlatformDistributionInfo(dist *define.DistributionInfo) {
+	release := ""
+	if out, err := exec.Command("freebsd-version", "-u").Output(); err == nil {
+		release = strings.TrimSpace(string(out))
+	} else {
+		logrus.Debugf("Running freebsd-version: %v", err)
+		// The kernel release is the best guess if the userland
+		// version is not available.
+		release, _ = unix.Sysctl("kern.osrelease")
+	}
+	version, patchLevel := parseFreeBSDRelease(release)
+	if dist.Distribution == "unknown" {
+		if ostype, err := unix.Sysctl("kern.ostype"); err == nil && ostype != "" {
+			dist.Distribution = strings.ToLower(ostype)
+		}
+	}
+	if dist.Version == "unknown" && version != "" {
+		dist.Version = version
+	}
+	dist.PatchLevel = patchLevel
+}
+
+// parseFreeBSDRelease splits a release such as 14.1-RELEASE-p5 into its
+// version and patch level.
+func parseFreeBSDRelease(release string) (string, string) {
+	parts := strings.Split(release, "-")
+	version := parts[0]
+	if len(parts) < 3 {
+		return version, ""
+	}
+	patchLevel := parts[len(parts)-1]
+	if len(patchLevel) < 2 || patchLevel[0] != 'p' || strings.Trim(patchLevel[1:], "0123456789") != "" {
+		return version, ""
+	}
+	return version, patchLevel
+}
+
+// setPlatformOCIRuntimeInfo reduces the version reported by the OCI runtime
+// to its version number. ocijail only prints its name and version, unlike
+// runc and crun which list the spec version and build details as well.
+func setPlatformOCIRuntimeInfo(info *define.OCIRuntimeInfo) {
+	info.Version = parseOCIRuntimeVersion(filepath.Base(info.Path), info.Version)
+}
+
+// parseOCIRuntimeVersion returns the version number from the output of
+// "<runtime> --version" if its first line is of the form "<name> X.Y.Z" or
+// "<name> version X.Y.Z". Any other output is returned unchanged.
+func parseOCIRuntimeVersion(name, output string) string {
+	line, _, _ := strings.Cut(output, "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != name {
+		return output
+	}
+	fields = fields[1:]
+	if len(fields) == 2 && fields[0] == "version" {
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return output
+	}
+	return strings.TrimPrefix(fields[0], "v")
+}
+
 func timeToPercent(time uint64, total uint64) float64 {
 	return 100.0 * float64(time) / float64(total)
 }
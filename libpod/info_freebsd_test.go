@@ -0,0 +1,45 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFreeBSDRelease(t *testing.T) {
+	tests := []struct {
+		release    string
+		version    string
+		patchLevel string
+	}{
+		{"14.1-RELEASE-p5", "14.1", "p5"},
+		{"14.1-RELEASE", "14.1", ""},
+		{"15.0-CURRENT", "15.0", ""},
+		{"13.2-STABLE-pre", "13.2", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		version, patchLevel := parseFreeBSDRelease(tt.release)
+		assert.Equal(t, tt.version, version, tt.release)
+		assert.Equal(t, tt.patchLevel, patchLevel, tt.release)
+	}
+}
+
+func TestParseOCIRuntimeVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		version string
+	}{
+		{"ocijail", "ocijail 0.3.0", "0.3.0"},
+		{"ocijail", "ocijail version v0.3.0", "0.3.0"},
+		{"ocijail", "something else 0.3.0", "something else 0.3.0"},
+		{"runc", "runc version 1.1.12\ncommit: v1.1.12\nspec: 1.0.2-dev", "1.1.12"},
+		{"ocijail", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.version, parseOCIRuntimeVersion(tt.name, tt.output), tt.output)
+	}
+}
@@ -22,6 +22,14 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// setPlatformDistributionInfo does nothing on Linux, where /etc/os-release
+// contains all the distribution information.
+func setPlatformDistributionInfo(dist *define.DistributionInfo) {}
+
+// setPlatformOCIRuntimeInfo does nothing on Linux, where the full output of
+// the runtime's --version is reported.
+func setPlatformOCIRuntimeInfo(info *define.OCIRuntimeInfo) {}
+
 func (r *Runtime) setPlatformHostInfo(info *define.HostInfo) error {
 	seccompProfilePath, err := DefaultSeccompPath()
 	if err != nil {
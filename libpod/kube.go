@@ -505,6 +505,7 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 	var (
 		hostname    string
 		stopTimeout *uint
+		podSysctls  []v1.Sysctl
 	)
 
 	// Let's sort the containers in order of created time
@@ -579,6 +580,7 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 			if err != nil {
 				return nil, err
 			}
+			podSysctls = addVnetSysctls(podSysctls, ctr)
 			if infraDNS != nil {
 				if servers := infraDNS.Nameservers; len(servers) > 0 {
 					dnsInfo.Nameservers = servers
@@ -603,7 +605,7 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 	}
 	podName := removeUnderscores(p.Name())
 
-	return newPodObject(
+	pod := newPodObject(
 		podName,
 		podAnnotations,
 		podInitCtrs,
@@ -613,7 +615,41 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 		hostNetwork,
 		hostUsers,
 		hostname,
-		stopTimeout), nil
+		stopTimeout)
+	setPodSysctls(pod, podSysctls)
+	return pod, nil
+}
+
+// addVnetSysctls adds the net.* sysctls which are set in the container's
+// vnet on FreeBSD to sysctls, unless they are already there. Kube play sets
+// pod sysctls in the vnet of the pod's infra container.
+func addVnetSysctls(sysctls []v1.Sysctl, c *Container) []v1.Sysctl {
+	value := c.config.Spec.Annotations[define.VnetSysctlsAnnotation]
+	if value == "" {
+		return sysctls
+	}
+	for _, sysctl := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(sysctl, "=")
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(sysctls, func(s v1.Sysctl) bool { return s.Name == key }) {
+			continue
+		}
+		sysctls = append(sysctls, v1.Sysctl{Name: key, Value: val})
+	}
+	return sysctls
+}
+
+// setPodSysctls sets the sysctls of the pod's security context.
+func setPodSysctls(pod *v1.Pod, sysctls []v1.Sysctl) {
+	if len(sysctls) == 0 {
+		return
+	}
+	if pod.Spec.SecurityContext == nil {
+		pod.Spec.SecurityContext = &v1.PodSecurityContext{}
+	}
+	pod.Spec.SecurityContext.Sysctls = sysctls
 }
 
 func newPodObject(podName string, annotations map[string]string, initCtrs, containers []v1.Container, volumes []v1.Volume, dnsOptions *v1.PodDNSConfig, hostNetwork, hostUsers bool, hostname string, stopTimeout *uint) *v1.Pod {
@@ -675,9 +711,11 @@ func simplePodWithV1Containers(ctx context.Context, ctrs []*Container, getServic
 		hostname      string
 		restartPolicy *string
 		stopTimeout   *uint
+		podSysctls    []v1.Sysctl
 	)
 	for _, ctr := range ctrs {
 		ctrNames = append(ctrNames, removeUnderscores(ctr.Name()))
+		podSysctls = addVnetSysctls(podSysctls, ctr)
 		for k, v := range ctr.config.Spec.Annotations {
 			if !podmanOnly && define.IsReservedAnnotation(k) {
 				continue
@@ -799,6 +837,7 @@ func simplePodWithV1Containers(ctx context.Context, ctrs []*Container, getServic
 		hostUsers,
 		hostname,
 		stopTimeout)
+	setPodSysctls(pod, podSysctls)
 
 	// Set the pod's restart policy
 	policy := ""
@@ -944,6 +983,18 @@ func containerToV1Container(ctx context.Context, c *Container, getService bool)
 			kubeContainer.Resources.Limits[v1.ResourceMemory] = *qty
 		}
 
+		if resources.Memory != nil &&
+			resources.Memory.Reservation != nil &&
+			*resources.Memory.Reservation > 0 {
+			if kubeContainer.Resources.Requests == nil {
+				kubeContainer.Resources.Requests = v1.ResourceList{}
+			}
+
+			qty := kubeContainer.Resources.Requests.Memory()
+			qty.Set(*resources.Memory.Reservation)
+			kubeContainer.Resources.Requests[v1.ResourceMemory] = *qty
+		}
+
 		if resources.CPU != nil &&
 			resources.CPU.Quota != nil &&
 			resources.CPU.Period != nil {
@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"time"
+)
+
+// mergedLine is a log line received from one of the merged inputs.
+type mergedLine struct {
+	input    int
+	line     *LogLine
+	received time.Time
+}
+
+// MergeLogLines reads the log lines of several containers from inputs and
+// writes them to output ordered by their timestamps. Lines of a single input
+// must already be in order. A line is held back until every open input has
+// a line pending, so that older lines of other containers can be written
+// first, but at most for the given window, so that a quiet container does
+// not stall followed logs. Partial lines of a container are never
+// interleaved with lines of other containers. MergeLogLines returns after
+// all inputs have been closed and their lines written.
+func MergeLogLines(inputs []chan *LogLine, output chan<- *LogLine, window time.Duration) {
+	recv := make(chan mergedLine)
+	for i := range inputs {
+		go func(i int, in chan *LogLine) {
+			for line := range in {
+				recv <- mergedLine{input: i, line: line, received: time.Now()}
+			}
+			// A nil line signals that the input was closed.
+			recv <- mergedLine{input: i}
+		}(i, inputs[i])
+	}
+
+	pending := make([][]mergedLine, len(inputs))
+	closed := make([]bool, len(inputs))
+	open := len(inputs)
+	// continued is the input whose partial line was written last, or -1.
+	continued := -1
+
+	// next returns the input holding the line to write next, or -1 if
+	// there is none.
+	next := func() int {
+		if continued >= 0 && len(pending[continued]) > 0 {
+			return continued
+		}
+		oldest := -1
+		for i, lines := range pending {
+			if len(lines) == 0 {
+				continue
+			}
+			if oldest < 0 || lines[0].line.Time.Before(pending[oldest][0].line.Time) {
+				oldest = i
+			}
+		}
+		return oldest
+	}
+
+	// ready returns whether the line of the given input may be written
+	// without waiting for lines of the other inputs.
+	ready := func(input int) bool {
+		if input == continued || time.Since(pending[input][0].received) >= window {
+			return true
+		}
+		if continued >= 0 && !closed[continued] {
+			return false
+		}
+		for i := range pending {
+			if !closed[i] && len(pending[i]) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		oldest := next()
+		for oldest >= 0 && ready(oldest) {
+			line := pending[oldest][0].line
+			pending[oldest] = pending[oldest][1:]
+			output <- line
+			continued = -1
+			if line.Partial() {
+				continued = oldest
+			}
+			oldest = next()
+		}
+		if open == 0 && oldest < 0 {
+			return
+		}
+
+		var (
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		if oldest >= 0 {
+			timer = time.NewTimer(window - time.Since(pending[oldest][0].received))
+			timeout = timer.C
+		}
+		select {
+		case m := <-recv:
+			if m.line == nil {
+				closed[m.input] = true
+				open--
+			} else {
+				pending[m.input] = append(pending[m.input], m)
+			}
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
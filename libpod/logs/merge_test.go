@@ -0,0 +1,75 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeMergeTestLine(cid, msg string, offset time.Duration, partial bool) *LogLine {
+	typ := FullLogType
+	if partial {
+		typ = PartialLogType
+	}
+	line := makeTestLogLine(typ, msg)
+	line.CID = cid
+	line.Time = logTime.Add(offset)
+	return line
+}
+
+func TestMergeLogLines(t *testing.T) {
+	first := []*LogLine{
+		makeMergeTestLine("a", "a1", 1*time.Second, false),
+		makeMergeTestLine("a", "a2", 4*time.Second, true),
+		makeMergeTestLine("a", "a3", 6*time.Second, false),
+	}
+	second := []*LogLine{
+		makeMergeTestLine("b", "b1", 0, false),
+		makeMergeTestLine("b", "b2", 2*time.Second, false),
+		makeMergeTestLine("b", "b3", 5*time.Second, false),
+	}
+
+	inputs := []chan *LogLine{make(chan *LogLine), make(chan *LogLine)}
+	for i, lines := range [][]*LogLine{first, second} {
+		go func(input chan *LogLine, lines []*LogLine) {
+			for _, line := range lines {
+				input <- line
+			}
+			close(input)
+		}(inputs[i], lines)
+	}
+
+	output := make(chan *LogLine)
+	go func() {
+		MergeLogLines(inputs, output, time.Minute)
+		close(output)
+	}()
+
+	msgs := []string{}
+	for line := range output {
+		msgs = append(msgs, line.Msg)
+	}
+	// The partial line a2 is continued by a3 before b3 is written.
+	assert.Equal(t, []string{"b1", "a1", "b2", "a2", "a3", "b3"}, msgs)
+}
+
+func TestMergeLogLinesWindow(t *testing.T) {
+	quiet := make(chan *LogLine)
+	busy := make(chan *LogLine, 1)
+	busy <- makeMergeTestLine("b", "b1", 0, false)
+
+	output := make(chan *LogLine)
+	go MergeLogLines([]chan *LogLine{quiet, busy}, output, 10*time.Millisecond)
+
+	// The line is written once the window expired even though the
+	// quiet input has not sent anything yet.
+	select {
+	case line := <-output:
+		assert.Equal(t, "b1", line.Msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for merged log line")
+	}
+	close(quiet)
+	close(busy)
+}
@@ -5,8 +5,10 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/containers/common/libnetwork/etchosts"
 	"github.com/containers/common/libnetwork/types"
@@ -37,6 +39,21 @@ func (c *Container) convertPortMappings() []types.PortMapping {
 	return newPorts
 }
 
+// usesPortProxy returns true if the ports of the container are published by
+// a userspace proxy instead of the firewall rules of the network backend.
+func (c *Container) usesPortProxy() bool {
+	return slices.Contains(c.config.NetworkOptions["bridge"], define.BridgePortHandlerOption+"="+define.ProxyPortHandler)
+}
+
+// usesRateLimit returns true if the container sets a rate limit for its
+// traffic.
+func (c *Container) usesRateLimit() bool {
+	return slices.ContainsFunc(c.config.NetworkOptions["bridge"], func(opt string) bool {
+		name, _, _ := strings.Cut(opt, "=")
+		return name == define.BridgeIngressRateOption || name == define.BridgeEgressRateOption
+	})
+}
+
 func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOptions) types.NetworkOptions {
 	nameservers := make([]string, 0, len(c.runtime.config.Containers.DNSServers.Get())+len(c.config.DNSServer))
 	nameservers = append(nameservers, c.runtime.config.Containers.DNSServers.Get()...)
@@ -63,7 +80,7 @@ func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOpt
 // setUpNetwork will set up the networks, on error it will also tear down the cni
 // networks. If rootless it will join/create the rootless network namespace.
 func (r *Runtime) setUpNetwork(ns string, opts types.NetworkOptions) (map[string]types.StatusBlock, error) {
-	return r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+	return r.Network().Setup(ns, types.SetupOptions{NetworkOptions: opts})
 }
 
 // getNetworkPodName return the pod name (hostname) used by dns backend.
@@ -81,7 +98,7 @@ func getNetworkPodName(c *Container) string {
 // Tear down a container's network configuration and joins the
 // rootless net ns as rootless user
 func (r *Runtime) teardownNetworkBackend(ns string, opts types.NetworkOptions) error {
-	return r.network.Teardown(ns, types.TeardownOptions{NetworkOptions: opts})
+	return r.Network().Teardown(ns, types.TeardownOptions{NetworkOptions: opts})
 }
 
 // Tear down a container's network backend configuration, but do not tear down the
@@ -154,23 +171,50 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 	// Set the same network settings as before..
 	netStatus := ctr.getNetworkStatus()
 	for network, perNetOpts := range networkOpts {
-		for name, netInt := range netStatus[network].Interfaces {
-			perNetOpts.InterfaceName = name
-			perNetOpts.StaticMAC = netInt.MacAddress
-			for _, netAddress := range netInt.Subnets {
-				perNetOpts.StaticIPs = append(perNetOpts.StaticIPs, netAddress.IPNet.IP)
-			}
-			// Normally interfaces have a length of 1, only for some special cni configs we could get more.
-			// For now just use the first interface to get the ips this should be good enough for most cases.
-			break
-		}
-		networkOpts[network] = perNetOpts
+		networkOpts[network] = statusNetworkOptions(perNetOpts, netStatus[network], true, true)
 	}
 	ctr.perNetworkOpts = networkOpts
 
 	return r.configureNetNS(ctr, ctr.state.NetNS)
 }
 
+// statusNetworkOptions returns the options of a network of the container
+// updated to recreate its interfaces in the given network status with the
+// same name and, unless ignored, the same MAC and IP addresses. The network
+// backends create a single interface per network, except for some special CNI
+// configs: it gets the name and MAC address of the interface named in the
+// options, or else of the first interface by name, and the IP addresses of all
+// the interfaces. The options are unchanged if the status has no interfaces.
+func statusNetworkOptions(opts types.PerNetworkOptions, status types.StatusBlock, keepIPs, keepMAC bool) types.PerNetworkOptions {
+	if len(status.Interfaces) == 0 {
+		return opts
+	}
+	names := make([]string, 0, len(status.Interfaces))
+	for name := range status.Interfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if _, ok := status.Interfaces[opts.InterfaceName]; !ok {
+		opts.InterfaceName = names[0]
+	}
+
+	opts.StaticMAC = nil
+	if keepMAC {
+		opts.StaticMAC = status.Interfaces[opts.InterfaceName].MacAddress
+	}
+	opts.StaticIPs = nil
+	if keepIPs {
+		for _, name := range names {
+			for _, netAddress := range status.Interfaces[name].Subnets {
+				if !slices.ContainsFunc(opts.StaticIPs, netAddress.IPNet.IP.Equal) {
+					opts.StaticIPs = append(opts.StaticIPs, netAddress.IPNet.IP)
+				}
+			}
+		}
+	}
+	return opts
+}
+
 // Produce an InspectNetworkSettings containing information on the container
 // network.
 func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, error) {
@@ -245,6 +289,8 @@ func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, e
 
 	// Set network namespace path
 	settings.SandboxKey = c.state.NetNS
+	// The host side details are added once the networks are filled in.
+	defer c.addNetworkHostInfo(settings)
 
 	netStatus := c.getNetworkStatus()
 	// If this is empty, we're probably slirp4netns
@@ -293,6 +339,51 @@ func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, e
 	return settings, nil
 }
 
+// InspectNetworkHost returns the host side details of the networks of the
+// container. They are empty if the network of the container is not set up or
+// on platforms which do not track them.
+func (c *Container) InspectNetworkHost() (*define.InspectNetworkHost, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+	if c.config.NetNsCtr != "" {
+		netNsCtr, err := c.runtime.GetContainer(c.config.NetNsCtr)
+		if err != nil {
+			return nil, err
+		}
+		return netNsCtr.InspectNetworkHost()
+	}
+	if c.state.NetNS == "" {
+		return &define.InspectNetworkHost{}, nil
+	}
+	return c.networkHost()
+}
+
+// addNetworkHostInfo adds the host side details of the networks of the
+// container to its inspect data. Failing to read them is not fatal.
+func (c *Container) addNetworkHostInfo(settings *define.InspectNetworkSettings) {
+	host, err := c.networkHost()
+	if err != nil {
+		logrus.Debugf("Reading host network details of container %s: %v", c.ID(), err)
+		return
+	}
+	settings.NetworkJail = host.NetworkJail
+	settings.PFAnchors = host.PFAnchors
+	for name, iface := range host.Interfaces {
+		if network, ok := settings.Networks[name]; ok {
+			network.BridgeInterface = iface.Bridge
+			network.HostInterface = iface.Interface
+			network.IngressRate = iface.IngressRate
+			network.EgressRate = iface.EgressRate
+		}
+	}
+}
+
 // resultToBasicNetworkConfig produces an InspectBasicNetworkConfig from a CNI
 // result
 func resultToBasicNetworkConfig(result types.StatusBlock) define.InspectBasicNetworkConfig {
@@ -391,6 +482,14 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) erro
 		netName: networks[netName],
 	}
 
+	var ifaces []string
+	for iface := range networkStatus[netName].Interfaces {
+		ifaces = append(ifaces, iface)
+	}
+	if err := c.runtime.stopDHCPClients(c, c.state.NetNS, ifaces); err != nil {
+		logrus.Errorf("Stopping DHCP clients of container %s in network %s: %v", c.ID(), netName, err)
+	}
+
 	if err := c.runtime.teardownNetworkBackend(c.state.NetNS, opts); err != nil {
 		return err
 	}
@@ -528,6 +627,12 @@ func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNe
 	if len(results) != 1 {
 		return errors.New("when adding aliases, results must be of length 1")
 	}
+	if err := c.runtime.applyStaticNetworkOptions(c, c.state.NetNS, opts.Networks, results); err != nil {
+		return err
+	}
+	if err := c.runtime.startDHCPClients(c, c.state.NetNS, opts.Networks, results); err != nil {
+		return err
+	}
 
 	// we need to get the old host entries before we add the new one to the status
 	// if we do not add do it here we will get the wrong existing entries which will throw of the logic
@@ -640,13 +745,113 @@ func (r *Runtime) ConnectContainerToNetwork(nameOrID, netName string, netOpts ty
 	return ctr.NetworkConnect(nameOrID, netName, netOpts)
 }
 
+// RemoveNetwork removes the network with the given name or ID from the network
+// backend and cleans up what its containers left behind on the host. The
+// caller must make sure that no container uses the network any more.
+func (r *Runtime) RemoveNetwork(nameOrID string) error {
+	network, err := r.Network().NetworkInspect(nameOrID)
+	if err != nil {
+		return err
+	}
+	if err := r.Network().NetworkRemove(network.Name); err != nil {
+		return err
+	}
+	if err := cleanupRemovedNetwork(&network); err != nil {
+		return fmt.Errorf("cleaning up host interfaces of network %s: %w", network.Name, err)
+	}
+	return nil
+}
+
+// UpdateNetwork updates the network with the given name or ID and applies the
+// change to the containers running on it.
+func (r *Runtime) UpdateNetwork(nameOrID string, options types.NetworkUpdateOptions) error {
+	network, err := r.Network().NetworkInspect(nameOrID)
+	if err != nil {
+		return err
+	}
+	if err := r.Network().NetworkUpdate(network.Name, options); err != nil {
+		return err
+	}
+
+	ctrs, err := r.GetAllContainers()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ctr := range ctrs {
+		if err := ctr.networkUpdated(network.Name); err != nil && !errors.Is(err, define.ErrNoSuchCtr) {
+			errs = append(errs, fmt.Errorf("updating network %s of container %s: %w", network.Name, ctr.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// networkUpdated applies a change of the configuration of network netName to
+// the container, if it is running and connected to it.
+func (c *Container) networkUpdated(netName string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if !c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning) {
+		return nil
+	}
+	networks, err := c.networks()
+	if err != nil {
+		return err
+	}
+	if _, ok := networks[netName]; !ok {
+		return nil
+	}
+	return c.reloadUpdatedNetwork(netName)
+}
+
+// updateNameservers replaces the nameservers of oldServers in the container's
+// resolv.conf with those of newServers.
+func (c *Container) updateNameservers(oldServers, newServers []net.IP) error {
+	removed, added := nameserverChanges(oldServers, newServers)
+	if len(removed) > 0 {
+		logrus.Debugf("Removing DNS Servers %v from resolv.conf", removed)
+		if err := c.removeNameserver(removed); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		logrus.Debugf("Adding DNS Servers %v to resolv.conf", added)
+		if err := c.addNameserver(added); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nameserverChanges returns the servers of oldServers which are not in
+// newServers and those of newServers which are not in oldServers.
+func nameserverChanges(oldServers, newServers []net.IP) (removed, added []string) {
+	contains := func(servers []net.IP, ip net.IP) bool {
+		return slices.ContainsFunc(servers, ip.Equal)
+	}
+	for _, ip := range oldServers {
+		if !contains(newServers, ip) {
+			removed = append(removed, ip.String())
+		}
+	}
+	for _, ip := range newServers {
+		if !contains(oldServers, ip) {
+			added = append(added, ip.String())
+		}
+	}
+	return removed, added
+}
+
 // normalizeNetworkName takes a network name, a partial or a full network ID and
 // returns: 1) the network name and 2) the network_interface name for macvlan
 // and ipvlan drivers if the naming pattern is "device" defined in the
 // containers.conf file. Else, "".
 // If the network is not found an error is returned.
 func (r *Runtime) normalizeNetworkName(nameOrID string) (string, string, error) {
-	net, err := r.network.NetworkInspect(nameOrID)
+	net, err := r.Network().NetworkInspect(nameOrID)
 	if err != nil {
 		return "", "", err
 	}
@@ -724,3 +929,27 @@ func compareOCICNIPorts(i, j types.OCICNIPortMapping) bool {
 
 	return i.ContainerPort < j.ContainerPort
 }
+
+// getContainerNetNS returns the network namespace of the container, or of
+// the container it shares its network namespace with, together with that
+// container.
+func getContainerNetNS(ctr *Container) (string, *Container, error) {
+	if ctr.state.NetNS != "" {
+		return ctr.state.NetNS, nil, nil
+	}
+	if ctr.config.NetNsCtr != "" {
+		c, err := ctr.runtime.GetContainer(ctr.config.NetNsCtr)
+		if err != nil {
+			return "", nil, err
+		}
+		if err = c.syncContainer(); err != nil {
+			return "", c, err
+		}
+		netNs, c2, err := getContainerNetNS(c)
+		if c2 != nil {
+			c = c2
+		}
+		return netNs, c, err
+	}
+	return "", nil, nil
+}
@@ -0,0 +1,45 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusNetworkOptions(t *testing.T) {
+	mac0, _ := net.ParseMAC("02:00:00:00:00:00")
+	mac1, _ := net.ParseMAC("02:00:00:00:00:01")
+	netAddress := func(ip string) types.NetAddress {
+		return types.NetAddress{IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(24, 32)}}}
+	}
+	status := types.StatusBlock{Interfaces: map[string]types.NetInterface{
+		"eth1": {MacAddress: types.HardwareAddr(mac1), Subnets: []types.NetAddress{netAddress("10.89.0.6")}},
+		"eth0": {MacAddress: types.HardwareAddr(mac0), Subnets: []types.NetAddress{netAddress("10.89.0.5"), netAddress("10.89.0.6")}},
+	}}
+	opts := types.PerNetworkOptions{
+		Aliases:   []string{"web"},
+		StaticIPs: []net.IP{net.ParseIP("10.89.0.5")},
+	}
+
+	got := statusNetworkOptions(opts, status, true, true)
+	assert.Equal(t, "eth0", got.InterfaceName)
+	assert.Equal(t, types.HardwareAddr(mac0), got.StaticMAC)
+	assert.Equal(t, []net.IP{net.ParseIP("10.89.0.5"), net.ParseIP("10.89.0.6")}, got.StaticIPs)
+	assert.Equal(t, []string{"web"}, got.Aliases)
+
+	opts.InterfaceName = "eth1"
+	got = statusNetworkOptions(opts, status, false, true)
+	assert.Equal(t, "eth1", got.InterfaceName)
+	assert.Equal(t, types.HardwareAddr(mac1), got.StaticMAC)
+	assert.Nil(t, got.StaticIPs)
+
+	got = statusNetworkOptions(opts, status, true, false)
+	assert.Nil(t, got.StaticMAC)
+	assert.Len(t, got.StaticIPs, 2)
+
+	assert.Equal(t, opts, statusNetworkOptions(opts, types.StatusBlock{}, true, true))
+}
@@ -0,0 +1,395 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// dhcpTimeout is how long dhclient tries to get a lease before the container
+// fails to start.
+const dhcpTimeout = 30 * time.Second
+
+// dhclientScript wraps dhclient-script(8). The vnet jail shares the root
+// directory of the host, so the script must not change the hostname or
+// /etc/resolv.conf. The name servers of the lease are passed to the
+// container through its network status instead.
+const dhclientScript = `#!/bin/sh
+unset new_host_name new_domain_name new_domain_search new_domain_name_servers
+unset old_host_name old_domain_name old_domain_search old_domain_name_servers
+exec /sbin/dhclient-script "$@"
+`
+
+// dhcpDir returns the directory holding the configuration, lease and pid
+// files of the DHCP clients of containers.
+func (r *Runtime) dhcpDir() string {
+	return filepath.Join(r.config.Engine.TmpDir, "dhcp")
+}
+
+// dhcpFiles returns the path prefix of the files of the DHCP client of a
+// container's interface.
+func (r *Runtime) dhcpFiles(ctr *Container, iface string) string {
+	return filepath.Join(r.dhcpDir(), ctr.ID()+"."+iface)
+}
+
+// dhcpNetworks returns the names of the given networks which get their
+// addresses from DHCP.
+func (r *Runtime) dhcpNetworks(networks map[string]types.PerNetworkOptions) (map[string]bool, error) {
+	dhcp := make(map[string]bool)
+	for name := range networks {
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := network.Labels[define.NetworkDHCPLabel]; ok {
+			dhcp[name] = true
+		}
+	}
+	return dhcp, nil
+}
+
+// startDHCPClients runs dhclient inside the vnet jail for the interface of
+// the container in each of the given networks which uses DHCP, waits for a
+// lease and records the leased address, gateway and name servers in the
+// network status. A client left from a previous setup of the interface is
+// stopped first, without releasing its lease so that the new client gets the
+// same address.
+func (r *Runtime) startDHCPClients(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) (retErr error) {
+	dhcp, err := r.dhcpNetworks(networks)
+	if err != nil {
+		return err
+	}
+	if len(dhcp) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(dhcp))
+	var ifaces []string
+	for name := range dhcp {
+		names = append(names, name)
+		for iface := range status[name].Interfaces {
+			ifaces = append(ifaces, iface)
+		}
+	}
+	sort.Strings(names)
+	if err := r.stopDHCPClients(ctr, "", ifaces); err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := r.stopDHCPClients(ctr, ctrNS, ifaces); err != nil {
+				logrus.Errorf("Stopping DHCP clients of container %s: %v", ctr.ID(), err)
+			}
+		}
+	}()
+
+	if err := os.MkdirAll(r.dhcpDir(), 0o700); err != nil {
+		return err
+	}
+	script := filepath.Join(r.dhcpDir(), "dhclient-script")
+	if err := os.WriteFile(script, []byte(dhclientScript), 0o700); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		block := status[name]
+		for iface, netIface := range block.Interfaces {
+			lease, err := r.startDHCPClient(ctr, ctrNS, iface, script)
+			if err != nil {
+				return fmt.Errorf("getting an address for interface %s of container %s in network %s: %w", iface, ctr.ID(), name, err)
+			}
+			netIface.Subnets = []types.NetAddress{lease.netAddress()}
+			block.Interfaces[iface] = netIface
+			block.DNSServerIPs = append(block.DNSServerIPs, lease.nameservers...)
+			block.DNSSearchDomains = append(block.DNSSearchDomains, lease.searchDomains...)
+			logrus.Debugf("Leased address %s for interface %s of container %s in network %s", lease.address, iface, ctr.ID(), name)
+		}
+		status[name] = block
+	}
+	return nil
+}
+
+// startDHCPClient runs dhclient for an interface inside the vnet jail and
+// returns its lease. dhclient goes to the background once it has a lease.
+func (r *Runtime) startDHCPClient(ctr *Container, ctrNS, iface, script string) (*dhcpLease, error) {
+	files := r.dhcpFiles(ctr, iface)
+	conf := fmt.Sprintf("timeout %d;\nscript %q;\n", int(dhcpTimeout.Seconds()), script)
+	if err := os.WriteFile(files+".conf", []byte(conf), 0o600); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("jexec", ctrNS, "dhclient", "-c", files+".conf", "-l", files+".leases", "-p", files+".pid", iface).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("running dhclient: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	data, err := os.ReadFile(files + ".leases")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	lease, err := parseDHCPLease(data)
+	if err != nil {
+		return nil, fmt.Errorf("no lease after %s: %w", dhcpTimeout, err)
+	}
+	return lease, nil
+}
+
+// stopDHCPClients stops the DHCP clients of the given interfaces of the
+// container, or of all its interfaces if ifaces is nil, and releases their
+// leases unless ctrNS is empty. This must happen before the interfaces are
+// removed.
+func (r *Runtime) stopDHCPClients(ctr *Container, ctrNS string, ifaces []string) error {
+	if ifaces == nil {
+		// The configuration file is written first, dhclient may not
+		// have written its pid or lease file yet.
+		confFiles, err := filepath.Glob(r.dhcpFiles(ctr, "*") + ".conf")
+		if err != nil {
+			return err
+		}
+		for _, confFile := range confFiles {
+			ifaces = append(ifaces, strings.TrimPrefix(strings.TrimSuffix(filepath.Base(confFile), ".conf"), ctr.ID()+"."))
+		}
+	}
+	macs := make(map[string]net.HardwareAddr)
+	for _, block := range ctr.getNetworkStatus() {
+		for iface, netIface := range block.Interfaces {
+			macs[iface] = net.HardwareAddr(netIface.MacAddress)
+		}
+	}
+	var errs []error
+	for _, iface := range ifaces {
+		files := r.dhcpFiles(ctr, iface)
+		if err := stopDHCPClient(files + ".pid"); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if data, err := os.ReadFile(files + ".leases"); err == nil && ctrNS != "" && macs[iface] != nil {
+			if lease, err := parseDHCPLease(data); err == nil {
+				if err := releaseDHCPLease(ctrNS, lease, macs[iface]); err != nil {
+					logrus.Warnf("Releasing DHCP lease of %s for interface %s of container %s: %v", lease.address, iface, ctr.ID(), err)
+				}
+			}
+		}
+		for _, ext := range []string{".conf", ".leases", ".pid"} {
+			if err := os.Remove(files + ext); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stopDHCPClient terminates the dhclient process with the pid in pidFile and
+// waits for it to exit.
+func stopDHCPClient(pidFile string) error {
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("invalid pid in %s: %w", pidFile, err)
+	}
+	if err := unix.Kill(pid, unix.SIGTERM); err != nil {
+		if errors.Is(err, unix.ESRCH) {
+			return nil
+		}
+		return fmt.Errorf("stopping dhclient %d: %w", pid, err)
+	}
+	for i := 0; i < 50; i++ {
+		if err := unix.Kill(pid, 0); errors.Is(err, unix.ESRCH) {
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return fmt.Errorf("dhclient %d did not exit", pid)
+}
+
+// releaseDHCPLease sends a DHCPRELEASE for the lease to its server from
+// inside the vnet jail, so that the address is free for other hosts right
+// away. dhclient(8) itself cannot release a lease.
+func releaseDHCPLease(ctrNS string, lease *dhcpLease, mac net.HardwareAddr) error {
+	if lease.server == nil {
+		return errors.New("the lease has no server identifier")
+	}
+	xid := make([]byte, 4)
+	if _, err := rand.Read(xid); err != nil {
+		return err
+	}
+	packet := dhcpReleasePacket(binary.BigEndian.Uint32(xid), lease.address, lease.server, mac)
+	cmd := exec.Command("jexec", ctrNS, "nc", "-u", "-w", "1", "-s", lease.address.String(), "-p", "68", lease.server.String(), "67")
+	cmd.Stdin = bytes.NewReader(packet)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// dhcpReleasePacket returns a DHCPRELEASE message (RFC 2131) for the client
+// address with the given client hardware address.
+func dhcpReleasePacket(xid uint32, client, server net.IP, mac net.HardwareAddr) []byte {
+	// op, htype, hlen, hops, xid, secs, flags, ciaddr, yiaddr, siaddr,
+	// giaddr, chaddr, sname and file make up 236 bytes.
+	p := make([]byte, 236, 300)
+	p[0] = 1 // BOOTREQUEST
+	p[1] = 1 // Ethernet
+	p[2] = byte(len(mac))
+	binary.BigEndian.PutUint32(p[4:8], xid)
+	copy(p[12:16], client.To4())
+	copy(p[28:44], mac)
+	// Magic cookie
+	p = append(p, 99, 130, 83, 99)
+	// DHCP message type DHCPRELEASE
+	p = append(p, 53, 1, 7)
+	// Server identifier
+	p = append(p, 54, 4)
+	p = append(p, server.To4()...)
+	// Client identifier, which dhclient sends as the hardware type
+	// followed by the hardware address.
+	p = append(p, 61, byte(1+len(mac)), 1)
+	p = append(p, mac...)
+	// End
+	p = append(p, 255)
+	// Pad to the minimum BOOTP message size.
+	for len(p) < 300 {
+		p = append(p, 0)
+	}
+	return p
+}
+
+// dhcpLease is the lease of a DHCP client.
+type dhcpLease struct {
+	address       net.IP
+	mask          net.IPMask
+	routers       []net.IP
+	server        net.IP
+	nameservers   []net.IP
+	searchDomains []string
+}
+
+// netAddress returns the leased address for the network status.
+func (l *dhcpLease) netAddress() types.NetAddress {
+	addr := types.NetAddress{
+		IPNet: types.IPNet{IPNet: net.IPNet{IP: l.address, Mask: l.mask}},
+	}
+	if len(l.routers) > 0 {
+		addr.Gateway = l.routers[0]
+	}
+	return addr
+}
+
+// parseDHCPLease parses the last lease in a dhclient.leases(5) file.
+func parseDHCPLease(data []byte) (*dhcpLease, error) {
+	var (
+		lease, current *dhcpLease
+		domainName     []string
+		domainSearch   []string
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "lease {":
+			current = &dhcpLease{}
+			domainName, domainSearch = nil, nil
+			continue
+		case line == "}":
+			if current != nil && current.address != nil {
+				current.searchDomains = domainSearch
+				if current.searchDomains == nil {
+					current.searchDomains = domainName
+				}
+				lease = current
+			}
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+		stmt := strings.Fields(strings.TrimSuffix(line, ";"))
+		if len(stmt) < 2 {
+			continue
+		}
+		if stmt[0] == "fixed-address" {
+			current.address = net.ParseIP(stmt[1]).To4()
+			if current.address == nil {
+				return nil, fmt.Errorf("invalid address %q in lease", stmt[1])
+			}
+			continue
+		}
+		if stmt[0] != "option" || len(stmt) < 3 {
+			continue
+		}
+		value := strings.Join(stmt[2:], " ")
+		switch stmt[1] {
+		case "subnet-mask":
+			ip := net.ParseIP(value).To4()
+			if ip == nil {
+				return nil, fmt.Errorf("invalid subnet mask %q in lease", value)
+			}
+			current.mask = net.IPMask(ip)
+		case "routers":
+			current.routers = parseDHCPAddresses(value)
+		case "dhcp-server-identifier":
+			current.server = net.ParseIP(value).To4()
+		case "domain-name-servers":
+			current.nameservers = parseDHCPAddresses(value)
+		case "domain-name":
+			domainName = parseDHCPStrings(value)
+		case "domain-search":
+			domainSearch = parseDHCPStrings(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if lease == nil {
+		return nil, errors.New("no lease found")
+	}
+	if lease.mask == nil {
+		lease.mask = lease.address.DefaultMask()
+	}
+	return lease, nil
+}
+
+// parseDHCPAddresses parses a comma-separated list of addresses in a lease,
+// skipping invalid ones.
+func parseDHCPAddresses(value string) []net.IP {
+	var ips []net.IP
+	for _, s := range strings.Split(value, ",") {
+		if ip := net.ParseIP(strings.TrimSpace(s)); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// parseDHCPStrings parses a list of quoted strings in a lease, such as
+// "example.com", "example.org", where each string may also hold several
+// names separated by spaces.
+func parseDHCPStrings(value string) []string {
+	var names []string
+	for _, s := range strings.Split(value, ",") {
+		names = append(names, strings.Fields(strings.Trim(strings.TrimSpace(s), `"`))...)
+	}
+	return names
+}
@@ -0,0 +1,80 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDHCPLease(t *testing.T) {
+	leases := `lease {
+  interface "eth0";
+  fixed-address 192.168.1.50;
+  option subnet-mask 255.255.255.0;
+  option routers 192.168.1.1;
+  option domain-name-servers 192.168.1.2;
+  option domain-name "old.example.com";
+  option dhcp-server-identifier 192.168.1.2;
+  renew 0 2026/10/18 10:00:00;
+}
+lease {
+  interface "eth0";
+  fixed-address 192.168.1.51;
+  option subnet-mask 255.255.254.0;
+  option routers 192.168.1.1,192.168.1.254;
+  option domain-name-servers 192.168.1.2,192.168.1.3;
+  option domain-name "example.com";
+  option domain-search "example.com", "example.org";
+  option dhcp-server-identifier 192.168.1.2;
+  renew 0 2026/10/18 11:00:00;
+}
+`
+	lease, err := parseDHCPLease([]byte(leases))
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.1.51", lease.address.String())
+	assert.Equal(t, net.IPv4Mask(255, 255, 254, 0), lease.mask)
+	assert.Equal(t, []net.IP{net.ParseIP("192.168.1.1"), net.ParseIP("192.168.1.254")}, lease.routers)
+	assert.Equal(t, "192.168.1.2", lease.server.String())
+	assert.Equal(t, []net.IP{net.ParseIP("192.168.1.2"), net.ParseIP("192.168.1.3")}, lease.nameservers)
+	assert.Equal(t, []string{"example.com", "example.org"}, lease.searchDomains)
+
+	addr := lease.netAddress()
+	assert.Equal(t, "192.168.1.51/23", addr.IPNet.String())
+	assert.Equal(t, "192.168.1.1", addr.Gateway.String())
+
+	// Without a subnet mask or domain-search option.
+	lease, err = parseDHCPLease([]byte("lease {\n  fixed-address 10.0.0.5;\n  option domain-name \"a.example b.example\";\n}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, net.IPv4Mask(255, 0, 0, 0), lease.mask)
+	assert.Equal(t, []string{"a.example", "b.example"}, lease.searchDomains)
+	assert.Nil(t, lease.server)
+
+	_, err = parseDHCPLease(nil)
+	assert.Error(t, err)
+
+	_, err = parseDHCPLease([]byte("lease {\n  fixed-address bogus;\n}\n"))
+	assert.Error(t, err)
+}
+
+func TestDHCPReleasePacket(t *testing.T) {
+	mac := net.HardwareAddr{0x58, 0x9c, 0xfc, 0x00, 0x01, 0x02}
+	p := dhcpReleasePacket(0x01020304, net.ParseIP("192.168.1.51"), net.ParseIP("192.168.1.2"), mac)
+	require.Len(t, p, 300)
+	assert.Equal(t, []byte{1, 1, 6, 0}, p[0:4])
+	assert.Equal(t, uint32(0x01020304), binary.BigEndian.Uint32(p[4:8]))
+	assert.Equal(t, []byte{192, 168, 1, 51}, p[12:16])
+	assert.Equal(t, []byte(mac), p[28:34])
+	options := []byte{
+		99, 130, 83, 99,
+		53, 1, 7,
+		54, 4, 192, 168, 1, 2,
+		61, 7, 1, 0x58, 0x9c, 0xfc, 0x00, 0x01, 0x02,
+		255,
+	}
+	assert.Equal(t, options, p[236:236+len(options)])
+}
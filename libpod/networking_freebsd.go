@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"bytes"
 	"crypto/rand"
 	jdec "encoding/json"
 	"errors"
@@ -10,12 +11,18 @@ import (
 	"net"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/containers/buildah/pkg/jail"
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
 	"github.com/containers/storage/pkg/lockfile"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
 type Netstat struct {
@@ -92,6 +99,39 @@ func (r *Runtime) setupNetNS(ctr *Container) error {
 	return err
 }
 
+// newNetworkJailEvent creates a network event for the container's vnet jail.
+// If err is not nil, it is recorded in the event.
+func (c *Container) newNetworkJailEvent(status events.Status, netName, jailName string, err error) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Type = events.Network
+	e.Network = netName
+	e.Attributes = map[string]string{events.NetworkJailAttribute: jailName}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write network event: %q", err)
+	}
+}
+
+// networkJailChildrenMax returns the number of child jails allowed in the jail
+// owning a container's vnet. Only the container itself runs there unless it
+// is the infra container of a pod, which shares its vnet with the other
+// members of the pod, including its init containers, as they are added.
+func networkJailChildrenMax(ctr *Container) int {
+	if !ctr.IsInfra() {
+		return 1
+	}
+	childrenMax, err := unix.SysctlUint32("security.jail.children.max")
+	if err != nil {
+		logrus.Warnf("Reading sysctl security.jail.children.max, pod %s is limited to one container: %v", ctr.PodID(), err)
+		return 1
+	}
+	return int(childrenMax)
+}
+
 // Create and configure a new network namespace for a container
 func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[string]types.StatusBlock, rerr error) {
 	if err := r.exposeMachinePorts(ctr.config.PortMappings); err != nil {
@@ -116,14 +156,428 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 	}
 
 	netOpts := ctr.getNetworkOptions(networks)
+	dhcp, err := r.dhcpNetworks(netOpts.Networks)
+	if err != nil {
+		return nil, err
+	}
+	if len(dhcp) > 0 {
+		// The address of a DHCP network comes from the lease. When the
+		// network is reloaded the options hold the previous lease as a
+		// static address, the client asks for it again instead.
+		perNetOpts := make(map[string]types.PerNetworkOptions, len(netOpts.Networks))
+		for name, opts := range netOpts.Networks {
+			if dhcp[name] {
+				opts.StaticIPs = nil
+			}
+			perNetOpts[name] = opts
+		}
+		netOpts.Networks = perNetOpts
+	}
+	// The network backend would publish the ports on a specific host
+	// address on every host address, they are forwarded by our own rules
+	// instead. The ports published by the port proxy need no rules.
+	backendPorts, hostIPPorts, proxyPorts := ctr.publishedPorts()
+	netOpts.PortMappings = backendPorts
+	if err := checkHostIPPorts(hostIPPorts); err != nil {
+		return nil, err
+	}
 	netStatus, err := r.setUpNetwork(ctrNS, netOpts)
 	if err != nil {
+		names := make([]string, 0, len(networks))
+		for name := range networks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		ctr.newNetworkJailEvent(events.NetworkSetupError, strings.Join(names, ","), ctrNS, err)
+		return nil, err
+	}
+	defer func() {
+		if rerr != nil {
+			if err := r.teardownNetworkBackend(ctrNS, netOpts); err != nil {
+				logrus.Errorf("failed to cleanup network: %v", err)
+			}
+		}
+	}()
+
+	if err := r.applyStaticNetworkOptions(ctr, ctrNS, networks, netStatus); err != nil {
+		return nil, err
+	}
+
+	if err := setVnetSysctls(ctr, ctrNS); err != nil {
+		return nil, err
+	}
+
+	if err := r.startDHCPClients(ctr, ctrNS, netOpts.Networks, netStatus); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rerr != nil {
+			if err := r.stopDHCPClients(ctr, ctrNS, nil); err != nil {
+				logrus.Errorf("Stopping DHCP clients of container %s: %v", ctr.ID(), err)
+			}
+		}
+	}()
+
+	if err := r.addVnetRoutes(ctr, ctrNS, networks); err != nil {
+		return nil, err
+	}
+
+	if err := r.addHostIPPortForwarding(ctr, netStatus, hostIPPorts); err != nil {
+		return nil, err
+	}
+
+	if err := r.startPortProxy(ctr, netStatus, proxyPorts); err != nil {
+		return nil, err
+	}
+
+	if err := r.addTrafficShaping(ctr, netStatus); err != nil {
 		return nil, err
 	}
 
 	return netStatus, err
 }
 
+// addVnetRoutes adds the static routes of the container's networks and of the
+// container itself inside its vnet. The CNI plugins do not install the routes
+// of a network, netavark does and they are skipped as they already exist.
+func (r *Runtime) addVnetRoutes(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions) error {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var routes []types.Route
+	for _, name := range names {
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, network.Routes...)
+	}
+	routes = append(routes, ctr.config.Routes...)
+
+	for _, route := range routes {
+		if route.Metric != nil {
+			logrus.Debugf("Ignoring metric of route to %s in vnet %s, routes have no metric on FreeBSD", route.Destination.String(), ctrNS)
+		}
+		args := routeArgs(route)
+		// Like for sysctls, prefer 'route -j' and fall back to jexec
+		// for releases without it.
+		out, err := exec.Command("route", append([]string{"-j", ctrNS}, args...)...).CombinedOutput()
+		if err != nil && !strings.Contains(string(out), "File exists") {
+			out, err = exec.Command("jexec", append([]string{ctrNS, "route"}, args...)...).CombinedOutput()
+		}
+		if err != nil && !strings.Contains(string(out), "File exists") {
+			return fmt.Errorf("adding route to %s via %s for container %s: %v: %s", route.Destination.String(), route.Gateway, ctr.ID(), err, strings.TrimSpace(string(out)))
+		}
+		logrus.Debugf("Added route to %s via %s in vnet %s for container %s", route.Destination.String(), route.Gateway, ctrNS, ctr.ID())
+	}
+	return nil
+}
+
+// routeArgs returns the arguments of route(8) adding the given route.
+func routeArgs(route types.Route) []string {
+	family := "-inet"
+	if route.Destination.IP.To4() == nil {
+		family = "-inet6"
+	}
+	return []string{"-q", "add", family, "-net", route.Destination.String(), route.Gateway.String()}
+}
+
+// maxInterfaceNameLen is the longest network interface name, IFNAMSIZ minus
+// the terminating NUL.
+const maxInterfaceNameLen = 15
+
+// interfaceRename is the renaming of the interface of a container in a
+// network.
+type interfaceRename struct {
+	network, from, to string
+}
+
+// vnetInterfaceRenames returns the interfaces in the network status which
+// must be renamed to get the interface names requested for the networks. The
+// network backend may ignore the requested name.
+func vnetInterfaceRenames(networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) ([]interfaceRename, error) {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var renames []interfaceRename
+	for _, name := range names {
+		want := networks[name].InterfaceName
+		ifaces := status[name].Interfaces
+		if want == "" {
+			continue
+		}
+		if _, ok := ifaces[want]; ok {
+			continue
+		}
+		if len(want) > maxInterfaceNameLen {
+			return nil, fmt.Errorf("interface name %q for network %s is longer than %d characters: %w", want, name, maxInterfaceNameLen, define.ErrInvalidArg)
+		}
+		if len(ifaces) != 1 {
+			logrus.Warnf("Cannot name the interface of network %s %s, the network has %d interfaces", name, want, len(ifaces))
+			continue
+		}
+		for from := range ifaces {
+			renames = append(renames, interfaceRename{network: name, from: from, to: want})
+		}
+	}
+	return renames, nil
+}
+
+// renameVnetInterfaces gives the interfaces of the container in its vnet the
+// names requested for its networks and records the new names in the network
+// status, which is used by stats and inspect.
+func renameVnetInterfaces(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) error {
+	renames, err := vnetInterfaceRenames(networks, status)
+	if err != nil {
+		return err
+	}
+	for _, rename := range renames {
+		if err := vnetIfconfig(ctrNS, rename.from, "name", rename.to); err != nil {
+			return fmt.Errorf("renaming interface %s of container %s to %s: %w", rename.from, ctr.ID(), rename.to, err)
+		}
+		block := status[rename.network]
+		block.Interfaces[rename.to] = block.Interfaces[rename.from]
+		delete(block.Interfaces, rename.from)
+		logrus.Debugf("Renamed interface %s to %s in vnet %s for container %s", rename.from, rename.to, ctrNS, ctr.ID())
+	}
+	return nil
+}
+
+// vnetIfconfig runs ifconfig with the given arguments inside the vnet jail.
+func vnetIfconfig(ctrNS string, args ...string) error {
+	// Like for sysctls, prefer 'ifconfig -j' and fall back to jexec for
+	// releases without it.
+	out, err := exec.Command("ifconfig", append([]string{"-j", ctrNS}, args...)...).CombinedOutput()
+	if err != nil {
+		out, err = exec.Command("jexec", append([]string{ctrNS, "ifconfig"}, args...)...).CombinedOutput()
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// staticAddressChange holds the static addresses of the interface of a
+// container in a network which the network backend did not configure.
+type staticAddressChange struct {
+	network, iface string
+	// mac is nil if the interface has the requested MAC address.
+	mac   types.HardwareAddr
+	addrs []types.NetAddress
+}
+
+// vnetStaticAddressChanges compares the static MAC and IP addresses requested
+// for the networks of a container with the addresses in the network status
+// and returns the ones which are missing. The prefix and gateway of a missing
+// IP address are those of the subnet of the network which contains it.
+func vnetStaticAddressChanges(networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock, subnets map[string][]types.Subnet) ([]staticAddressChange, error) {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var changes []staticAddressChange
+	for _, name := range names {
+		opts := networks[name]
+		if len(opts.StaticMAC) == 0 && len(opts.StaticIPs) == 0 {
+			continue
+		}
+		ifaces := status[name].Interfaces
+		if len(ifaces) != 1 {
+			logrus.Warnf("Cannot check the static addresses of network %s, the network has %d interfaces", name, len(ifaces))
+			continue
+		}
+		change := staticAddressChange{network: name}
+		var iface types.NetInterface
+		for name, ifc := range ifaces {
+			change.iface, iface = name, ifc
+		}
+		if len(opts.StaticMAC) > 0 && !bytes.Equal(opts.StaticMAC, iface.MacAddress) {
+			change.mac = opts.StaticMAC
+		}
+	ips:
+		for _, ip := range opts.StaticIPs {
+			for _, addr := range iface.Subnets {
+				if addr.IPNet.IP.Equal(ip) {
+					continue ips
+				}
+			}
+			found := false
+			for _, subnet := range subnets[name] {
+				if subnet.Subnet.Contains(ip) {
+					change.addrs = append(change.addrs, types.NetAddress{
+						IPNet:   types.IPNet{IPNet: net.IPNet{IP: ip, Mask: subnet.Subnet.Mask}},
+						Gateway: subnet.Gateway,
+					})
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("static ip %s is not in a subnet of network %s: %w", ip, name, define.ErrInvalidArg)
+			}
+		}
+		if change.mac != nil || len(change.addrs) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// applyStaticNetworkOptions applies the interface names and the static MAC
+// and IP addresses requested for the given networks of the container to its
+// interfaces, as the network backend may ignore them, and records them in the
+// network status.
+func (r *Runtime) applyStaticNetworkOptions(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) error {
+	if err := renameVnetInterfaces(ctr, ctrNS, networks, status); err != nil {
+		return err
+	}
+
+	subnets := make(map[string][]types.Subnet)
+	for name, opts := range networks {
+		if len(opts.StaticIPs) == 0 {
+			continue
+		}
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		subnets[name] = network.Subnets
+	}
+	changes, err := vnetStaticAddressChanges(networks, status, subnets)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		block := status[change.network]
+		iface := block.Interfaces[change.iface]
+		if change.mac != nil {
+			if err := vnetIfconfig(ctrNS, change.iface, "ether", change.mac.String()); err != nil {
+				return fmt.Errorf("setting mac address %s on interface %s of container %s: %w", change.mac, change.iface, ctr.ID(), err)
+			}
+			iface.MacAddress = change.mac
+		}
+		for _, addr := range change.addrs {
+			if err := vnetIfconfig(ctrNS, change.iface, addressFamily(addr.IPNet.IP), addr.IPNet.String(), "alias"); err != nil {
+				return fmt.Errorf("adding address %s to interface %s of container %s: %w", addr.IPNet.String(), change.iface, ctr.ID(), err)
+			}
+			iface.Subnets = append(iface.Subnets, addr)
+		}
+		block.Interfaces[change.iface] = iface
+		logrus.Debugf("Applied static addresses to interface %s of container %s in network %s", change.iface, ctr.ID(), change.network)
+	}
+	return nil
+}
+
+// setVnetSysctls sets the net.* sysctls requested for the container inside
+// its vnet.
+func setVnetSysctls(ctr *Container, ctrNS string) error {
+	if ctr.config.Spec == nil || ctr.config.Spec.Annotations[define.VnetSysctlsAnnotation] == "" {
+		return nil
+	}
+	for _, sysctl := range strings.Split(ctr.config.Spec.Annotations[define.VnetSysctlsAnnotation], ",") {
+		// First try running 'sysctl -j' which does not depend on
+		// the contents of the jail.
+		cmd := exec.Command("sysctl", "-j", ctrNS, sysctl)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			// Fall back to using jexec for releases where sysctl
+			// does not have the -j flag.
+			cmd := exec.Command("jexec", ctrNS, "sysctl", sysctl)
+			out, err = cmd.CombinedOutput()
+		}
+		if err != nil {
+			return fmt.Errorf("setting sysctl %s for container %s: %v: %s", sysctl, ctr.ID(), err, strings.TrimSpace(string(out)))
+		}
+		logrus.Debugf("Set sysctl %s in vnet %s for container %s", sysctl, ctrNS, ctr.ID())
+	}
+	return nil
+}
+
+// defaultVnetDevfsRuleset is the devfs ruleset of vnet jails unless another
+// one is selected, devfsrules_jail from /etc/defaults/devfs.rules.
+const defaultVnetDevfsRuleset = 4
+
+// vnetDevfsRuleset returns the devfs ruleset of the vnet jail of the
+// container. The ruleset set for the container takes precedence over the
+// ruleset set by the labels of its networks, which takes precedence over the
+// default set in containers.conf.
+func (r *Runtime) vnetDevfsRuleset(ctr *Container) (int, error) {
+	if ctr.config.VnetDevfsRuleset != nil {
+		return *ctr.config.VnetDevfsRuleset, nil
+	}
+	networks, err := ctr.networks()
+	if err != nil {
+		return 0, err
+	}
+	labels := make(map[string]map[string]string, len(networks))
+	for name := range networks {
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return 0, err
+		}
+		labels[name] = network.Labels
+	}
+	var annotations map[string]string
+	if ctr.config.Spec != nil {
+		annotations = ctr.config.Spec.Annotations
+	}
+	return selectVnetDevfsRuleset(labels, annotations)
+}
+
+// selectVnetDevfsRuleset returns the devfs ruleset set by the labels of the
+// given networks, which must agree, or else by the given annotations, or else
+// the default ruleset.
+func selectVnetDevfsRuleset(networkLabels map[string]map[string]string, annotations map[string]string) (int, error) {
+	names := make([]string, 0, len(networkLabels))
+	for name := range networkLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ruleset, from := 0, ""
+	for _, name := range names {
+		value, ok := networkLabels[name][define.VnetDevfsRulesetLabel]
+		if !ok {
+			continue
+		}
+		n, err := parseDevfsRuleset(value)
+		if err != nil {
+			return 0, fmt.Errorf("label %s of network %s: %w", define.VnetDevfsRulesetLabel, name, err)
+		}
+		if from != "" && n != ruleset {
+			return 0, fmt.Errorf("networks %s and %s select different devfs rulesets %d and %d: %w", from, name, ruleset, n, define.ErrInvalidArg)
+		}
+		ruleset, from = n, name
+	}
+	if from != "" {
+		return ruleset, nil
+	}
+	if value, ok := annotations[define.VnetDevfsRulesetAnnotation]; ok {
+		n, err := parseDevfsRuleset(value)
+		if err != nil {
+			return 0, fmt.Errorf("annotation %s: %w", define.VnetDevfsRulesetAnnotation, err)
+		}
+		return n, nil
+	}
+	return defaultVnetDevfsRuleset, nil
+}
+
+// parseDevfsRuleset parses a devfs ruleset number.
+func parseDevfsRuleset(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid devfs ruleset %q: %w", value, define.ErrInvalidArg)
+	}
+	if err := checkDevfsRuleset(n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
 // Create and configure a new network namespace for a container
 func (r *Runtime) createNetNS(ctr *Container) (n string, q map[string]types.StatusBlock, retErr error) {
 	b := make([]byte, 16)
@@ -133,22 +587,36 @@ func (r *Runtime) createNetNS(ctr *Container) (n string, q map[string]types.Stat
 	}
 	netns := fmt.Sprintf("vnet-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 
+	devfsRuleset, err := r.vnetDevfsRuleset(ctr)
+	if err != nil {
+		return "", nil, err
+	}
+
 	jconf := jail.NewConfig()
 	jconf.Set("name", netns)
 	jconf.Set("vnet", jail.NEW)
-	jconf.Set("children.max", 1)
+	jconf.Set("children.max", networkJailChildrenMax(ctr))
 	jconf.Set("persist", true)
 	jconf.Set("enforce_statfs", 0)
-	jconf.Set("devfs_ruleset", 4)
+	jconf.Set("devfs_ruleset", devfsRuleset)
 	jconf.Set("allow.raw_sockets", true)
 	jconf.Set("allow.chflags", true)
 	jconf.Set("securelevel", -1)
+	if ctr.IsInfra() {
+		// The members of the pod inherit the SysV IPC objects of
+		// the vnet jail if they share the pod's IPC.
+		jconf.Set("sysvmsg", jail.NEW)
+		jconf.Set("sysvsem", jail.NEW)
+		jconf.Set("sysvshm", jail.NEW)
+	}
 	j, err := jail.Create(jconf)
 	if err != nil {
 		return "", nil, fmt.Errorf("Failed to create vnet jail %s for container %s: %w", netns, ctr.ID(), err)
 	}
 
+	r.recordJail(netns)
 	logrus.Debugf("Created vnet jail %s for container %s", netns, ctr.ID())
+	ctr.newNetworkJailEvent(events.NetworkJailCreate, "", netns, nil)
 
 	var networkStatus map[string]types.StatusBlock
 	networkStatus, err = r.configureNetNS(ctr, netns)
@@ -169,36 +637,377 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 		// do not return an error otherwise we would prevent network cleanup
 		logrus.Errorf("failed to free gvproxy machine ports: %v", err)
 	}
-	if err := r.teardownNetwork(ctr); err != nil {
-		return err
+
+	if err := removeHostIPPortForwarding(ctr); err != nil {
+		logrus.Errorf("Removing port forwarding rules of container %s: %v", ctr.ID(), err)
+	}
+	if err := r.stopPortProxy(ctr); err != nil {
+		logrus.Errorf("Stopping port proxy of container %s: %v", ctr.ID(), err)
+	}
+	if err := removeTrafficShaping(ctr); err != nil {
+		logrus.Errorf("Removing traffic shaping rules of container %s: %v", ctr.ID(), err)
+	}
+
+	// The leases are released from the container's interfaces, so the
+	// clients must stop before the interfaces are removed.
+	if err := r.stopDHCPClients(ctr, ctr.state.NetNS, nil); err != nil {
+		logrus.Errorf("Stopping DHCP clients of container %s: %v", ctr.ID(), err)
 	}
 
-	if ctr.state.NetNS != "" {
+	// Do not check the error here, we want to always release the vnet
+	// jail, otherwise it would be kept alive forever by its persist flag
+	// when the network backend fails to remove the container's pf rules.
+	return ctr.releaseNetNS(r.teardownNetwork(ctr), releaseNetworkJail)
+}
+
+// releaseNetNS releases the container's vnet jail with release after its
+// networks were torn down, and clears the network jail from the container's
+// state. The error of the network teardown, teardownErr, is returned once the
+// jail is released.
+func (c *Container) releaseNetNS(teardownErr error, release func(string) error) error {
+	if c.state.NetNS != "" {
 		// If PostConfigureNetNS is false, then we are running with a
 		// separate vnet jail so we need to clean that up now.
-		if !ctr.config.PostConfigureNetNS {
-			// Rather than destroying the jail immediately, reset the
-			// persist flag so that it will live until the container is
-			// done.
-			netjail, err := jail.FindByName(ctr.state.NetNS)
-			if err != nil {
-				return fmt.Errorf("finding network jail %s: %w", ctr.state.NetNS, err)
+		if !c.config.PostConfigureNetNS {
+			if err := release(c.state.NetNS); err != nil {
+				if teardownErr != nil {
+					logrus.Error(teardownErr)
+				}
+				return err
 			}
-			jconf := jail.NewConfig()
-			jconf.Set("persist", false)
-			if err := netjail.Set(jconf); err != nil {
-				return fmt.Errorf("releasing network jail %s: %w", ctr.state.NetNS, err)
+			c.releasedNetworkJail = c.state.NetNS
+			c.newNetworkJailEvent(events.NetworkJailRemove, "", c.state.NetNS, nil)
+		}
+		c.state.NetNS = ""
+		c.state.NetJID = 0
+	}
+	return teardownErr
+}
+
+// releaseNetworkJail resets the persist flag of a vnet jail so that it will
+// be removed once the container jail inside it is gone. A jail which no
+// longer exists, e.g. after a reboot, has nothing left to release.
+func releaseNetworkJail(name string) error {
+	// Rather than destroying the jail immediately, reset the persist flag
+	// so that it will live until the container is done.
+	netjail, err := jail.FindByName(name)
+	if err != nil {
+		if errors.Is(err, unix.ENOENT) {
+			logrus.Debugf("Network jail %s is already removed", name)
+			return nil
+		}
+		return fmt.Errorf("finding network jail %s: %w", name, err)
+	}
+	jconf := jail.NewConfig()
+	jconf.Set("persist", false)
+	if err := netjail.Set(jconf); err != nil {
+		return fmt.Errorf("releasing network jail %s: %w", name, err)
+	}
+	return nil
+}
+
+// minNetworkJailRemoveTimeout is the shortest time reapNetworkJail waits for a
+// released network jail to be removed before removing it forcibly.
+const minNetworkJailRemoveTimeout = time.Second
+
+// networkJailRemoveTimeout returns how long reapNetworkJail waits for the
+// released network jail of the container to be removed. Processes left in
+// the container's jail are given the container's stop timeout to exit, as
+// when stopping it.
+func (c *Container) networkJailRemoveTimeout() time.Duration {
+	timeout := time.Duration(c.StopTimeout()) * time.Second
+	if timeout < minNetworkJailRemoveTimeout {
+		return minNetworkJailRemoveTimeout
+	}
+	return timeout
+}
+
+// reapNetworkJail waits for the network jail released by teardownNetNS to be
+// removed by the kernel, which happens once the container's jail inside it is
+// gone. If the jail is still there after networkJailRemoveTimeout, e.g.
+// because processes linger in the container's jail, it is removed with
+// jail(8), killing whatever is left in it.
+func (c *Container) reapNetworkJail() error {
+	name := c.releasedNetworkJail
+	if name == "" {
+		return nil
+	}
+	c.releasedNetworkJail = ""
+
+	timeout := c.networkJailRemoveTimeout()
+	deadline := time.Now().Add(timeout)
+	for i := 0; ; i++ {
+		if _, err := jail.FindByName(name); err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				c.runtime.forgetJail(name)
+				return nil
 			}
+			return fmt.Errorf("finding network jail %s: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		if i == 0 {
+			logrus.Debugf("Waiting up to %s for network jail %s of container %s to be removed", timeout, name, c.ID())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	logrus.Warnf("Network jail %s of container %s still exists %s after the container was removed, removing it", name, c.ID(), timeout)
+	if out, err := exec.Command("jail", "-r", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("removing network jail %s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	c.runtime.forgetJail(name)
+	return nil
+}
+
+// reloadUpdatedNetwork reloads the networks of the container since the network
+// backend only applies the configuration of a network when it is set up, and
+// updates the nameservers the network provides in the container's
+// resolv.conf.
+func (c *Container) reloadUpdatedNetwork(netName string) error {
+	oldStatus := c.getNetworkStatus()[netName]
+	if err := c.reloadNetwork(); err != nil {
+		return err
+	}
+	newStatus := c.getNetworkStatus()[netName]
+	return c.updateNameservers(oldStatus.DNSServerIPs, newStatus.DNSServerIPs)
+}
+
+// cleanupRemovedNetwork removes what the containers of a removed network left
+// on the host when their network was not torn down, e.g. because Podman
+// crashed: the stale epair interfaces attached to the bridge of the network,
+// the bridge itself and the addresses of the network in the pf NAT table.
+func cleanupRemovedNetwork(network *types.Network) error {
+	var errs []error
+	if network.Driver == types.BridgeNetworkDriver && network.NetworkInterface != "" {
+		if err := removeNetworkBridge(network.NetworkInterface); err != nil {
+			errs = append(errs, err)
 		}
-		ctr.state.NetNS = ""
+	}
+	if err := removeNatAddresses(network.Subnets); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// removeNetworkBridge destroys the epair interfaces attached to the bridge
+// whose other end is back on the host, which happens when the vnet jail
+// holding it is removed without tearing down its network. The bridge is
+// destroyed once it has no members left.
+func removeNetworkBridge(bridge string) error {
+	if _, err := net.InterfaceByName(bridge); err != nil {
+		// The bridge is only created along with the first container of
+		// the network.
+		logrus.Debugf("Bridge %s not found: %v", bridge, err)
+		return nil
+	}
+	out, err := exec.Command("ifconfig", bridge).Output()
+	if err != nil {
+		return fmt.Errorf("reading bridge %s: %w", bridge, err)
+	}
+	members := bridgeMembers(out)
+	remaining := 0
+	for _, member := range members {
+		peer := epairPeer(member)
+		if peer == "" {
+			remaining++
+			continue
+		}
+		if _, err := net.InterfaceByName(peer); err != nil {
+			// The other end is still in a jail.
+			remaining++
+			continue
+		}
+		logrus.Debugf("Destroying stale interface %s of bridge %s", member, bridge)
+		if err := destroyInterface(member); err != nil {
+			return err
+		}
+	}
+	if remaining > 0 {
+		logrus.Infof("Keeping bridge %s, %d of its members are in use", bridge, remaining)
+		return nil
+	}
+	return destroyInterface(bridge)
+}
+
+// networkHost returns the vnet jail, the pf anchors and the host interfaces of
+// the networks of the container.
+func (c *Container) networkHost() (*define.InspectNetworkHost, error) {
+	host := &define.InspectNetworkHost{
+		NetworkJail: c.state.NetNS,
+		Interfaces:  make(map[string]define.InspectNetworkHostInterface, len(c.state.NetworkStatus)),
+	}
+	if c.state.NetNS == "" {
+		return host, nil
+	}
+	backendPorts, hostIPPorts, _ := c.publishedPorts()
+	if len(backendPorts) > 0 {
+		host.PFAnchors = append(host.PFAnchors, pfRdrAnchor+"/"+c.ID())
+	}
+	if len(hostIPPorts) > 0 {
+		host.PFAnchors = append(host.PFAnchors, hostIPAnchor(c.ID()))
+	}
+
+	shaped := false
+	for name, status := range c.state.NetworkStatus {
+		network, err := c.runtime.Network().NetworkInspect(name)
+		if err != nil {
+			return nil, err
+		}
+		iface := define.InspectNetworkHostInterface{Bridge: network.NetworkInterface}
+		if iface.Bridge != "" {
+			iface.Interface = hostEpair(iface.Bridge, status)
+		}
+		if rates, err := c.networkRates(&network); err == nil {
+			iface.IngressRate = rates.ingress
+			iface.EgressRate = rates.egress
+		}
+		if iface.IngressRate != 0 || iface.EgressRate != 0 {
+			shaped = true
+		}
+		host.Interfaces[name] = iface
+	}
+	if shaped {
+		host.PFAnchors = append(host.PFAnchors, shapingAnchor(c.ID()))
+	}
+	return host, nil
+}
+
+// hostEpair returns the member of the bridge which is the host end of the
+// epair of the container in the given network status, or an empty string if
+// it cannot be determined.
+func hostEpair(bridge string, status types.StatusBlock) string {
+	out, err := exec.Command("ifconfig", bridge).Output()
+	if err != nil {
+		logrus.Debugf("Reading bridge %s: %v", bridge, err)
+		return ""
+	}
+	members := make(map[string]net.HardwareAddr)
+	for _, member := range bridgeMembers(out) {
+		if iface, err := net.InterfaceByName(member); err == nil {
+			members[member] = iface.HardwareAddr
+		}
+	}
+	for _, ctrIface := range status.Interfaces {
+		if name := epairHostEnd(net.HardwareAddr(ctrIface.MacAddress), members); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// epairHostEnd returns the name of the interface in members which is the
+// other end of the epair with the MAC address ctrMAC. The two ends of an
+// epair get the same MAC address apart from the last byte, 0x0a for the a
+// end on the host and 0x0b for the b end given to the container. Nothing
+// matches if the container's MAC address was set explicitly.
+func epairHostEnd(ctrMAC net.HardwareAddr, members map[string]net.HardwareAddr) string {
+	if len(ctrMAC) != 6 || ctrMAC[5] != 0x0b {
+		return ""
+	}
+	for name, mac := range members {
+		if !strings.HasPrefix(name, "epair") || !strings.HasSuffix(name, "a") || len(mac) != 6 {
+			continue
+		}
+		if mac[5] == 0x0a && bytes.Equal(mac[:5], ctrMAC[:5]) {
+			return name
+		}
+	}
+	return ""
+}
+
+// bridgeMembers returns the names of the member interfaces of a bridge from
+// the output of ifconfig(8).
+func bridgeMembers(ifconfigOutput []byte) []string {
+	var members []string
+	for _, line := range scanLines(ifconfigOutput) {
+		member, ok := strings.CutPrefix(strings.TrimSpace(line), "member: ")
+		if !ok {
+			continue
+		}
+		if fields := strings.Fields(member); len(fields) > 0 {
+			members = append(members, fields[0])
+		}
+	}
+	return members
+}
+
+// epairPeer returns the name of the other end of an epair interface, or an
+// empty string for other interfaces.
+func epairPeer(name string) string {
+	if !strings.HasPrefix(name, "epair") {
+		return ""
+	}
+	switch {
+	case strings.HasSuffix(name, "a"):
+		return strings.TrimSuffix(name, "a") + "b"
+	case strings.HasSuffix(name, "b"):
+		return strings.TrimSuffix(name, "b") + "a"
+	}
+	return ""
+}
+
+// destroyInterface destroys a cloned network interface. Destroying one end
+// of an epair destroys both.
+func destroyInterface(name string) error {
+	if out, err := exec.Command("ifconfig", name, "destroy").CombinedOutput(); err != nil {
+		return fmt.Errorf("destroying interface %s: %w: %s", name, err, strings.TrimSpace(string(out)))
 	}
 	return nil
 }
 
+// removeNatAddresses removes the addresses in the given subnets from the pf
+// NAT table.
+func removeNatAddresses(subnets []types.Subnet) error {
+	out, err := exec.Command("pfctl", "-t", pfNatTable, "-T", "show").Output()
+	if err != nil {
+		// pf may not be loaded, in which case there is nothing to
+		// clean up.
+		logrus.Debugf("Listing pf table %s: %v", pfNatTable, err)
+		return nil
+	}
+	addrs := natAddresses(out, subnets)
+	if len(addrs) == 0 {
+		return nil
+	}
+	args := append([]string{"-t", pfNatTable, "-T", "delete"}, addrs...)
+	if out, err := exec.Command("pfctl", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("removing addresses from pf table %s: %w: %s", pfNatTable, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// natAddresses returns the entries of the pf NAT table, as listed by pfctl(8),
+// which are in one of the given subnets.
+func natAddresses(pfctlOutput []byte, subnets []types.Subnet) []string {
+	var addrs []string
+	for _, entry := range strings.Fields(string(pfctlOutput)) {
+		addr, _, _ := strings.Cut(entry, "/")
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		for _, subnet := range subnets {
+			if subnet.Subnet.Contains(ip) {
+				addrs = append(addrs, entry)
+				break
+			}
+		}
+	}
+	return addrs
+}
+
 // TODO (5.0): return the statistics per network interface
 // This would allow better compat with docker.
 func getContainerNetIO(ctr *Container) (map[string]define.ContainerNetworkStats, error) {
-	if ctr.state.NetNS == "" {
+	// Containers sharing the vnet of another container, e.g. the infra
+	// container of their pod, report the statistics of that vnet.
+	netNS, _, err := getContainerNetNS(ctr)
+	if err != nil {
+		return nil, err
+	}
+	if netNS == "" {
 		// If NetNS is nil, it was set as none, and no netNS
 		// was set up this is a valid state and thus return no
 		// error, nor any statistics
@@ -207,12 +1016,12 @@ func getContainerNetIO(ctr *Container) (map[string]define.ContainerNetworkStats,
 
 	// First try running 'netstat -j' - this lets us retrieve stats from
 	// containers which don't have a separate vnet jail.
-	cmd := exec.Command("netstat", "-j", ctr.state.NetNS, "-bi", "--libxo", "json")
+	cmd := exec.Command("netstat", "-j", netNS, "-bi", "--libxo", "json")
 	out, err := cmd.Output()
 	if err != nil {
 		// Fall back to using jexec so that this still works on 13.2
 		// which does not have the -j flag.
-		cmd := exec.Command("jexec", ctr.state.NetNS, "netstat", "-bi", "--libxo", "json")
+		cmd := exec.Command("jexec", netNS, "netstat", "-bi", "--libxo", "json")
 		out, err = cmd.Output()
 	}
 	if err != nil {
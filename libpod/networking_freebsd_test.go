@@ -0,0 +1,260 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBridgeMembers(t *testing.T) {
+	out := []byte(`cni-podman0: flags=8843<UP,BROADCAST,RUNNING,SIMPLEX,MULTICAST> metric 0 mtu 1500
+	ether 58:9c:fc:10:ff:c1
+	inet 10.88.0.1 netmask 0xffff0000 broadcast 10.88.255.255
+	id 00:00:00:00:00:00 priority 32768 hellotime 2 fwddelay 15
+	maxage 20 holdcnt 6 proto rstp maxaddr 2000 timeout 1200
+	root id 00:00:00:00:00:00 priority 32768 ifcost 0 port 0
+	member: epair1a flags=143<LEARNING,DISCOVER,AUTOEDGE,AUTOPTP>
+	        ifmaxaddr 0 port 5 priority 128 path cost 2000
+	member: em0 flags=143<LEARNING,DISCOVER,AUTOEDGE,AUTOPTP>
+	        ifmaxaddr 0 port 1 priority 128 path cost 20000
+	groups: bridge
+	nd6 options=9<PERFORMNUD,IFDISABLED>
+`)
+	assert.Equal(t, []string{"epair1a", "em0"}, bridgeMembers(out))
+	assert.Empty(t, bridgeMembers([]byte("lo0: flags=8049<UP,LOOPBACK,RUNNING,MULTICAST> metric 0 mtu 16384\n")))
+}
+
+func TestEpairPeer(t *testing.T) {
+	assert.Equal(t, "epair12b", epairPeer("epair12a"))
+	assert.Equal(t, "epair12a", epairPeer("epair12b"))
+	assert.Equal(t, "", epairPeer("em0"))
+	assert.Equal(t, "", epairPeer("epair12"))
+}
+
+func TestNatAddresses(t *testing.T) {
+	_, subnet, err := net.ParseCIDR("10.89.0.0/24")
+	assert.NoError(t, err)
+	subnets := []types.Subnet{{Subnet: types.IPNet{IPNet: *subnet}}}
+
+	out := []byte("   10.88.0.2\n   10.89.0.2\n   10.89.0.7\n   10.89.0.0/28\n   fd00::2\n")
+	assert.Equal(t, []string{"10.89.0.2", "10.89.0.7", "10.89.0.0/28"}, natAddresses(out, subnets))
+	assert.Empty(t, natAddresses(out, nil))
+}
+
+func TestRouteArgs(t *testing.T) {
+	dst, err := types.ParseCIDR("10.10.0.0/16")
+	assert.NoError(t, err)
+	route := types.Route{Destination: dst, Gateway: net.ParseIP("10.89.0.1")}
+	assert.Equal(t, []string{"-q", "add", "-inet", "-net", "10.10.0.0/16", "10.89.0.1"}, routeArgs(route))
+
+	dst, err = types.ParseCIDR("fd00:1::/64")
+	assert.NoError(t, err)
+	route = types.Route{Destination: dst, Gateway: net.ParseIP("fd00::1")}
+	assert.Equal(t, []string{"-q", "add", "-inet6", "-net", "fd00:1::/64", "fd00::1"}, routeArgs(route))
+}
+
+func TestSplitHostIPPorts(t *testing.T) {
+	ports := []types.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostIP: "0.0.0.0", HostPort: 8081, ContainerPort: 80, Protocol: "tcp"},
+		{HostIP: "127.0.0.1", HostPort: 8082, ContainerPort: 80, Protocol: "tcp"},
+		{HostIP: "::", HostPort: 8083, ContainerPort: 80, Protocol: "tcp"},
+	}
+	backendPorts, hostIPPorts := splitHostIPPorts(ports)
+	assert.Equal(t, []types.PortMapping{ports[0], ports[1], ports[3]}, backendPorts)
+	assert.Equal(t, []types.PortMapping{ports[2]}, hostIPPorts)
+}
+
+func TestCheckHostIPs(t *testing.T) {
+	hostAddrs := []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("192.168.1.10")}
+	assert.NoError(t, checkHostIPs([]types.PortMapping{{HostIP: "127.0.0.1", HostPort: 8080}}, hostAddrs))
+	assert.ErrorContains(t, checkHostIPs([]types.PortMapping{{HostIP: "192.168.1.11", HostPort: 8080}}, hostAddrs), "not assigned")
+	assert.ErrorContains(t, checkHostIPs([]types.PortMapping{{HostIP: "localhost", HostPort: 8080}}, hostAddrs), "not an IP address")
+}
+
+func TestHostIPRules(t *testing.T) {
+	targets := map[string]portTarget{
+		"inet": {addr: net.ParseIP("10.88.0.2"), gateway: net.ParseIP("10.88.0.1"), bridge: "cni-podman0"},
+	}
+	ports := []types.PortMapping{
+		{HostIP: "192.168.1.10", HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1},
+		{HostIP: "127.0.0.1", HostPort: 5000, ContainerPort: 6000, Protocol: "tcp,udp", Range: 3},
+	}
+	rules, err := hostIPRules(ports, targets)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"nat on cni-podman0 inet proto { tcp udp } from 127.0.0.1 to 10.88.0.2 port 6000:6002 -> 10.88.0.1",
+		"rdr pass inet proto tcp from any to 192.168.1.10 port 8080 -> 10.88.0.2 port 80",
+		"rdr pass inet proto { tcp udp } from any to 127.0.0.1 port 5000:5002 -> 10.88.0.2 port 6000:*",
+	}, rules)
+
+	_, err = hostIPRules([]types.PortMapping{{HostIP: "::1", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}}, targets)
+	assert.ErrorContains(t, err, "no inet6 address")
+}
+
+func TestEpairHostEnd(t *testing.T) {
+	mac := func(s string) net.HardwareAddr {
+		hw, err := net.ParseMAC(s)
+		assert.NoError(t, err)
+		return hw
+	}
+	members := map[string]net.HardwareAddr{
+		"em0":     mac("58:9c:fc:10:ff:c1"),
+		"epair0a": mac("02:2e:43:c8:a1:0a"),
+		"epair1a": mac("02:9f:13:30:cc:0a"),
+	}
+	assert.Equal(t, "epair1a", epairHostEnd(mac("02:9f:13:30:cc:0b"), members))
+	assert.Equal(t, "", epairHostEnd(mac("02:9f:13:30:cc:0a"), members))
+	assert.Equal(t, "", epairHostEnd(mac("44:33:22:11:00:99"), members))
+	assert.Equal(t, "", epairHostEnd(nil, members))
+}
+
+func TestSelectVnetDevfsRuleset(t *testing.T) {
+	annotations := map[string]string{define.VnetDevfsRulesetAnnotation: "10"}
+
+	ruleset, err := selectVnetDevfsRuleset(map[string]map[string]string{"podman": nil}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, ruleset)
+
+	ruleset, err = selectVnetDevfsRuleset(map[string]map[string]string{"podman": nil}, annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, ruleset)
+
+	labels := map[string]map[string]string{
+		"podman": nil,
+		"lan":    {define.VnetDevfsRulesetLabel: "5"},
+		"dmz":    {define.VnetDevfsRulesetLabel: "5"},
+	}
+	ruleset, err = selectVnetDevfsRuleset(labels, annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, ruleset)
+
+	labels["dmz"][define.VnetDevfsRulesetLabel] = "6"
+	_, err = selectVnetDevfsRuleset(labels, annotations)
+	assert.ErrorContains(t, err, "networks dmz and lan select different devfs rulesets 6 and 5")
+
+	_, err = selectVnetDevfsRuleset(map[string]map[string]string{"lan": {define.VnetDevfsRulesetLabel: "x"}}, nil)
+	assert.ErrorContains(t, err, "invalid devfs ruleset")
+	_, err = selectVnetDevfsRuleset(nil, map[string]string{define.VnetDevfsRulesetAnnotation: "70000"})
+	assert.ErrorContains(t, err, "between 0 and 65535")
+}
+
+func TestVnetInterfaceRenames(t *testing.T) {
+	networks := map[string]types.PerNetworkOptions{
+		"podman": {InterfaceName: "eth0"},
+		"web":    {InterfaceName: "web0"},
+		"multi":  {InterfaceName: "multi0"},
+	}
+	status := map[string]types.StatusBlock{
+		"podman": {Interfaces: map[string]types.NetInterface{"eth0": {}}},
+		"web":    {Interfaces: map[string]types.NetInterface{"eth1": {}}},
+		"multi":  {Interfaces: map[string]types.NetInterface{"eth2": {}, "eth3": {}}},
+	}
+	renames, err := vnetInterfaceRenames(networks, status)
+	assert.NoError(t, err)
+	assert.Equal(t, []interfaceRename{{network: "web", from: "eth1", to: "web0"}}, renames)
+
+	networks["web"] = types.PerNetworkOptions{InterfaceName: "averyveryverylongname0"}
+	_, err = vnetInterfaceRenames(networks, status)
+	assert.ErrorContains(t, err, "longer than 15 characters")
+}
+
+func TestVnetStaticAddressChanges(t *testing.T) {
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+	otherMAC, _ := net.ParseMAC("02:00:00:00:00:02")
+	_, subnet, _ := net.ParseCIDR("10.89.0.0/24")
+	subnets := map[string][]types.Subnet{
+		"web": {{Subnet: types.IPNet{IPNet: *subnet}, Gateway: net.ParseIP("10.89.0.1")}},
+	}
+	networks := map[string]types.PerNetworkOptions{
+		"podman": {},
+		"web": {
+			StaticMAC: types.HardwareAddr(mac),
+			StaticIPs: []net.IP{net.ParseIP("10.89.0.5"), net.ParseIP("10.89.0.6")},
+		},
+	}
+	status := map[string]types.StatusBlock{
+		"podman": {Interfaces: map[string]types.NetInterface{"eth0": {}}},
+		"web": {Interfaces: map[string]types.NetInterface{"eth1": {
+			MacAddress: types.HardwareAddr(otherMAC),
+			Subnets: []types.NetAddress{{
+				IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.89.0.5"), Mask: subnet.Mask}},
+			}},
+		}}},
+	}
+	changes, err := vnetStaticAddressChanges(networks, status, subnets)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "web", changes[0].network)
+	assert.Equal(t, "eth1", changes[0].iface)
+	assert.Equal(t, types.HardwareAddr(mac), changes[0].mac)
+	assert.Len(t, changes[0].addrs, 1)
+	assert.Equal(t, "10.89.0.6/24", changes[0].addrs[0].IPNet.String())
+	assert.Equal(t, "10.89.0.1", changes[0].addrs[0].Gateway.String())
+
+	networks["web"] = types.PerNetworkOptions{StaticIPs: []net.IP{net.ParseIP("10.90.0.6")}}
+	_, err = vnetStaticAddressChanges(networks, status, subnets)
+	assert.ErrorContains(t, err, "static ip 10.90.0.6 is not in a subnet of network web")
+}
+
+func TestReleaseNetNS(t *testing.T) {
+	eventer := &recordingEventer{}
+	newCtr := func() *Container {
+		return &Container{
+			config:  &ContainerConfig{ID: "0123abcd"},
+			state:   &ContainerState{NetNS: "netjail", NetJID: 3},
+			runtime: &Runtime{eventer: eventer},
+		}
+	}
+	var released []string
+	release := func(name string) error {
+		released = append(released, name)
+		return nil
+	}
+
+	// The jail is released even when removing the pf rules failed, and
+	// the teardown error is returned afterwards.
+	teardownErr := errors.New("removing pf rules")
+	ctr := newCtr()
+	err := ctr.releaseNetNS(teardownErr, release)
+	assert.ErrorIs(t, err, teardownErr)
+	assert.Equal(t, []string{"netjail"}, released)
+	assert.Equal(t, "netjail", ctr.releasedNetworkJail)
+	assert.Empty(t, ctr.state.NetNS)
+	assert.Zero(t, ctr.state.NetJID)
+	if assert.Len(t, eventer.events, 1) {
+		assert.Equal(t, events.NetworkJailRemove, eventer.events[0].Status)
+	}
+
+	// A failed release keeps the jail in the state so it is retried.
+	releaseErr := errors.New("releasing jail")
+	ctr = newCtr()
+	err = ctr.releaseNetNS(teardownErr, func(string) error { return releaseErr })
+	assert.ErrorIs(t, err, releaseErr)
+	assert.Equal(t, "netjail", ctr.state.NetNS)
+	assert.Empty(t, ctr.releasedNetworkJail)
+
+	// Without a separate vnet jail there is nothing to release.
+	released = nil
+	ctr = newCtr()
+	ctr.config.PostConfigureNetNS = true
+	assert.NoError(t, ctr.releaseNetNS(nil, release))
+	assert.Empty(t, released)
+	assert.Empty(t, ctr.state.NetNS)
+}
+
+func TestNetworkJailRemoveTimeout(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{}}
+	ctr.config.StopTimeout = 10
+	assert.Equal(t, 10*time.Second, ctr.networkJailRemoveTimeout())
+	ctr.config.StopTimeout = 0
+	assert.Equal(t, minNetworkJailRemoveTimeout, ctr.networkJailRemoveTimeout())
+}
@@ -0,0 +1,231 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// hostIPAnchorSuffix is appended to the container ID in the name of the pf
+// anchor holding the port forwarding rules for ports published on a specific
+// host address. The CNI port forwarder ignores the host address, so these
+// ports are forwarded by rules installed by Podman instead.
+const hostIPAnchorSuffix = "-hostip"
+
+// hostIPAnchor returns the name of the pf anchor holding the port forwarding
+// rules of the container for ports published on a specific host address.
+func hostIPAnchor(ctrID string) string {
+	return pfRdrAnchor + "/" + ctrID + hostIPAnchorSuffix
+}
+
+// splitHostIPPorts splits the given ports into the ports published on all
+// host addresses, which are forwarded by the network backend, and the ports
+// published on a specific host address.
+func splitHostIPPorts(ports []types.PortMapping) (backendPorts, hostIPPorts []types.PortMapping) {
+	for _, port := range ports {
+		if ip := net.ParseIP(port.HostIP); port.HostIP == "" || (ip != nil && ip.IsUnspecified()) {
+			backendPorts = append(backendPorts, port)
+		} else {
+			hostIPPorts = append(hostIPPorts, port)
+		}
+	}
+	return backendPorts, hostIPPorts
+}
+
+// publishedPorts splits the ports of the container by how they are published:
+// by the network backend, by our pf rules for ports on a specific host address
+// or by the port proxy.
+func (c *Container) publishedPorts() (backendPorts, hostIPPorts, proxyPorts []types.PortMapping) {
+	ports := c.convertPortMappings()
+	if c.usesPortProxy() {
+		return nil, nil, ports
+	}
+	backendPorts, hostIPPorts = splitHostIPPorts(ports)
+	return backendPorts, hostIPPorts, nil
+}
+
+// checkHostIPPorts returns an error if one of the given ports is published on
+// an address which is not assigned to the host. pf would never redirect
+// traffic to such a port.
+func checkHostIPPorts(ports []types.PortMapping) error {
+	if len(ports) == 0 {
+		return nil
+	}
+	ifAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("listing host addresses: %w", err)
+	}
+	hostAddrs := make([]net.IP, 0, len(ifAddrs))
+	for _, addr := range ifAddrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			hostAddrs = append(hostAddrs, ipNet.IP)
+		}
+	}
+	return checkHostIPs(ports, hostAddrs)
+}
+
+// checkHostIPs returns an error if the host address of one of the given ports
+// is not in hostAddrs.
+func checkHostIPs(ports []types.PortMapping, hostAddrs []net.IP) error {
+	for _, port := range ports {
+		ip := net.ParseIP(port.HostIP)
+		if ip == nil {
+			return fmt.Errorf("publishing port %d on %q: host address is not an IP address", port.HostPort, port.HostIP)
+		}
+		found := false
+		for _, addr := range hostAddrs {
+			if addr.Equal(ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("publishing port %d on %s: address is not assigned to any interface of the host", port.HostPort, port.HostIP)
+		}
+	}
+	return nil
+}
+
+// portTarget is the address of a container which published ports of one
+// address family are forwarded to.
+type portTarget struct {
+	// addr is the address of the container.
+	addr net.IP
+	// gateway is the address of the host on the container's network.
+	gateway net.IP
+	// bridge is the host interface of the container's network.
+	bridge string
+}
+
+// addHostIPPortForwarding installs the pf rules forwarding the given ports,
+// published on specific host addresses, to the container.
+func (r *Runtime) addHostIPPortForwarding(ctr *Container, status map[string]types.StatusBlock, ports []types.PortMapping) error {
+	if len(ports) == 0 {
+		return nil
+	}
+	targets, err := r.portTargets(status)
+	if err != nil {
+		return err
+	}
+	rules, err := hostIPRules(ports, targets)
+	if err != nil {
+		return fmt.Errorf("forwarding ports of container %s: %w", ctr.ID(), err)
+	}
+	anchor := hostIPAnchor(ctr.ID())
+	cmd := exec.Command("pfctl", "-a", anchor, "-f", "-")
+	cmd.Stdin = strings.NewReader(strings.Join(rules, "\n") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("loading pf anchor %s for container %s: %w: %s", anchor, ctr.ID(), err, strings.TrimSpace(string(out)))
+	}
+	logrus.Debugf("Loaded %d port forwarding rules into pf anchor %s", len(rules), anchor)
+	return nil
+}
+
+// removeHostIPPortForwarding flushes the pf rules forwarding the ports of the
+// container which are published on specific host addresses.
+func removeHostIPPortForwarding(ctr *Container) error {
+	if _, hostIPPorts, _ := ctr.publishedPorts(); len(hostIPPorts) == 0 {
+		return nil
+	}
+	anchor := hostIPAnchor(ctr.ID())
+	if out, err := exec.Command("pfctl", "-a", anchor, "-F", "all").CombinedOutput(); err != nil {
+		return fmt.Errorf("flushing pf anchor %s: %w: %s", anchor, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// portTargets returns the first address of each family, "inet" or "inet6", of
+// the container in the given network status, in the order of the network
+// names.
+func (r *Runtime) portTargets(status map[string]types.StatusBlock) (map[string]portTarget, error) {
+	names := make([]string, 0, len(status))
+	for name := range status {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	targets := make(map[string]portTarget, 2)
+	for _, name := range names {
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return nil, err
+		}
+		ifNames := make([]string, 0, len(status[name].Interfaces))
+		for ifName := range status[name].Interfaces {
+			ifNames = append(ifNames, ifName)
+		}
+		sort.Strings(ifNames)
+		for _, ifName := range ifNames {
+			for _, subnet := range status[name].Interfaces[ifName].Subnets {
+				family := addressFamily(subnet.IPNet.IP)
+				if _, ok := targets[family]; ok {
+					continue
+				}
+				targets[family] = portTarget{
+					addr:    subnet.IPNet.IP,
+					gateway: subnet.Gateway,
+					bridge:  network.NetworkInterface,
+				}
+			}
+		}
+	}
+	return targets, nil
+}
+
+// addressFamily returns the pf address family of the given IP address.
+func addressFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return "inet"
+	}
+	return "inet6"
+}
+
+// hostIPRules returns the pf rules forwarding the given ports, published on
+// specific host addresses, to the container address of the same family. Ports
+// published on a loopback address are also translated to come from the
+// gateway of the container, which could not reply to the host's loopback
+// address otherwise.
+func hostIPRules(ports []types.PortMapping, targets map[string]portTarget) ([]string, error) {
+	var nat, rdr []string
+	for _, port := range ports {
+		hostIP := net.ParseIP(port.HostIP)
+		if hostIP == nil {
+			return nil, fmt.Errorf("publishing port %d on %q: host address is not an IP address", port.HostPort, port.HostIP)
+		}
+		family := addressFamily(hostIP)
+		target, ok := targets[family]
+		if !ok {
+			return nil, fmt.Errorf("publishing port %d on %s: container has no %s address to forward it to", port.HostPort, port.HostIP, family)
+		}
+		protocols := strings.Split(port.Protocol, ",")
+		proto := protocols[0]
+		if len(protocols) > 1 {
+			proto = "{ " + strings.Join(protocols, " ") + " }"
+		}
+		hostPorts := strconv.Itoa(int(port.HostPort))
+		ctrPort := strconv.Itoa(int(port.ContainerPort))
+		ctrPorts := ctrPort
+		if port.Range > 1 {
+			hostPorts += ":" + strconv.Itoa(int(port.HostPort+port.Range-1))
+			ctrPorts += ":" + strconv.Itoa(int(port.ContainerPort+port.Range-1))
+			ctrPort += ":*"
+		}
+		rdr = append(rdr, fmt.Sprintf("rdr pass %s proto %s from any to %s port %s -> %s port %s",
+			family, proto, hostIP, hostPorts, target.addr, ctrPort))
+		if hostIP.IsLoopback() {
+			if target.gateway == nil || target.bridge == "" {
+				return nil, fmt.Errorf("publishing port %d on %s: network of the container has no gateway", port.HostPort, port.HostIP)
+			}
+			nat = append(nat, fmt.Sprintf("nat on %s %s proto %s from %s to %s port %s -> %s",
+				target.bridge, family, proto, hostIP, target.addr, ctrPorts, target.gateway))
+		}
+	}
+	return append(nat, rdr...), nil
+}
@@ -22,6 +22,12 @@ import (
 
 // Create and configure a new network namespace for a container
 func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[string]types.StatusBlock, rerr error) {
+	if ctr.usesPortProxy() {
+		return nil, fmt.Errorf("publishing ports with %s=%s: %w", define.BridgePortHandlerOption, define.ProxyPortHandler, define.ErrOSNotSupported)
+	}
+	if ctr.usesRateLimit() {
+		return nil, fmt.Errorf("limiting the rate of the traffic with %s or %s: %w", define.BridgeIngressRateOption, define.BridgeEgressRateOption, define.ErrOSNotSupported)
+	}
 	if err := r.exposeMachinePorts(ctr.config.PortMappings); err != nil {
 		return nil, err
 	}
@@ -63,6 +69,10 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 
+	if err := addNetNSRoutes(ctrNS, ctr.config.Routes); err != nil {
+		return nil, fmt.Errorf("adding routes for container %s: %w", ctr.ID(), err)
+	}
+
 	// set up rootless port forwarder when rootless with ports and the network status is empty,
 	// if this is called from network reload the network status will not be empty and we should
 	// not set up port because they are still active
@@ -77,6 +87,30 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 	return netStatus, err
 }
 
+// addNetNSRoutes adds static routes to the network namespace. Existing routes
+// to the same destinations are replaced so that the routes can be added again
+// when the network is reloaded.
+func addNetNSRoutes(ctrNS string, routes []types.Route) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	return ns.WithNetNSPath(ctrNS, func(_ ns.NetNS) error {
+		for _, route := range routes {
+			nlRoute := &netlink.Route{
+				Dst: &route.Destination.IPNet,
+				Gw:  route.Gateway,
+			}
+			if route.Metric != nil {
+				nlRoute.Priority = int(*route.Metric)
+			}
+			if err := netlink.RouteReplace(nlRoute); err != nil {
+				return fmt.Errorf("adding route to %s via %s: %w", route.Destination.String(), route.Gateway, err)
+			}
+		}
+		return nil
+	})
+}
+
 // Create and configure a new network namespace for a container
 func (r *Runtime) createNetNS(ctr *Container) (n string, q map[string]types.StatusBlock, retErr error) {
 	ctrNS, err := netns.NewNS()
@@ -165,27 +199,6 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 	return prevErr
 }
 
-func getContainerNetNS(ctr *Container) (string, *Container, error) {
-	if ctr.state.NetNS != "" {
-		return ctr.state.NetNS, nil, nil
-	}
-	if ctr.config.NetNsCtr != "" {
-		c, err := ctr.runtime.GetContainer(ctr.config.NetNsCtr)
-		if err != nil {
-			return "", nil, err
-		}
-		if err = c.syncContainer(); err != nil {
-			return "", c, err
-		}
-		netNs, c2, err := getContainerNetNS(c)
-		if c2 != nil {
-			c = c2
-		}
-		return netNs, c, err
-	}
-	return "", nil, nil
-}
-
 // Returns a map of interface name to statistics for that interface.
 func getContainerNetIO(ctr *Container) (map[string]define.ContainerNetworkStats, error) {
 	perNetworkStats := make(map[string]define.ContainerNetworkStats)
@@ -299,3 +312,43 @@ func (c *Container) inspectJoinedNetworkNS(networkns string) (q types.StatusBloc
 	})
 	return result, err
 }
+
+// reapNetworkJail does nothing on Linux, there is no network jail to reap.
+func (c *Container) reapNetworkJail() error {
+	return nil
+}
+
+// cleanupRemovedNetwork does nothing on Linux, the network backend removes the
+// bridge of a network along with its last container.
+func cleanupRemovedNetwork(network *types.Network) error {
+	return nil
+}
+
+// reloadUpdatedNetwork does nothing on Linux, netavark passes the DNS servers
+// of a network to aardvark-dns, which is the nameserver of the containers.
+func (c *Container) reloadUpdatedNetwork(netName string) error {
+	return nil
+}
+
+// networkHost returns no details, the host side of the networks of a
+// container is not tracked on Linux.
+func (c *Container) networkHost() (*define.InspectNetworkHost, error) {
+	return &define.InspectNetworkHost{}, nil
+}
+
+// applyStaticNetworkOptions is a no-op on Linux, the network backends apply
+// the interface names and static addresses themselves.
+func (r *Runtime) applyStaticNetworkOptions(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) error {
+	return nil
+}
+
+// startDHCPClients is a no-op on Linux, the netavark dhcp-proxy runs the DHCP
+// clients of the containers.
+func (r *Runtime) startDHCPClients(ctr *Container, ctrNS string, networks map[string]types.PerNetworkOptions, status map[string]types.StatusBlock) error {
+	return nil
+}
+
+// stopDHCPClients is a no-op on Linux, see startDHCPClients.
+func (r *Runtime) stopDHCPClients(ctr *Container, ctrNS string, ifaces []string) error {
+	return nil
+}
@@ -529,3 +529,20 @@ func Benchmark_ocicniPortsToNetTypesPorts1m(b *testing.B) {
 	b.ResetTimer()
 	benchmarkOCICNIPortsToNetTypesPorts(b, ports)
 }
+
+func TestNameserverChanges(t *testing.T) {
+	oldServers := []net.IP{net.ParseIP("10.89.0.1"), net.ParseIP("1.1.1.1")}
+	newServers := []net.IP{net.ParseIP("10.89.0.1"), net.ParseIP("8.8.8.8"), net.ParseIP("fd00::1")}
+
+	removed, added := nameserverChanges(oldServers, newServers)
+	assert.Equal(t, []string{"1.1.1.1"}, removed)
+	assert.Equal(t, []string{"8.8.8.8", "fd00::1"}, added)
+
+	removed, added = nameserverChanges(oldServers, oldServers)
+	assert.Empty(t, removed)
+	assert.Empty(t, added)
+
+	removed, added = nameserverChanges(nil, newServers[:1])
+	assert.Empty(t, removed)
+	assert.Equal(t, []string{"10.89.0.1"}, added)
+}
@@ -0,0 +1,208 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/pkg/portproxy"
+	"github.com/containers/storage/pkg/reexec"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// portProxyCommand is the reexec key of the userspace port proxy. It is run
+// with the forwards of the container as argument and the host sockets in the
+// same order as extra files.
+const portProxyCommand = "podman-port-proxy"
+
+func init() {
+	reexec.Register(portProxyCommand, portProxyMain)
+}
+
+// portProxyMain is the main function of the port proxy. It forwards the
+// traffic received on the host sockets to the container until it is
+// terminated.
+func portProxyMain() {
+	if err := portProxyInner(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func portProxyInner() error {
+	if len(os.Args) != 2 {
+		return errors.New("internal error, need the forwards as argument")
+	}
+	var forwards []portproxy.Forward
+	if err := json.Unmarshal([]byte(os.Args[1]), &forwards); err != nil {
+		return fmt.Errorf("decoding forwards: %w", err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, unix.SIGTERM, unix.SIGINT)
+	signal.Ignore(unix.SIGHUP)
+
+	for i, forward := range forwards {
+		// The first extra file is fd 3.
+		f := os.NewFile(uintptr(3+i), forward.Protocol)
+		switch forward.Protocol {
+		case "tcp":
+			l, err := net.FileListener(f)
+			if err != nil {
+				return fmt.Errorf("listening for %s: %w", forward.Target, err)
+			}
+			go portproxy.ServeTCP(l, forward.Target)
+		case "udp":
+			pc, err := net.FilePacketConn(f)
+			if err != nil {
+				return fmt.Errorf("receiving for %s: %w", forward.Target, err)
+			}
+			go portproxy.ServeUDP(pc, forward.Target)
+		default:
+			return fmt.Errorf("unsupported protocol %s", forward.Protocol)
+		}
+		f.Close()
+	}
+
+	<-sigCh
+	return nil
+}
+
+// portProxyDir returns the directory holding the pid and log files of the
+// port proxies.
+func (r *Runtime) portProxyDir() string {
+	return filepath.Join(r.config.Engine.TmpDir, "port-proxy")
+}
+
+// startPortProxy opens the host sockets of the given ports and starts the port
+// proxy forwarding their traffic to the container, replacing the proxy of a
+// previous network setup of the container. Binding the sockets here reports
+// conflicts to the caller.
+func (r *Runtime) startPortProxy(ctr *Container, status map[string]types.StatusBlock, ports []types.PortMapping) error {
+	if err := r.stopPortProxy(ctr); err != nil {
+		return err
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	targets, err := r.portTargets(status)
+	if err != nil {
+		return err
+	}
+
+	var (
+		forwards []portproxy.Forward
+		files    []*os.File
+	)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for _, port := range ports {
+		family := "inet"
+		isV6 := false
+		if port.HostIP != "" {
+			hostIP := net.ParseIP(port.HostIP)
+			if hostIP == nil {
+				return fmt.Errorf("publishing port %d on %q: host address is not an IP address", port.HostPort, port.HostIP)
+			}
+			family = addressFamily(hostIP)
+			isV6 = family == "inet6"
+		}
+		target, ok := targets[family]
+		if !ok {
+			return fmt.Errorf("publishing port %d: container %s has no %s address to forward it to", port.HostPort, ctr.ID(), family)
+		}
+		for _, protocol := range strings.Split(port.Protocol, ",") {
+			if protocol != "tcp" && protocol != "udp" {
+				return fmt.Errorf("publishing port %d: protocol %s is not supported by the port proxy", port.HostPort, protocol)
+			}
+			for i := uint16(0); i < port.Range; i++ {
+				f, err := bindPort(protocol, port.HostIP, port.HostPort+i, isV6, nil)
+				if err != nil {
+					return fmt.Errorf("publishing port %d: %w", port.HostPort+i, err)
+				}
+				files = append(files, f)
+				forwards = append(forwards, portproxy.Forward{
+					Protocol: protocol,
+					Target:   net.JoinHostPort(target.addr.String(), strconv.Itoa(int(port.ContainerPort+i))),
+				})
+			}
+		}
+	}
+
+	data, err := json.Marshal(forwards)
+	if err != nil {
+		return err
+	}
+	dir := r.portProxyDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(filepath.Join(dir, ctr.ID()+".log"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := reexec.Command(portProxyCommand, string(data))
+	cmd.ExtraFiles = files
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting port proxy of container %s: %w", ctr.ID(), err)
+	}
+	// Reap the proxy if it exits while we are still running, it is
+	// reparented to init otherwise.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	pidFile := filepath.Join(dir, ctr.ID()+".pid")
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0o600); err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+	logrus.Debugf("Started port proxy %d forwarding %d ports of container %s", cmd.Process.Pid, len(forwards), ctr.ID())
+	return nil
+}
+
+// stopPortProxy terminates the port proxy of the container, if any.
+func (r *Runtime) stopPortProxy(ctr *Container) error {
+	dir := r.portProxyDir()
+	pidFile := filepath.Join(dir, ctr.ID()+".pid")
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("parsing pid file %s: %w", pidFile, err)
+	}
+	if err := unix.Kill(pid, unix.SIGTERM); err != nil && !errors.Is(err, unix.ESRCH) {
+		return fmt.Errorf("stopping port proxy %d: %w", pid, err)
+	}
+	logrus.Debugf("Stopped port proxy %d of container %s", pid, ctr.ID())
+	if err := os.Remove(pidFile); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, ctr.ID()+".log")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/sirupsen/logrus"
+)
+
+// pfShapingAnchor is the pf anchor holding the rules which pass the traffic
+// of containers with a rate limit through dummynet pipes. The main ruleset
+// must evaluate it with 'anchor "cni-shaping/*"'.
+const pfShapingAnchor = "cni-shaping"
+
+// maxDummynetPipe is the highest dummynet pipe number.
+const maxDummynetPipe = 65535
+
+// shapingAnchor returns the name of the pf anchor holding the traffic shaping
+// rules of the container.
+func shapingAnchor(ctrID string) string {
+	return pfShapingAnchor + "/" + ctrID
+}
+
+// networkRates are the rate limits, in bits per second, of the traffic of a
+// container in a network. Zero means unlimited.
+type networkRates struct {
+	ingress uint64
+	egress  uint64
+}
+
+// trafficRates returns the rate limits of the container in a network with
+// the given labels. The ingress_rate and egress_rate options of the container
+// take precedence over the labels of the network.
+func trafficRates(ctrOpts []string, labels map[string]string) (networkRates, error) {
+	ingress := labels[define.NetworkIngressRateLabel]
+	egress := labels[define.NetworkEgressRateLabel]
+	for _, opt := range ctrOpts {
+		name, value, _ := strings.Cut(opt, "=")
+		switch name {
+		case define.BridgeIngressRateOption:
+			ingress = value
+		case define.BridgeEgressRateOption:
+			egress = value
+		}
+	}
+	var (
+		rates networkRates
+		err   error
+	)
+	if ingress != "" {
+		if rates.ingress, err = util.ParseNetworkRate(ingress); err != nil {
+			return rates, err
+		}
+	}
+	if egress != "" {
+		if rates.egress, err = util.ParseNetworkRate(egress); err != nil {
+			return rates, err
+		}
+	}
+	return rates, nil
+}
+
+// networkRates returns the rate limits of the container in the given network.
+func (c *Container) networkRates(network *types.Network) (networkRates, error) {
+	rates, err := trafficRates(c.config.NetworkOptions["bridge"], network.Labels)
+	if err != nil {
+		return rates, fmt.Errorf("rate limit of container %s in network %s: %w", c.ID(), network.Name, err)
+	}
+	return rates, nil
+}
+
+// usesTrafficShaping returns true if the traffic of the container is limited
+// in any of its networks.
+func (c *Container) usesTrafficShaping() bool {
+	for name := range c.state.NetworkStatus {
+		network, err := c.runtime.Network().NetworkInspect(name)
+		if err != nil {
+			// Better look for rules which may not exist than to leak
+			// them.
+			return true
+		}
+		if rates, err := c.networkRates(&network); err != nil || rates != (networkRates{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// usedDummynetPipes parses the output of 'dnctl pipe show' and returns the
+// numbers of the configured pipes.
+func usedDummynetPipes(out []byte) map[int]bool {
+	pipes := make(map[int]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		number, _, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || number == "" || strings.TrimLeft(number, "0123456789") != "" {
+			continue
+		}
+		if n, err := strconv.Atoi(number); err == nil {
+			pipes[n] = true
+		}
+	}
+	return pipes
+}
+
+// freeDummynetPipes returns the n lowest pipe numbers which are not used.
+func freeDummynetPipes(used map[int]bool, n int) ([]int, error) {
+	pipes := make([]int, 0, n)
+	for pipe := 1; pipe <= maxDummynetPipe && len(pipes) < n; pipe++ {
+		if !used[pipe] {
+			pipes = append(pipes, pipe)
+		}
+	}
+	if len(pipes) < n {
+		return nil, fmt.Errorf("no free dummynet pipes left")
+	}
+	return pipes, nil
+}
+
+var dnpipeRegexp = regexp.MustCompile(`dnpipe\s*\(?\s*([0-9]+)`)
+
+// anchorDummynetPipes parses the output of 'pfctl -s rules' for an anchor and
+// returns the dummynet pipes used by its rules.
+func anchorDummynetPipes(out []byte) []int {
+	var pipes []int
+	for _, match := range dnpipeRegexp.FindAllSubmatch(out, -1) {
+		if n, err := strconv.Atoi(string(match[1])); err == nil {
+			pipes = append(pipes, n)
+		}
+	}
+	return pipes
+}
+
+// shapingRules returns the pf rules passing the traffic of the host end of an
+// epair through the given pipes. Traffic entering the host end was sent by the
+// container, traffic leaving it is received by the container. A zero pipe
+// adds no rule.
+func shapingRules(iface string, ingressPipe, egressPipe int) []string {
+	var rules []string
+	if egressPipe != 0 {
+		rules = append(rules, fmt.Sprintf("match in on %s all dnpipe %d", iface, egressPipe))
+	}
+	if ingressPipe != 0 {
+		rules = append(rules, fmt.Sprintf("match out on %s all dnpipe %d", iface, ingressPipe))
+	}
+	return rules
+}
+
+// addTrafficShaping limits the rate of the traffic of the container in each
+// network with a rate limit. Each limit is a dummynet pipe, which the rules of
+// the pf anchor of the container apply to the host end of the epair of the
+// network.
+func (r *Runtime) addTrafficShaping(ctr *Container, status map[string]types.StatusBlock) error {
+	// A network reload sets up the network again.
+	if err := removeTrafficShaping(ctr); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(status))
+	for name := range status {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	type shapedInterface struct {
+		name  string
+		rates networkRates
+	}
+	var ifaces []shapedInterface
+	pipeCount := 0
+	for _, name := range names {
+		network, err := r.Network().NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		rates, err := ctr.networkRates(&network)
+		if err != nil {
+			return err
+		}
+		if rates == (networkRates{}) {
+			continue
+		}
+		iface := ""
+		if network.NetworkInterface != "" {
+			iface = hostEpair(network.NetworkInterface, status[name])
+		}
+		if iface == "" {
+			return fmt.Errorf("limiting the rate of container %s in network %s: host interface not found", ctr.ID(), name)
+		}
+		ifaces = append(ifaces, shapedInterface{name: iface, rates: rates})
+		if rates.ingress != 0 {
+			pipeCount++
+		}
+		if rates.egress != 0 {
+			pipeCount++
+		}
+	}
+	if len(ifaces) == 0 {
+		return nil
+	}
+
+	// Serialize the allocation of the pipes with the other containers.
+	lock, err := lockfile.GetLockFile(filepath.Join(r.config.Engine.TmpDir, "dummynet.lock"))
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	out, err := exec.Command("dnctl", "pipe", "show").Output()
+	if err != nil {
+		return fmt.Errorf("listing dummynet pipes, is the dummynet module loaded: %w", err)
+	}
+	pipes, err := freeDummynetPipes(usedDummynetPipes(out), pipeCount)
+	if err != nil {
+		return err
+	}
+	var configured []int
+	configurePipe := func(rate uint64) (int, error) {
+		if rate == 0 {
+			return 0, nil
+		}
+		pipe := pipes[len(configured)]
+		if out, err := exec.Command("dnctl", "pipe", strconv.Itoa(pipe), "config", "bw", fmt.Sprintf("%dbit/s", rate)).CombinedOutput(); err != nil {
+			return 0, fmt.Errorf("configuring dummynet pipe %d: %w: %s", pipe, err, strings.TrimSpace(string(out)))
+		}
+		configured = append(configured, pipe)
+		return pipe, nil
+	}
+
+	var rules []string
+	for _, iface := range ifaces {
+		ingressPipe, err := configurePipe(iface.rates.ingress)
+		if err != nil {
+			deleteDummynetPipes(configured)
+			return err
+		}
+		egressPipe, err := configurePipe(iface.rates.egress)
+		if err != nil {
+			deleteDummynetPipes(configured)
+			return err
+		}
+		rules = append(rules, shapingRules(iface.name, ingressPipe, egressPipe)...)
+	}
+
+	anchor := shapingAnchor(ctr.ID())
+	cmd := exec.Command("pfctl", "-a", anchor, "-f", "-")
+	cmd.Stdin = strings.NewReader(strings.Join(rules, "\n") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		deleteDummynetPipes(configured)
+		return fmt.Errorf("loading pf anchor %s for container %s: %w: %s", anchor, ctr.ID(), err, strings.TrimSpace(string(out)))
+	}
+	logrus.Debugf("Loaded %d traffic shaping rules into pf anchor %s", len(rules), anchor)
+	return nil
+}
+
+// removeTrafficShaping flushes the traffic shaping rules of the container and
+// deletes the dummynet pipes they use.
+func removeTrafficShaping(ctr *Container) error {
+	if !ctr.usesTrafficShaping() {
+		return nil
+	}
+	anchor := shapingAnchor(ctr.ID())
+	out, err := exec.Command("pfctl", "-a", anchor, "-s", "rules").Output()
+	if err != nil {
+		logrus.Debugf("Listing pf anchor %s: %v", anchor, err)
+	}
+	deleteDummynetPipes(anchorDummynetPipes(out))
+	if out, err := exec.Command("pfctl", "-a", anchor, "-F", "all").CombinedOutput(); err != nil {
+		return fmt.Errorf("flushing pf anchor %s: %w: %s", anchor, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// deleteDummynetPipes deletes the given dummynet pipes. Failures are only
+// logged, a leaked pipe is harmless once no rule uses it.
+func deleteDummynetPipes(pipes []int) {
+	for _, pipe := range pipes {
+		if out, err := exec.Command("dnctl", "pipe", "delete", strconv.Itoa(pipe)).CombinedOutput(); err != nil {
+			logrus.Warnf("Deleting dummynet pipe %d: %v: %s", pipe, err, strings.TrimSpace(string(out)))
+		}
+	}
+}
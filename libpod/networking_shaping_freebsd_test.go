@@ -0,0 +1,52 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficRates(t *testing.T) {
+	labels := map[string]string{
+		define.NetworkIngressRateLabel: "10Mbit/s",
+		define.NetworkEgressRateLabel:  "1M",
+	}
+	rates, err := trafficRates(nil, labels)
+	assert.NoError(t, err)
+	assert.Equal(t, networkRates{ingress: 10000000, egress: 1000000}, rates)
+
+	rates, err = trafficRates([]string{"port_handler=proxy", "egress_rate=1KByte/s"}, labels)
+	assert.NoError(t, err)
+	assert.Equal(t, networkRates{ingress: 10000000, egress: 8000}, rates)
+
+	rates, err = trafficRates(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, networkRates{}, rates)
+
+	_, err = trafficRates(nil, map[string]string{define.NetworkIngressRateLabel: "fast"})
+	assert.Error(t, err)
+}
+
+func TestDummynetPipes(t *testing.T) {
+	show := []byte(`00001:  10.000 Mbit/s    0 ms burst 0
+q131073  50 sl. 0 flows (1 buckets) sched 65537 weight 0 lmax 0 pri 0 droptail
+ sched 65537 type FIFO flags 0x0 0 buckets 0 active
+00003:   1.000 Mbit/s    0 ms burst 0
+`)
+	used := usedDummynetPipes(show)
+	assert.Equal(t, map[int]bool{1: true, 3: true}, used)
+
+	pipes, err := freeDummynetPipes(used, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5}, pipes)
+
+	rules := shapingRules("epair0a", 2, 4)
+	assert.Equal(t, []string{"match in on epair0a all dnpipe 4", "match out on epair0a all dnpipe 2"}, rules)
+	assert.Equal(t, []string{"match in on epair1a all dnpipe 5"}, shapingRules("epair1a", 0, 5))
+
+	out := []byte("match in on epair0a all dnpipe 4\nmatch out on epair0a all dnpipe(2, 7)\n")
+	assert.Equal(t, []int{4, 2}, anchorDummynetPipes(out))
+}
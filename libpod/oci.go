@@ -71,6 +71,9 @@ type OCIRuntime interface { //nolint:interfacebloat
 	HTTPAttach(ctr *Container, r *http.Request, w http.ResponseWriter, streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, hijackDone chan<- bool, streamAttach, streamLogs bool) error
 	// AttachResize resizes the terminal in use by the given container.
 	AttachResize(ctr *Container, newSize resize.TerminalSize) error
+	// ReopenContainerLog makes the runtime reopen the log file of the
+	// given container, e.g. after it was rotated.
+	ReopenContainerLog(ctr *Container) error
 
 	// ExecContainer executes a command in a running container.
 	// Returns an int (PID of exec session), error channel (errors from
@@ -123,6 +126,10 @@ type OCIRuntime interface { //nolint:interfacebloat
 	// SupportsCheckpoint returns whether this OCI runtime
 	// implementation supports the CheckpointContainer() operation.
 	SupportsCheckpoint() bool
+	// SupportsCheckpointOption is whether the runtime supports the given
+	// option of its checkpoint and restore operations, such as
+	// "file-locks".
+	SupportsCheckpointOption(option string) bool
 	// SupportsJSONErrors is whether the runtime can return JSON-formatted
 	// error messages.
 	SupportsJSONErrors() bool
@@ -132,6 +139,15 @@ type OCIRuntime interface { //nolint:interfacebloat
 	// SupportsKVM os whether the OCI runtime supports running containers
 	// without KVM separation
 	SupportsKVM() bool
+	// SupportsRlimits is whether the runtime applies the resource limits
+	// of the container process.
+	SupportsRlimits() bool
+	// SupportsDevices is whether the runtime creates the devices listed
+	// in the container spec.
+	SupportsDevices() bool
+	// SupportsHook is whether the runtime runs the OCI hooks of the given
+	// stage listed in the container spec.
+	SupportsHook(stage string) bool
 
 	// AttachSocketPath is the path to the socket to attach to a given
 	// container.
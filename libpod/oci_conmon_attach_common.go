@@ -9,17 +9,14 @@ import (
 	"io"
 	"net"
 	"os"
-	"path/filepath"
 	"syscall"
 
-	"github.com/containers/common/pkg/config"
 	"github.com/containers/common/pkg/detach"
 	"github.com/containers/common/pkg/resize"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/errorhandling"
 	"github.com/moby/term"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/sys/unix"
 )
 
 /* Sync with stdpipe_t in conmon.c */
@@ -46,12 +43,7 @@ func (r *ConmonOCIRuntime) Attach(c *Container, params *AttachOptions) error {
 		return fmt.Errorf("started chan not passed when startContainer set: %w", define.ErrInternal)
 	}
 
-	keys := config.DefaultDetachKeys
-	if params.DetachKeys != nil {
-		keys = *params.DetachKeys
-	}
-
-	detachKeys, err := processDetachKeys(keys)
+	detachKeys, err := ctrDetachKeys(c, params.DetachKeys)
 	if err != nil {
 		return err
 	}
@@ -143,11 +135,7 @@ func (c *Container) attachToExec(streams *define.AttachStreams, keys *string, se
 	defer errorhandling.CloseQuiet(startFd)
 	defer errorhandling.CloseQuiet(attachFd)
 
-	detachString := config.DefaultDetachKeys
-	if keys != nil {
-		detachString = *keys
-	}
-	detachKeys, err := processDetachKeys(detachString)
+	detachKeys, err := ctrDetachKeys(c, keys)
 	if err != nil {
 		return err
 	}
@@ -195,6 +183,17 @@ func (c *Container) attachToExec(streams *define.AttachStreams, keys *string, se
 	return readStdio(conn, streams, receiveStdoutError, stdinDone)
 }
 
+// ctrDetachKeys returns the detach key sequence for an attach session to the
+// container, falling back to the detach_keys set in containers.conf when the
+// caller did not ask for a particular sequence.
+func ctrDetachKeys(ctr *Container, keys *string) ([]byte, error) {
+	detachString := ctr.runtime.config.Engine.DetachKeys
+	if keys != nil {
+		detachString = *keys
+	}
+	return processDetachKeys(detachString)
+}
+
 func processDetachKeys(keys string) ([]byte, error) {
 	// Check the validity of the provided keys first
 	if len(keys) == 0 {
@@ -207,23 +206,31 @@ func processDetachKeys(keys string) ([]byte, error) {
 	return detachKeys, nil
 }
 
-func registerResizeFunc(r <-chan resize.TerminalSize, bundlePath string) {
+func registerResizeFunc(ctr *Container, r <-chan resize.TerminalSize, bundlePath string) {
 	resize.HandleResizing(r, func(size resize.TerminalSize) {
-		controlPath := filepath.Join(bundlePath, "ctl")
-		controlFile, err := os.OpenFile(controlPath, unix.O_WRONLY, 0)
-		if err != nil {
-			logrus.Debugf("Could not open ctl file: %v", err)
-			return
-		}
-		defer controlFile.Close()
-
 		logrus.Debugf("Received a resize event: %+v", size)
-		if _, err = fmt.Fprintf(controlFile, "%d %d %d\n", 1, size.Height, size.Width); err != nil {
-			logrus.Warnf("Failed to write to control file to resize terminal: %v", err)
+		if err := resizeTerminal(ctr, bundlePath, size); err != nil {
+			logrus.Debugf("Failed to resize terminal: %v", err)
 		}
 	})
 }
 
+// resizeTerminal asks the conmon owning the control file in bundlePath to
+// resize its terminal. The control file is opened without blocking, so a
+// conmon which has already exited does not hold up further resize events.
+func resizeTerminal(ctr *Container, bundlePath string, size resize.TerminalSize) error {
+	controlFile, err := openControlFile(ctr, bundlePath)
+	if err != nil {
+		return err
+	}
+	defer controlFile.Close()
+
+	if _, err = fmt.Fprintf(controlFile, "%d %d %d\n", 1, size.Height, size.Width); err != nil {
+		return fmt.Errorf("failed to write to ctl file to resize terminal: %w", err)
+	}
+	return nil
+}
+
 func setupStdioChannels(streams *define.AttachStreams, conn *net.UnixConn, detachKeys []byte) (chan error, chan error) {
 	receiveStdoutError := make(chan error)
 	go func() {
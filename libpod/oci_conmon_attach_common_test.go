@@ -0,0 +1,60 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/resize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestCtrDetachKeys(t *testing.T) {
+	ctr := &Container{runtime: &Runtime{config: &config.Config{}}}
+	ctr.runtime.config.Engine.DetachKeys = "ctrl-a,ctrl-d"
+
+	keys, err := ctrDetachKeys(ctr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 4}, keys)
+
+	requested := "ctrl-p,ctrl-q"
+	keys, err = ctrDetachKeys(ctr, &requested)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{16, 17}, keys)
+
+	disabled := ""
+	keys, err = ctrDetachKeys(ctr, &disabled)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	invalid := "ctrl-"
+	_, err = ctrDetachKeys(ctr, &invalid)
+	assert.Error(t, err)
+}
+
+func TestResizeTerminal(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{ID: "test"}}
+	bundlePath := t.TempDir()
+	require.NoError(t, unix.Mkfifo(filepath.Join(bundlePath, "ctl"), 0o600))
+
+	// Without conmon reading the control file the resize fails instead
+	// of blocking.
+	err := resizeTerminal(ctr, bundlePath, resize.TerminalSize{Width: 80, Height: 24})
+	assert.Error(t, err)
+
+	reader, err := os.OpenFile(filepath.Join(bundlePath, "ctl"), unix.O_RDONLY|unix.O_NONBLOCK, 0)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	err = resizeTerminal(ctr, bundlePath, resize.TerminalSize{Width: 80, Height: 24})
+	require.NoError(t, err)
+	buf := make([]byte, 64)
+	n, err := reader.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "1 24 80\n", string(buf[:n]))
+}
@@ -18,7 +18,6 @@ import (
 	"strings"
 	"sync"
 	"syscall"
-	"text/template"
 	"time"
 
 	"github.com/containers/common/pkg/config"
@@ -36,6 +35,7 @@ import (
 	"github.com/containers/podman/v5/utils"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	"golang.org/x/sys/unix"
 )
 
@@ -53,6 +53,7 @@ type ConmonOCIRuntime struct {
 	name              string
 	path              string
 	conmonPath        string
+	monitor           *conmonMonitor
 	conmonEnv         []string
 	tmpDir            string
 	exitsDir          string
@@ -65,6 +66,11 @@ type ConmonOCIRuntime struct {
 	supportsNoCgroups bool
 	enableKeyring     bool
 	persistDir        string
+
+	// features are the features reported by the runtime, queried on
+	// first use. It is nil if the runtime cannot report them.
+	features     *ociRuntimeFeatures
+	featuresOnce sync.Once
 }
 
 // Make a new Conmon-based OCI runtime with the given options.
@@ -94,7 +100,8 @@ func newConmonOCIRuntime(name string, paths []string, conmonPath string, runtime
 
 	runtime := new(ConmonOCIRuntime)
 	runtime.name = name
-	runtime.conmonPath = conmonPath
+	runtime.conmonPath = monitorPathForRuntime(name, conmonPath, runtimeCfg)
+	runtime.monitor = newConmonMonitor(runtime.conmonPath)
 	runtime.runtimeFlags = runtimeFlags
 
 	runtime.conmonEnv = runtimeCfg.Engine.ConmonEnvVars.Get()
@@ -299,7 +306,7 @@ func (r *ConmonOCIRuntime) StartContainer(ctr *Container) error {
 	if path, ok := os.LookupEnv("PATH"); ok {
 		env = append(env, fmt.Sprintf("PATH=%s", path))
 	}
-	if err := utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "start", ctr.ID())...); err != nil {
+	if err := utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.ctrRuntimeFlags(ctr), "start", ctr.ID())...); err != nil {
 		return err
 	}
 
@@ -318,7 +325,7 @@ func (r *ConmonOCIRuntime) UpdateContainer(ctr *Container, resources *spec.Linux
 	if path, ok := os.LookupEnv("PATH"); ok {
 		env = append(env, fmt.Sprintf("PATH=%s", path))
 	}
-	args := r.runtimeFlags
+	args := r.ctrRuntimeFlags(ctr)
 	args = append(args, "update")
 	tempFile, additionalArgs, err := generateResourceFile(resources)
 	if err != nil {
@@ -374,8 +381,7 @@ func (r *ConmonOCIRuntime) killContainer(ctr *Container, signal uint, all, captu
 		return nil, err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	var args []string
-	args = append(args, r.runtimeFlags...)
+	args := r.ctrRuntimeFlags(ctr)
 	if all {
 		args = append(args, "kill", "--all", ctr.ID(), strconv.FormatUint(uint64(signal), 10))
 	} else {
@@ -481,6 +487,9 @@ func (r *ConmonOCIRuntime) StopContainer(ctr *Container, timeout uint, all bool)
 			}
 			logrus.Debugf("Timed out stopping container %s with %s, resorting to SIGKILL: %v", ctr.ID(), sigName, err)
 			logrus.Warnf("StopSignal %s failed to stop container %s in %d seconds, resorting to SIGKILL", sigName, ctr.Name(), timeout)
+			// Record the escalation, the container did not stop within
+			// its stop timeout and is about to be killed.
+			ctr.newStopTimeoutEvent(sigName, timeout)
 		} else {
 			// No error, the container is dead
 			return nil
@@ -510,7 +519,7 @@ func (r *ConmonOCIRuntime) DeleteContainer(ctr *Container) error {
 		return err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "delete", "--force", ctr.ID())...)
+	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.ctrRuntimeFlags(ctr), "delete", "--force", ctr.ID())...)
 }
 
 // PauseContainer pauses the given container.
@@ -520,7 +529,7 @@ func (r *ConmonOCIRuntime) PauseContainer(ctr *Container) error {
 		return err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "pause", ctr.ID())...)
+	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.ctrRuntimeFlags(ctr), "pause", ctr.ID())...)
 }
 
 // UnpauseContainer unpauses the given container.
@@ -530,7 +539,7 @@ func (r *ConmonOCIRuntime) UnpauseContainer(ctr *Container) error {
 		return err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "resume", ctr.ID())...)
+	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.ctrRuntimeFlags(ctr), "resume", ctr.ID())...)
 }
 
 // This filters out ENOTCONN errors which can happen on FreeBSD if the
@@ -581,11 +590,7 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 		logrus.Debugf("Successfully connected to container %s attach socket %s", ctr.ID(), attachSock)
 	}
 
-	detachString := ctr.runtime.config.Engine.DetachKeys
-	if detachKeys != nil {
-		detachString = *detachKeys
-	}
-	isDetach, err := processDetachKeys(detachString)
+	isDetach, err := ctrDetachKeys(ctr, detachKeys)
 	if err != nil {
 		return err
 	}
@@ -793,15 +798,21 @@ func openControlFile(ctr *Container, parentDir string) (*os.File, error) {
 
 // AttachResize resizes the terminal used by the given container.
 func (r *ConmonOCIRuntime) AttachResize(ctr *Container, newSize resize.TerminalSize) error {
+	logrus.Debugf("Received a resize event for container %s: %+v", ctr.ID(), newSize)
+	return resizeTerminal(ctr, ctr.bundlePath(), newSize)
+}
+
+// ReopenContainerLog makes conmon reopen the log file of the given container.
+func (r *ConmonOCIRuntime) ReopenContainerLog(ctr *Container) error {
 	controlFile, err := openControlFile(ctr, ctr.bundlePath())
 	if err != nil {
 		return err
 	}
 	defer controlFile.Close()
 
-	logrus.Debugf("Received a resize event for container %s: %+v", ctr.ID(), newSize)
-	if _, err = fmt.Fprintf(controlFile, "%d %d %d\n", 1, newSize.Height, newSize.Width); err != nil {
-		return fmt.Errorf("failed to write to ctl file to resize terminal: %w", err)
+	logrus.Debugf("Reopening log file of container %s", ctr.ID())
+	if _, err = fmt.Fprintf(controlFile, "%d %d %d\n", 2, 0, 0); err != nil {
+		return fmt.Errorf("failed to write to ctl file to reopen log: %w", err)
 	}
 
 	return nil
@@ -819,8 +830,7 @@ func (r *ConmonOCIRuntime) CheckpointContainer(ctr *Container, options Container
 	logrus.Debugf("Writing checkpoint to %s", imagePath)
 	logrus.Debugf("Writing checkpoint logs to %s", workPath)
 	logrus.Debugf("Pre-dump the container %t", options.PreCheckPoint)
-	args := []string{}
-	args = append(args, r.runtimeFlags...)
+	args := r.ctrRuntimeFlags(ctr)
 	args = append(args, "checkpoint")
 	args = append(args, "--image-path")
 	args = append(args, imagePath)
@@ -905,9 +915,23 @@ func (r *ConmonOCIRuntime) CheckConmonRunning(ctr *Container) (bool, error) {
 // SupportsCheckpoint checks if the OCI runtime supports checkpointing
 // containers.
 func (r *ConmonOCIRuntime) SupportsCheckpoint() bool {
+	if enabled, known := r.getFeatures().enabled("checkpoint"); known {
+		return enabled
+	}
 	return crutils.CRRuntimeSupportsCheckpointRestore(r.path)
 }
 
+// SupportsCheckpointOption checks if the OCI runtime supports the given option
+// of checkpoint and restore. Unless the runtime reports otherwise with an
+// annotation like org.opencontainers.runc.checkpoint.file-locks.enabled, it is
+// assumed to.
+func (r *ConmonOCIRuntime) SupportsCheckpointOption(option string) bool {
+	if enabled, known := r.getFeatures().enabled("checkpoint." + option); known {
+		return enabled
+	}
+	return true
+}
+
 // SupportsJSONErrors checks if the OCI runtime supports JSON-formatted error
 // messages.
 func (r *ConmonOCIRuntime) SupportsJSONErrors() bool {
@@ -917,7 +941,13 @@ func (r *ConmonOCIRuntime) SupportsJSONErrors() bool {
 // SupportsNoCgroups checks if the OCI runtime supports running containers
 // without cgroups (the --cgroup-manager=disabled flag).
 func (r *ConmonOCIRuntime) SupportsNoCgroups() bool {
-	return r.supportsNoCgroups
+	if r.supportsNoCgroups {
+		return true
+	}
+	// Runtimes which report no Linux features, such as the FreeBSD
+	// ones, do not use cgroups at all.
+	features := r.getFeatures()
+	return features != nil && features.Linux == nil
 }
 
 // SupportsKVM checks if the OCI runtime supports running containers
@@ -926,6 +956,46 @@ func (r *ConmonOCIRuntime) SupportsKVM() bool {
 	return r.supportsKVM
 }
 
+// SupportsRlimits checks if the OCI runtime applies resource limits. Unless
+// the runtime reports otherwise, it is assumed to.
+func (r *ConmonOCIRuntime) SupportsRlimits() bool {
+	if enabled, known := r.getFeatures().enabled("rlimits"); known {
+		return enabled
+	}
+	return true
+}
+
+// SupportsDevices checks if the OCI runtime creates the devices of the
+// container. Unless the runtime reports otherwise, it is assumed to.
+func (r *ConmonOCIRuntime) SupportsDevices() bool {
+	if enabled, known := r.getFeatures().enabled("devices"); known {
+		return enabled
+	}
+	return true
+}
+
+// SupportsHook checks if the OCI runtime runs the hooks of the given stage.
+// Runtimes which do not report their features are assumed to run them on
+// Linux but not on FreeBSD, where libpod runs them instead.
+func (r *ConmonOCIRuntime) SupportsHook(stage string) bool {
+	if f := r.getFeatures(); f != nil {
+		return slices.Contains(f.Hooks, stage)
+	}
+	return runtimeRunsHooks
+}
+
+// getFeatures returns the features reported by the OCI runtime, or nil if it
+// does not support the features subcommand.
+func (r *ConmonOCIRuntime) getFeatures() *ociRuntimeFeatures {
+	r.featuresOnce.Do(func() {
+		r.features = probeOCIRuntimeFeatures(r.path)
+		if r.features == nil {
+			logrus.Debugf("OCI runtime %s does not report its features", r.name)
+		}
+	})
+	return r.features
+}
+
 // AttachSocketPath is the path to a single container's attach socket.
 func (r *ConmonOCIRuntime) AttachSocketPath(ctr *Container) (string, error) {
 	if ctr == nil {
@@ -949,6 +1019,21 @@ func (r *ConmonOCIRuntime) OOMFilePath(ctr *Container) (string, error) {
 	return filepath.Join(r.persistDir, ctr.ID(), "oom"), nil
 }
 
+// ctrRuntimeFlags returns the flags to pass to the OCI runtime for the given
+// container: the global runtime flags followed by any flags recorded in the
+// container's configuration which are not already among them.
+// The returned slice is freshly allocated and can safely be appended to.
+func (r *ConmonOCIRuntime) ctrRuntimeFlags(ctr *Container) []string {
+	flags := make([]string, 0, len(r.runtimeFlags)+len(ctr.config.RuntimeFlags))
+	flags = append(flags, r.runtimeFlags...)
+	for _, flag := range ctr.config.RuntimeFlags {
+		if !slices.Contains(r.runtimeFlags, flag) {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
 // RuntimeInfo provides information on the runtime.
 func (r *ConmonOCIRuntime) RuntimeInfo() (*define.ConmonInfo, *define.OCIRuntimeInfo, error) {
 	runtimePackage := version.Package(r.path)
@@ -1024,25 +1109,7 @@ func waitPidStop(pid int, timeout time.Duration) error {
 }
 
 func (r *ConmonOCIRuntime) getLogTag(ctr *Container) (string, error) {
-	logTag := ctr.LogTag()
-	if logTag == "" {
-		return "", nil
-	}
-	data, err := ctr.inspectLocked(false)
-	if err != nil {
-		// FIXME: this error should probably be returned
-		return "", nil //nolint: nilerr
-	}
-	tmpl, err := template.New("container").Parse(logTag)
-	if err != nil {
-		return "", fmt.Errorf("template parsing error %s: %w", logTag, err)
-	}
-	var b bytes.Buffer
-	err = tmpl.Execute(&b, data)
-	if err != nil {
-		return "", err
-	}
-	return b.String(), nil
+	return ctr.expandLogTag()
 }
 
 func getPreserveFdExtraFiles(preserveFD []uint, preserveFDs uint) (uint, []*os.File, []*os.File, error) {
@@ -1082,6 +1149,10 @@ func getPreserveFdExtraFiles(preserveFD []uint, preserveFDs uint) (uint, []*os.F
 func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) (int64, error) {
 	var stderrBuf bytes.Buffer
 
+	if err := r.monitor.detect(); err != nil {
+		return 0, err
+	}
+
 	parentSyncPipe, childSyncPipe, err := newPipe()
 	if err != nil {
 		return 0, fmt.Errorf("creating socket pair: %w", err)
@@ -1164,6 +1235,8 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	if val := os.Getenv("LISTEN_FDS"); val != "" {
 		if preserveFDs > 0 || len(ctr.config.PreserveFD) > 0 {
 			logrus.Warnf("Ignoring LISTEN_FDS to preserve custom user-specified FDs")
+		} else if ctr.config.Console {
+			logrus.Warnf("Ignoring LISTEN_FDS to pass the console of the container")
 		} else {
 			fds, err := strconv.Atoi(val)
 			if err != nil {
@@ -1177,6 +1250,19 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	if err != nil {
 		return 0, err
 	}
+
+	// The console is passed after the preserved FDs, as the container
+	// expects it at consoleFD().
+	console, err := ctr.startConsole()
+	if err != nil {
+		return 0, err
+	}
+	if console != nil {
+		// Our copy is not needed once conmon has been started.
+		defer console.Close()
+		extraFiles = append(extraFiles, console)
+		preserveFDs++
+	}
 	if preserveFDs > 0 {
 		args = append(args, formatRuntimeOpts("--preserve-fds", strconv.FormatUint(uint64(preserveFDs), 10))...)
 	}
@@ -1189,6 +1275,9 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		if restoreOptions.FileLocks {
 			args = append(args, "--runtime-opt", "--file-locks")
 		}
+		if restoreOptions.TCPClose {
+			args = append(args, "--runtime-opt", "--tcp-close")
+		}
 		if restoreOptions.Pod != "" {
 			mountLabel := ctr.config.MountLabel
 			processLabel := ctr.config.ProcessLabel
@@ -1215,6 +1304,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		}
 	}
 
+	args = r.monitor.filterArgs(args)
 	logrus.WithFields(logrus.Fields{
 		"args": args,
 	}).Debugf("running conmon: %s", r.conmonPath)
@@ -1247,7 +1337,8 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	cmd.Env = append(cmd.Env, conmonEnv...)
 	cmd.ExtraFiles = append(cmd.ExtraFiles, childSyncPipe, childStartPipe)
 
-	if r.reservePorts && !rootless.IsRootless() && !ctr.config.NetMode.IsSlirp4netns() {
+	// The port proxy already holds the ports.
+	if r.reservePorts && !rootless.IsRootless() && !ctr.config.NetMode.IsSlirp4netns() && !ctr.usesPortProxy() {
 		ports, err := bindPorts(ctr.convertPortMappings())
 		if err != nil {
 			return 0, err
@@ -1399,12 +1490,8 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 		"--persist-dir", persistDir,
 		"--full-attach",
 	}
-	if len(r.runtimeFlags) > 0 {
-		rFlags := []string{}
-		for _, arg := range r.runtimeFlags {
-			rFlags = append(rFlags, "--runtime-arg", arg)
-		}
-		args = append(args, rFlags...)
+	for _, arg := range r.ctrRuntimeFlags(ctr) {
+		args = append(args, "--runtime-arg", arg)
 	}
 
 	if ctr.CgroupManager() == config.SystemdCgroupsManager && !ctr.config.NoCgroups && ctr.config.CgroupsMode != cgroupSplit {
@@ -1430,7 +1517,9 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 		fallthrough
 	case define.JSONLogging:
 		fallthrough
-	case define.KubernetesLogging:
+	case define.KubernetesLogging, define.SyslogLogging:
+		// Output for syslog is written to a k8s-file log, which is
+		// forwarded to syslog by a separate Podman process.
 		logDriverArg = fmt.Sprintf("%s:%s", define.KubernetesLogging, logPath)
 	}
 
@@ -1445,6 +1534,11 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 	if ctr.config.LogSize > 0 {
 		size = ctr.config.LogSize
 	}
+	if ctr.rotatesLogs() {
+		// The log manager rotates the log at its maximum size, conmon
+		// only truncates it if the log manager falls behind.
+		size *= 2
+	}
 	if size > 0 {
 		args = append(args, "--log-size-max", strconv.FormatInt(size, 10))
 	}
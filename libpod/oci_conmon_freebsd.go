@@ -8,6 +8,10 @@ import (
 	"os/exec"
 )
 
+// runtimeRunsHooks is whether an OCI runtime which does not report its
+// features is assumed to run OCI hooks. ocijail does not.
+const runtimeRunsHooks = false
+
 func (r *ConmonOCIRuntime) createRootlessContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) (int64, error) {
 	return -1, errors.New("unsupported (*ConmonOCIRuntime) createRootlessContainer")
 }
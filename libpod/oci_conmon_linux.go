@@ -25,6 +25,10 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// runtimeRunsHooks is whether an OCI runtime which does not report its
+// features is assumed to run OCI hooks.
+const runtimeRunsHooks = true
+
 func (r *ConmonOCIRuntime) createRootlessContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) (int64, error) {
 	type result struct {
 		restoreDuration int64
@@ -0,0 +1,178 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// monitorKind identifies how a container monitor is driven.
+type monitorKind string
+
+const (
+	// monitorConmon is conmon or any monitor with a conmon compatible
+	// command line.
+	monitorConmon monitorKind = "conmon"
+	// monitorConmonRs is conmon-rs, which is driven over RPC.
+	monitorConmonRs monitorKind = "conmon-rs"
+)
+
+// optionalMonitorOptions are the monitor options which are only passed if
+// the monitor supports them, mapped to whether they take a value. Older
+// conmon versions and alternative monitors lack some of them.
+var optionalMonitorOptions = map[string]bool{
+	"--exit-delay":     true,
+	"--full-attach":    false,
+	"--log-size-max":   true,
+	"--log-tag":        true,
+	"--no-new-keyring": false,
+	"--persist-dir":    true,
+	"--syslog":         false,
+}
+
+// passthroughMonitorOptions are the monitor options whose values are passed
+// on to other programs and may look like monitor options themselves.
+var passthroughMonitorOptions = map[string]bool{
+	"--exit-command-arg": true,
+	"--runtime-arg":      true,
+	"--runtime-opt":      true,
+}
+
+var monitorOptionRegexp = regexp.MustCompile(`--[a-z][a-z0-9-]*`)
+
+// conmonMonitor is the binary monitoring the containers of an OCI runtime.
+// Its kind and the options it supports are detected on first use.
+type conmonMonitor struct {
+	path string
+
+	once    sync.Once
+	kind    monitorKind
+	options map[string]bool
+	err     error
+}
+
+func newConmonMonitor(path string) *conmonMonitor {
+	return &conmonMonitor{path: path}
+}
+
+// monitorPathForRuntime returns the monitor to use for the given OCI
+// runtime. Entries of the form "<runtime>:<path>" in the conmon_path list of
+// containers.conf select a monitor for a single runtime; the first one
+// pointing to an existing file is used. Otherwise the default monitor is
+// returned.
+func monitorPathForRuntime(name, defaultPath string, runtimeCfg *config.Config) string {
+	base := filepath.Base(name)
+	for _, entry := range runtimeCfg.Engine.ConmonPath.Get() {
+		runtime, path, ok := strings.Cut(entry, ":")
+		if !ok || runtime != base || strings.HasPrefix(entry, "/") {
+			continue
+		}
+		if stat, err := os.Stat(path); err == nil && stat.Mode().IsRegular() {
+			return path
+		}
+	}
+	return defaultPath
+}
+
+// checkMonitorConfig rejects entries of the form "<runtime>:<path>" in the
+// conmonrs_path list of containers.conf. Podman cannot drive conmon-rs, so
+// selecting it for a runtime would make every container of that runtime fail
+// to start.
+func checkMonitorConfig(runtimeCfg *config.Config) error {
+	for _, entry := range runtimeCfg.Engine.ConmonRsPath.Get() {
+		if runtime, _, ok := strings.Cut(entry, ":"); ok && !strings.HasPrefix(entry, "/") {
+			return fmt.Errorf("conmonrs_path entry %q selects conmon-rs for OCI runtime %s, which Podman cannot use as container monitor: %w", entry, runtime, define.ErrNotImplemented)
+		}
+	}
+	return nil
+}
+
+// parseMonitorHelp returns the kind of a monitor and the options it
+// supports from the output of its --help option. The kind is empty if the
+// monitor is not recognized.
+func parseMonitorHelp(help string) (monitorKind, map[string]bool) {
+	options := make(map[string]bool)
+	for _, option := range monitorOptionRegexp.FindAllString(help, -1) {
+		options[option] = true
+	}
+	switch {
+	case options["--api-version"]:
+		return monitorConmon, options
+	case strings.Contains(help, "conmonrs") || strings.Contains(help, "conmon-rs"):
+		return monitorConmonRs, options
+	}
+	return "", options
+}
+
+// detect probes the monitor for its kind and supported options. It returns
+// an error if the monitor cannot be driven by Podman.
+func (m *conmonMonitor) detect() error {
+	m.once.Do(func() {
+		out, err := exec.Command(m.path, "--help").CombinedOutput()
+		if err != nil {
+			m.err = fmt.Errorf("probing container monitor %s: %w", m.path, err)
+			return
+		}
+		m.kind, m.options = parseMonitorHelp(string(out))
+		switch m.kind {
+		case monitorConmon:
+			logrus.Debugf("Using container monitor %s", m.path)
+			for _, option := range m.unsupportedOptions() {
+				logrus.Warnf("Container monitor %s does not support %s, the corresponding setting is ignored", m.path, option)
+			}
+		case monitorConmonRs:
+			m.err = fmt.Errorf("container monitor %s is conmon-rs, which needs its RPC client: %w", m.path, define.ErrNotImplemented)
+		default:
+			m.err = fmt.Errorf("container monitor %s is neither conmon compatible nor conmon-rs: %w", m.path, define.ErrInvalidArg)
+		}
+	})
+	return m.err
+}
+
+// unsupportedOptions returns the optional options the monitor does not
+// support, sorted.
+func (m *conmonMonitor) unsupportedOptions() []string {
+	var unsupported []string
+	for option := range optionalMonitorOptions {
+		if !m.options[option] {
+			unsupported = append(unsupported, option)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+// filterArgs removes the optional options the monitor does not support from
+// its arguments. detect() warns about them once, since this disables
+// settings such as log size limits.
+func (m *conmonMonitor) filterArgs(args []string) []string {
+	filtered := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		option, _, hasValue := strings.Cut(args[i], "=")
+		if passthroughMonitorOptions[option] && !hasValue && i+1 < len(args) {
+			filtered = append(filtered, args[i], args[i+1])
+			i++
+			continue
+		}
+		takesValue, optional := optionalMonitorOptions[option]
+		if !optional || m.options[option] {
+			filtered = append(filtered, args[i])
+			continue
+		}
+		if takesValue && !hasValue {
+			i++
+		}
+	}
+	return filtered
+}
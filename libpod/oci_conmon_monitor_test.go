@@ -0,0 +1,73 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMonitorHelp(t *testing.T) {
+	kind, options := parseMonitorHelp(`Usage:
+  conmon [OPTION?] - basic container runtime monitor
+
+Application Options:
+  --api-version                 Conmon API version to use
+  -c, --cid                     Container ID
+  --full-attach                 Don't truncate the path to the attach socket.
+  --syslog                      Log to syslog (use with cgroupfs cgroup manager)
+`)
+	assert.Equal(t, monitorConmon, kind)
+	assert.True(t, options["--full-attach"])
+	assert.False(t, options["--log-tag"])
+
+	kind, _ = parseMonitorHelp("Usage: conmonrs [OPTIONS] --runtime <RUNTIME>\n")
+	assert.Equal(t, monitorConmonRs, kind)
+
+	kind, _ = parseMonitorHelp("usage: true\n")
+	assert.Equal(t, monitorKind(""), kind)
+}
+
+func TestMonitorFilterArgs(t *testing.T) {
+	m := &conmonMonitor{options: map[string]bool{"--syslog": true}}
+	args := []string{"-c", "id", "--full-attach", "--log-tag", "tag", "--log-size-max=10", "--syslog", "--exit-command-arg", "--full-attach"}
+	assert.Equal(t, []string{"-c", "id", "--syslog", "--exit-command-arg", "--full-attach"}, m.filterArgs(args))
+}
+
+func TestMonitorUnsupportedOptions(t *testing.T) {
+	m := &conmonMonitor{options: map[string]bool{"--syslog": true, "--log-tag": true, "--persist-dir": true}}
+	assert.Equal(t, []string{"--exit-delay", "--full-attach", "--log-size-max", "--no-new-keyring"}, m.unsupportedOptions())
+}
+
+func TestMonitorPathForRuntime(t *testing.T) {
+	dir := t.TempDir()
+	conmon := filepath.Join(dir, "conmon")
+	assert.NoError(t, os.WriteFile(conmon, nil, 0o755))
+
+	cfg := &config.Config{}
+	cfg.Engine.ConmonPath.Set([]string{"/usr/bin/conmon", "ocijail:" + filepath.Join(dir, "missing"), "ocijail:" + conmon})
+
+	assert.Equal(t, conmon, monitorPathForRuntime("/usr/local/bin/ocijail", "/usr/bin/conmon", cfg))
+	assert.Equal(t, "/usr/bin/conmon", monitorPathForRuntime("crun", "/usr/bin/conmon", cfg))
+
+	// conmon-rs is never selected
+	cfg.Engine.ConmonPath.Set([]string{"/usr/bin/conmon"})
+	cfg.Engine.ConmonRsPath.Set([]string{"ocijail:" + conmon})
+	assert.Equal(t, "/usr/bin/conmon", monitorPathForRuntime("ocijail", "/usr/bin/conmon", cfg))
+}
+
+func TestCheckMonitorConfig(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Engine.ConmonRsPath.Set([]string{"/usr/bin/conmonrs", "/usr/libexec/podman/conmonrs"})
+	assert.NoError(t, checkMonitorConfig(cfg))
+
+	cfg.Engine.ConmonRsPath.Set([]string{"/usr/bin/conmonrs", "ocijail:/usr/bin/conmonrs"})
+	err := checkMonitorConfig(cfg)
+	assert.ErrorIs(t, err, define.ErrNotImplemented)
+	assert.ErrorContains(t, err, `"ocijail:/usr/bin/conmonrs"`)
+}
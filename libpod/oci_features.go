@@ -0,0 +1,65 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ociRuntimeFeatures is the part of the output of the features subcommand of
+// an OCI runtime which is used by libpod. See features.md in the runtime spec.
+type ociRuntimeFeatures struct {
+	OCIVersionMin string                   `json:"ociVersionMin,omitempty"`
+	OCIVersionMax string                   `json:"ociVersionMax,omitempty"`
+	Hooks         []string                 `json:"hooks,omitempty"`
+	MountOptions  []string                 `json:"mountOptions,omitempty"`
+	Linux         *ociRuntimeLinuxFeatures `json:"linux,omitempty"`
+	Annotations   map[string]string        `json:"annotations,omitempty"`
+}
+
+// ociRuntimeLinuxFeatures are the Linux specific features of an OCI runtime.
+// Runtimes for other platforms do not report them.
+type ociRuntimeLinuxFeatures struct {
+	Namespaces   []string `json:"namespaces,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// probeOCIRuntimeFeatures runs the features subcommand of the OCI runtime at
+// the given path. It returns nil if the runtime does not support it.
+func probeOCIRuntimeFeatures(path string) *ociRuntimeFeatures {
+	out, err := exec.Command(path, "features").Output()
+	if err != nil {
+		return nil
+	}
+	features, err := parseOCIRuntimeFeatures(out)
+	if err != nil {
+		return nil
+	}
+	return features
+}
+
+func parseOCIRuntimeFeatures(data []byte) (*ociRuntimeFeatures, error) {
+	features := new(ociRuntimeFeatures)
+	if err := json.Unmarshal(data, features); err != nil {
+		return nil, fmt.Errorf("parsing OCI runtime features: %w", err)
+	}
+	return features, nil
+}
+
+// enabled returns whether the runtime reports the named feature as enabled
+// with an annotation like org.opencontainers.runc.checkpoint.enabled, and
+// whether it reports the feature at all.
+func (f *ociRuntimeFeatures) enabled(name string) (enabled bool, known bool) {
+	if f == nil {
+		return false, false
+	}
+	suffix := "." + name + ".enabled"
+	for key, value := range f.Annotations {
+		if strings.HasSuffix(key, suffix) {
+			return value == "true", true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,68 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIRuntimeFeatures(t *testing.T) {
+	features, err := parseOCIRuntimeFeatures([]byte(`{
+		"ociVersionMin": "1.0.0",
+		"ociVersionMax": "1.2.0",
+		"linux": {"namespaces": ["pid", "network"]},
+		"annotations": {
+			"org.opencontainers.runc.checkpoint.enabled": "true",
+			"org.freebsd.ocijail.devices.enabled": "false"
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", features.OCIVersionMax)
+	assert.NotNil(t, features.Linux)
+
+	enabled, known := features.enabled("checkpoint")
+	assert.True(t, enabled)
+	assert.True(t, known)
+	enabled, known = features.enabled("devices")
+	assert.False(t, enabled)
+	assert.True(t, known)
+	_, known = features.enabled("rlimits")
+	assert.False(t, known)
+
+	var missing *ociRuntimeFeatures
+	_, known = missing.enabled("checkpoint")
+	assert.False(t, known)
+
+	_, err = parseOCIRuntimeFeatures([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSupportsCheckpointOption(t *testing.T) {
+	r := &ConmonOCIRuntime{}
+	r.featuresOnce.Do(func() {})
+	assert.True(t, r.SupportsCheckpointOption("file-locks"))
+
+	r.features = &ociRuntimeFeatures{Annotations: map[string]string{
+		"org.opencontainers.runc.checkpoint.enabled":            "true",
+		"org.opencontainers.runc.checkpoint.file-locks.enabled": "false",
+	}}
+	assert.False(t, r.SupportsCheckpointOption("file-locks"))
+	assert.True(t, r.SupportsCheckpointOption("tcp-close"))
+
+	opts := ContainerCheckpointOptions{TCPEstablished: true, TCPClose: true}
+	assert.Equal(t, []string{"tcp-established", "tcp-close"}, opts.runtimeOptions())
+	assert.Nil(t, ContainerCheckpointOptions{}.runtimeOptions())
+}
+
+func TestSupportsHook(t *testing.T) {
+	r := &ConmonOCIRuntime{}
+	r.featuresOnce.Do(func() {})
+	assert.Equal(t, runtimeRunsHooks, r.SupportsHook("prestart"))
+
+	r.features = &ociRuntimeFeatures{Hooks: []string{"createRuntime", "poststop"}}
+	assert.True(t, r.SupportsHook("createRuntime"))
+	assert.False(t, r.SupportsHook("prestart"))
+}
@@ -134,6 +134,11 @@ func (r *MissingRuntime) AttachResize(ctr *Container, newSize resize.TerminalSiz
 	return r.printError()
 }
 
+// ReopenContainerLog is not available as the runtime is missing
+func (r *MissingRuntime) ReopenContainerLog(ctr *Container) error {
+	return r.printError()
+}
+
 // ExecContainer is not available as the runtime is missing
 func (r *MissingRuntime) ExecContainer(ctr *Container, sessionID string, options *ExecOptions, streams *define.AttachStreams, newSize *resize.TerminalSize) (int, chan error, error) {
 	return -1, nil, r.printError()
@@ -199,6 +204,27 @@ func (r *MissingRuntime) SupportsKVM() bool {
 	return false
 }
 
+// SupportsCheckpointOption returns false as checkpointing requires a working
+// runtime
+func (r *MissingRuntime) SupportsCheckpointOption(option string) bool {
+	return false
+}
+
+// SupportsRlimits returns false as there is no runtime to create containers
+func (r *MissingRuntime) SupportsRlimits() bool {
+	return false
+}
+
+// SupportsDevices returns false as there is no runtime to create containers
+func (r *MissingRuntime) SupportsDevices() bool {
+	return false
+}
+
+// SupportsHook returns false as there is no runtime to create containers
+func (r *MissingRuntime) SupportsHook(stage string) bool {
+	return false
+}
+
 // AttachSocketPath does not work as there is no runtime to attach to.
 // (Theoretically we could follow ExitFilePath but there is no guarantee the
 // container is running and thus has an attach socket...)
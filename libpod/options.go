@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
 	"github.com/containers/podman/v5/pkg/namespaces"
+	lsignal "github.com/containers/podman/v5/pkg/signal"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage"
@@ -171,6 +174,25 @@ func WithCtrOCIRuntime(runtime string) CtrCreateOption {
 	}
 }
 
+// WithCtrRuntimeFlags sets additional flags passed to the OCI runtime whenever
+// it is invoked for the container. Flags may be given with or without their
+// leading "--".
+func WithCtrRuntimeFlags(runtimeFlags []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.RuntimeFlags = make([]string, 0, len(runtimeFlags))
+		for _, flag := range runtimeFlags {
+			if !strings.HasPrefix(flag, "-") {
+				flag = "--" + flag
+			}
+			ctr.config.RuntimeFlags = append(ctr.config.RuntimeFlags, flag)
+		}
+		return nil
+	}
+}
+
 // WithConmonPath specifies the path to the conmon binary which manages the
 // runtime.
 func WithConmonPath(path string) RuntimeOption {
@@ -545,6 +567,22 @@ func WithMaxLogSize(limit int64) CtrCreateOption {
 	}
 }
 
+// WithMaxLogFiles sets the number of log files kept when the container log is
+// rotated at its maximum size, including the current log file.
+func WithMaxLogFiles(count uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrRuntimeFinalized
+		}
+		if count == 0 {
+			return fmt.Errorf("the number of log files must be at least 1: %w", define.ErrInvalidArg)
+		}
+		ctr.config.LogMaxFiles = count
+
+		return nil
+	}
+}
+
 // WithShmDir sets the directory that should be mounted on /dev/shm.
 func WithShmDir(dir string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -784,6 +822,58 @@ func WithName(name string) CtrCreateOption {
 	}
 }
 
+// WithIOPriority sets the I/O priority class of the container.
+func WithIOPriority(class define.IOPriority) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if class.Nice < -20 || class.Nice > 20 {
+			return fmt.Errorf("nice value of I/O priority class %s must be between -20 and 20: %w", class.Class, define.ErrInvalidArg)
+		}
+		ctr.config.IOPriority = &class
+
+		return nil
+	}
+}
+
+// WithMemoryLock sets the amount of memory in bytes the container may lock.
+func WithMemoryLock(size int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if size < 0 {
+			return fmt.Errorf("memory lock cannot be negative: %w", define.ErrInvalidArg)
+		}
+		ctr.config.MemoryLock = size
+
+		return nil
+	}
+}
+
+// WithMemoryReclaimSignal sets the signal that will be sent to the container
+// when it exceeds its memory reservation.
+func WithMemoryReclaimSignal(signal syscall.Signal) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if signal == 0 {
+			return fmt.Errorf("memory reclaim signal cannot be 0: %w", define.ErrInvalidArg)
+		} else if signal > lsignal.SIGRTMAX {
+			return fmt.Errorf("memory reclaim signal cannot be greater than %d (SIGRTMAX): %w", lsignal.SIGRTMAX, define.ErrInvalidArg)
+		}
+
+		ctr.config.MemoryReclaimSignal = uint(signal)
+
+		return nil
+	}
+}
+
 // WithStopSignal sets the signal that will be sent to stop the container.
 func WithStopSignal(signal syscall.Signal) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -793,8 +883,8 @@ func WithStopSignal(signal syscall.Signal) CtrCreateOption {
 
 		if signal == 0 {
 			return fmt.Errorf("stop signal cannot be 0: %w", define.ErrInvalidArg)
-		} else if signal > 64 {
-			return fmt.Errorf("stop signal cannot be greater than 64 (SIGRTMAX): %w", define.ErrInvalidArg)
+		} else if signal > lsignal.SIGRTMAX {
+			return fmt.Errorf("stop signal cannot be greater than %d (SIGRTMAX): %w", lsignal.SIGRTMAX, define.ErrInvalidArg)
 		}
 
 		ctr.config.StopSignal = uint(signal)
@@ -1101,7 +1191,7 @@ func WithLogDriver(driver string) CtrCreateOption {
 		switch driver {
 		case "":
 			return fmt.Errorf("log driver must be set: %w", define.ErrInvalidArg)
-		case define.JournaldLogging, define.KubernetesLogging, define.JSONLogging, define.NoLogging, define.PassthroughLogging, define.PassthroughTTYLogging:
+		case define.JournaldLogging, define.KubernetesLogging, define.JSONLogging, define.NoLogging, define.PassthroughLogging, define.PassthroughTTYLogging, define.SyslogLogging:
 			break
 		default:
 			return fmt.Errorf("invalid log driver: %w", define.ErrInvalidArg)
@@ -1145,6 +1235,22 @@ func WithLogTag(tag string) CtrCreateOption {
 	}
 }
 
+// WithLogOptions sets options specific to the container's log driver.
+func WithLogOptions(options map[string]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.LogOptions = make(map[string]string, len(options))
+		for key, val := range options {
+			ctr.config.LogOptions[key] = val
+		}
+
+		return nil
+	}
+}
+
 // WithCgroupsMode disables the creation of Cgroups for the conmon process.
 func WithCgroupsMode(mode string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1214,6 +1320,42 @@ func WithDNS(dnsServers []string) CtrCreateOption {
 	}
 }
 
+// WithRoutes sets static routes to add to the container's network once it is
+// set up.
+func WithRoutes(routes []nettypes.Route) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.Routes = append(ctr.config.Routes, routes...)
+		return nil
+	}
+}
+
+// WithVnetDevfsRuleset sets the devfs ruleset of the vnet jail holding the
+// network of the container. Only used on FreeBSD.
+func WithVnetDevfsRuleset(ruleset int) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if err := checkDevfsRuleset(ruleset); err != nil {
+			return err
+		}
+		ctr.config.VnetDevfsRuleset = &ruleset
+		return nil
+	}
+}
+
+// checkDevfsRuleset returns an error if ruleset is not a valid devfs ruleset
+// number.
+func checkDevfsRuleset(ruleset int) error {
+	if ruleset < 0 || ruleset > 65535 {
+		return fmt.Errorf("devfs ruleset must be between 0 and 65535, got %d: %w", ruleset, define.ErrInvalidArg)
+	}
+	return nil
+}
+
 // WithDNSOption sets additional dns options for the container.
 func WithDNSOption(dnsOptions []string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1789,7 +1931,11 @@ func WithTimezone(path string) CtrCreateOption {
 		if ctr.valid {
 			return define.ErrCtrFinalized
 		}
-		if path != "local" {
+		if path == "local" {
+			if _, err := localTimezone(); err != nil {
+				return fmt.Errorf("finding local timezone: %w", err)
+			}
+		} else {
 			// validate the format of the timezone specified if it's not "local"
 			_, err := time.LoadLocation(path)
 			if err != nil {
@@ -1897,6 +2043,39 @@ func WithHostDevice(dev []specs.LinuxDevice) CtrCreateOption {
 	}
 }
 
+// WithHostUserGroups adds the groups of the host users to /etc/group.
+func WithHostUserGroups() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.HostUserGroups = true
+		return nil
+	}
+}
+
+// WithNoPasswdFile makes the container not add entries to /etc/passwd.
+func WithNoPasswdFile() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.NoPasswd = true
+		return nil
+	}
+}
+
+// WithNoGroupFile makes the container not add entries to /etc/group.
+func WithNoGroupFile() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.NoGroup = true
+		return nil
+	}
+}
+
 // WithSelectedPasswordManagement makes it so that the container either does or does not set up /etc/passwd or /etc/group
 func WithSelectedPasswordManagement(passwd *bool) CtrCreateOption {
 	return func(c *Container) error {
@@ -1992,6 +2171,26 @@ func WithPodExitPolicy(policy string) PodCreateOption {
 	}
 }
 
+// WithPodCPUPlacement sets a hint to choose the cpuset of the pod on
+// creation so that it shares as few CPUs as possible with other pods.
+func WithPodCPUPlacement(placement string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		switch placement {
+		case define.CPUPlacementDomain, define.CPUPlacementCPUs:
+		default:
+			return fmt.Errorf("invalid CPU placement %q, must be %q or %q: %w", placement, define.CPUPlacementDomain, define.CPUPlacementCPUs, define.ErrInvalidArg)
+		}
+
+		pod.config.CPUPlacement = placement
+
+		return nil
+	}
+}
+
 // WithPodRestartPolicy sets the restart policy of the pod.
 func WithPodRestartPolicy(policy string) PodCreateOption {
 	return func(pod *Pod) error {
@@ -2308,6 +2507,75 @@ func WithChrootDirs(dirs []string) CtrCreateOption {
 	}
 }
 
+// WithMetadataKeys selects the labels and annotations which are written to
+// the container's metadata file in /run.
+func WithMetadataKeys(keys []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, key := range keys {
+			if _, err := path.Match(key, ""); err != nil {
+				return fmt.Errorf("invalid metadata key %q: %w", key, define.ErrInvalidArg)
+			}
+		}
+		ctr.config.MetadataKeys = keys
+
+		return nil
+	}
+}
+
+// WithIdentity issues an identity certificate, valid for ttl, from the CA in
+// the given directory each time the container starts.
+func WithIdentity(caDir string, ttl time.Duration) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !filepath.IsAbs(caDir) {
+			return fmt.Errorf("identity CA directory %q must be an absolute path: %w", caDir, define.ErrInvalidArg)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("identity certificate validity cannot be negative: %w", define.ErrInvalidArg)
+		}
+		ctr.config.IdentityCA = caDir
+		ctr.config.IdentityTTL = ttl
+
+		return nil
+	}
+}
+
+// WithCollectCores indicates that core dumps of the container's processes
+// should be collected in a directory on the host.
+func WithCollectCores() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.CollectCores = true
+
+		return nil
+	}
+}
+
+// WithConsole gives the container a console, which is passed to the
+// container in addition to its standard streams and can be attached to at any
+// time.
+func WithConsole() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.Console = true
+
+		return nil
+	}
+}
+
 // WithPasswdEntry sets the entry to write to the /etc/passwd file.
 func WithPasswdEntry(passwdEntry string) CtrCreateOption {
 	return func(ctr *Container) error {
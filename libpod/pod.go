@@ -94,6 +94,10 @@ type PodConfig struct {
 
 	// ResourceLimits hold the pod level resource limits
 	ResourceLimits specs.LinuxResources
+
+	// CPUPlacement is the hint used to choose the pod's cpuset when it
+	// was created.
+	CPUPlacement string `json:"cpuPlacement,omitempty"`
 }
 
 // podState represents a pod's state
@@ -485,6 +489,32 @@ func (p *Pod) GetPodStats(previousContainerStats map[string]*define.ContainerSta
 	return newContainerStats, nil
 }
 
+// GetPodStatsTotal returns the stats of the pod as a whole, summed over its
+// running containers. Network traffic is counted once per network
+// namespace, so that containers sharing the network of the infra container
+// are not counted several times.
+func (p *Pod) GetPodStatsTotal(previousContainerStats map[string]*define.ContainerStats) (*define.ContainerStats, error) {
+	containerStats, err := p.GetPodStats(previousContainerStats)
+	if err != nil {
+		return nil, err
+	}
+	netOwners := make(map[string]string, len(containerStats))
+	for id := range containerStats {
+		ctr, err := p.runtime.state.Container(id)
+		if err != nil {
+			return nil, err
+		}
+		netOwners[id] = id
+		if ctr.config.NetNsCtr != "" {
+			netOwners[id] = ctr.config.NetNsCtr
+		}
+	}
+	total := sumContainerStats(containerStats, netOwners, p.MemoryLimit())
+	total.ContainerID = p.ID()
+	total.Name = p.Name()
+	return total, nil
+}
+
 // ProcessLabel returns the SELinux label associated with the pod
 func (p *Pod) ProcessLabel() (string, error) {
 	if !p.HasInfraContainer() {
@@ -673,12 +673,13 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		infraConfig = new(define.InspectPodInfraConfig)
 		infraConfig.HostNetwork = p.NetworkMode() == "host"
 		infraConfig.StaticIP = infra.config.ContainerNetworkConfig.StaticIP
-		infraConfig.NoManageResolvConf = infra.config.UseImageResolvConf
+		infraConfig.NoManageResolvConf = infra.useImageResolvConf()
 		infraConfig.NoManageHosts = infra.config.UseImageHosts
 		infraConfig.CPUPeriod = p.CPUPeriod()
 		infraConfig.CPUQuota = p.CPUQuota()
 		infraConfig.CPUSetCPUs = p.ResourceLim().CPU.Cpus
 		infraConfig.PidNS = p.NamespaceMode(specs.PIDNamespace)
+		infraConfig.IpcNS = p.ipcMode(infra)
 		infraConfig.UserNS = p.NamespaceMode(specs.UserNamespace)
 		infraConfig.UtsNS = p.NamespaceMode(specs.UTSNamespace)
 		namedVolumes, mounts := infra.SortUserVolumes(infra.config.Spec)
@@ -729,6 +730,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		Created:             p.CreatedTime(),
 		CreateCommand:       p.config.CreateCommand,
 		ExitPolicy:          string(p.config.ExitPolicy),
+		CPUPlacement:        p.config.CPUPlacement,
 		State:               podState,
 		Hostname:            p.config.Hostname,
 		Labels:              p.Labels(),
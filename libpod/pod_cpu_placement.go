@@ -0,0 +1,168 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/parsers"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+)
+
+// placePodCPUs sets the cpuset of a pod created with a CPU placement hint,
+// choosing CPUs which are used by as few other pods as possible.
+func (r *Runtime) placePodCPUs(pod *Pod) error {
+	if pod.config.CPUPlacement == "" {
+		return nil
+	}
+	cpu := pod.config.ResourceLimits.CPU
+	if cpu != nil && cpu.Cpus != "" {
+		return fmt.Errorf("cannot use CPU placement %q together with a cpuset: %w", pod.config.CPUPlacement, define.ErrInvalidArg)
+	}
+
+	domains, err := hostCPUDomains()
+	if err != nil {
+		return fmt.Errorf("reading CPU topology: %w", err)
+	}
+	usage, err := r.podCPUUsage()
+	if err != nil {
+		return err
+	}
+
+	var cpus []int
+	switch pod.config.CPUPlacement {
+	case define.CPUPlacementDomain:
+		cpus = placeOnDomain(domains, usage)
+	case define.CPUPlacementCPUs:
+		count := 1
+		if cpu != nil && cpu.Quota != nil && cpu.Period != nil && *cpu.Period > 0 {
+			count = int(math.Ceil(float64(*cpu.Quota) / float64(*cpu.Period)))
+		}
+		cpus = placeOnCPUs(domains, usage, count)
+	default:
+		return fmt.Errorf("unknown CPU placement %q: %w", pod.config.CPUPlacement, define.ErrInvalidArg)
+	}
+
+	if pod.config.ResourceLimits.CPU == nil {
+		pod.config.ResourceLimits.CPU = new(specs.LinuxCPU)
+	}
+	pod.config.ResourceLimits.CPU.Cpus = formatCPUList(cpus)
+	logrus.Debugf("Placing pod %s on CPUs %s", pod.ID(), pod.config.ResourceLimits.CPU.Cpus)
+	return nil
+}
+
+// podCPUUsage returns the number of existing pods with a cpuset including
+// each CPU.
+func (r *Runtime) podCPUUsage() (map[int]int, error) {
+	pods, err := r.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[int]int)
+	for _, p := range pods {
+		cpu := p.config.ResourceLimits.CPU
+		if cpu == nil || cpu.Cpus == "" {
+			continue
+		}
+		cpus, err := parsers.ParseUintList(cpu.Cpus)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid cpuset %q of pod %s: %v", cpu.Cpus, p.ID(), err)
+			continue
+		}
+		for c := range cpus {
+			usage[c]++
+		}
+	}
+	return usage, nil
+}
+
+// placeOnDomain returns the CPUs of the NUMA domain whose CPUs are least
+// used by other pods.
+func placeOnDomain(domains [][]int, usage map[int]int) []int {
+	best, bestLoad := -1, 0
+	for i, domain := range domains {
+		if len(domain) == 0 {
+			continue
+		}
+		load := 0
+		for _, c := range domain {
+			load += usage[c]
+		}
+		// Compare the average use of the CPUs so that domains of
+		// different sizes are treated alike.
+		if best < 0 || load*len(domains[best]) < bestLoad*len(domain) {
+			best, bestLoad = i, load
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	return domains[best]
+}
+
+// placeOnCPUs returns count CPUs which are least used by other pods. The
+// CPUs are taken from a single NUMA domain if there is one with enough CPUs.
+func placeOnCPUs(domains [][]int, usage map[int]int, count int) []int {
+	leastUsed := func(cpus []int) ([]int, int) {
+		sorted := append([]int{}, cpus...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if usage[sorted[i]] != usage[sorted[j]] {
+				return usage[sorted[i]] < usage[sorted[j]]
+			}
+			return sorted[i] < sorted[j]
+		})
+		if len(sorted) > count {
+			sorted = sorted[:count]
+		}
+		load := 0
+		for _, c := range sorted {
+			load += usage[c]
+		}
+		sort.Ints(sorted)
+		return sorted, load
+	}
+
+	var (
+		best     []int
+		bestLoad int
+		all      []int
+	)
+	for _, domain := range domains {
+		all = append(all, domain...)
+		if len(domain) < count {
+			continue
+		}
+		cpus, load := leastUsed(domain)
+		if best == nil || load < bestLoad {
+			best, bestLoad = cpus, load
+		}
+	}
+	if best == nil {
+		best, _ = leastUsed(all)
+	}
+	return best
+}
+
+// formatCPUList formats a sorted list of CPUs as a cpuset list, e.g. 0-3,8.
+func formatCPUList(cpus []int) string {
+	ranges := []string{}
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(cpus[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
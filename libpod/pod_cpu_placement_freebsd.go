@@ -0,0 +1,32 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// hostCPUDomains returns the CPUs of each memory domain of the host.
+func hostCPUDomains() ([][]int, error) {
+	ncpu, err := unix.SysctlUint32("hw.ncpu")
+	if err != nil {
+		return nil, err
+	}
+	ndomain, err := unix.SysctlUint32("vm.ndomains")
+	if err != nil || ndomain == 0 {
+		ndomain = 1
+	}
+	domains := make([][]int, ndomain)
+	for c := 0; c < int(ncpu); c++ {
+		// Kernels without NUMA support do not report the domain of
+		// a CPU, which is then in the only domain.
+		domain, err := unix.SysctlUint32(fmt.Sprintf("dev.cpu.%d.%%domain", c))
+		if err != nil || domain >= ndomain {
+			domain = 0
+		}
+		domains[domain] = append(domains[domain], c)
+	}
+	return domains, nil
+}
@@ -0,0 +1,47 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/storage/pkg/parsers"
+)
+
+// hostCPUDomains returns the online CPUs of each NUMA node of the host.
+func hostCPUDomains() ([][]int, error) {
+	nodes, err := filepath.Glob("/sys/devices/system/node/node[0-9]*/cpulist")
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		// Without NUMA support, all CPUs are in a single domain.
+		nodes = []string{"/sys/devices/system/cpu/online"}
+	}
+	domains := make([][]int, 0, len(nodes))
+	for _, node := range nodes {
+		data, err := os.ReadFile(node)
+		if err != nil {
+			return nil, err
+		}
+		list := strings.TrimSpace(string(data))
+		if list == "" {
+			// Memory-only nodes have no CPUs.
+			continue
+		}
+		set, err := parsers.ParseUintList(list)
+		if err != nil {
+			return nil, err
+		}
+		cpus := make([]int, 0, len(set))
+		for c := range set {
+			cpus = append(cpus, c)
+		}
+		sort.Ints(cpus)
+		domains = append(domains, cpus)
+	}
+	return domains, nil
+}
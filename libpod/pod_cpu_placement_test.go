@@ -0,0 +1,37 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceOnDomain(t *testing.T) {
+	domains := [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}}
+
+	assert.Equal(t, []int{0, 1, 2, 3}, placeOnDomain(domains, map[int]int{}))
+	assert.Equal(t, []int{4, 5, 6, 7}, placeOnDomain(domains, map[int]int{0: 1, 1: 1, 2: 1, 3: 1}))
+	// The domain with the lower average use wins.
+	assert.Equal(t, []int{0, 1, 2, 3}, placeOnDomain(domains, map[int]int{0: 1, 4: 1, 5: 1}))
+	assert.Nil(t, placeOnDomain(nil, map[int]int{}))
+}
+
+func TestPlaceOnCPUs(t *testing.T) {
+	domains := [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}}
+
+	assert.Equal(t, []int{0, 1}, placeOnCPUs(domains, map[int]int{}, 2))
+	assert.Equal(t, []int{2, 3}, placeOnCPUs(domains, map[int]int{0: 1, 1: 1}, 2))
+	// Stay within a single domain if possible.
+	assert.Equal(t, []int{4, 5, 6}, placeOnCPUs(domains, map[int]int{0: 1, 1: 1, 7: 1}, 3))
+	// Span domains if no domain has enough CPUs.
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, placeOnCPUs(domains, map[int]int{6: 1, 7: 1}, 6))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, placeOnCPUs(domains, map[int]int{}, 10))
+}
+
+func TestFormatCPUList(t *testing.T) {
+	assert.Equal(t, "", formatCPUList(nil))
+	assert.Equal(t, "3", formatCPUList([]int{3}))
+	assert.Equal(t, "0-3,8,10-11", formatCPUList([]int{0, 1, 2, 3, 8, 10, 11}))
+}
@@ -5,3 +5,13 @@ package libpod
 func (p *Pod) platformRefresh() error {
 	return nil
 }
+
+// ipcMode returns the SysV IPC mode of the pod. The infra container's jail, or
+// the vnet jail it runs in, owns the SysV IPC objects of the pod when it has
+// its own network. Otherwise SysV IPC is disabled in the pod.
+func (p *Pod) ipcMode(infra *Container) string {
+	if infra.config.CreateNetNS {
+		return "private"
+	}
+	return "none"
+}
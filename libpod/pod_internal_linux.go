@@ -9,6 +9,7 @@ import (
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/rootless"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,3 +35,8 @@ func (p *Pod) platformRefresh() error {
 	}
 	return nil
 }
+
+// ipcMode returns the IPC namespace mode of the pod's infra container.
+func (p *Pod) ipcMode(infra *Container) string {
+	return p.NamespaceMode(specs.IPCNamespace)
+}
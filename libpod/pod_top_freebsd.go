@@ -45,28 +45,17 @@ func (p *Pod) GetPodPidInformation(descriptors []string) ([]string, error) {
 		}
 	}
 
-	// Also support comma-separated input.
-	psDescriptors := []string{}
-	for _, d := range descriptors {
-		for _, s := range strings.Split(d, ",") {
-			if s != "" {
-				psDescriptors = append(psDescriptors, s)
-			}
-		}
-	}
-
 	// For consistency with pod_top_linux.go, only allow descriptor names
-	for _, d := range psDescriptors {
-		if _, ok := isDescriptor[d]; !ok {
-			return nil, fmt.Errorf("unknown descriptor: %s", d)
-		}
+	keywords, err := psKeywordList(descriptors)
+	if err != nil {
+		return nil, err
 	}
 
 	args := []string{
 		"-J",
 		strings.Join(jailNames, ","),
 		"-ao",
-		strings.Join(psDescriptors, ","),
+		keywords,
 	}
 
 	output, err := execPS(args)
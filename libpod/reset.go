@@ -153,6 +153,17 @@ func (r *Runtime) Reset(ctx context.Context) error {
 		}
 	}
 
+	// Clean up whatever crashed containers left behind on the host.
+	orphans, err := r.PruneOrphans(false)
+	if err != nil {
+		logrus.Errorf("Removing orphaned container resources: %v", err)
+	}
+	for _, o := range orphans {
+		if o.Err != nil {
+			logrus.Errorf("Removing orphaned %s %s: %v", o.Kind, o.Name, o.Err)
+		}
+	}
+
 	if err := r.stopPauseProcess(); err != nil {
 		logrus.Errorf("Stopping pause process: %v", err)
 	}
@@ -185,17 +196,17 @@ func (r *Runtime) Reset(ctx context.Context) error {
 	}
 
 	// remove all networks
-	nets, err := r.network.NetworkList()
+	nets, err := r.Network().NetworkList()
 	if err != nil {
 		return err
 	}
 	for _, net := range nets {
 		// do not delete the default network
-		if net.Name == r.network.DefaultNetworkName() {
+		if net.Name == r.Network().DefaultNetworkName() {
 			continue
 		}
 		// ignore not exists errors because of the TOCTOU problem
-		if err := r.network.NetworkRemove(net.Name); err != nil && !errors.Is(err, types.ErrNoSuchNetwork) {
+		if err := r.Network().NetworkRemove(net.Name); err != nil && !errors.Is(err, types.ErrNoSuchNetwork) {
 			logrus.Errorf("Removing network %s: %v", net.Name, err)
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"syscall"
@@ -67,14 +68,17 @@ type Runtime struct {
 	storageConfig storage.StoreOptions
 	storageSet    storageSet
 
-	state                  State
-	store                  storage.Store
-	storageService         *storageService
-	imageContext           *types.SystemContext
-	defaultOCIRuntime      OCIRuntime
-	ociRuntimes            map[string]OCIRuntime
-	runtimeFlags           []string
-	network                nettypes.ContainerNetwork
+	state             State
+	store             storage.Store
+	storageService    *storageService
+	imageContext      *types.SystemContext
+	defaultOCIRuntime OCIRuntime
+	ociRuntimes       map[string]OCIRuntime
+	runtimeFlags      []string
+	network           nettypes.ContainerNetwork
+	// networkLock guards network, which is replaced when the
+	// configuration is reloaded while API handlers use it.
+	networkLock            sync.RWMutex
 	conmonPath             string
 	libimageRuntime        *libimage.Runtime
 	libimageEventsShutdown chan bool
@@ -491,6 +495,10 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	}
 	runtime.imageContext.SignaturePolicyPath = runtime.config.Engine.SignaturePolicyPath
 
+	if err := checkMonitorConfig(runtime.config); err != nil {
+		return err
+	}
+
 	// Get us at least one working OCI runtime.
 	runtime.ociRuntimes = make(map[string]OCIRuntime)
 
@@ -784,6 +792,9 @@ func (r *Runtime) Shutdown(force bool) error {
 
 	// Shutdown all containers if --force is given
 	if force {
+		if err := r.recordRunningContainers(); err != nil {
+			logrus.Errorf("Recording running containers: %v", err)
+		}
 		ctrs, err := r.state.AllContainers(false)
 		if err != nil {
 			logrus.Errorf("Retrieving containers from database: %v", err)
@@ -891,6 +902,19 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 		}
 	}
 
+	// Network jails of containers which were running when Podman or the
+	// host crashed are kept alive by their persist flag and are no longer
+	// known to any container after the refresh.
+	orphans, err := r.PruneNetworkOrphans()
+	if err != nil {
+		logrus.Errorf("Removing orphaned container networks: %v", err)
+	}
+	for _, o := range orphans {
+		if o.Err != nil {
+			logrus.Errorf("Removing orphaned %s %s: %v", o.Kind, o.Name, o.Err)
+		}
+	}
+
 	// Create a file indicating the runtime is alive and ready
 	file, err := os.OpenFile(alivePath, os.O_RDONLY|os.O_CREATE, 0644)
 	if err != nil {
@@ -1096,17 +1120,58 @@ func (r *Runtime) Reload() error {
 	return nil
 }
 
-// reloadContainersConf reloads the containers.conf
+// reloadContainersConf reloads the containers.conf. Settings which are read
+// when a container is created or started, such as the DNS servers, the
+// default annotations and the FreeBSD jail profiles, take effect through the
+// new config. The network interface is recreated first, so that the runtime
+// keeps its old config and network interface when that fails.
 func (r *Runtime) reloadContainersConf() error {
 	config, err := config.Reload()
 	if err != nil {
 		return err
 	}
+	netInterface, err := r.reloadNetworkInterface(config)
+	if err != nil {
+		return err
+	}
 	r.config = config
+	if netInterface != nil {
+		r.networkLock.Lock()
+		r.network = netInterface
+		r.networkLock.Unlock()
+		logrus.Infof("Applied new network configuration, default network is %q", config.Network.DefaultNetwork)
+	}
 	logrus.Infof("Applied new containers configuration: %v", config)
 	return nil
 }
 
+// reloadNetworkInterface returns a new network interface for newConfig if the
+// [network] section of containers.conf changed, so that new containers pick up
+// the new default network and subnet settings, or nil if it did not change.
+// DNS settings are read from the runtime config when a container is started
+// and need no special handling. Switching the network backend requires a
+// restart and is ignored here.
+func (r *Runtime) reloadNetworkInterface(newConfig *config.Config) (nettypes.ContainerNetwork, error) {
+	// The network interface is only set up when we are in the userns.
+	if r.Network() == nil {
+		return nil, nil
+	}
+	oldConfig := r.config
+	newBackend := newConfig.Network.NetworkBackend
+	if newBackend != "" && newBackend != oldConfig.Network.NetworkBackend {
+		logrus.Warnf("Changing the network backend from %q to %q requires a restart, ignoring", oldConfig.Network.NetworkBackend, newBackend)
+	}
+	newConfig.Network.NetworkBackend = oldConfig.Network.NetworkBackend
+	if reflect.DeepEqual(oldConfig.Network, newConfig.Network) {
+		return nil, nil
+	}
+	_, netInterface, err := network.NetworkBackend(r.store, newConfig, r.syslog)
+	if err != nil {
+		return nil, fmt.Errorf("reloading network configuration: %w", err)
+	}
+	return netInterface, nil
+}
+
 // reloadStorageConf reloads the storage.conf
 func (r *Runtime) reloadStorageConf() error {
 	configFile, err := storage.DefaultConfigFile()
@@ -1188,6 +1253,8 @@ func (r *Runtime) getRuntimeAliveLock() (*lockfile.LockFile, error) {
 
 // Network returns the network interface which is used by the runtime
 func (r *Runtime) Network() nettypes.ContainerNetwork {
+	r.networkLock.RLock()
+	defer r.networkLock.RUnlock()
 	return r.network
 }
 
@@ -111,6 +111,46 @@ func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config
 	return r.setupContainer(ctx, ctr)
 }
 
+// RecreateContainer replaces the given container with a new one using the
+// specified image for its root filesystem. The new container keeps the ID, the
+// name, the volumes and the configuration of the old one and is started if the
+// old one was running.
+func (r *Runtime) RecreateContainer(ctx context.Context, ctr *Container, imageID string) (*Container, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	config := ctr.ConfigWithNetworks()
+	if config == nil {
+		return nil, fmt.Errorf("copying configuration of container %s: %w", ctr.ID(), define.ErrInternal)
+	}
+	rSpec := ctr.Spec()
+	if rSpec == nil {
+		return nil, fmt.Errorf("copying spec of container %s: %w", ctr.ID(), define.ErrInternal)
+	}
+	state, err := ctr.State()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := ctr.StopTimeout()
+	if err := r.RemoveContainer(ctx, ctr, true, false, &timeout); err != nil {
+		return nil, fmt.Errorf("removing container %s: %w", ctr.ID(), err)
+	}
+
+	config.RootfsImageID = imageID
+	newCtr, err := r.RestoreContainer(ctx, rSpec, config)
+	if err != nil {
+		return nil, fmt.Errorf("recreating container %s: %w", config.ID, err)
+	}
+	if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+		if err := newCtr.Start(ctx, true); err != nil {
+			return newCtr, fmt.Errorf("starting recreated container %s: %w", newCtr.ID(), err)
+		}
+	}
+	return newCtr, nil
+}
+
 // RenameContainer renames the given container.
 // Returns a copy of the container that has been renamed if successful.
 func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName string) (*Container, error) {
@@ -140,6 +180,12 @@ func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName s
 	// the config.
 	oldName := ctr.config.Name
 	ctr.config.Name = newName
+	// A hostname which was set to the name of the container follows
+	// the name.
+	renameHostname := ctr.config.Spec.Hostname == oldName
+	if renameHostname {
+		setConfigHostname(ctr.config, newName)
+	}
 
 	// Step 2: rewrite the old container's config in the DB.
 	if err := r.state.SafeRewriteContainerConfig(ctr, oldName, ctr.config.Name, ctr.config); err != nil {
@@ -147,6 +193,9 @@ func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName s
 		// Set config back to the old name so reflect what is actually
 		// present in the DB.
 		ctr.config.Name = oldName
+		if renameHostname {
+			setConfigHostname(ctr.config, oldName)
+		}
 		return nil, fmt.Errorf("renaming container %s: %w", ctr.ID(), err)
 	}
 
@@ -160,6 +209,12 @@ func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName s
 		return nil, err
 	}
 
+	if renameHostname && ctr.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := ctr.updateRunningHostname(); err != nil {
+			return nil, err
+		}
+	}
+
 	ctr.newContainerEvent(events.Rename)
 	return ctr, nil
 }
@@ -348,6 +403,14 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 	}
 
+	// Check that the runtime can apply the spec
+	if ctr.config.Spec.Process != nil && len(ctr.config.Spec.Process.Rlimits) > 0 && !ctr.ociRuntime.SupportsRlimits() {
+		return nil, fmt.Errorf("requested OCI runtime %s does not support resource limits: %w", ctr.ociRuntime.Name(), define.ErrInvalidArg)
+	}
+	if ctr.config.Spec.Linux != nil && len(ctr.config.Spec.Linux.Devices) > 0 && !ctr.ociRuntime.SupportsDevices() {
+		return nil, fmt.Errorf("requested OCI runtime %s does not support adding devices: %w", ctr.ociRuntime.Name(), define.ErrInvalidArg)
+	}
+
 	var pod *Pod
 	if ctr.config.Pod != "" {
 		// Get the pod from state
@@ -457,12 +520,19 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 	}()
 
+	if err := ctr.expandMountTemplates(); err != nil {
+		return nil, err
+	}
+
 	ctr.config.SecretsPath = filepath.Join(ctr.config.StaticDir, "secrets")
 	err = os.MkdirAll(ctr.config.SecretsPath, 0755)
 	if err != nil {
 		return nil, err
 	}
 	for _, secr := range ctr.config.Secrets {
+		if secr.Ephemeral {
+			continue
+		}
 		err = ctr.extractSecretToCtrStorage(secr)
 		if err != nil {
 			return nil, err
@@ -553,11 +623,27 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 	switch ctr.config.LogDriver {
 	case define.NoLogging, define.PassthroughLogging, define.JournaldLogging:
 		break
+	case define.SyslogLogging:
+		if _, _, _, err := parseSyslogOptions(ctr.config.LogOptions); err != nil {
+			return nil, err
+		}
+		if ctr.config.LogPath == "" {
+			ctr.config.LogPath = filepath.Join(ctr.config.StaticDir, "ctr.log")
+		}
 	default:
 		if ctr.config.LogPath == "" {
 			ctr.config.LogPath = filepath.Join(ctr.config.StaticDir, "ctr.log")
 		}
 	}
+	if ctr.config.LogMaxFiles > 1 {
+		switch ctr.config.LogDriver {
+		case define.NoLogging, define.PassthroughLogging, define.PassthroughTTYLogging, define.JournaldLogging:
+			return nil, fmt.Errorf("log option max-file is not supported by the %s log driver: %w", ctr.config.LogDriver, define.ErrInvalidArg)
+		}
+		if ctr.logSizeMax() <= 0 {
+			return nil, fmt.Errorf("log option max-file requires a maximum log size: %w", define.ErrInvalidArg)
+		}
+	}
 
 	if useDevShm && !MountExists(ctr.config.Spec.Mounts, "/dev/shm") && ctr.config.ShmDir == "" && !ctr.config.NoShm {
 		ctr.config.ShmDir = filepath.Join(ctr.bundlePath(), "shm")
@@ -1011,6 +1097,12 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 	}
 	removedCtrs[c.ID()] = nil
 
+	// Keep the record of the container's jail if anything failed, so that
+	// system prune --orphans can remove what is left of it.
+	if retErr == nil {
+		r.forgetJail(c.ID())
+	}
+
 	// Deallocate the container's lock
 	if err := c.lock.Free(); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		reportErrorf("freeing lock for container %s: %w", c.ID(), err)
@@ -1275,6 +1367,108 @@ func (r *Runtime) GetAllContainers() ([]*Container, error) {
 	return r.state.AllContainers(false)
 }
 
+// GetAllContainersWithState retrieves all the containers matching all of the
+// given filters, with their states loaded from the database in a single
+// transaction. The states are brought up to date without syncing every
+// container with the database: only containers which have exited since their
+// state was saved are synced, and the jail IDs of all the containers are
+// looked up together. The filters are called on batched containers, so they
+// do not sync the containers again.
+// As for any state read without holding the container lock, the states may be
+// outdated by the time they are used, which is acceptable for listing
+// containers.
+func (r *Runtime) GetAllContainersWithState(filters ...ContainerFilter) ([]*Container, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]*Container, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		exited, err := ctr.hasExitFile()
+		if err != nil {
+			return nil, err
+		}
+		if exited {
+			// The exit file must be handled with the lock held
+			// and the result saved, so do a full sync.
+			ctr.lock.Lock()
+			err = ctr.syncContainer()
+			ctr.lock.Unlock()
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					continue
+				}
+				return nil, err
+			}
+		}
+		current = append(current, ctr)
+	}
+	r.syncAllJailIDs(current)
+
+	ctrsFiltered := make([]*Container, 0, len(current))
+	for _, ctr := range current {
+		include := true
+		if len(filters) > 0 {
+			if err := ctr.Batch(func(c *Container) error {
+				for _, filter := range filters {
+					include = include && filter(c)
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if include {
+			ctrsFiltered = append(ctrsFiltered, ctr)
+		}
+	}
+
+	return ctrsFiltered, nil
+}
+
+// RecordRunningContainers records which containers are running or paused, so
+// that exactly these containers can be started again after the host was
+// rebooted. It must be called before the containers are stopped for a
+// shutdown of the host.
+func (r *Runtime) RecordRunningContainers() error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.recordRunningContainers()
+}
+
+func (r *Runtime) recordRunningContainers() error {
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		if err := ctr.recordRunning(); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return fmt.Errorf("recording state of container %s: %w", ctr.ID(), err)
+		}
+	}
+	return nil
+}
+
+// GetPreviouslyRunningContainers returns the containers which were running
+// when RecordRunningContainers was last called and have not been started
+// since.
+func (r *Runtime) GetPreviouslyRunningContainers() ([]*Container, error) {
+	previouslyRunning := func(c *Container) bool {
+		running, _ := c.PreviouslyRunning()
+		return running
+	}
+	return r.GetContainers(false, previouslyRunning)
+}
+
 // GetRunningContainers is a helper function for GetContainers
 func (r *Runtime) GetRunningContainers() ([]*Container, error) {
 	running := func(c *Container) bool {
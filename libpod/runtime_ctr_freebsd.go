@@ -2,6 +2,56 @@
 
 package libpod
 
+import (
+	"fmt"
+)
+
 const (
 	useDevShm = false
 )
+
+// syncAllJailIDs updates the jail IDs of the given containers like
+// syncJailIDs, but lists the jails once instead of looking up each of them.
+// Containers sharing the network of another container are resolved using the
+// given containers where possible, instead of loading them from the database.
+func (r *Runtime) syncAllJailIDs(ctrs []*Container) {
+	byID := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		byID[ctr.ID()] = ctr
+	}
+
+	var (
+		jids    map[string]int
+		listErr error
+	)
+	lookup := func(name string) (int, error) {
+		if jids == nil && listErr == nil {
+			jids, listErr = allJailIDs()
+		}
+		if listErr != nil {
+			return 0, listErr
+		}
+		jid, ok := jids[name]
+		if !ok {
+			return 0, fmt.Errorf("looking up jail %s: no such jail", name)
+		}
+		return jid, nil
+	}
+
+	for _, ctr := range ctrs {
+		c := ctr
+		netNS := func() (string, error) {
+			dep := c
+			for dep.state.NetNS == "" && dep.config.NetNsCtr != "" {
+				next, ok := byID[dep.config.NetNsCtr]
+				if !ok {
+					netNS, _, err := getContainerNetNS(dep)
+					return netNS, err
+				}
+				dep = next
+			}
+			return dep.state.NetNS, nil
+		}
+		ctr.updateJailIDs(netNS, lookup)
+	}
+}
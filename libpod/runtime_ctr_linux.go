@@ -5,3 +5,6 @@ package libpod
 const (
 	useDevShm = true
 )
+
+// syncAllJailIDs does nothing on Linux, containers do not run in jails.
+func (r *Runtime) syncAllJailIDs(ctrs []*Container) {}
@@ -121,7 +121,7 @@ func (r *Runtime) Build(ctx context.Context, options buildahDefine.BuildOptions,
 		options.Runtime = r.GetOCIRuntimePath()
 	}
 	// share the network interface between podman and buildah
-	options.NetworkInterface = r.network
+	options.NetworkInterface = r.Network()
 	id, ref, err := imagebuildah.BuildDockerfiles(ctx, r.store, options, dockerfiles...)
 	// Write event for build completion
 	r.newImageBuildCompleteEvent(id)
@@ -0,0 +1,140 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OrphanJail is a jail named after a container which no longer
+	// exists.
+	OrphanJail = "jail"
+	// OrphanVnetJail is a vnet jail which is not used by any container.
+	OrphanVnetJail = "vnet jail"
+	// OrphanPFAnchor is a pf anchor holding the port forwarding rules of
+	// a container which no longer exists.
+	OrphanPFAnchor = "pf anchor"
+	// OrphanRuntimeDir is a conmon exit file or persist directory of a
+	// container which no longer exists.
+	OrphanRuntimeDir = "runtime directory"
+)
+
+// Orphan is a host resource left behind by a container which no longer exists
+// in the database, e.g. after a crash of Podman or of the host.
+type Orphan struct {
+	// Kind is the kind of the resource, one of the Orphan constants.
+	Kind string
+	// Name is the name or path of the resource.
+	Name string
+	// Err is set if the resource could not be removed.
+	Err error
+
+	// record is the recorded jail the resource belongs to, see
+	// recordJail.
+	record string
+	remove func() error
+}
+
+// PruneOrphans finds the host resources which belong to containers no longer
+// known to Podman and removes them, unless dryRun is set. Errors removing a
+// single resource are set in the returned orphan.
+func (r *Runtime) PruneOrphans(dryRun bool) ([]*Orphan, error) {
+	return r.pruneOrphans(dryRun, func(*Orphan) bool { return true })
+}
+
+// PruneNetworkOrphans is like PruneOrphans but only removes the vnet jails and
+// pf anchors left behind by containers which did not clean up their network,
+// e.g. because Podman crashed.
+func (r *Runtime) PruneNetworkOrphans() ([]*Orphan, error) {
+	return r.pruneOrphans(false, func(o *Orphan) bool {
+		return o.Kind == OrphanVnetJail || o.Kind == OrphanPFAnchor
+	})
+}
+
+func (r *Runtime) pruneOrphans(dryRun bool, match func(*Orphan) bool) ([]*Orphan, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	found, stale, err := r.findOrphans()
+	if err != nil {
+		return nil, err
+	}
+	orphans := make([]*Orphan, 0, len(found))
+	for _, o := range found {
+		if match(o) {
+			orphans = append(orphans, o)
+		}
+	}
+	if dryRun {
+		return orphans, nil
+	}
+	for _, o := range orphans {
+		o.Err = o.remove()
+		if o.Kind == OrphanVnetJail {
+			r.newNetworkJailCleanupEvent(o.Name, o.Err)
+		}
+	}
+
+	// Forget the jails which have nothing left on the host.
+	left := make(map[string]bool)
+	for _, o := range found {
+		if !match(o) || o.Err != nil {
+			left[o.record] = true
+		}
+	}
+	for _, o := range orphans {
+		if !left[o.record] {
+			r.forgetJail(o.record)
+		}
+	}
+	for _, name := range stale {
+		r.forgetJail(name)
+	}
+	return orphans, nil
+}
+
+// syncedContainers returns all containers with their state synced under their
+// lock, so that a container which is being started or stopped is seen once
+// that is done rather than with a network jail which is about to be recorded
+// or released. Containers removed in the meantime are left out.
+func (r *Runtime) syncedContainers() ([]*Container, error) {
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return nil, err
+	}
+	synced := make([]*Container, 0, len(ctrs))
+	for _, c := range ctrs {
+		c.lock.Lock()
+		err := c.syncContainer()
+		c.lock.Unlock()
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		synced = append(synced, c)
+	}
+	return synced, nil
+}
+
+// newNetworkJailCleanupEvent creates a network event for the removal of a vnet
+// jail which was not used by any container. If err is not nil, it is recorded
+// in the event.
+func (r *Runtime) newNetworkJailCleanupEvent(jailName string, err error) {
+	e := events.NewEvent(events.NetworkJailCleanup)
+	e.Type = events.Network
+	e.Attributes = map[string]string{events.NetworkJailAttribute: jailName}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := r.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write network event: %q", err)
+	}
+}
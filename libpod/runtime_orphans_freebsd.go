@@ -0,0 +1,325 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/containers/buildah/pkg/jail"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// vnetJailRegexp matches the names createNetNS generates for vnet jails.
+var vnetJailRegexp = regexp.MustCompile(`^vnet-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// jailRecordDir returns the directory recording the vnet jails and container
+// jails created by this store. Other stores on the same host and jails not
+// created by Podman are never treated as orphans.
+func (r *Runtime) jailRecordDir() string {
+	return filepath.Join(r.config.Engine.StaticDir, "jails")
+}
+
+// recordJail records that the jail with the given name, a vnet jail or a
+// container ID, was created by this store.
+func (r *Runtime) recordJail(name string) {
+	dir := r.jailRecordDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		logrus.Errorf("Recording jail %s: %v", name, err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+		logrus.Errorf("Recording jail %s: %v", name, err)
+	}
+}
+
+// forgetJail removes the record of a jail which no longer exists.
+func (r *Runtime) forgetJail(name string) {
+	if err := os.Remove(filepath.Join(r.jailRecordDir(), name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Errorf("Removing record of jail %s: %v", name, err)
+	}
+}
+
+// recordedJails returns the names of the jails recorded by recordJail.
+func (r *Runtime) recordedJails() (map[string]bool, error) {
+	entries, err := os.ReadDir(r.jailRecordDir())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading jail records: %w", err)
+	}
+	recorded := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		recorded[entry.Name()] = true
+	}
+	return recorded, nil
+}
+
+// findOrphans returns the jails, pf anchors and conmon runtime directories
+// left behind by containers of this store which no longer exist, and the
+// records of jails which are gone along with everything else of their
+// container. The host resources are listed before the containers, so that
+// everything listed was created by a container which is in the list unless it
+// no longer exists.
+func (r *Runtime) findOrphans() ([]*Orphan, []string, error) {
+	recorded, err := r.recordedJails()
+	if err != nil {
+		return nil, nil, err
+	}
+	jails, err := listJails()
+	if err != nil {
+		return nil, nil, err
+	}
+	anchors := make(map[string][]string)
+	for _, parent := range []string{pfRdrAnchor, pfShapingAnchor} {
+		if anchors[parent], err = listPFAnchors(parent); err != nil {
+			// pf may not be loaded, in which case there is nothing
+			// to clean up.
+			logrus.Debugf("Listing pf anchors: %v", err)
+		}
+	}
+	dirs, err := r.runtimeDirEntries()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctrs, err := r.syncedContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+	orphans := jailOrphans(jails, recorded, ctrs)
+	for _, parent := range []string{pfRdrAnchor, pfShapingAnchor} {
+		orphans = append(orphans, pfAnchorOrphans(anchors[parent], recorded, ctrs)...)
+	}
+	orphans = append(orphans, runtimeDirOrphans(dirs, recorded, ctrs)...)
+	return orphans, staleJailRecords(recorded, orphans, ctrs), nil
+}
+
+// staleJailRecords returns the recorded jails which are neither used by one of
+// the given containers nor have any orphaned resources left.
+func staleJailRecords(recorded map[string]bool, orphans []*Orphan, ctrs []*Container) []string {
+	used := make(map[string]bool, len(orphans)+2*len(ctrs))
+	for _, o := range orphans {
+		used[o.record] = true
+	}
+	for _, c := range ctrs {
+		used[c.ID()] = true
+		if c.state.NetNS != "" {
+			used[c.state.NetNS] = true
+		}
+	}
+	var stale []string
+	for name := range recorded {
+		if !used[name] {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// listJails returns the names of the jails visible to us.
+func listJails() ([]string, error) {
+	out, err := exec.Command("jls", "name").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing jails: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// listPFAnchors returns the anchors below the given anchor.
+func listPFAnchors(parent string) ([]string, error) {
+	out, err := exec.Command("pfctl", "-a", parent, "-s", "Anchors").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing pf anchors: %w", err)
+	}
+	var anchors []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if anchor := strings.TrimSpace(scanner.Text()); anchor != "" {
+			anchors = append(anchors, anchor)
+		}
+	}
+	return anchors, scanner.Err()
+}
+
+// jailOrphans returns the top level jails created by this store which are not
+// used by any of the given containers: recorded vnet jails not recorded as the
+// network jail of a container which may still run in it and recorded jails
+// named after unknown container IDs. Child jails are removed along with their
+// parent.
+func jailOrphans(jails []string, recorded map[string]bool, ctrs []*Container) []*Orphan {
+	known := make(map[string]bool, 2*len(ctrs))
+	leakedBy := make(map[string]*Container)
+	for _, c := range ctrs {
+		known[c.ID()] = true
+		if c.state.NetNS == "" {
+			continue
+		}
+		// The network jail of a container which is configured or
+		// exited was not released when its network was cleaned up,
+		// either because that failed or because the state was reset
+		// by a refresh after a crash.
+		if c.state.State == define.ContainerStateConfigured || c.state.State == define.ContainerStateExited {
+			leakedBy[c.state.NetNS] = c
+		} else {
+			known[c.state.NetNS] = true
+		}
+	}
+	var orphans []*Orphan
+	for _, name := range jails {
+		if !recorded[name] || known[name] {
+			continue
+		}
+		kind := OrphanJail
+		switch {
+		case vnetJailRegexp.MatchString(name):
+			kind = OrphanVnetJail
+		case stringid.ValidateID(name) != nil:
+			continue
+		}
+		name := name
+		remove := func() error { return removeJail(name) }
+		if c := leakedBy[name]; c != nil {
+			remove = func() error { return removeLeakedNetworkJail(c, name) }
+		}
+		orphans = append(orphans, &Orphan{
+			Kind:   kind,
+			Name:   name,
+			record: name,
+			remove: remove,
+		})
+	}
+	return orphans
+}
+
+// removeJail removes a jail along with its children, unless it is already
+// gone.
+func removeJail(name string) error {
+	if _, err := jail.FindByName(name); errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	if out, err := exec.Command("jail", "-r", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("removing jail %s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// removeLeakedNetworkJail removes the network jail which a configured or
+// exited container still refers to. The state is checked again under the lock
+// of the container, which may have been started since it was listed, and the
+// network of the container is cleaned up before the jail is removed.
+func removeLeakedNetworkJail(c *Container, name string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if c.state.NetNS != name || !c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
+		return fmt.Errorf("vnet jail %s is in use by container %s again: %w", name, c.ID(), define.ErrCtrStateInvalid)
+	}
+	if err := c.cleanupNetwork(); err != nil {
+		return err
+	}
+	c.releasedNetworkJail = ""
+	return removeJail(name)
+}
+
+// pfAnchorOrphans returns the port forwarding and traffic shaping anchors of
+// recorded containers which are not in the given list.
+func pfAnchorOrphans(anchors []string, recorded map[string]bool, ctrs []*Container) []*Orphan {
+	known := make(map[string]bool, len(ctrs))
+	for _, c := range ctrs {
+		known[c.ID()] = true
+	}
+	var orphans []*Orphan
+	for _, anchor := range anchors {
+		id, ok := strings.CutPrefix(anchor, pfRdrAnchor+"/")
+		shaping := false
+		if !ok {
+			id, ok = strings.CutPrefix(anchor, pfShapingAnchor+"/")
+			shaping = ok
+		}
+		id = strings.TrimSuffix(id, hostIPAnchorSuffix)
+		if !ok || !recorded[id] || known[id] {
+			continue
+		}
+		anchor := anchor
+		orphans = append(orphans, &Orphan{
+			Kind:   OrphanPFAnchor,
+			Name:   anchor,
+			record: id,
+			remove: func() error {
+				if shaping {
+					// Free the dummynet pipes used by the
+					// rules.
+					out, err := exec.Command("pfctl", "-a", anchor, "-s", "rules").Output()
+					if err != nil {
+						logrus.Debugf("Listing pf anchor %s: %v", anchor, err)
+					}
+					deleteDummynetPipes(anchorDummynetPipes(out))
+				}
+				if out, err := exec.Command("pfctl", "-a", anchor, "-F", "all").CombinedOutput(); err != nil {
+					return fmt.Errorf("flushing pf anchor %s: %w: %s", anchor, err, strings.TrimSpace(string(out)))
+				}
+				return nil
+			},
+		})
+	}
+	return orphans
+}
+
+// runtimeDirEntries returns the paths of the entries of the conmon exits and
+// persist directories.
+func (r *Runtime) runtimeDirEntries() ([]string, error) {
+	var paths []string
+	for _, dir := range []string{"exits", "persist"} {
+		dir = filepath.Join(r.config.Engine.TmpDir, dir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading runtime directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// runtimeDirOrphans returns the entries of the conmon exits and persist
+// directories of recorded containers which are not in the given list. The
+// directories may be shared with other stores, which do not record their
+// containers here.
+func runtimeDirOrphans(paths []string, recorded map[string]bool, ctrs []*Container) []*Orphan {
+	known := make(map[string]bool, len(ctrs))
+	for _, c := range ctrs {
+		known[c.ID()] = true
+	}
+	var orphans []*Orphan
+	for _, path := range paths {
+		id := filepath.Base(path)
+		if !recorded[id] || known[id] {
+			continue
+		}
+		path := path
+		orphans = append(orphans, &Orphan{
+			Kind:   OrphanRuntimeDir,
+			Name:   path,
+			record: id,
+			remove: func() error { return os.RemoveAll(path) },
+		})
+	}
+	return orphans
+}
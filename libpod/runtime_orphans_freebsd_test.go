@@ -0,0 +1,67 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphans(t *testing.T) {
+	known := strings.Repeat("a", 64)
+	exited := strings.Repeat("c", 64)
+	unknown := strings.Repeat("b", 64)
+	foreign := strings.Repeat("d", 64)
+	used := "vnet-00112233-4455-6677-8899-aabbccddeeff"
+	leaked := "vnet-10112233-4455-6677-8899-aabbccddeeff"
+	stale := "vnet-20112233-4455-6677-8899-aabbccddeeff"
+	other := "vnet-30112233-4455-6677-8899-aabbccddeeff"
+	ctrs := []*Container{
+		{
+			config: &ContainerConfig{ID: known},
+			state:  &ContainerState{State: define.ContainerStateRunning, NetNS: used},
+		},
+		{
+			config: &ContainerConfig{ID: exited},
+			state:  &ContainerState{State: define.ContainerStateExited, NetNS: leaked},
+		},
+	}
+	recorded := map[string]bool{
+		used:    true,
+		leaked:  true,
+		stale:   true,
+		known:   true,
+		unknown: true,
+		// The jail no longer exists.
+		"vnet-40112233-4455-6677-8899-aabbccddeeff": true,
+		// Not a name generated by Podman.
+		"vnet-www": true,
+	}
+
+	jails := []string{used, used + "." + known, leaked, stale, stale + "." + unknown, other, known, unknown, foreign, "vnet-www", "www"}
+	var names []string
+	orphans := jailOrphans(jails, recorded, ctrs)
+	for _, o := range orphans {
+		names = append(names, o.Kind+" "+o.Name)
+	}
+	assert.Equal(t, []string{"vnet jail " + leaked, "vnet jail " + stale, "jail " + unknown}, names)
+
+	anchors := []string{"cni-rdr/" + known, "cni-rdr/" + known + "-hostip", "cni-rdr/" + unknown, "cni-rdr/" + foreign, "other/" + unknown, "cni-shaping/" + known, "cni-shaping/" + unknown}
+	names = nil
+	for _, o := range pfAnchorOrphans(anchors, recorded, ctrs) {
+		names = append(names, o.Kind+" "+o.Name)
+	}
+	assert.Equal(t, []string{"pf anchor cni-rdr/" + unknown, "pf anchor cni-shaping/" + unknown}, names)
+
+	paths := []string{"/run/libpod/exits/" + known, "/run/libpod/exits/" + unknown, "/run/libpod/persist/" + unknown, "/run/libpod/persist/" + foreign}
+	names = nil
+	for _, o := range runtimeDirOrphans(paths, recorded, ctrs) {
+		names = append(names, o.Kind+" "+o.Name)
+	}
+	assert.Equal(t, []string{"runtime directory /run/libpod/exits/" + unknown, "runtime directory /run/libpod/persist/" + unknown}, names)
+
+	assert.Equal(t, []string{"vnet-40112233-4455-6677-8899-aabbccddeeff", "vnet-www"}, staleJailRecords(recorded, orphans, ctrs))
+}
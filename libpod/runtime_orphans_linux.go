@@ -0,0 +1,16 @@
+//go:build !remote
+
+package libpod
+
+// findOrphans returns the host resources left behind by containers which no
+// longer exist. Nothing is tracked on Linux, the storage of such containers is
+// removed by GarbageCollect.
+func (r *Runtime) findOrphans() ([]*Orphan, []string, error) {
+	return nil, nil, nil
+}
+
+// recordJail does nothing on Linux, which has no jails.
+func (r *Runtime) recordJail(name string) {}
+
+// forgetJail does nothing on Linux, which has no jails.
+func (r *Runtime) forgetJail(name string) {}
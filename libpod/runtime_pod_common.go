@@ -52,6 +52,14 @@ func (r *Runtime) NewPod(ctx context.Context, p specgen.PodSpecGenerator, option
 
 	pod.valid = true
 
+	if pod.config.CPUPlacement != "" {
+		if err := r.placePodCPUs(pod); err != nil {
+			return nil, err
+		}
+		limits := pod.config.ResourceLimits
+		p.ResourceLimits = &limits
+	}
+
 	parentCgroup, err := r.platformMakePod(pod, p.ResourceLimits)
 	if err != nil {
 		return nil, err
@@ -4,9 +4,14 @@ package libpod
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	nettypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_generateName(t *testing.T) {
@@ -25,3 +30,95 @@ func Test_generateName(t *testing.T) {
 	n2, _ := r.generateName()
 	assert.NotEqual(t, n1, n2)
 }
+
+func TestGetAllContainersWithState(t *testing.T) {
+	state, path, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	r := &Runtime{
+		state: state,
+		valid: true,
+	}
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr1))
+	require.NoError(t, state.AddContainer(ctr2))
+
+	ctr1.state.State = define.ContainerStateExited
+	ctr1.state.ExitCode = 3
+	require.NoError(t, state.SaveContainer(ctr1))
+	ctr2.state.State = define.ContainerStateCreated
+	require.NoError(t, state.SaveContainer(ctr2))
+
+	ctrs, err := r.GetAllContainersWithState()
+	require.NoError(t, err)
+	assert.Len(t, ctrs, 2)
+
+	// The filters see the states loaded from the database
+	exited := func(c *Container) bool {
+		status, err := c.State()
+		return err == nil && status == define.ContainerStateExited
+	}
+	ctrs, err = r.GetAllContainersWithState(exited)
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, ctr1.ID(), ctrs[0].ID())
+	assert.Equal(t, int32(3), ctrs[0].state.ExitCode)
+}
+
+// fakeNetwork is a network interface which is only compared by identity.
+type fakeNetwork struct {
+	nettypes.ContainerNetwork
+}
+
+func TestReloadContainersConf(t *testing.T) {
+	conf := filepath.Join(t.TempDir(), "containers.conf")
+	require.NoError(t, os.WriteFile(conf, []byte(`
+[containers]
+annotations = ["io.podman.annotations.jail-profile=web"]
+
+[network]
+default_network = "reloaded"
+
+[jail_profiles.web]
+securelevel = 2
+`), 0o644))
+	t.Setenv("CONTAINERS_CONF", conf)
+
+	oldConfig, err := config.New(nil)
+	require.NoError(t, err)
+	oldNetwork := &fakeNetwork{}
+	r := &Runtime{config: oldConfig, network: oldNetwork}
+
+	// The runtime keeps its config and network interface when the new
+	// network interface cannot be created.
+	oldConfig.Network.NetworkBackend = "bogus"
+	oldConfig.Network.DefaultNetwork = "podman"
+	assert.Error(t, r.reloadContainersConf())
+	assert.True(t, r.config == oldConfig, "config replaced")
+	assert.Same(t, oldNetwork, r.Network())
+
+	// Without a network interface only the config is replaced, which
+	// holds the defaults read when containers are created.
+	r.network = nil
+	require.NoError(t, r.reloadContainersConf())
+	assert.False(t, r.config == oldConfig, "config not replaced")
+	assert.Nil(t, r.Network())
+	assert.Equal(t, "reloaded", r.config.Network.DefaultNetwork)
+	assert.Contains(t, r.config.Containers.Annotations.Get(), "io.podman.annotations.jail-profile=web")
+	require.Contains(t, r.config.JailProfiles, "web")
+	assert.Equal(t, 2, *r.config.JailProfiles["web"].Securelevel)
+
+	// An unchanged network section keeps the network interface.
+	r.network = oldNetwork
+	newConfig, err := config.New(nil)
+	require.NoError(t, err)
+	netInterface, err := r.reloadNetworkInterface(newConfig)
+	require.NoError(t, err)
+	assert.Nil(t, netInterface)
+}
@@ -3,7 +3,10 @@
 package libpod
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 )
@@ -12,6 +15,16 @@ import (
 // The previousStats is used to correctly calculate cpu percentages. You
 // should pass nil if there is no previous stat for this container.
 func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*define.ContainerStats, error) {
+	return c.getContainerStats(previousStats, nil)
+}
+
+// netStatsCache holds the network stats of network namespaces while the
+// stats of several containers are collected, so that the stats of a
+// namespace shared by several containers are only read once.
+type netStatsCache map[string]map[string]define.ContainerNetworkStats
+
+// getContainerStats implements GetContainerStats. netCache may be nil.
+func (c *Container) getContainerStats(previousStats *define.ContainerStats, netCache netStatsCache) (*define.ContainerStats, error) {
 	stats := new(define.ContainerStats)
 	stats.ContainerID = c.ID()
 	stats.Name = c.Name()
@@ -41,7 +54,7 @@ func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*de
 		}
 	}
 
-	netStats, err := getContainerNetIO(c)
+	netStats, err := c.getNetIO(netCache)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +66,192 @@ func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*de
 	return stats, nil
 }
 
+// getNetIO returns the network stats of the container, reading them from
+// netCache if another container using the same network namespace was
+// already sampled. netCache may be nil.
+func (c *Container) getNetIO(netCache netStatsCache) (map[string]define.ContainerNetworkStats, error) {
+	if netCache == nil {
+		return getContainerNetIO(c)
+	}
+	netNS, _, err := getContainerNetNS(c)
+	if err != nil {
+		return nil, err
+	}
+	if netStats, ok := netCache[netNS]; ok && netNS != "" {
+		return netStats, nil
+	}
+	netStats, err := getContainerNetIO(c)
+	if err != nil {
+		return nil, err
+	}
+	if netNS != "" {
+		netCache[netNS] = netStats
+	}
+	return netStats, nil
+}
+
+// GetRunningContainersStats returns the stats of all running containers,
+// collected in a single pass. It is meant for monitoring agents which
+// frequently scrape the stats of many containers: the network stats of
+// containers sharing a network namespace, e.g. the containers of a pod, are
+// only read once. previous maps container IDs to the stats returned by the
+// previous call and is used to calculate the CPU usage since then, it may
+// be nil. Containers which stop while the stats are collected are skipped.
+// If the stats of other containers cannot be read, the stats of the
+// remaining containers are returned along with an error for each of them;
+// the returned stats are only nil if the containers cannot be listed.
+func (r *Runtime) GetRunningContainersStats(previous map[string]*define.ContainerStats) ([]*define.ContainerStats, error) {
+	ctrs, err := r.GetRunningContainers()
+	if err != nil {
+		return nil, err
+	}
+	netCache := make(netStatsCache)
+	stats := make([]*define.ContainerStats, 0, len(ctrs))
+	var errs []error
+	for _, ctr := range ctrs {
+		s, err := ctr.getContainerStats(previous[ctr.ID()], netCache)
+		if err != nil {
+			// The container may have been stopped or removed since
+			// it was listed, and containers without cgroups have no
+			// stats.
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrNoCgroups) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("getting stats of container %s: %w", ctr.ID(), err))
+			continue
+		}
+		stats = append(stats, s)
+	}
+	return stats, errors.Join(errs...)
+}
+
+// ContainerStatsStreamOptions configures Runtime.StreamContainerStats.
+type ContainerStatsStreamOptions struct {
+	// Containers returns the containers to sample. It is called for every
+	// sample so that containers started in the meantime are included.
+	Containers func() ([]*Container, error)
+	// Interval is the time between two samples. Only a single sample is
+	// taken if it is zero.
+	Interval time.Duration
+	// IgnoreRemoved skips containers which were removed or stopped after
+	// they were listed instead of failing.
+	IgnoreRemoved bool
+}
+
+// StreamContainerStats samples the stats of containers every interval and
+// passes them to report until the context is cancelled or report fails.
+// The stats of a container are computed relative to its previous sample so
+// that the CPU usage reflects the last interval.
+func (r *Runtime) StreamContainerStats(ctx context.Context, options ContainerStatsStreamOptions, report func([]define.ContainerStats) error) error {
+	var ticker *time.Ticker
+	if options.Interval > 0 {
+		ticker = time.NewTicker(options.Interval)
+		defer ticker.Stop()
+	}
+
+	previous := make(map[string]*define.ContainerStats)
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		ctrs, err := options.Containers()
+		if err != nil {
+			return fmt.Errorf("unable to get list of containers: %w", err)
+		}
+		current := make(map[string]*define.ContainerStats, len(ctrs))
+		stats := make([]define.ContainerStats, 0, len(ctrs))
+		netCache := make(netStatsCache)
+		for _, ctr := range ctrs {
+			s, err := ctr.getContainerStats(previous[ctr.ID()], netCache)
+			if err != nil {
+				if options.IgnoreRemoved && (errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrStateInvalid)) {
+					continue
+				}
+				return err
+			}
+			current[ctr.ID()] = s
+			stats = append(stats, *s)
+		}
+		previous = current
+
+		if err := report(stats); err != nil {
+			return err
+		}
+		if ticker == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetOnlineCPUs returns the number of online CPUs as set in the container cpu-set using sched_getaffinity
 func GetOnlineCPUs(container *Container) (int, error) {
 	return getOnlineCPUs(container)
 }
+
+// sumContainerStats sums the given container stats. netOwners maps each
+// container to the container owning its network namespace, the network
+// stats of a namespace are only counted once. If memLimit is zero, the
+// highest memory limit of the containers is used.
+func sumContainerStats(stats map[string]*define.ContainerStats, netOwners map[string]string, memLimit uint64) *define.ContainerStats {
+	total := &define.ContainerStats{
+		MemLimit: memLimit,
+		Network:  make(map[string]define.ContainerNetworkStats),
+	}
+	countedNet := make(map[string]bool)
+	for id, s := range stats {
+		total.AvgCPU += s.AvgCPU
+		total.CPU += s.CPU
+		total.CPUNano += s.CPUNano
+		total.CPUSystemNano += s.CPUSystemNano
+		if s.SystemNano > total.SystemNano {
+			total.SystemNano = s.SystemNano
+		}
+		total.MemUsage += s.MemUsage
+		if memLimit == 0 && s.MemLimit > total.MemLimit {
+			total.MemLimit = s.MemLimit
+		}
+		total.BlockInput += s.BlockInput
+		total.BlockOutput += s.BlockOutput
+		total.PIDs += s.PIDs
+		total.OpenFiles += s.OpenFiles
+		total.Threads += s.Threads
+		if s.UpTime > total.UpTime {
+			total.UpTime = s.UpTime
+		}
+		if s.Duration > total.Duration {
+			total.Duration = s.Duration
+		}
+
+		owner := netOwners[id]
+		if owner == "" {
+			owner = id
+		}
+		if countedNet[owner] {
+			continue
+		}
+		countedNet[owner] = true
+		for iface, net := range s.Network {
+			sum := total.Network[iface]
+			sum.RxBytes += net.RxBytes
+			sum.RxDropped += net.RxDropped
+			sum.RxErrors += net.RxErrors
+			sum.RxPackets += net.RxPackets
+			sum.TxBytes += net.TxBytes
+			sum.TxDropped += net.TxDropped
+			sum.TxErrors += net.TxErrors
+			sum.TxPackets += net.TxPackets
+			total.Network[iface] = sum
+		}
+	}
+	if total.MemLimit > 0 {
+		total.MemPerc = float64(total.MemUsage) / float64(total.MemLimit) * 100
+	}
+	return total
+}
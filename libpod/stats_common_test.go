@@ -0,0 +1,63 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumContainerStats(t *testing.T) {
+	stats := map[string]*define.ContainerStats{
+		"infra": {
+			CPU:      1,
+			CPUNano:  100,
+			MemUsage: 10,
+			MemLimit: 100,
+			PIDs:     1,
+			Network: map[string]define.ContainerNetworkStats{
+				"eth0": {RxBytes: 1000, TxBytes: 2000},
+			},
+		},
+		"app": {
+			CPU:        2.5,
+			CPUNano:    200,
+			MemUsage:   40,
+			MemLimit:   200,
+			PIDs:       3,
+			BlockInput: 5,
+			// Same vnet as the infra container.
+			Network: map[string]define.ContainerNetworkStats{
+				"eth0": {RxBytes: 1000, TxBytes: 2000},
+			},
+		},
+		"other": {
+			PIDs: 1,
+			Network: map[string]define.ContainerNetworkStats{
+				"eth0": {RxBytes: 1, TxBytes: 2},
+			},
+		},
+	}
+	netOwners := map[string]string{
+		"infra": "infra",
+		"app":   "infra",
+	}
+
+	total := sumContainerStats(stats, netOwners, 0)
+	assert.Equal(t, 3.5, total.CPU)
+	assert.Equal(t, uint64(300), total.CPUNano)
+	assert.Equal(t, uint64(50), total.MemUsage)
+	assert.Equal(t, uint64(200), total.MemLimit)
+	assert.Equal(t, 25.0, total.MemPerc)
+	assert.Equal(t, uint64(5), total.PIDs)
+	assert.Equal(t, uint64(5), total.BlockInput)
+	assert.Equal(t, map[string]define.ContainerNetworkStats{
+		"eth0": {RxBytes: 1001, TxBytes: 2002},
+	}, total.Network)
+
+	total = sumContainerStats(stats, netOwners, 500)
+	assert.Equal(t, uint64(500), total.MemLimit)
+	assert.Equal(t, 10.0, total.MemPerc)
+}
@@ -53,10 +53,12 @@ func (c *Container) getPlatformContainerStats(stats *define.ContainerStats, prev
 		case "maxproc": // number of processes
 			stats.PIDs = val
 		case "openfiles": // file descriptor table size
+			stats.OpenFiles = val
 		case "vmemoryuse": // address space limit, in bytes
 		case "pseudoterminals": // number of PTYs
 		case "swapuse": // swap space that may be reserved or used, in bytes
 		case "nthr": // number of threads
+			stats.Threads = val
 		case "msgqqueued": // number of queued SysV messages
 		case "msgqsize": // SysV message queue size, in bytes
 		case "nmsgq": // number of SysV message queues
@@ -78,11 +80,39 @@ func (c *Container) getPlatformContainerStats(stats *define.ContainerStats, prev
 		}
 	}
 	stats.MemLimit = c.getMemLimit()
+	if resources := c.LinuxResources(); resources != nil && resources.Pids != nil && resources.Pids.Limit > 0 {
+		stats.PIDsLimit = uint64(resources.Pids.Limit)
+	}
 	stats.SystemNano = now
 
 	return nil
 }
 
+// getProcessCounts returns the open file and thread counts of the
+// container's jail from its resource accounting.
+func (c *Container) getProcessCounts() (uint64, uint64, error) {
+	entries, err := c.racctUsage()
+	if err != nil {
+		return 0, 0, err
+	}
+	return entries["openfiles"], entries["nthr"], nil
+}
+
+// racctUsage returns the resource usage of the container's jail, keyed by
+// the rctl(8) resource names.
+func (c *Container) racctUsage() (map[string]uint64, error) {
+	jailName, err := c.jailName()
+	if err != nil {
+		return nil, fmt.Errorf("getting jail name: %w", err)
+	}
+
+	entries, err := rctl.GetRacct("jail:" + jailName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read accounting for %s: %w", jailName, err)
+	}
+	return entries, nil
+}
+
 // getMemory limit returns the memory limit for a container
 func (c *Container) getMemLimit() uint64 {
 	memLimit := uint64(math.MaxUint64)
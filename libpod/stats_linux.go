@@ -3,7 +3,11 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -69,6 +73,60 @@ func (c *Container) getPlatformContainerStats(stats *define.ContainerStats, prev
 	return nil
 }
 
+// racctUsage returns nil on Linux, which has no resource accounting for
+// jails.
+func (c *Container) racctUsage() (map[string]uint64, error) {
+	return nil, nil
+}
+
+// getProcessCounts returns the open file and thread counts summed over
+// all processes in the container's cgroup.
+func (c *Container) getProcessCounts() (uint64, uint64, error) {
+	if c.config.NoCgroups {
+		return 0, 0, fmt.Errorf("cannot count processes of container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
+	}
+
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return 0, 0, err
+	}
+	dir := filepath.Join("/sys/fs/cgroup", cgroupPath)
+	if !unified {
+		dir = filepath.Join("/sys/fs/cgroup/pids", cgroupPath)
+	}
+	pids, err := runccgroup.GetAllPids(dir)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to list processes in cgroup %s: %w", dir, err)
+	}
+
+	var openFiles, threads uint64
+	for _, pid := range pids {
+		procDir := filepath.Join("/proc", strconv.Itoa(pid))
+		fds, err := os.ReadDir(filepath.Join(procDir, "fd"))
+		if err != nil {
+			// The process may have exited since the cgroup was read.
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return 0, 0, err
+		}
+		tasks, err := os.ReadDir(filepath.Join(procDir, "task"))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return 0, 0, err
+		}
+		openFiles += uint64(len(fds))
+		threads += uint64(len(tasks))
+	}
+	return openFiles, threads, nil
+}
+
 // getMemory limit returns the memory limit for a container
 func (c *Container) getMemLimit(memLimit uint64) uint64 {
 	si := &syscall.Sysinfo_t{}
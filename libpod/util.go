@@ -62,8 +62,10 @@ func (m byDestination) parts(i int) int {
 	return strings.Count(filepath.Clean(m[i].Destination), string(os.PathSeparator))
 }
 
+// sortMounts sorts mounts by the depth of their destination. The sort is
+// stable so that mounts stacked on the same destination keep their order.
 func sortMounts(m []spec.Mount) []spec.Mount {
-	sort.Sort(byDestination(m))
+	sort.Stable(byDestination(m))
 	return m
 }
 
@@ -287,3 +289,17 @@ func writeStringToPath(path, contents, mountLabel string, uid, gid int) error {
 
 	return nil
 }
+
+// timezoneFromLocaltime returns the name of the timezone which the given
+// localtime(5) file links to. It is empty if the file does not link into a
+// zoneinfo directory.
+func timezoneFromLocaltime(path string) (string, error) {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if _, name, ok := strings.Cut(target, "/zoneinfo/"); ok {
+		return name, nil
+	}
+	return "", nil
+}
@@ -4,7 +4,11 @@ package libpod
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"syscall"
+	"unsafe"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
@@ -38,3 +42,90 @@ func Unmount(mount string) {
 		}
 	}
 }
+
+// jailID returns the ID of the jail with the given name.
+func jailID(name string) (int, error) {
+	key, err := unix.ByteSliceFromString("name")
+	if err != nil {
+		return 0, err
+	}
+	value, err := unix.ByteSliceFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	iov := []unix.Iovec{{Base: &key[0]}, {Base: &value[0]}}
+	iov[0].SetLen(len(key))
+	iov[1].SetLen(len(value))
+	jid, _, errno := unix.Syscall(unix.SYS_JAIL_GET, uintptr(unsafe.Pointer(&iov[0])), uintptr(len(iov)), 0)
+	if errno != 0 {
+		return 0, fmt.Errorf("looking up jail %s: %w", name, errno)
+	}
+	return int(jid), nil
+}
+
+// allJailIDs returns the IDs of all the jails visible to us, keyed by name.
+func allJailIDs() (map[string]int, error) {
+	lastKey, err := unix.ByteSliceFromString("lastjid")
+	if err != nil {
+		return nil, err
+	}
+	nameKey, err := unix.ByteSliceFromString("name")
+	if err != nil {
+		return nil, err
+	}
+	// Jail names are limited to MAXHOSTNAMELEN
+	name := make([]byte, 256)
+	ids := make(map[string]int)
+	var lastJID int32
+	for {
+		iov := []unix.Iovec{
+			{Base: &lastKey[0]}, {Base: (*byte)(unsafe.Pointer(&lastJID))},
+			{Base: &nameKey[0]}, {Base: &name[0]},
+		}
+		iov[0].SetLen(len(lastKey))
+		iov[1].SetLen(int(unsafe.Sizeof(lastJID)))
+		iov[2].SetLen(len(nameKey))
+		iov[3].SetLen(len(name))
+		jid, _, errno := unix.Syscall(unix.SYS_JAIL_GET, uintptr(unsafe.Pointer(&iov[0])), uintptr(len(iov)), 0)
+		if errno == unix.ENOENT {
+			return ids, nil
+		}
+		if errno != 0 {
+			return nil, fmt.Errorf("listing jails: %w", errno)
+		}
+		ids[unix.ByteSliceToString(name)] = int(jid)
+		lastJID = int32(jid)
+	}
+}
+
+// localTimezone returns the name of the host's timezone, which is used for
+// --tz=local. It is empty if the name cannot be determined. tzsetup(8)
+// installs /etc/localtime as a copy of the zone file and records the name of
+// the zone in /var/db/zoneinfo. Without /etc/localtime the host uses UTC,
+// which is the default on FreeBSD.
+func localTimezone() (string, error) {
+	if _, err := os.Stat("/etc/localtime"); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "UTC", nil
+		}
+		return "", err
+	}
+	data, err := os.ReadFile("/var/db/zoneinfo")
+	if err == nil {
+		if name := strings.TrimSpace(string(data)); name != "" {
+			return name, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	return timezoneFromLocaltime("/etc/localtime")
+}
+
+// isTmpfs returns true if path is on a tmpfs file system.
+func isTmpfs(path string) (bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return false, fmt.Errorf("statfs %s: %w", path, err)
+	}
+	return unix.ByteSliceToString(st.Fstypename[:]) == "tmpfs", nil
+}
@@ -165,3 +165,23 @@ func Unmount(mount string) {
 		}
 	}
 }
+
+// localTimezone returns the name of the host's timezone, which is used for
+// --tz=local. It is empty if the name cannot be determined. Without
+// /etc/localtime the host uses UTC.
+func localTimezone() (string, error) {
+	name, err := timezoneFromLocaltime("/etc/localtime")
+	if errors.Is(err, os.ErrNotExist) {
+		return "UTC", nil
+	}
+	return name, err
+}
+
+// isTmpfs returns true if path is on a tmpfs file system.
+func isTmpfs(path string) (bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return false, fmt.Errorf("statfs %s: %w", path, err)
+	}
+	return st.Type == unix.TMPFS_MAGIC, nil
+}
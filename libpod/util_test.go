@@ -0,0 +1,70 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezoneFromLocaltime(t *testing.T) {
+	dir := t.TempDir()
+	zoneDir := filepath.Join(dir, "zoneinfo", "Europe")
+	require.NoError(t, os.MkdirAll(zoneDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(zoneDir, "Berlin"), []byte("TZif"), 0o644))
+
+	link := filepath.Join(dir, "localtime")
+	require.NoError(t, os.Symlink(filepath.Join(zoneDir, "Berlin"), link))
+	name, err := timezoneFromLocaltime(link)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", name)
+
+	// A copy of the zone file, as installed by tzsetup(8), has no name.
+	zoneCopy := filepath.Join(dir, "localtime.copy")
+	require.NoError(t, os.WriteFile(zoneCopy, []byte("TZif"), 0o644))
+	name, err = timezoneFromLocaltime(zoneCopy)
+	require.NoError(t, err)
+	assert.Equal(t, "", name)
+
+	_, err = timezoneFromLocaltime(filepath.Join(dir, "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestChownRecursive(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("changing the ownership of files requires root")
+	}
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		sub := filepath.Join(dir, "d", strconv.Itoa(i), "nested")
+		require.NoError(t, os.MkdirAll(sub, 0o755))
+		file := filepath.Join(sub, "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+		paths = append(paths, sub, file)
+	}
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.Symlink("/etc/passwd", link))
+	paths = append(paths, dir, link)
+
+	// Running it twice must give the same result.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, chownRecursive(dir, 1234, 5678))
+		for _, path := range paths {
+			st, err := os.Lstat(path)
+			require.NoError(t, err)
+			stat := st.Sys().(*syscall.Stat_t)
+			assert.Equal(t, uint32(1234), stat.Uid, path)
+			assert.Equal(t, uint32(5678), stat.Gid, path)
+		}
+	}
+	st, err := os.Stat("/etc/passwd")
+	require.NoError(t, err)
+	assert.NotEqual(t, uint32(1234), st.Sys().(*syscall.Stat_t).Uid)
+}
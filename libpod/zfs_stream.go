@@ -0,0 +1,176 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/sirupsen/logrus"
+)
+
+// zfsStreamMagic is DMU_BACKUP_MAGIC, which is stored in the DRR_BEGIN record
+// at the start of every zfs send stream.
+const zfsStreamMagic = 0x2f5bacbac
+
+// isZFSStream reports whether header is the start of a zfs send stream. The
+// stream starts with a DRR_BEGIN record, which has a type of 0 and is written
+// in the byte order of the sending host.
+func isZFSStream(header []byte) bool {
+	if len(header) < 16 {
+		return false
+	}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		if order.Uint32(header[0:4]) == 0 && order.Uint64(header[8:16]) == zfsStreamMagic {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZFSStream reports whether the file at path is a zfs send stream, as
+// written by ExportZFS, rather than a tar archive.
+func IsZFSStream(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(f, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return isZFSStream(header), nil
+}
+
+// ExportZFS exports the root filesystem of the container as a zfs send
+// stream of a snapshot of the container's dataset. This avoids walking the
+// filesystem to build a tar archive but requires the zfs graph driver. The
+// stream can be imported with ImportZFS or received with zfs receive.
+func (c *Container) ExportZFS(out io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return fmt.Errorf("cannot export container %s as it is being removed: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+
+	return c.exportZFS(out)
+}
+
+func (c *Container) exportZFS(out io.Writer) error {
+	if driver := c.runtime.store.GraphDriverName(); driver != "zfs" {
+		return fmt.Errorf("exporting a zfs stream requires the zfs storage driver, not %q: %w", driver, define.ErrInvalidArg)
+	}
+	parent, err := c.runtime.zfsParentDataset()
+	if err != nil {
+		return err
+	}
+	ctr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return fmt.Errorf("looking up storage for container %s: %w", c.ID(), err)
+	}
+
+	// A snapshot gives a consistent view of the filesystem, even if the
+	// container is running.
+	snapshot := fmt.Sprintf("%s/%s@podman-export-%d", parent, ctr.LayerID, time.Now().UnixNano())
+	if out, err := exec.Command("zfs", "snapshot", snapshot).CombinedOutput(); err != nil {
+		return fmt.Errorf("creating snapshot %s: %w: %s", snapshot, err, strings.TrimSpace(string(out)))
+	}
+	defer func() {
+		if out, err := exec.Command("zfs", "destroy", snapshot).CombinedOutput(); err != nil {
+			logrus.Errorf("Removing snapshot %s: %v: %s", snapshot, err, strings.TrimSpace(string(out)))
+		}
+	}()
+
+	cmd := exec.Command("zfs", "send", snapshot)
+	cmd.Stdout = out
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sending snapshot %s: %w: %s", snapshot, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ImportZFS creates an image from a zfs send stream, as written by
+// ExportZFS. The stream is received into a temporary dataset, which is then
+// imported like a tar archive, since c/storage can only record the digest of
+// a layer from its tar stream. It requires the zfs graph driver.
+func (r *Runtime) ImportZFS(ctx context.Context, path string, options *libimage.ImportOptions) (string, error) {
+	if driver := r.store.GraphDriverName(); driver != "zfs" {
+		return "", fmt.Errorf("importing a zfs stream requires the zfs storage driver, not %q: %w", driver, define.ErrInvalidArg)
+	}
+	parent, err := r.zfsParentDataset()
+	if err != nil {
+		return "", err
+	}
+
+	input, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer input.Close()
+
+	mountPoint, err := os.MkdirTemp(r.store.RunRoot(), "zfs-import")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(mountPoint)
+
+	dataset := parent + "/import-" + stringid.GenerateRandomID()
+	cmd := exec.Command("zfs", "receive", "-o", "mountpoint="+mountPoint, "-o", "readonly=on", dataset)
+	cmd.Stdin = input
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("receiving zfs stream from %s: %w: %s", path, err, strings.TrimSpace(string(out)))
+	}
+	defer func() {
+		// This also unmounts the dataset and removes the received
+		// snapshot.
+		if out, err := exec.Command("zfs", "destroy", "-r", dataset).CombinedOutput(); err != nil {
+			logrus.Errorf("Removing dataset %s: %v: %s", dataset, err, strings.TrimSpace(string(out)))
+		}
+	}()
+
+	tarball, err := os.CreateTemp(util.Tmpdir(), "import")
+	if err != nil {
+		return "", fmt.Errorf("creating file: %w", err)
+	}
+	defer os.Remove(tarball.Name())
+	defer tarball.Close()
+
+	rootfs, err := chrootarchive.Tar(mountPoint, nil, mountPoint)
+	if err != nil {
+		return "", fmt.Errorf("reading received dataset %s: %w", dataset, err)
+	}
+	defer rootfs.Close()
+	if _, err := io.Copy(tarball, rootfs); err != nil {
+		return "", fmt.Errorf("saving %s to %s: %w", dataset, tarball.Name(), err)
+	}
+	if err := tarball.Close(); err != nil {
+		return "", err
+	}
+
+	return r.libimageRuntime.Import(ctx, tarball.Name(), options)
+}
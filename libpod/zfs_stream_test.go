@@ -0,0 +1,53 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsZFSStream(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		header := make([]byte, 312)
+		order.PutUint32(header[4:8], 0x118)
+		order.PutUint64(header[8:16], zfsStreamMagic)
+		assert.True(t, isZFSStream(header), "%s", order)
+	}
+
+	// A record which is not DRR_BEGIN.
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], 1)
+	binary.LittleEndian.PutUint64(header[8:16], zfsStreamMagic)
+	assert.False(t, isZFSStream(header))
+
+	assert.False(t, isZFSStream(header[:8]))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "etc/hostname", Mode: 0o644, Size: 4}))
+	_, err := tw.Write([]byte("ctr\n"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	assert.False(t, isZFSStream(buf.Bytes()))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.tar")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	isZFS, err := IsZFSStream(path)
+	require.NoError(t, err)
+	assert.False(t, isZFS)
+
+	path = filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	isZFS, err = IsZFSStream(path)
+	require.NoError(t, err)
+	assert.False(t, isZFS)
+}
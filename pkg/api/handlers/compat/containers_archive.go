@@ -36,7 +36,8 @@ func Archive(w http.ResponseWriter, r *http.Request) {
 
 func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder, runtime *libpod.Runtime) {
 	query := struct {
-		Path string `schema:"path"`
+		Path         string `schema:"path"`
+		NoFollowLink bool   `schema:"noFollowLink"`
 	}{}
 
 	err := decoder.Decode(&query, r.URL.Query())
@@ -52,7 +53,8 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 
 	containerName := utils.GetName(r)
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
-	statReport, err := containerEngine.ContainerStat(r.Context(), containerName, query.Path)
+	archiveOptions := entities.ArchiveOptions{NoFollowLink: query.NoFollowLink}
+	statReport, err := containerEngine.ContainerStat(r.Context(), containerName, query.Path, archiveOptions)
 
 	// NOTE
 	// The statReport may actually be set even in case of an error.  That's
@@ -83,7 +85,7 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 		return
 	}
 
-	copyFunc, err := containerEngine.ContainerCopyToArchive(r.Context(), containerName, query.Path, w)
+	copyFunc, err := containerEngine.ContainerCopyToArchive(r.Context(), containerName, query.Path, w, archiveOptions)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, err)
 		return
@@ -249,8 +249,20 @@ func cliOpts(cc handlers.CreateContainerConfig, rtc *config.Config) (*entities.C
 
 	// dns
 	dns := make([]net.IP, 0, len(cc.HostConfig.DNS))
+	useImageResolvConf := false
 	for _, d := range cc.HostConfig.DNS {
-		dns = append(dns, net.ParseIP(d))
+		if d == "none" {
+			if len(cc.HostConfig.DNS) > 1 {
+				return nil, nil, fmt.Errorf("%s is not allowed to be specified with other DNS ip addresses", d)
+			}
+			useImageResolvConf = true
+			break
+		}
+		ip := net.ParseIP(d)
+		if ip == nil {
+			return nil, nil, fmt.Errorf("%s is not an ip address", d)
+		}
+		dns = append(dns, ip)
 	}
 
 	// publish
@@ -298,14 +310,15 @@ func cliOpts(cc handlers.CreateContainerConfig, rtc *config.Config) (*entities.C
 	// Note: we cannot emulate compat exactly here. we only allow specifics of networks to be
 	// defined when there is only one network.
 	netInfo := entities.NetOptions{
-		AddHosts:       cc.HostConfig.ExtraHosts,
-		DNSOptions:     cc.HostConfig.DNSOptions,
-		DNSSearch:      cc.HostConfig.DNSSearch,
-		DNSServers:     dns,
-		Network:        nsmode,
-		PublishPorts:   specPorts,
-		NetworkOptions: netOpts,
-		NoHosts:        rtc.Containers.NoHosts,
+		AddHosts:           cc.HostConfig.ExtraHosts,
+		DNSOptions:         cc.HostConfig.DNSOptions,
+		DNSSearch:          cc.HostConfig.DNSSearch,
+		DNSServers:         dns,
+		UseImageResolvConf: useImageResolvConf,
+		Network:            nsmode,
+		PublishPorts:       specPorts,
+		NetworkOptions:     netOpts,
+		NoHosts:            rtc.Containers.NoHosts,
 	}
 
 	// docker-compose sets the mac address on the container config instead
@@ -5,25 +5,48 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
 )
 
 func ExportContainer(w http.ResponseWriter, r *http.Request) {
+	decoder := utils.GetDecoder(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	name := utils.GetName(r)
+
+	query := struct {
+		Format string `schema:"format"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
 	con, err := runtime.LookupContainer(name)
 	if err != nil {
 		utils.ContainerNotFound(w, name, err)
 		return
 	}
 
-	// set the correct header
-	w.Header().Set("Content-Type", "application/x-tar")
+	export := con.Export
+	switch query.Format {
+	case "", "tar":
+		// set the correct header
+		w.Header().Set("Content-Type", "application/x-tar")
+	case "zfs":
+		export = con.ExportZFS
+		w.Header().Set("Content-Type", "application/octet-stream")
+	default:
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("unsupported export format %q: %w", query.Format, define.ErrInvalidArg))
+		return
+	}
 	// NOTE: As described in w.Write() it automatically sets the http code to
 	// 200 on first write if no other code was set.
 
-	if err := con.Export(w); err != nil {
+	if err := export(w); err != nil {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to export container: %w", err))
 		return
 	}
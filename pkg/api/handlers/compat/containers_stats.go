@@ -0,0 +1,153 @@
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v5/pkg/api/types"
+	docker "github.com/docker/docker/api/types"
+	"github.com/sirupsen/logrus"
+)
+
+const DefaultStatsPeriod = 5 * time.Second
+
+func StatsContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+
+	query := struct {
+		Stream  bool `schema:"stream"`
+		OneShot bool `schema:"one-shot"` // added schema for one shot
+	}{
+		Stream: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Stream && query.OneShot { // mismatch. one-shot can only be passed with stream=false
+		utils.Error(w, http.StatusBadRequest, define.ErrInvalidArg)
+		return
+	}
+
+	name := utils.GetName(r)
+	ctnr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	stats, err := ctnr.GetContainerStats(nil)
+	if err != nil {
+		utils.InternalServerError(w, fmt.Errorf("failed to obtain Container %s stats: %w", name, err))
+		return
+	}
+
+	coder := json.NewEncoder(w)
+	// Write header and content type.
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	// Set up JSON encoder for streaming.
+	coder.SetEscapeHTML(true)
+	var preRead time.Time
+	var preCPUStats CPUStats
+	if query.Stream {
+		preRead = time.Now()
+		preCPUStats = CPUStats{
+			CPUUsage: docker.CPUUsage{
+				TotalUsage:        stats.CPUNano,
+				PercpuUsage:       stats.PerCPU,
+				UsageInKernelmode: stats.CPUSystemNano,
+				UsageInUsermode:   stats.CPUNano - stats.CPUSystemNano,
+			},
+			CPU:            stats.CPU,
+			SystemUsage:    systemCPUUsage(stats),
+			OnlineCPUs:     0,
+			ThrottlingData: docker.ThrottlingData{},
+		}
+	}
+	onlineCPUs, err := libpod.GetOnlineCPUs(ctnr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	// The samples are taken by libpod, which computes the CPU usage of
+	// each sample relative to the previous one.
+	streamOptions := libpod.ContainerStatsStreamOptions{
+		Containers: func() ([]*libpod.Container, error) {
+			return []*libpod.Container{ctnr}, nil
+		},
+	}
+	if query.Stream && !query.OneShot {
+		streamOptions.Interval = DefaultStatsPeriod
+	}
+	err = runtime.StreamContainerStats(r.Context(), streamOptions, func(samples []define.ContainerStats) error {
+		stats := &samples[0]
+		inspect, err := ctnr.Inspect(false)
+		if err != nil {
+			return fmt.Errorf("unable to inspect container: %w", err)
+		}
+
+		s, err := platformStats(ctnr, stats, onlineCPUs)
+		if err != nil {
+			return err
+		}
+		s.Read = time.Now()
+		s.PreRead = preRead
+		s.PreCPUStats = preCPUStats
+
+		net := make(map[string]docker.NetworkStats)
+		for netName, netStats := range stats.Network {
+			net[netName] = docker.NetworkStats{
+				RxBytes:    netStats.RxBytes,
+				RxPackets:  netStats.RxPackets,
+				RxErrors:   netStats.RxErrors,
+				RxDropped:  netStats.RxDropped,
+				TxBytes:    netStats.TxBytes,
+				TxPackets:  netStats.TxPackets,
+				TxErrors:   netStats.TxErrors,
+				TxDropped:  netStats.TxDropped,
+				EndpointID: inspect.NetworkSettings.EndpointID,
+				InstanceID: "",
+			}
+		}
+
+		statsJSON := StatsJSON{
+			Stats:    *s,
+			Name:     stats.Name,
+			ID:       stats.ContainerID,
+			Networks: net,
+		}
+
+		var jsonOut interface{}
+		if utils.IsLibpodRequest(r) {
+			jsonOut = statsJSON
+		} else {
+			jsonOut = DockerStatsJSON(statsJSON)
+		}
+
+		if err := coder.Encode(jsonOut); err != nil {
+			return fmt.Errorf("unable to encode stats: %w", err)
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		preRead = s.Read
+		preCPUStats = s.CPUStats
+		return nil
+	})
+	if err != nil {
+		logrus.Errorf("Unable to get container stats: %v", err)
+	}
+}
@@ -1,15 +1,45 @@
 package compat
 
 import (
-	"fmt"
-	"net/http"
-	"time"
+	goruntime "runtime"
 
-	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	docker "github.com/docker/docker/api/types"
 )
 
-const DefaultStatsPeriod = 5 * time.Second
+// systemCPUUsage returns the CPU time available on the host in nanoseconds
+// at the time of the sample, so that clients computing the CPU usage as
+// the container's share of the host's CPU time get the same result as
+// libpod.
+func systemCPUUsage(stats *define.ContainerStats) uint64 {
+	return stats.SystemNano * uint64(goruntime.NumCPU())
+}
 
-func StatsContainer(w http.ResponseWriter, r *http.Request) {
-	utils.Error(w, http.StatusBadRequest, fmt.Errorf("compat.StatsContainer not supported on FreeBSD"))
+// platformStats returns the process, CPU and memory stats of a container
+// collected by libpod from the resource accounting of its jail.
+func platformStats(_ *libpod.Container, stats *define.ContainerStats, onlineCPUs int) (*Stats, error) {
+	if onlineCPUs == 0 {
+		onlineCPUs = goruntime.NumCPU()
+	}
+	return &Stats{
+		PidsStats: docker.PidsStats{
+			Current: stats.PIDs,
+			Limit:   stats.PIDsLimit,
+		},
+		CPUStats: CPUStats{
+			CPUUsage: docker.CPUUsage{
+				TotalUsage:        stats.CPUNano,
+				UsageInKernelmode: stats.CPUSystemNano,
+				UsageInUsermode:   stats.CPUNano - stats.CPUSystemNano,
+			},
+			CPU:         stats.CPU,
+			SystemUsage: systemCPUUsage(stats),
+			OnlineCPUs:  uint32(onlineCPUs),
+		},
+		MemoryStats: docker.MemoryStats{
+			Usage: stats.MemUsage,
+			Limit: stats.MemLimit,
+		},
+	}, nil
 }
@@ -59,6 +59,8 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 	libpodConfig.WorkDir = input.WorkingDir
 	libpodConfig.Privileged = input.Privileged
 	libpodConfig.User = input.User
+	libpodConfig.CPUs = input.CPUs
+	libpodConfig.Nice = input.Nice
 
 	if input.Tty {
 		util.ExecAddTERM(ctr.Env(), libpodConfig.Environment)
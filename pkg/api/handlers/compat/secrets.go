@@ -38,12 +38,11 @@ func ListSecrets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReports := make([]entities.SecretInfoReportCompat, 0, len(reports))
 	for _, report := range reports {
 		compatRep := entities.SecretInfoReportCompat{
 			SecretInfoReport: *report,
-			Version:          entities.SecretVersion{Index: 1},
+			Version:          entities.SecretVersion{Index: report.Version},
 		}
 		compatReports = append(compatReports, compatRep)
 	}
@@ -87,10 +86,9 @@ func InspectSecret(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReport := entities.SecretInfoReportCompat{
 		SecretInfoReport: *reports[0],
-		Version:          entities.SecretVersion{Index: 1},
+		Version:          entities.SecretVersion{Index: reports[0].Version},
 	}
 	utils.WriteResponse(w, http.StatusOK, compatReport)
 }
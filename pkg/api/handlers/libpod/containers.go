@@ -308,6 +308,7 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 		IgnoreStaticMAC bool   `schema:"ignoreStaticMAC"`
 		PrintStats      bool   `schema:"printStats"`
 		FileLocks       bool   `schema:"fileLocks"`
+		TCPClose        bool   `schema:"tcpClose"`
 		PublishPorts    string `schema:"publishPorts"`
 		Pod             string `schema:"pod"`
 	}{
@@ -328,6 +329,7 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 		IgnoreStaticMAC: query.IgnoreStaticMAC,
 		PrintStats:      query.PrintStats,
 		FileLocks:       query.FileLocks,
+		TCPClose:        query.TCPClose,
 		PublishPorts:    strings.Fields(query.PublishPorts),
 		Pod:             query.Pod,
 	}
@@ -399,6 +401,25 @@ func InitContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
 
+// ListExecSessions lists the exec sessions of a container, including the
+// resource usage of the running ones.
+func ListExecSessions(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	name := utils.GetName(r)
+	sessions, err := containerEngine.ContainerExecList(r.Context(), name, entities.ExecListOptions{})
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, sessions)
+}
+
 func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
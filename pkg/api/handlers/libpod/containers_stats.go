@@ -8,6 +8,7 @@ import (
 
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -86,3 +87,21 @@ func StatsContainer(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// StatsSnapshot returns the stats of all running containers collected in a
+// single pass. It is meant for monitoring agents which scrape the stats of
+// many containers at short intervals.
+func StatsSnapshot(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	stats, err := runtime.GetRunningContainersStats(nil)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	report := entities.ContainerStatsReport{Stats: make([]define.ContainerStats, 0, len(stats))}
+	for _, s := range stats {
+		report.Stats = append(report.Stats, *s)
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
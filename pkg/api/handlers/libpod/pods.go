@@ -540,6 +540,7 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		NamesOrIDs []string `schema:"namesOrIDs"`
 		All        bool     `schema:"all"`
+		Aggregate  bool     `schema:"aggregate"`
 		Stream     bool     `schema:"stream"`
 		Delay      int      `schema:"delay"`
 	}{
@@ -553,7 +554,7 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate input.
-	options := entities.PodStatsOptions{All: query.All}
+	options := entities.PodStatsOptions{All: query.All, Aggregate: query.Aggregate}
 	if err := entities.ValidatePodStatsOptions(query.NamesOrIDs, &options); err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -161,6 +161,10 @@ type HistoryResponse struct {
 
 type ExecCreateConfig struct {
 	docker.ExecConfig
+	// CPUs limits the number of CPUs the exec session may use.
+	CPUs float64 `json:"CPUs,omitempty"`
+	// Nice is the nice value of the exec session's process.
+	Nice int `json:"Nice,omitempty"`
 }
 
 type ExecStartConfig struct {
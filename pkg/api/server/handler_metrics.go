@@ -0,0 +1,184 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// containerMetric describes a per-container metric exported in the
+// Prometheus text format. Cumulative values are counters, whose names end
+// in _total, the others are gauges.
+type containerMetric struct {
+	name       string
+	help       string
+	metricType string
+	value      func(*define.ContainerStats) float64
+}
+
+var containerMetrics = []containerMetric{
+	{
+		name:       "podman_container_cpu_seconds_total",
+		help:       "Total CPU time consumed by the container in seconds.",
+		metricType: "counter",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.CPUNano) / 1e9 },
+	},
+	{
+		name:       "podman_container_cpu_percent",
+		help:       "CPU usage of the container since the previous scrape in percent of a single CPU.",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return s.CPU },
+	},
+	{
+		name:       "podman_container_memory_usage_bytes",
+		help:       "Memory used by the container in bytes.",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.MemUsage) },
+	},
+	{
+		name:       "podman_container_memory_limit_bytes",
+		help:       "Memory limit of the container in bytes.",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.MemLimit) },
+	},
+	{
+		name:       "podman_container_pids",
+		help:       "Number of processes in the container.",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.PIDs) },
+	},
+	{
+		name:       "podman_container_block_input_bytes",
+		help:       "Bytes read from block devices by the container (per second on FreeBSD).",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.BlockInput) },
+	},
+	{
+		name:       "podman_container_block_output_bytes",
+		help:       "Bytes written to block devices by the container (per second on FreeBSD).",
+		metricType: "gauge",
+		value:      func(s *define.ContainerStats) float64 { return float64(s.BlockOutput) },
+	},
+}
+
+// containerNetworkMetric describes a per-interface metric exported in the
+// Prometheus text format.
+type containerNetworkMetric struct {
+	name       string
+	help       string
+	metricType string
+	value      func(define.ContainerNetworkStats) float64
+}
+
+var containerNetworkMetrics = []containerNetworkMetric{
+	{
+		name:       "podman_container_network_receive_bytes_total",
+		help:       "Bytes received by the container on a network interface.",
+		metricType: "counter",
+		value:      func(s define.ContainerNetworkStats) float64 { return float64(s.RxBytes) },
+	},
+	{
+		name:       "podman_container_network_transmit_bytes_total",
+		help:       "Bytes transmitted by the container on a network interface.",
+		metricType: "counter",
+		value:      func(s define.ContainerNetworkStats) float64 { return float64(s.TxBytes) },
+	},
+}
+
+// metricsCollector serves the stats of the running containers for
+// Prometheus. The stats of the previous scrape are kept to compute the CPU
+// usage between scrapes.
+type metricsCollector struct {
+	// getStats returns the stats of the running containers, see
+	// libpod.Runtime.GetRunningContainersStats.
+	getStats func(previous map[string]*define.ContainerStats) ([]*define.ContainerStats, error)
+	lock     sync.Mutex
+	previous map[string]*define.ContainerStats
+}
+
+func newMetricsCollector(runtime *libpod.Runtime) *metricsCollector {
+	return &metricsCollector{
+		getStats: runtime.GetRunningContainersStats,
+		previous: make(map[string]*define.ContainerStats),
+	}
+}
+
+func (m *metricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stats, err := m.collect()
+	if err != nil {
+		logrus.Errorf("Collecting container metrics: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := writeMetrics(w, stats); err != nil {
+		logrus.Errorf("Writing container metrics: %v", err)
+	}
+}
+
+// collect returns the stats of all running containers.
+func (m *metricsCollector) collect() ([]*define.ContainerStats, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	stats, err := m.getStats(m.previous)
+	if err != nil {
+		if stats == nil {
+			return nil, err
+		}
+		// Leave out the containers whose stats cannot be read
+		// rather than failing the whole scrape.
+		logrus.Errorf("Collecting container metrics: %v", err)
+	}
+	current := make(map[string]*define.ContainerStats, len(stats))
+	for _, s := range stats {
+		current[s.ContainerID] = s
+	}
+	m.previous = current
+	return stats, nil
+}
+
+// writeMetrics writes the given container stats in the Prometheus text
+// exposition format.
+func writeMetrics(w io.Writer, stats []*define.ContainerStats) error {
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+
+	var b strings.Builder
+	for _, metric := range containerMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.metricType)
+		for _, s := range stats {
+			fmt.Fprintf(&b, "%s{id=\"%s\",name=\"%s\"} %g\n", metric.name, escapeLabelValue(s.ContainerID), escapeLabelValue(s.Name), metric.value(s))
+		}
+	}
+	for _, metric := range containerNetworkMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.metricType)
+		for _, s := range stats {
+			ifaces := make([]string, 0, len(s.Network))
+			for iface := range s.Network {
+				ifaces = append(ifaces, iface)
+			}
+			sort.Strings(ifaces)
+			for _, iface := range ifaces {
+				fmt.Fprintf(&b, "%s{id=\"%s\",name=\"%s\",interface=\"%s\"} %g\n", metric.name, escapeLabelValue(s.ContainerID), escapeLabelValue(s.Name), escapeLabelValue(iface), metric.value(s.Network[iface]))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a Prometheus label value.
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	stats := []*define.ContainerStats{
+		{
+			ContainerID: "b2",
+			Name:        "web",
+			CPUNano:     2500000000,
+			MemUsage:    1048576,
+			Network: map[string]define.ContainerNetworkStats{
+				"eth0": {RxBytes: 100, TxBytes: 200},
+			},
+		},
+		{
+			ContainerID: "a1",
+			Name:        `db"1`,
+			PIDs:        3,
+		},
+	}
+
+	var b strings.Builder
+	require.NoError(t, writeMetrics(&b, stats))
+	out := b.String()
+
+	assert.Contains(t, out, "# TYPE podman_container_cpu_seconds_total counter\n")
+	assert.Contains(t, out, "podman_container_cpu_seconds_total{id=\"a1\",name=\"db\\\"1\"} 0\npodman_container_cpu_seconds_total{id=\"b2\",name=\"web\"} 2.5\n")
+	assert.Contains(t, out, "# TYPE podman_container_memory_usage_bytes gauge\n")
+	assert.Contains(t, out, "podman_container_memory_usage_bytes{id=\"b2\",name=\"web\"} 1.048576e+06\n")
+	assert.Contains(t, out, "podman_container_pids{id=\"a1\",name=\"db\\\"1\"} 3\n")
+	assert.Contains(t, out, "# TYPE podman_container_network_transmit_bytes_total counter\n")
+	assert.Contains(t, out, "podman_container_network_transmit_bytes_total{id=\"b2\",name=\"web\",interface=\"eth0\"} 200\n")
+	assert.NotContains(t, out, "interface=\"eth0\"} 0")
+}
+
+func TestMetricsCollectorErrors(t *testing.T) {
+	var statsErr error
+	m := &metricsCollector{
+		getStats: func(previous map[string]*define.ContainerStats) ([]*define.ContainerStats, error) {
+			if statsErr != nil && strings.Contains(statsErr.Error(), "listing") {
+				return nil, statsErr
+			}
+			return []*define.ContainerStats{{ContainerID: "a1", Name: "db", PIDs: 3}}, statsErr
+		},
+		previous: make(map[string]*define.ContainerStats),
+	}
+
+	// A container whose stats cannot be read is left out of the scrape
+	statsErr = errors.New("getting stats of container b2: failed")
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "podman_container_pids{id=\"a1\",name=\"db\"} 3\n")
+	assert.Contains(t, m.previous, "a1")
+
+	// The scrape fails if the containers cannot be listed
+	statsErr = errors.New("listing containers: failed")
+	w = httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
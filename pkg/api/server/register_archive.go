@@ -152,6 +152,11 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     description: Path to a directory in the container to extract
 	//     required: true
 	//   - in: query
+	//     name: noFollowLink
+	//     type: boolean
+	//     description: if path is a symbolic link, copy the link itself instead of its target
+	//     default: false
+	//   - in: query
 	//     name: rename
 	//     type: string
 	//     description: JSON encoded map[string]string to translate paths
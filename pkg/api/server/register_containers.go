@@ -1157,6 +1157,24 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/stats"), s.APIHandler(libpod.StatsContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/stats/snapshot libpod ContainersStatsSnapshotLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get a snapshot of the stats of all running containers
+	// description: |
+	//   Return the resource usage statistics of all running containers, collected in a single pass.
+	//   The network statistics of containers sharing a network namespace, e.g. the containers of a pod, are only read once.
+	//   Unlike /libpod/containers/stats, the response is not streamed, which makes it suitable for monitoring agents scraping the statistics of many containers at short intervals.
+	//   The CPU percentage is the average since the container was started, or on FreeBSD the recent CPU usage reported by the kernel.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerStats"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/stats/snapshot"), s.APIHandler(libpod.StatsSnapshot)).Methods(http.MethodGet)
 
 	// swagger:operation GET /libpod/containers/{name}/top libpod ContainerTopLibpod
 	// ---
@@ -1511,6 +1529,12 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: format
+	//    type: string
+	//    default: tar
+	//    description: |
+	//      Format of the export: tar, or zfs for a zfs send stream of the container's dataset. The zfs format requires the zfs storage driver.
 	// produces:
 	// - application/json
 	// responses:
@@ -1631,6 +1655,10 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description: restore a container with file locks
 	//  - in: query
+	//    name: tcpClose
+	//    type: boolean
+	//    description: restore a container with established TCP connections but close them
+	//  - in: query
 	//    name: printStats
 	//    type: boolean
 	//    description: add restore statistics to the returned RestoreReport
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v5/pkg/api/handlers/compat"
+	"github.com/containers/podman/v5/pkg/api/handlers/libpod"
 	"github.com/gorilla/mux"
 )
 
@@ -237,6 +238,12 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//        WorkingDir:
 	//          type: string
 	//          description: The working directory for the exec process inside the container.
+	//        CPUs:
+	//          type: number
+	//          description: Number of CPUs the exec process may use. Only supported on FreeBSD.
+	//        Nice:
+	//          type: integer
+	//          description: Nice value of the exec process, from -20 to 20.
 	// produces:
 	// - application/json
 	// responses:
@@ -346,6 +353,29 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/exec/{id}/json"), s.APIHandler(compat.ExecInspectHandler)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/exec/json libpod ExecListLibpod
+	// ---
+	// tags:
+	//   - exec
+	// summary: List exec sessions
+	// description: |
+	//   List the exec sessions of a container. The CPU time and memory usage of running sessions are recorded when they are listed.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: name or ID of container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/containers/{name}/exec/json"), s.APIHandler(libpod.ListExecSessions)).Methods(http.MethodGet)
 	// ................. .... ........................ ...... ExecRemoveLibpod
 	// ---
 	// tags:
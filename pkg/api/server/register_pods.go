@@ -332,6 +332,10 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//    description: Provide statistics for all running pods.
 	//    type: boolean
 	//  - in: query
+	//    name: aggregate
+	//    description: Provide one set of statistics per pod, summed over its containers.
+	//    type: boolean
+	//  - in: query
 	//    name: namesOrIDs
 	//    description: Names or IDs of pods.
 	//    type: array
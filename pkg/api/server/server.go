@@ -35,6 +35,7 @@ type APIServer struct {
 	context.Context                  // Context to carry objects to handlers
 	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
 	PProfAddr          string        // Binding network address for pprof profiles
+	MetricsAddr        string        // Binding network address for Prometheus metrics
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
 }
 
@@ -85,6 +86,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		CorsHeaders: opts.CorsHeaders,
 		Listener:    listener,
 		PProfAddr:   opts.PProfAddr,
+		MetricsAddr: opts.MetricsAddr,
 		idleTracker: tracker,
 	}
 
@@ -193,6 +195,7 @@ func (s *APIServer) setupSystemd() {
 // Serve starts responding to HTTP requests.
 func (s *APIServer) Serve() error {
 	s.setupPprof()
+	s.setupMetrics()
 
 	if err := shutdown.Register("service", func(sig os.Signal) error {
 		return s.Shutdown(true)
@@ -257,6 +260,28 @@ func (s *APIServer) setupPprof() {
 	}()
 }
 
+// setupMetrics serves the stats of the running containers in the Prometheus
+// text format on /metrics
+//
+// Example:
+// curl http://localhost:9882/metrics
+func (s *APIServer) setupMetrics() {
+	if s.MetricsAddr == "" {
+		return
+	}
+
+	logrus.Infof("Metrics service listening on %q", s.MetricsAddr)
+	go func() {
+		router := mux.NewRouter()
+		router.Handle("/metrics", newMetricsCollector(s.Runtime)).Methods(http.MethodGet)
+
+		err := http.ListenAndServe(s.MetricsAddr, router)
+		if err != nil && err != http.ErrServerClosed {
+			logrus.Warnf("Metrics service failed: %v", err)
+		}
+	}()
+}
+
 // Shutdown is a clean shutdown waiting on existing clients
 func (s *APIServer) Shutdown(halt bool) error {
 	switch {
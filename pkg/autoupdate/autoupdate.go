@@ -47,6 +47,7 @@ type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
 	options          *entities.AutoUpdateOptions // User-specified options
 	unitToTasks      map[string][]*task          // Keeps track of tasks per unit
+	tasks            []*task                     // Tasks of containers not running in a systemd unit
 	updatedRawImages map[string]bool             // Keeps track of updated images
 	runtime          *libpod.Runtime             // The libpod runtime
 }
@@ -68,7 +69,7 @@ type task struct {
 	image        *libimage.Image   // Original image before the update
 	rawImageName string            // The container's raw image name
 	status       string            // Auto-update status
-	unit         string            // Name of the systemd unit
+	unit         string            // Name of the systemd unit, empty if the container is recreated by libpod
 }
 
 // LookupPolicy looks up the corresponding Policy for the specified
@@ -106,6 +107,9 @@ func LookupPolicy(s string) (Policy, error) {
 // of a running container is different than the local one. If the image digests
 // differ, it restarts the systemd unit with the new image.
 //
+// Containers which do not run in a systemd unit are recreated with the new
+// image and started again by libpod directly.
+//
 // It returns a slice of successfully restarted systemd units and a slice of
 // errors encountered during auto update.
 func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
@@ -123,24 +127,35 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	allErrors := auto.assembleTasks(ctx)
 
 	// Nothing to do.
-	if len(auto.unitToTasks) == 0 {
+	if len(auto.unitToTasks) == 0 && len(auto.tasks) == 0 {
 		return nil, allErrors
 	}
 
+	runtime.NewSystemEvent(events.AutoUpdate)
+
+	// Update all images/container according to their auto-update policy.
+	var allReports []*entities.AutoUpdateReport
+	for _, task := range auto.tasks {
+		if err := auto.updateContainer(ctx, task); err != nil {
+			allErrors = append(allErrors, err)
+		}
+		allReports = append(allReports, task.report())
+	}
+
+	if len(auto.unitToTasks) == 0 {
+		return allReports, allErrors
+	}
+
 	// Connect to DBUS.
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		logrus.Errorf(err.Error())
 		allErrors = append(allErrors, err)
-		return nil, allErrors
+		return allReports, allErrors
 	}
 	defer conn.Close()
 	auto.conn = conn
 
-	runtime.NewSystemEvent(events.AutoUpdate)
-
-	// Update all images/container according to their auto-update policy.
-	var allReports []*entities.AutoUpdateReport
 	for unit, tasks := range auto.unitToTasks {
 		unitErrors := auto.updateUnit(ctx, unit, tasks)
 		allErrors = append(allErrors, unitErrors...)
@@ -235,6 +250,68 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 	return errors
 }
 
+// updateContainer auto updates a task whose container does not run in a
+// systemd unit by recreating the container with the new image.
+func (u *updater) updateContainer(ctx context.Context, task *task) error {
+	updateAvailable, err := task.updateAvailable(ctx)
+	if err != nil {
+		task.status = statusFailed
+		return fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)
+	}
+
+	if !updateAvailable {
+		task.status = statusNotUpdated
+		return nil
+	}
+
+	if u.options.DryRun {
+		task.status = statusPending
+		return nil
+	}
+
+	if err := task.update(ctx); err != nil {
+		task.status = statusFailed
+		return fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)
+	}
+
+	updateError := task.recreate(ctx)
+	if updateError == nil {
+		task.status = statusUpdated
+		return nil
+	}
+	task.status = statusFailed
+	if !u.options.Rollback {
+		return fmt.Errorf("recreating container %s during update: %w", task.container.ID(), updateError)
+	}
+
+	// The update has failed and rollbacks are enabled.
+	if err := task.rollbackImage(); err != nil {
+		return fmt.Errorf("rolling back image for container %s: %w", task.container.ID(), err)
+	}
+	if err := task.recreate(ctx); err != nil {
+		return fmt.Errorf("recreating container %s during rollback: %w", task.container.ID(), err)
+	}
+	task.status = statusRolledBack
+	return nil
+}
+
+// recreate replaces the task's container with a new one using the image its
+// raw image name currently refers to.
+func (t *task) recreate(ctx context.Context) error {
+	image, _, err := t.auto.runtime.LibimageRuntime().LookupImage(t.rawImageName, nil)
+	if err != nil {
+		return err
+	}
+	ctr, err := t.auto.runtime.RecreateContainer(ctx, t.container, image.ID())
+	if ctr != nil {
+		t.container = ctr
+	}
+	if err == nil {
+		logrus.Infof("Successfully recreated container %s with image %s", ctr.ID(), image.ID())
+	}
+	return err
+}
+
 // report creates an auto-update report for the task.
 func (t *task) report() *entities.AutoUpdateReport {
 	return &entities.AutoUpdateReport{
@@ -396,18 +473,13 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			continue
 		}
 
-		// Make sure the container runs in a systemd unit which is
+		// Look up the systemd unit the container runs in, which is
 		// stored as a label at container creation.
-		unit, exists, err := u.systemdUnitForContainer(ctr, labels)
+		unit, inUnit, err := u.systemdUnitForContainer(ctr, labels)
 		if err != nil {
 			errors = append(errors, err)
 			continue
 		}
-		if !exists {
-			errors = append(errors, fmt.Errorf("auto-updating container %q: no %s label found", ctr.ID(), systemdDefine.EnvVariable))
-			continue
-		}
-
 		id, _ := ctr.Image()
 		image, exists := imageMap[id]
 		if !exists {
@@ -439,6 +511,13 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			status:       statusFailed, // must be updated later on
 		}
 
+		// Without a systemd unit, the container is recreated by
+		// libpod.
+		if !inUnit {
+			u.tasks = append(u.tasks, &t)
+			continue
+		}
+
 		// Add the task to the unit.
 		u.unitToTasks[unit] = append(u.unitToTasks[unit], &t)
 	}
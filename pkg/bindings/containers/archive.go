@@ -5,7 +5,6 @@ import (
 	"errors"
 	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/copy"
@@ -16,11 +15,19 @@ import (
 // report may be set even in case of an error.  This happens when the path
 // resolves to symlink pointing to a non-existent path.
 func Stat(ctx context.Context, nameOrID string, path string) (*types.ContainerStatReport, error) {
+	return StatWithOptions(ctx, nameOrID, path, nil)
+}
+
+// StatWithOptions checks if the specified path is on the container, see Stat.
+func StatWithOptions(ctx context.Context, nameOrID string, path string, options *ArchiveOptions) (*types.ContainerStatReport, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
 	params.Set("path", path)
 
 	response, err := conn.DoRequest(ctx, nil, http.MethodHead, "/containers/%s/archive", params, nil, nameOrID)
@@ -83,11 +90,19 @@ func CopyFromArchiveWithOptions(ctx context.Context, nameOrID string, path strin
 
 // CopyToArchive copy files from container
 func CopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (types.ContainerCopyFunc, error) {
+	return CopyToArchiveWithOptions(ctx, nameOrID, path, writer, nil)
+}
+
+// CopyToArchiveWithOptions copy files from container
+func CopyToArchiveWithOptions(ctx context.Context, nameOrID string, path string, writer io.Writer, options *ArchiveOptions) (types.ContainerCopyFunc, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
 	params.Set("path", path)
 
 	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/archive", params, nil, nameOrID)
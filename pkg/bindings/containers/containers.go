@@ -278,6 +278,23 @@ func Stats(ctx context.Context, containers []string, options *StatsOptions) (cha
 	return statsChan, nil
 }
 
+// StatsSnapshot returns the stats of all running containers, collected by
+// the service in a single pass.
+func StatsSnapshot(ctx context.Context) (*types.ContainerStatsReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/stats/snapshot", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var report types.ContainerStatsReport
+	return &report, response.Process(&report)
+}
+
 // Top gathers statistics about the running processes in a container. The nameOrID can be a container name
 // or a partial/full ID.  The descriptors allow for specifying which data to collect from the process.
 func Top(ctx context.Context, nameOrID string, options *TopOptions) ([]string, error) {
@@ -415,8 +432,10 @@ func Export(ctx context.Context, nameOrID string, w io.Writer, options *ExportOp
 	if options == nil {
 		options = new(ExportOptions)
 	}
-	_ = options
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return err
@@ -79,6 +79,31 @@ func ExecInspect(ctx context.Context, sessionID string, options *ExecInspectOpti
 	return respStruct, nil
 }
 
+// ExecList lists the exec sessions of a container.
+func ExecList(ctx context.Context, nameOrID string, options *ExecListOptions) ([]*define.InspectExecSession, error) {
+	if options == nil {
+		options = new(ExecListOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/exec/json", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var sessions []*define.InspectExecSession
+	if err := resp.Process(&sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
 // ExecStart starts (but does not attach to) a given exec session.
 func ExecStart(ctx context.Context, sessionID string, options *ExecStartOptions) error {
 	if options == nil {
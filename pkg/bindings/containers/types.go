@@ -87,6 +87,7 @@ type RestoreOptions struct {
 	PrintStats     *bool
 	PublishPorts   []string
 	FileLocks      *bool
+	TCPClose       *bool
 }
 
 // CreateOptions are optional options for creating containers
@@ -110,6 +111,12 @@ type DiffOptions struct {
 //go:generate go run ../generator/generator.go ExecInspectOptions
 type ExecInspectOptions struct{}
 
+// ExecListOptions are optional options for listing the exec sessions of a
+// container
+//
+//go:generate go run ../generator/generator.go ExecListOptions
+type ExecListOptions struct{}
+
 // ExecStartOptions are optional options for starting
 // exec sessions
 //
@@ -253,7 +260,9 @@ type StopOptions struct {
 // ExportOptions are optional options for exporting containers
 //
 //go:generate go run ../generator/generator.go ExportOptions
-type ExportOptions struct{}
+type ExportOptions struct {
+	Format *string
+}
 
 // InitOptions are optional options for initing containers
 //
@@ -336,6 +345,16 @@ type CopyOptions struct {
 	NoOverwriteDirNonDir *bool
 }
 
+// ArchiveOptions are options for stat'ing and copying from a path in a
+// container.
+//
+//go:generate go run ../generator/generator.go ArchiveOptions
+type ArchiveOptions struct {
+	// NoFollowLink when true describes and copies a symbolic link itself
+	// instead of its target.
+	NoFollowLink *bool
+}
+
 // ExecRemoveOptions are optional options for removing an exec session
 //
 //go:generate go run ../generator/generator.go ExecRemoveOptions
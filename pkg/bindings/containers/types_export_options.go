@@ -16,3 +16,18 @@ func (o *ExportOptions) Changed(fieldName string) bool {
 func (o *ExportOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithFormat set field Format to given value
+func (o *ExportOptions) WithFormat(value string) *ExportOptions {
+	o.Format = &value
+	return o
+}
+
+// GetFormat returns value of field Format
+func (o *ExportOptions) GetFormat() string {
+	if o.Format == nil {
+		var z string
+		return z
+	}
+	return *o.Format
+}
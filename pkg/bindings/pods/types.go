@@ -74,7 +74,8 @@ type UnpauseOptions struct {
 //
 //go:generate go run ../generator/generator.go StatsOptions
 type StatsOptions struct {
-	All *bool
+	All       *bool
+	Aggregate *bool
 }
 
 // RemoveOptions are optional options for removing pods
@@ -31,3 +31,18 @@ func (o *StatsOptions) GetAll() bool {
 	}
 	return *o.All
 }
+
+// WithAggregate set field Aggregate to given value
+func (o *StatsOptions) WithAggregate(value bool) *StatsOptions {
+	o.Aggregate = &value
+	return o
+}
+
+// GetAggregate returns value of field Aggregate
+func (o *StatsOptions) GetAggregate() bool {
+	if o.Aggregate == nil {
+		var z bool
+		return z
+	}
+	return *o.Aggregate
+}
@@ -18,6 +18,8 @@ type PruneOptions struct {
 	Filters  map[string][]string
 	Volumes  *bool
 	External *bool
+	DryRun   *bool
+	Orphans  *bool
 }
 
 // VersionOptions are optional options for getting version info
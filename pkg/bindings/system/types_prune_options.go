@@ -76,3 +76,33 @@ func (o *PruneOptions) GetExternal() bool {
 	}
 	return *o.External
 }
+
+// WithDryRun set field DryRun to given value
+func (o *PruneOptions) WithDryRun(value bool) *PruneOptions {
+	o.DryRun = &value
+	return o
+}
+
+// GetDryRun returns value of field DryRun
+func (o *PruneOptions) GetDryRun() bool {
+	if o.DryRun == nil {
+		var z bool
+		return z
+	}
+	return *o.DryRun
+}
+
+// WithOrphans set field Orphans to given value
+func (o *PruneOptions) WithOrphans(value bool) *PruneOptions {
+	o.Orphans = &value
+	return o
+}
+
+// GetOrphans returns value of field Orphans
+func (o *PruneOptions) GetOrphans() bool {
+	if o.Orphans == nil {
+		var z bool
+		return z
+	}
+	return *o.Orphans
+}
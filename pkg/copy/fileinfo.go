@@ -55,13 +55,24 @@ func ExtractFileInfoFromHeader(header *http.Header) (*FileInfo, error) {
 
 // ResolveHostPath resolves the specified, possibly relative, path on the host.
 func ResolveHostPath(path string) (*FileInfo, error) {
+	return resolveHostPath(path, os.Stat)
+}
+
+// ResolveHostPathNoFollow resolves the specified, possibly relative, path on
+// the host.  If the path is a symbolic link, the link itself is described
+// instead of its target.
+func ResolveHostPathNoFollow(path string) (*FileInfo, error) {
+	return resolveHostPath(path, os.Lstat)
+}
+
+func resolveHostPath(path string, stat func(string) (os.FileInfo, error)) (*FileInfo, error) {
 	resolvedHostPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, err
 	}
 	resolvedHostPath = PreserveBasePath(path, resolvedHostPath)
 
-	statInfo, err := os.Stat(resolvedHostPath)
+	statInfo, err := stat(resolvedHostPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, ErrENOENT
@@ -186,12 +186,49 @@ type CopyOptions struct {
 	NoOverwriteDirNonDir bool
 }
 
+// ArchiveOptions are options for stat'ing and copying a path in a container.
+type ArchiveOptions struct {
+	// NoFollowLink when true describes and copies a symbolic link itself
+	// instead of its target.
+	NoFollowLink bool
+}
+
 type CommitReport struct {
 	Id string //nolint:revive,stylecheck
 }
 
 type ContainerExportOptions struct {
 	Output io.Writer
+	// Format is the format of the export, "tar" (the default) or "zfs"
+	// for a zfs send stream of the container's dataset.
+	Format string
+}
+
+// ContainerDebugBundleOptions describes the options for collecting a debug
+// bundle for a container
+type ContainerDebugBundleOptions struct {
+	Output io.Writer
+}
+
+// ContainerTraceOptions describes the options for tracing the processes of a
+// container
+type ContainerTraceOptions struct {
+	// Tool is the tracing tool, dtrace or ktrace
+	Tool string
+	// Probes are the DTrace probe descriptions to enable
+	Probes []string
+	// Duration stops tracing after the given time if set
+	Duration time.Duration
+	// Output is the file on the host which receives the trace
+	Output string
+	// Stdout receives the dtrace output if Output is not set
+	Stdout io.Writer
+}
+
+// ContainerCoresExportOptions describes the options for exporting a core dump
+// collected from a container
+type ContainerCoresExportOptions struct {
+	Output io.Writer
 }
 
 type CheckpointOptions struct {
@@ -230,6 +267,7 @@ type RestoreOptions struct {
 	Pod             string
 	PrintStats      bool
 	FileLocks       bool
+	TCPClose        bool
 }
 
 type RestoreReport = types.RestoreReport
@@ -280,10 +318,12 @@ type ContainerLogsOptions struct {
 // a container
 type ExecOptions struct {
 	Cmd         []string
+	CPUs        float64
 	DetachKeys  string
 	Envs        map[string]string
 	Interactive bool
 	Latest      bool
+	Nice        int
 	PreserveFDs uint
 	PreserveFD  []uint
 	Privileged  bool
@@ -292,6 +332,12 @@ type ExecOptions struct {
 	WorkDir     string
 }
 
+// ExecListOptions describes the cli values to list the exec sessions of a
+// container
+type ExecListOptions struct {
+	Latest bool
+}
+
 // ContainerExistsOptions describes the cli values to check if a container exists
 type ContainerExistsOptions struct {
 	External bool
@@ -306,10 +352,19 @@ type ContainerStartOptions struct {
 	DetachKeys  string
 	Interactive bool
 	Latest      bool
-	SigProxy    bool
-	Stdout      *os.File
-	Stderr      *os.File
-	Stdin       *os.File
+	// Ordered starts the containers one at a time in dependency order.
+	Ordered  bool
+	SigProxy bool
+	// Timeout is the number of seconds to wait for each container to
+	// start when Ordered is set. Zero waits indefinitely.
+	Timeout uint
+	Stdout  *os.File
+	Stderr  *os.File
+	Stdin   *os.File
+
+	// AllPreviouslyRunning starts the containers which were running
+	// before the host was shut down.
+	AllPreviouslyRunning bool
 }
 
 // ContainerStartReport describes the response from starting
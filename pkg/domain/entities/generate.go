@@ -53,3 +53,11 @@ type GenerateSpecOptions struct {
 	Compact  bool
 	Name     bool
 }
+
+type GenerateJailConfReport = types.GenerateJailConfReport
+
+// GenerateJailConfOptions control the generation of jail.conf files.
+type GenerateJailConfOptions struct {
+	ID       string
+	FileName string
+}
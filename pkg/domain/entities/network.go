@@ -16,8 +16,9 @@ type NetworkListOptions struct {
 // NetworkReloadOptions describes options for reloading container network
 // configuration.
 type NetworkReloadOptions struct {
-	All    bool
-	Latest bool
+	All     bool
+	Cleanup bool
+	Latest  bool
 }
 
 // NetworkReloadReport describes the results of reloading a container network.
@@ -96,6 +96,7 @@ type PodCreateOptions struct {
 	Pid                string            `json:"pid,omitempty"`
 	Cpus               float64           `json:"cpus,omitempty"`
 	CpusetCpus         string            `json:"cpuset_cpus,omitempty"`
+	CPUPlacement       string            `json:"cpu_placement,omitempty"`
 	Userns             specgen.Namespace `json:"-"`
 	Volume             []string          `json:"volume,omitempty"`
 	VolumesFrom        []string          `json:"volumes_from,omitempty"`
@@ -134,140 +135,153 @@ const (
 )
 
 type ContainerCreateOptions struct {
-	Annotation         []string
-	Attach             []string
-	Authfile           string
-	BlkIOWeight        string
-	BlkIOWeightDevice  []string
-	CapAdd             []string
-	CapDrop            []string
-	CgroupNS           string
-	CgroupsMode        string
-	CgroupParent       string `json:"cgroup_parent,omitempty"`
-	CIDFile            string
-	ConmonPIDFile      string `json:"container_conmon_pidfile,omitempty"`
-	CPUPeriod          uint64
-	CPUQuota           int64
-	CPURTPeriod        uint64
-	CPURTRuntime       int64
-	CPUShares          uint64
-	CPUS               float64 `json:"cpus,omitempty"`
-	CPUSetCPUs         string  `json:"cpuset_cpus,omitempty"`
-	CPUSetMems         string
-	Devices            []string `json:"devices,omitempty"`
-	DeviceCgroupRule   []string
-	DeviceReadBPs      []string `json:"device_read_bps,omitempty"`
-	DeviceReadIOPs     []string
-	DeviceWriteBPs     []string
-	DeviceWriteIOPs    []string
-	Entrypoint         *string `json:"container_command,omitempty"`
-	Env                []string
-	EnvHost            bool
-	EnvFile            []string
-	Expose             []string
-	GIDMap             []string
-	GPUs               []string
-	GroupAdd           []string
-	HealthCmd          string
-	HealthInterval     string
-	HealthRetries      uint
-	HealthStartPeriod  string
-	HealthTimeout      string
-	HealthOnFailure    string
-	Hostname           string `json:"hostname,omitempty"`
-	HTTPProxy          bool
-	HostUsers          []string
-	ImageVolume        string
-	Init               bool
-	InitContainerType  string
-	InitPath           string
-	IntelRdtClosID     string
-	Interactive        bool
-	IPC                string
-	Label              []string
-	LabelFile          []string
-	LogDriver          string
-	LogOptions         []string
-	Memory             string
-	MemoryReservation  string
-	MemorySwap         string
-	MemorySwappiness   int64
-	Name               string `json:"container_name"`
-	NoHealthCheck      bool
-	OOMKillDisable     bool
-	OOMScoreAdj        *int
-	Arch               string
-	OS                 string
-	Variant            string
-	PID                string `json:"pid,omitempty"`
-	PIDsLimit          *int64
-	Platform           string
-	Pod                string
-	PodIDFile          string
-	Personality        string
-	PreserveFDs        uint
-	PreserveFD         []uint
-	Privileged         bool
-	PublishAll         bool
-	Pull               string
-	Quiet              bool
-	ReadOnly           bool
-	ReadWriteTmpFS     bool
-	Restart            string
-	Replace            bool
-	Requires           []string
-	Retry              *uint  `json:"retry,omitempty"`
-	RetryDelay         string `json:"retry_delay,omitempty"`
-	Rm                 bool
-	RootFS             bool
-	Secrets            []string
-	SecurityOpt        []string `json:"security_opt,omitempty"`
-	SdNotifyMode       string
-	ShmSize            string
-	ShmSizeSystemd     string
-	SignaturePolicy    string
-	StartupHCCmd       string
-	StartupHCInterval  string
-	StartupHCRetries   uint
-	StartupHCSuccesses uint
-	StartupHCTimeout   string
-	StopSignal         string
-	StopTimeout        uint
-	StorageOpts        []string
-	SubGIDName         string
-	SubUIDName         string
-	Sysctl             []string `json:"sysctl,omitempty"`
-	Systemd            string
-	Timeout            uint
-	TLSVerify          commonFlag.OptionalBool
-	TmpFS              []string
-	TTY                bool
-	Timezone           string
-	Umask              string
-	EnvMerge           []string
-	UnsetEnv           []string
-	UnsetEnvAll        bool
-	UIDMap             []string
-	Ulimit             []string
-	User               string
-	UserNS             string `json:"-"`
-	UTS                string
-	Mount              []string
-	Volume             []string `json:"volume,omitempty"`
-	VolumesFrom        []string `json:"volumes_from,omitempty"`
-	Workdir            string
-	SeccompPolicy      string
-	PidFile            string
-	ChrootDirs         []string
-	IsInfra            bool
-	IsClone            bool
-	DecryptionKeys     []string
-	Net                *NetOptions `json:"net,omitempty"`
+	Annotation          []string
+	Attach              []string
+	Authfile            string
+	BlkIOWeight         string
+	BlkIOWeightDevice   []string
+	CapAdd              []string
+	CapDrop             []string
+	CgroupNS            string
+	CgroupsMode         string
+	CgroupParent        string `json:"cgroup_parent,omitempty"`
+	CIDFile             string
+	ConmonPIDFile       string `json:"container_conmon_pidfile,omitempty"`
+	CPUPeriod           uint64
+	CPUQuota            int64
+	CPURTPeriod         uint64
+	CPURTRuntime        int64
+	CPUShares           uint64
+	CPUS                float64 `json:"cpus,omitempty"`
+	CPUSetCPUs          string  `json:"cpuset_cpus,omitempty"`
+	CPUSetMems          string
+	Devices             []string `json:"devices,omitempty"`
+	DeviceCgroupRule    []string
+	DeviceReadBPs       []string `json:"device_read_bps,omitempty"`
+	DeviceReadIOPs      []string
+	DeviceWriteBPs      []string
+	DeviceWriteIOPs     []string
+	Entrypoint          *string `json:"container_command,omitempty"`
+	Env                 []string
+	EnvHost             bool
+	EnvFile             []string
+	Expose              []string
+	GIDMap              []string
+	GPUs                []string
+	GroupAdd            []string
+	HealthCmd           string
+	HealthInterval      string
+	HealthRetries       uint
+	HealthStartPeriod   string
+	HealthTimeout       string
+	HealthOnFailure     string
+	Hostname            string `json:"hostname,omitempty"`
+	HTTPProxy           bool
+	HostUsers           []string
+	HostUserGroups      bool
+	ImageVolume         string
+	Init                bool
+	InitContainerType   string
+	InitPath            string
+	IntelRdtClosID      string
+	Interactive         bool
+	IPC                 string
+	JailProfile         string
+	Label               []string
+	LabelFile           []string
+	LogDriver           string
+	LogOptions          []string
+	Memory              string
+	MemoryReservation   string
+	MemoryReclaimSignal string
+	MemoryLock          string
+	IOPriority          string
+	MemorySwap          string
+	MemorySwappiness    int64
+	Name                string `json:"container_name"`
+	NoHealthCheck       bool
+	OOMKillDisable      bool
+	OOMScoreAdj         *int
+	Arch                string
+	OS                  string
+	Variant             string
+	PID                 string `json:"pid,omitempty"`
+	PIDsLimit           *int64
+	Platform            string
+	Pod                 string
+	PodIDFile           string
+	Personality         string
+	PreserveFDs         uint
+	PreserveFD          []uint
+	Privileged          bool
+	PublishAll          bool
+	Pull                string
+	Quiet               bool
+	ReadOnly            bool
+	ReadWriteTmpFS      bool
+	Restart             string
+	Replace             bool
+	Requires            []string
+	Retry               *uint  `json:"retry,omitempty"`
+	RetryDelay          string `json:"retry_delay,omitempty"`
+	Rm                  bool
+	RootFS              bool
+	Schedule            string
+	Secrets             []string
+	SecurityOpt         []string `json:"security_opt,omitempty"`
+	SdNotifyMode        string
+	ShmSize             string
+	ShmSizeSystemd      string
+	SignaturePolicy     string
+	StartupHCCmd        string
+	StartupHCInterval   string
+	StartupHCRetries    uint
+	StartupHCSuccesses  uint
+	StartupHCTimeout    string
+	StopSignal          string
+	StopTimeout         uint
+	StorageOpts         []string
+	SubGIDName          string
+	SubUIDName          string
+	Sysctl              []string `json:"sysctl,omitempty"`
+	Systemd             string
+	Timeout             uint
+	TLSVerify           commonFlag.OptionalBool
+	TmpFS               []string
+	TTY                 bool
+	Timezone            string
+	Umask               string
+	EnvMerge            []string
+	UnsetEnv            []string
+	UnsetEnvAll         bool
+	UIDMap              []string
+	Ulimit              []string
+	User                string
+	UserNS              string `json:"-"`
+	UTS                 string
+	Mount               []string
+	Volume              []string `json:"volume,omitempty"`
+	VolumesFrom         []string `json:"volumes_from,omitempty"`
+	Workdir             string
+	SeccompPolicy       string
+	PidFile             string
+	ChrootDirs          []string
+	CollectCores        bool
+	Console             bool
+	MetadataKeys        []string
+	IdentityCA          string
+	IdentityTTL         string
+	IsInfra             bool
+	IsClone             bool
+	DecryptionKeys      []string
+	Net                 *NetOptions `json:"net,omitempty"`
 
 	CgroupConf []string
 
 	GroupEntry  string
 	PasswdEntry string
+	NoGroup     bool
+	NoPasswd    bool
 }
 
 func NewInfraContainerCreateOptions() ContainerCreateOptions {
@@ -326,6 +340,7 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 	s.UtsNs = out
 	s.Hostname = p.Hostname
 	s.ExitPolicy = p.ExitPolicy
+	s.CPUPlacement = p.CPUPlacement
 	s.Labels = p.Labels
 	s.Devices = p.Devices
 	s.SecurityOpt = p.SecurityOpt
@@ -363,6 +378,7 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 		s.DNSServer = p.Net.DNSServers
 		s.DNSSearch = p.Net.DNSSearch
 		s.DNSOption = p.Net.DNSOptions
+		s.Routes = p.Net.Routes
 		s.NoManageHosts = p.Net.NoHosts
 		s.HostAdd = p.Net.AddHosts
 	}
@@ -432,6 +448,9 @@ type PodStatsOptions struct {
 	All bool
 	// Latest - provide stats for the latest pod.
 	Latest bool
+	// Aggregate - provide one report per pod, summed over its
+	// containers, instead of one report per container.
+	Aggregate bool
 }
 
 // PodStatsReport includes pod-resource statistics data.
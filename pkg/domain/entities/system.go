@@ -8,6 +8,7 @@ import (
 type ServiceOptions = types.ServiceOptions
 type SystemPruneOptions = types.SystemPruneOptions
 type SystemPruneReport = types.SystemPruneReport
+type OrphanPruneReport = types.OrphanPruneReport
 type SystemMigrateOptions = types.SystemMigrateOptions
 type SystemDfOptions = types.SystemDfOptions
 type SystemDfReport = types.SystemDfReport
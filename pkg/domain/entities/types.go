@@ -58,6 +58,7 @@ type NetOptions struct {
 	Network            specgen.Namespace                  `json:"netns,omitempty"`
 	NoHosts            bool                               `json:"no_manage_hosts,omitempty"`
 	PublishPorts       []types.PortMapping                `json:"portmappings,omitempty"`
+	Routes             []types.Route                      `json:"routes,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
 }
@@ -81,11 +82,16 @@ type DiffOptions struct {
 	Format string          `json:",omitempty"` // CLI only
 	Latest bool            `json:",omitempty"` // API and CLI, only supported by containers
 	Type   define.DiffType // Type which should be compared
+	// FileFlags also compares the file flags (FreeBSD only), which
+	// walks all files unless zfs diff can be used
+	FileFlags bool `json:",omitempty"`
 }
 
 // DiffReport provides changes for object
 type DiffReport struct {
 	Changes []archive.Change
+	// FlagChanges lists files whose file flags changed (FreeBSD only)
+	FlagChanges []define.FileFlagChange `json:",omitempty"`
 }
 
 type EventsOptions struct {
@@ -44,8 +44,14 @@ type ListContainer struct {
 	Namespaces ListContainerNamespaces
 	// The network names assigned to the container
 	Networks []string
+	// OpenFiles is the number of open file descriptors in the running
+	// container
+	OpenFiles uint64 `json:",omitempty"`
 	// The process id of the container
 	Pid int
+	// Jid is the ID of the jail of the running container. This is only
+	// reported on FreeBSD.
+	Jid int `json:",omitempty"`
 	// If the container is part of Pod, the Pod ID. Requires the pod
 	// boolean to be set
 	Pod string
@@ -66,6 +72,8 @@ type ListContainer struct {
 	State string
 	// Status is a human-readable approximation of a duration for json output
 	Status string
+	// Threads is the number of threads in the running container
+	Threads uint64 `json:",omitempty"`
 }
 
 // ListContainerNamespaces contains the identifiers of the container's Linux namespaces
@@ -37,5 +37,18 @@ type ContainerStatsReport struct {
 
 type ContainerUpdateOptions struct {
 	NameOrID string
-	Specgen  *specgen.SpecGenerator
+	// Specgen holds the new resource limits. It is nil if only labels are
+	// updated.
+	Specgen *specgen.SpecGenerator
+	// Labels are added to the container, replacing existing labels with
+	// the same key.
+	Labels map[string]string
+	// UnsetLabels are removed from the container.
+	UnsetLabels []string
+	// MemoryLock is the new amount of memory in bytes the container may
+	// lock. It is nil if it is not changed.
+	MemoryLock *int64
+	// Hostname is the new hostname of the container. It is empty if it
+	// is not changed.
+	Hostname string
 }
@@ -18,3 +18,7 @@ type GenerateKubeReport struct {
 type GenerateSpecReport struct {
 	Data []byte
 }
+
+type GenerateJailConfReport struct {
+	Data []byte
+}
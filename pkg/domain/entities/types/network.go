@@ -48,4 +48,24 @@ type NetworkContainerInfo struct {
 
 	// Interfaces configured for this container with their addresses
 	Interfaces map[string]commonTypes.NetInterface `json:"interfaces,omitempty"`
+
+	// NetworkJail is the vnet jail holding the network stack of the
+	// container. FreeBSD only.
+	NetworkJail string `json:"network_jail,omitempty"`
+
+	// HostInterface is the host end of the epair connecting the container
+	// to the bridge of the network. FreeBSD only.
+	HostInterface string `json:"host_interface,omitempty"`
+
+	// PFAnchors are the pf anchors holding the port forwarding and traffic
+	// shaping rules of the container. FreeBSD only.
+	PFAnchors []string `json:"pf_anchors,omitempty"`
+
+	// IngressRate is the rate limit, in bits per second, of the traffic
+	// received by the container in the network. FreeBSD only.
+	IngressRate uint64 `json:"ingress_rate,omitempty"`
+
+	// EgressRate is the rate limit, in bits per second, of the traffic
+	// sent by the container in the network. FreeBSD only.
+	EgressRate uint64 `json:"egress_rate,omitempty"`
 }
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/specgen"
 )
@@ -106,8 +107,10 @@ type ListPodsReport struct {
 	Namespace  string
 	// Network names connected to infra container
 	Networks []string
-	Status   string
-	Labels   map[string]string
+	// Ports published by the infra container for the pod
+	Ports  []types.PortMapping
+	Status string
+	Labels map[string]string
 }
 
 type ListPodContainer struct {
@@ -42,6 +42,8 @@ type SecretInfoReport struct {
 	UpdatedAt  time.Time
 	Spec       SecretSpec
 	SecretData string `json:"SecretData,omitempty"`
+	// Version is incremented every time the secret data is updated.
+	Version int `json:"Version,omitempty"`
 }
 
 type SecretInfoReportCompat struct {
@@ -7,12 +7,14 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
 )
 
-// ServiceOptions provides the input for starting an API and sidecar pprof services
+// ServiceOptions provides the input for starting an API and sidecar pprof and metrics services
 type ServiceOptions struct {
-	CorsHeaders string        // Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr   string        // Network address to bind pprof profiles service
-	Timeout     time.Duration // Duration of inactivity the service should wait before shutting down
-	URI         string        // Path to unix domain socket service should listen on
+	CorsHeaders           string        // Cross-Origin Resource Sharing (CORS) headers
+	FirewallWatchInterval time.Duration // Interval between checks for flushed container firewall rules, 0 disables the checks
+	PProfAddr             string        // Network address to bind pprof profiles service
+	MetricsAddr           string        // Network address to bind Prometheus metrics service
+	Timeout               time.Duration // Duration of inactivity the service should wait before shutting down
+	URI                   string        // Path to unix domain socket service should listen on
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -21,6 +23,12 @@ type SystemPruneOptions struct {
 	Volume   bool
 	Filters  map[string][]string `json:"filters" schema:"filters"`
 	External bool
+	// DryRun only lists the host resources left behind by removed
+	// containers, nothing is pruned.
+	DryRun bool
+	// Orphans also removes the host resources left behind by removed
+	// containers.
+	Orphans bool
 }
 
 // SystemPruneReport provides report after system prune is executed.
@@ -30,9 +38,18 @@ type SystemPruneReport struct {
 	ImagePruneReports     []*reports.PruneReport
 	NetworkPruneReports   []*NetworkPruneReport
 	VolumePruneReports    []*reports.PruneReport
+	OrphanPruneReports    []*OrphanPruneReport
 	ReclaimedSpace        uint64
 }
 
+// OrphanPruneReport describes a host resource left behind by a container
+// which no longer exists, such as a jail or a pf anchor.
+type OrphanPruneReport struct {
+	Kind string
+	Name string
+	Err  error
+}
+
 // SystemMigrateOptions describes the options needed for the
 // cli to migrate runtimes of containers
 type SystemMigrateOptions struct {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
 
@@ -15,10 +16,10 @@ func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrI
 	return container.CopyFromArchive(ctx, containerPath, options.Chown, options.NoOverwriteDirNonDir, options.Rename, reader)
 }
 
-func (ic *ContainerEngine) ContainerCopyToArchive(ctx context.Context, nameOrID, containerPath string, writer io.Writer) (entities.ContainerCopyFunc, error) {
+func (ic *ContainerEngine) ContainerCopyToArchive(ctx context.Context, nameOrID, containerPath string, writer io.Writer, options entities.ArchiveOptions) (entities.ContainerCopyFunc, error) {
 	container, err := ic.Libpod.LookupContainer(nameOrID)
 	if err != nil {
 		return nil, err
 	}
-	return container.CopyToArchive(ctx, containerPath, writer)
+	return container.CopyToArchive(ctx, containerPath, libpod.ContainerArchiveOptions{NoFollowLink: options.NoFollowLink}, writer)
 }
@@ -5,9 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -246,6 +249,13 @@ func (ic *ContainerEngine) ContainerUnpause(ctx context.Context, namesOrIds []st
 	return reports, nil
 }
 func (ic *ContainerEngine) ContainerStop(ctx context.Context, namesOrIds []string, options entities.StopOptions) ([]*entities.StopReport, error) {
+	// Stopping all containers is what happens when the host shuts down,
+	// remember which ones to start again after it booted.
+	if options.All && len(options.Filters) == 0 {
+		if err := ic.Libpod.RecordRunningContainers(); err != nil {
+			logrus.Errorf("Recording running containers: %v", err)
+		}
+	}
 	containers, err := getContainers(ic.Libpod,
 		getContainersOptions{
 			all:     options.All,
@@ -536,6 +546,16 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 	return reports, errs, nil
 }
 
+// ContainerLogManager manages the log of the container, i.e. rotates it or
+// forwards it to syslog, until it exits.
+func (ic *ContainerEngine) ContainerLogManager(ctx context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.ManageLogs(ctx)
+}
+
 func (ic *ContainerEngine) ContainerTop(ctx context.Context, options entities.TopOptions) (*entities.StringSliceReport, error) {
 	var (
 		container *libpod.Container
@@ -621,7 +641,68 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 	if err != nil {
 		return err
 	}
-	return ctr.Export(options.Output)
+	switch options.Format {
+	case "", "tar":
+		return ctr.Export(options.Output)
+	case "zfs":
+		return ctr.ExportZFS(options.Output)
+	default:
+		return fmt.Errorf("unsupported export format %q: %w", options.Format, define.ErrInvalidArg)
+	}
+}
+
+// ContainerDebugBundle writes a debug bundle for a container to the output
+func (ic *ContainerEngine) ContainerDebugBundle(ctx context.Context, nameOrID string, options entities.ContainerDebugBundleOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.DebugBundle(options.Output)
+}
+
+// ContainerTrace traces the processes of a container until it exits, the
+// duration has passed or the context is cancelled
+func (ic *ContainerEngine) ContainerTrace(ctx context.Context, nameOrID string, options entities.ContainerTraceOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	if options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Duration)
+		defer cancel()
+	}
+	return ctr.Trace(ctx, &libpod.TraceOptions{
+		Tool:   options.Tool,
+		Probes: options.Probes,
+		Output: options.Output,
+		Stdout: options.Stdout,
+	})
+}
+
+// ContainerCoresList lists the core dumps collected from a container
+func (ic *ContainerEngine) ContainerCoresList(ctx context.Context, nameOrID string) ([]define.CoreDump, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return ctr.CoreDumps()
+}
+
+// ContainerCoresExport writes a core dump collected from a container to the
+// output
+func (ic *ContainerEngine) ContainerCoresExport(ctx context.Context, nameOrID, name string, options entities.ContainerCoresExportOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	f, err := ctr.OpenCoreDump(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(options.Output, f)
+	return err
 }
 
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, options entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
@@ -679,6 +760,7 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 		Pod:             options.Pod,
 		PrintStats:      options.PrintStats,
 		FileLocks:       options.FileLocks,
+		TCPClose:        options.TCPClose,
 	}
 
 	filterFuncs := []libpod.ContainerFilter{
@@ -812,6 +894,19 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 	return nil
 }
 
+func (ic *ContainerEngine) ContainerConsole(ctx context.Context, nameOrID string, options entities.AttachOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	err = terminal.AttachConsole(ctx, ctr, options.Stdout, options.Stdin, options.DetachKeys)
+	if err != nil && !errors.Is(err, define.ErrDetach) {
+		return fmt.Errorf("attaching to the console of container %s: %w", ctr.ID(), err)
+	}
+	os.Stdout.WriteString("\n")
+	return nil
+}
+
 func makeExecConfig(options entities.ExecOptions, rt *libpod.Runtime) (*libpod.ExecConfig, error) {
 	execConfig := new(libpod.ExecConfig)
 	execConfig.Command = options.Cmd
@@ -824,6 +919,8 @@ func makeExecConfig(options entities.ExecOptions, rt *libpod.Runtime) (*libpod.E
 	execConfig.PreserveFDs = options.PreserveFDs
 	execConfig.PreserveFD = options.PreserveFD
 	execConfig.AttachStdin = options.Interactive
+	execConfig.CPUs = options.CPUs
+	execConfig.Nice = options.Nice
 
 	// Make an exit command
 	storageConfig := rt.StorageConfig()
@@ -895,6 +992,30 @@ func (ic *ContainerEngine) ContainerExec(ctx context.Context, nameOrID string, o
 	return define.TranslateExecErrorToExitCode(ec, err), err
 }
 
+func (ic *ContainerEngine) ContainerExecList(ctx context.Context, nameOrID string, options entities.ExecListOptions) ([]*define.InspectExecSession, error) {
+	containers, err := getContainers(ic.Libpod, getContainersOptions{latest: options.Latest, names: []string{nameOrID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) != 1 {
+		return nil, fmt.Errorf("%w: expected to find exactly one container but got %d", define.ErrInternal, len(containers))
+	}
+
+	sessions, err := containers[0].ListExecSessions()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*define.InspectExecSession, 0, len(sessions))
+	for _, session := range sessions {
+		inspect, err := session.Inspect()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, inspect)
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerExecDetached(ctx context.Context, nameOrID string, options entities.ExecOptions) (string, error) {
 	err := checkExecPreserveFDs(options)
 	if err != nil {
@@ -936,6 +1057,22 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 	if err != nil {
 		return nil, err
 	}
+	if options.AllPreviouslyRunning {
+		previous, err := ic.Libpod.GetPreviouslyRunningContainers()
+		if err != nil {
+			return nil, err
+		}
+		containers = make([]containerWrapper, 0, len(previous))
+		for _, ctr := range previous {
+			containers = append(containers, containerWrapper{Container: ctr})
+		}
+		// Bring them back in dependency order, as they were started
+		// before.
+		return ic.startContainersOrdered(ctx, containers, options)
+	}
+	if options.Ordered {
+		return ic.startContainersOrdered(ctx, containers, options)
+	}
 	// There can only be one container if attach was used
 	for i := range containers {
 		ctr := containers[i]
@@ -1037,6 +1174,108 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 	return reports, nil
 }
 
+// startContainersOrdered starts the containers one at a time such that every
+// container is started after its dependencies and after the infra container
+// of its pod. Containers whose dependencies failed to start are not started.
+// If a container does not start within the timeout, it is reported as failed
+// and its dependents are skipped, but the start is allowed to complete in the
+// background before returning.
+func (ic *ContainerEngine) startContainersOrdered(ctx context.Context, containers []containerWrapper, options entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
+	wrappers := make(map[string]containerWrapper, len(containers))
+	infra := make(map[string]string)
+	ctrs := make([]*libpod.Container, 0, len(containers))
+	for _, ctr := range containers {
+		wrappers[ctr.ID()] = ctr
+		if ctr.IsInfra() {
+			infra[ctr.PodID()] = ctr.ID()
+		}
+		ctrs = append(ctrs, ctr.Container)
+	}
+	sort.SliceStable(ctrs, func(i, j int) bool {
+		return ctrs[i].CreatedTime().Before(ctrs[j].CreatedTime())
+	})
+	ctrs, err := libpod.SortContainersByDependencies(ctrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	reports := make([]*entities.ContainerStartReport, 0, len(ctrs))
+	failed := make(map[string]bool)
+	for _, ctr := range ctrs {
+		report := &entities.ContainerStartReport{
+			Id:       ctr.ID(),
+			RawInput: wrappers[ctr.ID()].rawInput,
+			ExitCode: define.ExecErrorCodeGeneric,
+		}
+
+		var failedDeps []string
+		for _, dep := range ctr.Dependencies() {
+			if failed[dep] {
+				failedDeps = append(failedDeps, dep)
+			}
+		}
+		if infraID, ok := infra[ctr.PodID()]; ok && failed[infraID] {
+			failedDeps = append(failedDeps, infraID)
+		}
+		if len(failedDeps) > 0 {
+			failed[ctr.ID()] = true
+			report.Err = fmt.Errorf("not starting container %s, dependencies failed to start: %s: %w", ctr.ID(), strings.Join(failedDeps, ","), define.ErrCtrStateInvalid)
+			reports = append(reports, report)
+			continue
+		}
+
+		state, err := ctr.State()
+		if err != nil {
+			failed[ctr.ID()] = true
+			report.Err = err
+			reports = append(reports, report)
+			continue
+		}
+		if state == define.ContainerStateRunning {
+			continue
+		}
+
+		logrus.Debugf("Starting container %s", ctr.ID())
+		started := time.Now()
+		done := make(chan error, 1)
+		wg.Add(1)
+		go func(ctr *libpod.Container) {
+			defer wg.Done()
+			done <- ctr.Start(ctx, true)
+		}(ctr)
+
+		var timeout <-chan time.Time
+		timer := time.NewTimer(time.Duration(options.Timeout) * time.Second)
+		if options.Timeout > 0 {
+			timeout = timer.C
+		}
+		select {
+		case err = <-done:
+		case <-timeout:
+			err = fmt.Errorf("timed out after %d seconds: %w", options.Timeout, context.DeadlineExceeded)
+		}
+		timer.Stop()
+		if err != nil {
+			failed[ctr.ID()] = true
+			report.Err = fmt.Errorf("unable to start container %q: %w", ctr.ID(), err)
+			reports = append(reports, report)
+			if !errors.Is(err, context.DeadlineExceeded) && ctr.AutoRemove() {
+				if _, _, err := ic.removeContainer(ctx, ctr, entities.RmOptions{}); err != nil {
+					logrus.Errorf("Removing container %s: %v", ctr.ID(), err)
+				}
+			}
+			continue
+		}
+		logrus.Debugf("Started container %s in %s", ctr.ID(), time.Since(started))
+		report.ExitCode = 0
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerList(ctx context.Context, options entities.ContainerListOptions) ([]entities.ListContainer, error) {
 	if options.Latest {
 		options.Last = 1
@@ -1068,7 +1307,11 @@ func (ic *ContainerEngine) Diff(ctx context.Context, namesOrIDs []string, opts e
 		}
 	}
 	changes, err := ic.Libpod.GetDiff(parent, base, opts.Type)
-	return &entities.DiffReport{Changes: changes}, err
+	if err != nil || !opts.FileFlags {
+		return &entities.DiffReport{Changes: changes}, err
+	}
+	flagChanges, err := ic.Libpod.GetFileFlagDiff(parent, base, opts.Type)
+	return &entities.DiffReport{Changes: changes, FlagChanges: flagChanges}, err
 }
 
 func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.ContainerRunOptions) (*entities.ContainerRunReport, error) {
@@ -1561,64 +1804,32 @@ func (ic *ContainerEngine) ContainerStats(ctx context.Context, namesOrIds []stri
 		queryAll = true
 		containerFunc = ic.Libpod.GetAllContainers
 	default:
-		// queryAll is used to ignore errors when the container was removed between listing and
-		// checking stats which we should do for running containers as well
+		// Ignore errors for running containers as well
 		queryAll = true
 		containerFunc = ic.Libpod.GetRunningContainers
 	}
 
+	streamOptions := libpod.ContainerStatsStreamOptions{
+		Containers: containerFunc,
+		// queryAll is used to ignore errors when the container was removed between listing and
+		// checking stats
+		IgnoreRemoved: queryAll,
+	}
+	if options.Stream {
+		streamOptions.Interval = time.Second * time.Duration(options.Interval)
+	}
+
 	go func() {
 		defer close(statsChan)
-		var (
-			err            error
-			containers     []*libpod.Container
-			containerStats map[string]*define.ContainerStats
-		)
-		containerStats = make(map[string]*define.ContainerStats)
-
-	stream: // label to flatten the scope
-		select {
-		case <-ctx.Done():
-			// client cancelled
-			logrus.Debugf("Container stats stopped: context cancelled")
-			return
-		default:
-			// just fall through and do work
-		}
-
-		// Anonymous func to easily use the return values for streaming.
-		computeStats := func() ([]define.ContainerStats, error) {
-			containers, err = containerFunc()
-			if err != nil {
-				return nil, fmt.Errorf("unable to get list of containers: %w", err)
-			}
-
-			reportStats := []define.ContainerStats{}
-			for _, ctr := range containers {
-				stats, err := ctr.GetContainerStats(containerStats[ctr.ID()])
-				if err != nil {
-					if queryAll && (errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrStateInvalid)) {
-						continue
-					}
-					return nil, err
-				}
-
-				containerStats[ctr.ID()] = stats
-				reportStats = append(reportStats, *stats)
-			}
-			return reportStats, nil
-		}
-
-		report := entities.ContainerStatsReport{}
-		report.Stats, report.Error = computeStats()
-		statsChan <- report
-
-		if report.Error != nil || !options.Stream {
+		err := ic.Libpod.StreamContainerStats(ctx, streamOptions, func(stats []define.ContainerStats) error {
+			statsChan <- entities.ContainerStatsReport{Stats: stats}
+			return nil
+		})
+		if err != nil {
+			statsChan <- entities.ContainerStatsReport{Error: err}
 			return
 		}
-
-		time.Sleep(time.Second * time.Duration(options.Interval))
-		goto stream
+		logrus.Debugf("Container stats stopped")
 	}()
 
 	return statsChan, nil
@@ -1751,13 +1962,13 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
 func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *entities.ContainerUpdateOptions) (string, error) {
-	err := specgen.WeightDevices(updateOptions.Specgen)
-	if err != nil {
-		return "", err
-	}
-	err = specgen.FinishThrottleDevices(updateOptions.Specgen)
-	if err != nil {
-		return "", err
+	if updateOptions.Specgen != nil {
+		if err := specgen.WeightDevices(updateOptions.Specgen); err != nil {
+			return "", err
+		}
+		if err := specgen.FinishThrottleDevices(updateOptions.Specgen); err != nil {
+			return "", err
+		}
 	}
 	containers, err := getContainers(ic.Libpod, getContainersOptions{names: []string{updateOptions.NameOrID}})
 	if err != nil {
@@ -1767,8 +1978,25 @@ func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *e
 		return "", fmt.Errorf("container not found")
 	}
 
-	if err = containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
-		return "", err
+	if len(updateOptions.Labels) > 0 || len(updateOptions.UnsetLabels) > 0 {
+		if err := containers[0].UpdateLabels(updateOptions.Labels, updateOptions.UnsetLabels); err != nil {
+			return "", err
+		}
+	}
+	if updateOptions.MemoryLock != nil {
+		if err := containers[0].UpdateMemoryLock(*updateOptions.MemoryLock); err != nil {
+			return "", err
+		}
+	}
+	if updateOptions.Hostname != "" {
+		if err := containers[0].UpdateHostname(updateOptions.Hostname); err != nil {
+			return "", err
+		}
+	}
+	if updateOptions.Specgen != nil {
+		if err := containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
+			return "", err
+		}
 	}
 	return containers[0].ID(), nil
 }
@@ -3,16 +3,17 @@ package abi
 import (
 	"context"
 
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
 
-func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, containerPath string) (*entities.ContainerStatReport, error) {
+func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, containerPath string, options entities.ArchiveOptions) (*entities.ContainerStatReport, error) {
 	container, err := ic.Libpod.LookupContainer(nameOrID)
 	if err != nil {
 		return nil, err
 	}
 
-	info, err := container.Stat(ctx, containerPath)
+	info, err := container.Stat(ctx, containerPath, libpod.ContainerArchiveOptions{NoFollowLink: options.NoFollowLink})
 
 	if info != nil {
 		return &entities.ContainerStatReport{FileInfo: *info}, err
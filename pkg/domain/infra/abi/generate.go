@@ -44,6 +44,18 @@ func (ic *ContainerEngine) GenerateSystemd(ctx context.Context, nameOrID string,
 	return &entities.GenerateSystemdReport{Units: units}, nil
 }
 
+func (ic *ContainerEngine) GenerateJailConf(ctx context.Context, opts *entities.GenerateJailConfOptions) (*entities.GenerateJailConfReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ctr.JailConf()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.GenerateJailConfReport{Data: data}, nil
+}
+
 func (ic *ContainerEngine) GenerateSpec(ctx context.Context, opts *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
 	var spec *specgen.SpecGenerator
 	var pspec *specgen.PodSpecGenerator
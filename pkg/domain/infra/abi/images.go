@@ -28,6 +28,7 @@ import (
 	"github.com/containers/image/v5/signature"
 	"github.com/containers/image/v5/transports"
 	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
@@ -485,7 +486,18 @@ func (ir *ImageEngine) Import(ctx context.Context, options entities.ImageImportO
 		importOptions.Writer = os.Stderr
 	}
 
-	imageID, err := ir.Libpod.LibimageRuntime().Import(ctx, options.Source, importOptions)
+	// Streams written by podman export --format zfs have to be received
+	// with zfs, everything else is a tarball. The source may also be a URL
+	// which libimage downloads, so errors opening it are left to libimage.
+	var (
+		imageID string
+		err     error
+	)
+	if isZFS, _ := libpod.IsZFSStream(options.Source); isZFS && !options.SourceIsURL {
+		imageID, err = ir.Libpod.ImportZFS(ctx, options.Source, importOptions)
+	} else {
+		imageID, err = ir.Libpod.LibimageRuntime().Import(ctx, options.Source, importOptions)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -19,7 +19,7 @@ func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, op
 	var networkUpdateOptions types.NetworkUpdateOptions
 	networkUpdateOptions.AddDNSServers = options.AddDNSServers
 	networkUpdateOptions.RemoveDNSServers = options.RemoveDNSServers
-	err := ic.Libpod.Network().NetworkUpdate(netName, networkUpdateOptions)
+	err := ic.Libpod.UpdateNetwork(netName, networkUpdateOptions)
 	if err != nil {
 		return err
 	}
@@ -85,10 +85,19 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 		for _, st := range statuses {
 			// Make sure to only show the info for the correct network
 			if sb, ok := st.Status[net.Name]; ok {
-				containerMap[st.ID] = entities.NetworkContainerInfo{
+				info := entities.NetworkContainerInfo{
 					Name:       st.Name,
 					Interfaces: sb.Interfaces,
 				}
+				if st.Host != nil {
+					info.NetworkJail = st.Host.NetworkJail
+					hostIface := st.Host.Interfaces[net.Name]
+					info.HostInterface = hostIface.Interface
+					info.IngressRate = hostIface.IngressRate
+					info.EgressRate = hostIface.EgressRate
+					info.PFAnchors = st.Host.PFAnchors
+				}
+				containerMap[st.ID] = info
 			}
 		}
 
@@ -102,12 +111,29 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 }
 
 func (ic *ContainerEngine) NetworkReload(ctx context.Context, names []string, options entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
+	var reports []*entities.NetworkReloadReport
+	if options.Cleanup {
+		orphans, err := ic.Libpod.PruneNetworkOrphans()
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range orphans {
+			report := &entities.NetworkReloadReport{Id: o.Name}
+			if o.Err != nil {
+				report.Err = fmt.Errorf("removing orphaned %s %s: %w", o.Kind, o.Name, o.Err)
+			}
+			reports = append(reports, report)
+		}
+		if len(names) == 0 && !options.All && !options.Latest {
+			return reports, nil
+		}
+	}
+
 	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: names})
 	if err != nil {
 		return nil, err
 	}
 
-	reports := make([]*entities.NetworkReloadReport, 0, len(containers))
 	for _, ctr := range containers {
 		report := new(entities.NetworkReloadReport)
 		report.Id = ctr.ID()
@@ -128,6 +154,14 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 
 	for _, name := range namesOrIds {
 		report := entities.NetworkRmReport{Name: name}
+		// Containers refer to their networks by name, resolve IDs and
+		// partial IDs so that none of them is missed.
+		net, err := ic.Libpod.Network().NetworkInspect(name)
+		if err != nil {
+			report.Err = err
+			reports = append(reports, &report)
+			continue
+		}
 		containers, err := ic.Libpod.GetAllContainers()
 		if err != nil {
 			return reports, err
@@ -142,7 +176,7 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 			if err != nil {
 				return reports, err
 			}
-			if slices.Contains(networks, name) {
+			if slices.Contains(networks, net.Name) {
 				// if user passes force, we nuke containers and pods
 				if !options.Force {
 					// Without the force option, we return an error
@@ -162,7 +196,7 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 				}
 			}
 		}
-		if err := ic.Libpod.Network().NetworkRemove(name); err != nil {
+		if err := ic.Libpod.RemoveNetwork(net.Name); err != nil {
 			report.Err = err
 		}
 		reports = append(reports, &report)
@@ -174,6 +208,9 @@ func (ic *ContainerEngine) NetworkCreate(ctx context.Context, network types.Netw
 	if slices.Contains([]string{"none", "host", "bridge", "private", slirp4netns.BinaryName, pasta.BinaryName, "container", "ns", "default"}, network.Name) {
 		return nil, fmt.Errorf("cannot create network with name %q because it conflicts with a valid network mode", network.Name)
 	}
+	if err := checkNetworkCreate(&network); err != nil {
+		return nil, err
+	}
 	network, err := ic.Libpod.Network().NetworkCreate(network, createOptions)
 	if err != nil {
 		return nil, err
@@ -226,7 +263,7 @@ func (ic *ContainerEngine) NetworkPrune(ctx context.Context, options entities.Ne
 	for _, net := range nets {
 		pruneReport = append(pruneReport, &entities.NetworkPruneReport{
 			Name:  net.Name,
-			Error: ic.Libpod.Network().NetworkRemove(net.Name),
+			Error: ic.Libpod.RemoveNetwork(net.Name),
 		})
 	}
 	return pruneReport, nil
@@ -270,6 +307,8 @@ type ContainerNetStatus struct {
 	ID string
 	// Status contains the net status, the key is the network name
 	Status map[string]types.StatusBlock
+	// Host contains the host side details of the networks, if any
+	Host *define.InspectNetworkHost
 }
 
 func (ic *ContainerEngine) GetContainerNetStatuses() ([]ContainerNetStatus, error) {
@@ -287,10 +326,22 @@ func (ic *ContainerEngine) GetContainerNetStatuses() ([]ContainerNetStatus, erro
 			return nil, err
 		}
 
+		var host *define.InspectNetworkHost
+		if len(status) > 0 {
+			host, err = con.InspectNetworkHost()
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					continue
+				}
+				return nil, err
+			}
+		}
+
 		statuses = append(statuses, ContainerNetStatus{
 			ID:     con.ID(),
 			Name:   con.Name(),
 			Status: status,
+			Host:   host,
 		})
 	}
 	return statuses, nil
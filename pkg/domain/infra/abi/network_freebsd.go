@@ -0,0 +1,32 @@
+package abi
+
+import (
+	"fmt"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// checkNetworkCreate rejects networks which the network backend accepts but
+// cannot set up on FreeBSD, rather than failing when a container is started.
+// Neither the dhcp CNI plugin nor the netavark dhcp proxy are available on
+// FreeBSD, so a bridge network using the dhcp ipam driver is created without
+// ipam and labelled for libpod to run dhclient(8) in the container's vnet
+// jail instead.
+func checkNetworkCreate(network *types.Network) error {
+	isBridge := network.Driver == "" || network.Driver == types.BridgeNetworkDriver
+	if isBridge && network.IPAMOptions[types.Driver] == types.DHCPIPAMDriver {
+		if network.Internal {
+			return fmt.Errorf("ipam driver %s needs a network with access to a DHCP server, it cannot be used with an internal network: %w", types.DHCPIPAMDriver, define.ErrInvalidArg)
+		}
+		if len(network.Subnets) > 0 {
+			return fmt.Errorf("ipam driver %s cannot be used with a subnet: %w", types.DHCPIPAMDriver, define.ErrInvalidArg)
+		}
+		network.IPAMOptions[types.Driver] = types.NoneIPAMDriver
+		if network.Labels == nil {
+			network.Labels = make(map[string]string)
+		}
+		network.Labels[define.NetworkDHCPLabel] = "true"
+	}
+	return nil
+}
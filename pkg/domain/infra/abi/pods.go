@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -449,6 +450,7 @@ func (ic *ContainerEngine) listPodReportFromPod(p *libpod.Pod) (*entities.ListPo
 		return nil, err
 	}
 	networks := []string{}
+	var ports []types.PortMapping
 	if len(infraID) > 0 {
 		infra, err := p.InfraContainer()
 		if err != nil {
@@ -458,6 +460,10 @@ func (ic *ContainerEngine) listPodReportFromPod(p *libpod.Pod) (*entities.ListPo
 		if err != nil {
 			return nil, err
 		}
+		ports, err = infra.PortMappings()
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &entities.ListPodsReport{
 		Cgroup:     p.CgroupParent(),
@@ -468,6 +474,7 @@ func (ic *ContainerEngine) listPodReportFromPod(p *libpod.Pod) (*entities.ListPo
 		Name:       p.Name(),
 		Namespace:  p.Namespace(),
 		Networks:   networks,
+		Ports:      ports,
 		Status:     status,
 		Labels:     p.Labels(),
 	}, nil
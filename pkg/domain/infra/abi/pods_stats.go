@@ -30,6 +30,9 @@ func (ic *ContainerEngine) PodStats(ctx context.Context, namesOrIds []string, op
 	if err != nil {
 		return nil, fmt.Errorf("unable to get list of pods: %w", err)
 	}
+	if options.Aggregate {
+		return ic.podsToAggregateStatsReport(pods)
+	}
 	return ic.podsToStatsReport(pods)
 }
 
@@ -69,6 +72,40 @@ func (ic *ContainerEngine) podsToStatsReport(pods []*libpod.Pod) ([]*entities.Po
 	return reports, nil
 }
 
+// podsToAggregateStatsReport converts a slice of pods into a corresponding
+// slice of stats reports, one per pod summed over its containers.
+func (ic *ContainerEngine) podsToAggregateStatsReport(pods []*libpod.Pod) ([]*entities.PodStatsReport, error) {
+	reports := []*entities.PodStatsReport{}
+	for i := range pods { // Access by index to prevent potential loop-variable leaks.
+		podStats, err := pods[i].GetPodStatsTotal(nil)
+		if err != nil {
+			return nil, err
+		}
+		var podNetInput uint64
+		var podNetOutput uint64
+		for _, stats := range podStats.Network {
+			podNetInput += stats.RxBytes
+			podNetOutput += stats.TxBytes
+		}
+
+		r := entities.PodStatsReport{
+			CPU:           floatToPercentString(podStats.CPU),
+			MemUsage:      combineHumanValues(podStats.MemUsage, podStats.MemLimit),
+			MemUsageBytes: combineBytesValues(podStats.MemUsage, podStats.MemLimit),
+			Mem:           floatToPercentString(podStats.MemPerc),
+			NetIO:         combineHumanValues(podNetInput, podNetOutput),
+			BlockIO:       combineHumanValues(podStats.BlockInput, podStats.BlockOutput),
+			PIDS:          pidsToString(podStats.PIDs),
+			CID:           "--",
+			Name:          podStats.Name,
+			Pod:           pods[i].ID()[:12],
+		}
+		reports = append(reports, &r)
+	}
+
+	return reports, nil
+}
+
 func combineHumanValues(a, b uint64) string {
 	if a == 0 && b == 0 {
 		return "-- / --"
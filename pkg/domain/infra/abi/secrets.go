@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containers/common/pkg/secrets"
@@ -13,6 +14,10 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/utils"
 )
 
+// secretVersionKey is the metadata key holding the version of a secret. The
+// version starts at 1 and is incremented by every update of the secret data.
+const secretVersionKey = "io.podman.secret.version"
+
 func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
 	data, _ := io.ReadAll(reader)
 	secretsPath := ic.Libpod.GetSecretsStorageDir()
@@ -46,6 +51,7 @@ func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader
 	storeOpts := secrets.StoreOptions{
 		DriverOpts: options.DriverOpts,
 		Labels:     options.Labels,
+		Metadata:   map[string]string{secretVersionKey: "1"},
 		Replace:    options.Replace,
 	}
 
@@ -163,6 +169,58 @@ func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*
 	return &entities.BoolReport{Value: secret != nil}, nil
 }
 
+// SecretUpdate replaces the data of an existing secret, keeping its name,
+// driver and labels, and increments its version. The secret gets a new ID,
+// which is returned. Containers using the secret as an environment variable
+// pick up the new data the next time they are started.
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader) (*entities.SecretCreateReport, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+
+	secretID, err := updateSecret(manager, nameOrID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SecretCreateReport{
+		ID: secretID,
+	}, nil
+}
+
+// updateSecret replaces the data of a secret and increments its version. The
+// version is incremented while the secrets are locked, so that concurrent
+// updates do not lose increments.
+func updateSecret(manager *secrets.SecretsManager, nameOrID string, data []byte) (string, error) {
+	return manager.Update(nameOrID, data, func(secret secrets.Secret) secrets.StoreOptions {
+		metadata := make(map[string]string, len(secret.Metadata)+1)
+		for k, v := range secret.Metadata {
+			metadata[k] = v
+		}
+		metadata[secretVersionKey] = strconv.Itoa(secretVersion(secret) + 1)
+		return secrets.StoreOptions{
+			DriverOpts: secret.DriverOptions,
+			Labels:     secret.Labels,
+			Metadata:   metadata,
+		}
+	})
+}
+
+// secretVersion returns the version of the secret. Secrets created before
+// versions were recorded are at version 1.
+func secretVersion(secret secrets.Secret) int {
+	version, err := strconv.Atoi(secret.Metadata[secretVersionKey])
+	if err != nil || version < 1 {
+		return 1
+	}
+	return version
+}
+
 func secretToReport(secret secrets.Secret) *entities.SecretInfoReport {
 	return secretToReportWithData(secret, "")
 }
@@ -181,5 +239,6 @@ func secretToReportWithData(secret secrets.Secret, data string) *entities.Secret
 			Labels: secret.Labels,
 		},
 		SecretData: data,
+		Version:    secretVersion(secret),
 	}
 }
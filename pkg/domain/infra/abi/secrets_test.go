@@ -1,12 +1,15 @@
 package abi
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_secretToReport(t *testing.T) {
@@ -50,6 +53,7 @@ func Test_secretToReport(t *testing.T) {
 					Labels: map[string]string{"test-label": "test-value"},
 				},
 				SecretData: "test-secret-data",
+				Version:    1,
 			},
 		},
 	}
@@ -59,3 +63,41 @@ func Test_secretToReport(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateSecret(t *testing.T) {
+	manager, err := secrets.NewManager(t.TempDir())
+	require.NoError(t, err)
+	driverOpts := map[string]string{"path": t.TempDir()}
+	id, err := manager.Store("mysecret", []byte("data"), "file", secrets.StoreOptions{
+		DriverOpts: driverOpts,
+		Labels:     map[string]string{"app": "web"},
+	})
+	require.NoError(t, err)
+
+	// Concurrent updates must not lose increments of the version
+	const updates = 8
+	var wg sync.WaitGroup
+	errs := make([]error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = updateSecret(manager, "mysecret", []byte(fmt.Sprintf("data%d", i)))
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	secret, err := manager.Lookup("mysecret")
+	require.NoError(t, err)
+	assert.Equal(t, 1+updates, secretVersion(*secret))
+	assert.Equal(t, map[string]string{"app": "web"}, secret.Labels)
+	assert.Equal(t, driverOpts, secret.DriverOptions)
+
+	// The secret gets a new ID
+	assert.NotEqual(t, id, secret.ID)
+	_, err = manager.Lookup(id)
+	assert.ErrorIs(t, err, secrets.ErrNoSuchSecret)
+}
@@ -9,12 +9,12 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage"
-	"github.com/containers/storage/pkg/directory"
 	"github.com/sirupsen/logrus"
 )
 
@@ -75,6 +75,18 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		return systemPruneReport, nil
 	}
 
+	if options.DryRun {
+		if options.All || options.Volume || len(options.Filters) > 0 {
+			return nil, fmt.Errorf("system prune --dry-run cannot be combined with other options")
+		}
+		orphans, err := ic.Libpod.PruneOrphans(true)
+		if err != nil {
+			return nil, err
+		}
+		systemPruneReport.OrphanPruneReports = orphanPruneReports(orphans)
+		return systemPruneReport, nil
+	}
+
 	filters := []string{}
 	for k, v := range options.Filters {
 		filters = append(filters, fmt.Sprintf("%s=%s", k, v[0]))
@@ -159,10 +171,27 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		}
 	}
 
+	// Remove what containers which are gone left behind on the host.
+	if options.Orphans {
+		orphans, err := ic.Libpod.PruneOrphans(false)
+		if err != nil {
+			return nil, err
+		}
+		systemPruneReport.OrphanPruneReports = orphanPruneReports(orphans)
+	}
+
 	systemPruneReport.ReclaimedSpace = reclaimedSpace
 	return systemPruneReport, nil
 }
 
+func orphanPruneReports(orphans []*libpod.Orphan) []*entities.OrphanPruneReport {
+	orphanReports := make([]*entities.OrphanPruneReport, 0, len(orphans))
+	for _, o := range orphans {
+		orphanReports = append(orphanReports, &entities.OrphanPruneReport{Kind: o.Kind, Name: o.Name, Err: o.Err})
+	}
+	return orphanReports
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	var (
 		dfImages = []*entities.SystemDfImageReport{}
@@ -173,7 +202,22 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 		return nil, err
 	}
 
+	// Graph drivers such as zfs share blocks between layers, so the
+	// space used on disk differs from the size of the layer contents.
+	driverUsage, err := ic.Libpod.GraphDriverDiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	if driverUsage != nil {
+		totalImageSize = driverUsage.ImagesSize
+	}
+
 	for _, stat := range imageStats {
+		if driverUsage != nil {
+			if du, ok := driverUsage.Images[stat.ID]; ok {
+				stat.Size, stat.SharedSize, stat.UniqueSize = du.Size, du.SharedSize, du.UniqueSize
+			}
+		}
 		report := entities.SystemDfImageReport{
 			Repository: stat.Repository,
 			Tag:        stat.Tag,
@@ -199,21 +243,9 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 		if err != nil {
 			return nil, fmt.Errorf("failed to get state of container %s: %w", c.ID(), err)
 		}
-		conSize, err := c.RootFsSize()
-		if err != nil {
-			if errors.Is(err, storage.ErrContainerUnknown) {
-				logrus.Error(fmt.Errorf("failed to get root file system size of container %s: %w", c.ID(), err))
-			} else {
-				return nil, fmt.Errorf("failed to get root file system size of container %s: %w", c.ID(), err)
-			}
-		}
-		rwsize, err := c.RWSize()
+		conSize, rwsize, err := containerDiskUsage(c, iid, driverUsage)
 		if err != nil {
-			if errors.Is(err, storage.ErrContainerUnknown) {
-				logrus.Error(fmt.Errorf("failed to get read/write size of container %s: %w", c.ID(), err))
-			} else {
-				return nil, fmt.Errorf("failed to get read/write size of container %s: %w", c.ID(), err)
-			}
+			return nil, err
 		}
 		report := entities.SystemDfContainerReport{
 			ContainerID:  c.ID(),
@@ -248,7 +280,7 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 			// TODO: fix this.
 			continue
 		}
-		volSize, err := directory.Size(mountPoint)
+		volSize, err := v.DiskUsage()
 		if err != nil {
 			return nil, err
 		}
@@ -276,6 +308,34 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 	}, nil
 }
 
+// containerDiskUsage returns the size of the root file system and of the
+// writable layer of a container. driverUsage is used instead of measuring
+// the writable layer if the graph driver reported its size.
+func containerDiskUsage(c *libpod.Container, imageID string, driverUsage *libpod.GraphDriverDiskUsage) (int64, int64, error) {
+	if driverUsage != nil {
+		if size, ok := driverUsage.Containers[c.ID()]; ok {
+			return driverUsage.Images[imageID].Size + size, size, nil
+		}
+	}
+	conSize, err := c.RootFsSize()
+	if err != nil {
+		if errors.Is(err, storage.ErrContainerUnknown) {
+			logrus.Error(fmt.Errorf("failed to get root file system size of container %s: %w", c.ID(), err))
+		} else {
+			return 0, 0, fmt.Errorf("failed to get root file system size of container %s: %w", c.ID(), err)
+		}
+	}
+	rwsize, err := c.RWSize()
+	if err != nil {
+		if errors.Is(err, storage.ErrContainerUnknown) {
+			logrus.Error(fmt.Errorf("failed to get read/write size of container %s: %w", c.ID(), err))
+		} else {
+			return 0, 0, fmt.Errorf("failed to get read/write size of container %s: %w", c.ID(), err)
+		}
+	}
+	return conSize, rwsize, nil
+}
+
 func (ic *ContainerEngine) Reset(ctx context.Context) error {
 	return ic.Libpod.Reset(ctx)
 }
@@ -38,6 +38,31 @@ func ExecAttachCtr(ctx context.Context, ctr *libpod.Container, execConfig *libpo
 	return ctr.Exec(execConfig, streams, resizechan)
 }
 
+// AttachConsole attaches to the console of a container. The console is
+// always a terminal, so the terminal we are attached to is set to raw mode.
+func AttachConsole(ctx context.Context, ctr *libpod.Container, stdout, stdin *os.File, detachKeys string) error {
+	resize := make(chan resize.TerminalSize)
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		cancel, oldTermState, err := handleTerminalAttach(ctx, resize)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := restoreTerminal(oldTermState); err != nil {
+				logrus.Errorf("Unable to restore terminal: %q", err)
+			}
+		}()
+		defer cancel()
+	}
+
+	streams := new(define.AttachStreams)
+	streams.OutputStream = stdout
+	streams.InputStream = bufio.NewReader(stdin)
+	streams.AttachOutput = true
+	streams.AttachInput = true
+	return ctr.AttachConsole(streams, detachKeys, resize)
+}
+
 // StartAttachCtr starts and (if required) attaches to a container
 // if you change the signature of this function from os.File to io.Writer, it will trigger a downstream
 // error. we may need to just lint disable this one.
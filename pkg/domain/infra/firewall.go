@@ -0,0 +1,49 @@
+//go:build !remote
+
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/sirupsen/logrus"
+)
+
+// StartFirewallWatcher checks the firewall rules of the running containers
+// every interval in the background until ctx is cancelled, and reloads the
+// network of the containers whose rules were flushed, e.g. by reloading the
+// firewall. The rules are checked once before returning, which fails if the
+// platform does not support watching them.
+func StartFirewallWatcher(ctx context.Context, rt *libpod.Runtime, interval time.Duration) error {
+	if err := reloadFlushedFirewallRules(rt); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := reloadFlushedFirewallRules(rt); err != nil {
+				logrus.Errorf("Checking firewall rules: %v", err)
+			}
+		}
+	}()
+	logrus.Debugf("Started firewall watcher with interval %s", interval)
+	return nil
+}
+
+func reloadFlushedFirewallRules(rt *libpod.Runtime) error {
+	ctrs, err := rt.ReloadFlushedFirewallRules()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		logrus.Infof("Reloaded network of container %s after its firewall rules were flushed", ctr.ID())
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build !remote
+
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/schedule"
+	"github.com/sirupsen/logrus"
+)
+
+// StartScheduler runs the scheduler for containers created with --schedule
+// in the background until ctx is cancelled. At the start of every minute, it
+// starts the containers whose schedule is due. A container which is still
+// running from a previous run is not started again.
+//
+// The scheduler is not started if the service exits after being idle for
+// timeout, since scheduled runs would silently stop with it.
+func StartScheduler(ctx context.Context, rt *libpod.Runtime, timeout time.Duration) {
+	if timeout != 0 {
+		if ctrs, err := scheduledContainers(rt); err == nil && len(ctrs) > 0 {
+			logrus.Warnf("Not running %d scheduled containers since the service exits after %s of inactivity, use --time=0 to keep it running", len(ctrs), timeout)
+		}
+		return
+	}
+	go func() {
+		last := time.Now()
+		for {
+			next := last.Truncate(time.Minute).Add(time.Minute)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+			now := time.Now()
+			runScheduledContainers(ctx, rt, last, now)
+			last = now
+		}
+	}()
+	logrus.Debugf("Started container scheduler")
+}
+
+// runScheduledContainers starts the containers which are scheduled to run after since and
+// no later than now.
+func runScheduledContainers(ctx context.Context, rt *libpod.Runtime, since, now time.Time) {
+	ctrs, err := scheduledContainers(rt)
+	if err != nil {
+		logrus.Errorf("Listing scheduled containers: %v", err)
+		return
+	}
+	for _, ctr := range ctrs {
+		s, err := schedule.Parse(ctr.Labels()[schedule.Label])
+		if err != nil {
+			logrus.Errorf("Container %s: %v", ctr.ID(), err)
+			continue
+		}
+		next := s.Next(since)
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			logrus.Errorf("Getting state of scheduled container %s: %v", ctr.ID(), err)
+			continue
+		}
+		switch state {
+		case define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping:
+			logrus.Warnf("Skipping scheduled run of container %s, its previous run has not finished", ctr.ID())
+			continue
+		}
+		logrus.Infof("Starting scheduled container %s", ctr.ID())
+		if err := ctr.Start(ctx, true); err != nil {
+			logrus.Errorf("Starting scheduled container %s: %v", ctr.ID(), err)
+		}
+	}
+}
+
+// scheduledContainers returns the containers created with --schedule.
+func scheduledContainers(rt *libpod.Runtime) ([]*libpod.Container, error) {
+	return rt.GetContainers(false, func(c *libpod.Container) bool {
+		_, ok := c.Labels()[schedule.Label]
+		return ok
+	})
+}
@@ -313,6 +313,10 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 	return reports, errs, nil
 }
 
+func (ic *ContainerEngine) ContainerLogManager(ctx context.Context, nameOrID string) error {
+	return errors.New("managing container logs is not supported on the remote API")
+}
+
 func (ic *ContainerEngine) ContainerTop(ctx context.Context, opts entities.TopOptions) (*entities.StringSliceReport, error) {
 	switch {
 	case opts.Latest:
@@ -366,7 +370,31 @@ func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string,
 }
 
 func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string, options entities.ContainerExportOptions) error {
-	return containers.Export(ic.ClientCtx, nameOrID, options.Output, nil)
+	exportOptions := new(containers.ExportOptions)
+	if options.Format != "" {
+		exportOptions.WithFormat(options.Format)
+	}
+	return containers.Export(ic.ClientCtx, nameOrID, options.Output, exportOptions)
+}
+
+func (ic *ContainerEngine) ContainerDebugBundle(ctx context.Context, nameOrID string, options entities.ContainerDebugBundleOptions) error {
+	return errors.New("collecting a debug bundle is not supported on the remote API")
+}
+
+func (ic *ContainerEngine) ContainerTrace(ctx context.Context, nameOrID string, options entities.ContainerTraceOptions) error {
+	return errors.New("tracing a container is not supported on the remote API")
+}
+
+func (ic *ContainerEngine) ContainerConsole(ctx context.Context, nameOrID string, options entities.AttachOptions) error {
+	return errors.New("attaching to the console is not supported on the remote API")
+}
+
+func (ic *ContainerEngine) ContainerCoresList(ctx context.Context, nameOrID string) ([]define.CoreDump, error) {
+	return nil, errors.New("listing core dumps is not supported on the remote API")
+}
+
+func (ic *ContainerEngine) ContainerCoresExport(ctx context.Context, nameOrID, name string, options entities.ContainerCoresExportOptions) error {
+	return errors.New("exporting core dumps is not supported on the remote API")
 }
 
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, opts entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
@@ -441,6 +469,7 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 	options.WithKeep(opts.Keep)
 	options.WithName(opts.Name)
 	options.WithTCPEstablished(opts.TCPEstablished)
+	options.WithTCPClose(opts.TCPClose)
 	options.WithPod(opts.Pod)
 	options.WithPrintStats(opts.PrintStats)
 	options.WithPublishPorts(opts.PublishPorts)
@@ -585,10 +614,16 @@ func makeExecConfig(options entities.ExecOptions) *handlers.ExecCreateConfig {
 	createConfig.Env = env
 	createConfig.WorkingDir = options.WorkDir
 	createConfig.Cmd = options.Cmd
+	createConfig.CPUs = options.CPUs
+	createConfig.Nice = options.Nice
 
 	return createConfig
 }
 
+func (ic *ContainerEngine) ContainerExecList(ctx context.Context, nameOrID string, options entities.ExecListOptions) ([]*define.InspectExecSession, error) {
+	return containers.ExecList(ic.ClientCtx, nameOrID, nil)
+}
+
 func (ic *ContainerEngine) ContainerExec(ctx context.Context, nameOrID string, options entities.ExecOptions, streams define.AttachStreams) (exitCode int, retErr error) {
 	createConfig := makeExecConfig(options)
 
@@ -1071,12 +1106,14 @@ func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrI
 	return containers.CopyFromArchiveWithOptions(ic.ClientCtx, nameOrID, path, reader, copyOptions)
 }
 
-func (ic *ContainerEngine) ContainerCopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (entities.ContainerCopyFunc, error) {
-	return containers.CopyToArchive(ic.ClientCtx, nameOrID, path, writer)
+func (ic *ContainerEngine) ContainerCopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer, options entities.ArchiveOptions) (entities.ContainerCopyFunc, error) {
+	archiveOptions := new(containers.ArchiveOptions).WithNoFollowLink(options.NoFollowLink)
+	return containers.CopyToArchiveWithOptions(ic.ClientCtx, nameOrID, path, writer, archiveOptions)
 }
 
-func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, path string) (*entities.ContainerStatReport, error) {
-	return containers.Stat(ic.ClientCtx, nameOrID, path)
+func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, path string, options entities.ArchiveOptions) (*entities.ContainerStatReport, error) {
+	archiveOptions := new(containers.ArchiveOptions).WithNoFollowLink(options.NoFollowLink)
+	return containers.StatWithOptions(ic.ClientCtx, nameOrID, path, archiveOptions)
 }
 
 // Shutdown Libpod engine.
@@ -1106,6 +1143,15 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
 func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *entities.ContainerUpdateOptions) (string, error) {
+	if len(updateOptions.Labels) > 0 || len(updateOptions.UnsetLabels) > 0 {
+		return "", errors.New("updating container labels is not supported on the remote API")
+	}
+	if updateOptions.MemoryLock != nil {
+		return "", errors.New("updating the memory lock of a container is not supported on the remote API")
+	}
+	if updateOptions.Hostname != "" {
+		return "", errors.New("updating the hostname of a container is not supported on the remote API")
+	}
 	err := specgen.WeightDevices(updateOptions.Specgen)
 	if err != nil {
 		return "", err
@@ -50,6 +50,10 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 	return generate.Kube(ic.ClientCtx, nameOrIDs, options)
 }
 
+func (ic *ContainerEngine) GenerateJailConf(ctx context.Context, opts *entities.GenerateJailConfOptions) (*entities.GenerateJailConfReport, error) {
+	return nil, fmt.Errorf("GenerateJailConf is not supported on the remote API")
+}
+
 func (ic *ContainerEngine) GenerateSpec(ctx context.Context, opts *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
 	return nil, fmt.Errorf("GenerateSpec is not supported on the remote API")
 }
@@ -247,6 +247,6 @@ func (ic *ContainerEngine) PodInspect(ctx context.Context, namesOrIDs []string,
 }
 
 func (ic *ContainerEngine) PodStats(ctx context.Context, namesOrIds []string, opts entities.PodStatsOptions) ([]*entities.PodStatsReport, error) {
-	options := new(pods.StatsOptions).WithAll(opts.All)
+	options := new(pods.StatsOptions).WithAll(opts.All).WithAggregate(opts.Aggregate)
 	return pods.Stats(ic.ClientCtx, namesOrIds, options)
 }
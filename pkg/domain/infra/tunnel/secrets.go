@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -101,3 +102,7 @@ func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*
 	}
 	return &entities.BoolReport{Value: exists}, nil
 }
+
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader) (*entities.SecretCreateReport, error) {
+	return nil, errors.New("updating secrets is not supported on the remote API")
+}
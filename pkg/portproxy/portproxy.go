@@ -0,0 +1,138 @@
+// Package portproxy forwards the traffic received on host sockets to the
+// address of a container. It publishes the ports of containers created with
+// --network bridge:port_handler=proxy, when no firewall rules are installed
+// for them.
+package portproxy
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dialTimeout is the time allowed to connect to the container.
+	dialTimeout = 10 * time.Second
+	// udpSessionTimeout is the time after which the forwarding of the
+	// datagrams of an idle UDP client is dropped.
+	udpSessionTimeout = 60 * time.Second
+	// maxDatagramSize is the size of the largest UDP datagram.
+	maxDatagramSize = 65535
+)
+
+// Forward is a host socket and the container address the traffic it receives
+// is forwarded to. The socket is passed to the proxy as a file.
+type Forward struct {
+	// Protocol is either tcp or udp.
+	Protocol string `json:"protocol"`
+	// Target is the address and port of the container.
+	Target string `json:"target"`
+}
+
+// ServeTCP accepts the connections on l and forwards each of them to a new
+// connection to target until l is closed.
+func ServeTCP(l net.Listener, target string) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logrus.Errorf("Accepting connection for %s: %v", target, err)
+			}
+			return
+		}
+		go forwardTCP(conn, target)
+	}
+}
+
+func forwardTCP(conn net.Conn, target string) {
+	defer conn.Close()
+	backend, err := net.DialTimeout("tcp", target, dialTimeout)
+	if err != nil {
+		logrus.Debugf("Forwarding connection from %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	defer backend.Close()
+
+	done := make(chan struct{}, 2)
+	copyHalf := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		// Let the peer see the end of the stream while the other
+		// direction may still be in use.
+		if tcpConn, ok := dst.(*net.TCPConn); ok {
+			_ = tcpConn.CloseWrite()
+		}
+		done <- struct{}{}
+	}
+	go copyHalf(backend, conn)
+	go copyHalf(conn, backend)
+	<-done
+	<-done
+}
+
+// ServeUDP forwards the datagrams received on pc to target, from a separate
+// socket for each client so that the replies of the container can be sent
+// back to it, until pc is closed.
+func ServeUDP(pc net.PacketConn, target string) {
+	targetAddr, err := net.ResolveUDPAddr("udp", target)
+	if err != nil {
+		logrus.Errorf("Resolving %s: %v", target, err)
+		return
+	}
+
+	var mu sync.Mutex
+	sessions := make(map[string]*net.UDPConn)
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, client, err := pc.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logrus.Errorf("Receiving datagram for %s: %v", target, err)
+			}
+			return
+		}
+		mu.Lock()
+		backend, ok := sessions[client.String()]
+		if !ok {
+			backend, err = net.DialUDP("udp", nil, targetAddr)
+			if err != nil {
+				mu.Unlock()
+				logrus.Debugf("Forwarding datagram from %s: %v", client, err)
+				continue
+			}
+			sessions[client.String()] = backend
+			go func(client net.Addr, backend *net.UDPConn) {
+				replyUDP(pc, client, backend)
+				mu.Lock()
+				delete(sessions, client.String())
+				mu.Unlock()
+				backend.Close()
+			}(client, backend)
+		}
+		mu.Unlock()
+		if _, err := backend.Write(buf[:n]); err != nil {
+			logrus.Debugf("Forwarding datagram from %s: %v", client, err)
+		}
+	}
+}
+
+// replyUDP sends the datagrams received from the container on backend to the
+// client through pc until the client has been idle for udpSessionTimeout.
+func replyUDP(pc net.PacketConn, client net.Addr, backend *net.UDPConn) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		if err := backend.SetReadDeadline(time.Now().Add(udpSessionTimeout)); err != nil {
+			return
+		}
+		n, err := backend.Read(buf)
+		if err != nil {
+			return
+		}
+		if _, err := pc.WriteTo(buf[:n], client); err != nil {
+			logrus.Debugf("Replying to %s: %v", client, err)
+		}
+	}
+}
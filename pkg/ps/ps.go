@@ -54,13 +54,14 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	}
 
 	// Load the containers with their states populated.  This speeds things
-	// up considerably as we use a signel DB connection to load the
-	// containers' states instead of one per container.
+	// up considerably as we use a single DB transaction to load the
+	// containers' states instead of one per container, and the filters
+	// use the loaded states rather than syncing each container again.
 	//
 	// This may return slightly outdated states but that's acceptable for
 	// listing containers; any state is outdated the point a container lock
 	// gets released.
-	cons, err := runtime.GetContainers(true, filterFuncs...)
+	cons, err := runtime.GetAllContainersWithState(filterFuncs...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +141,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		exitCode                                int32
 		exited                                  bool
 		pid                                     int
+		jid                                     int
 		size                                    *psdefine.ContainerSize
 		startedTime                             time.Time
 		exitedTime                              time.Time
@@ -148,6 +150,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		networks                                []string
 		healthStatus                            string
 		restartCount                            uint
+		openFiles, threads                      uint64
 	)
 
 	batchErr := ctr.Batch(func(c *libpod.Container) error {
@@ -181,6 +184,11 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			return fmt.Errorf("unable to obtain container pid: %w", err)
 		}
 
+		jid, _, err = c.JailIDs()
+		if err != nil {
+			return fmt.Errorf("unable to obtain container jail ID: %w", err)
+		}
+
 		portMappings, err = c.PortMappings()
 		if err != nil {
 			return err
@@ -201,6 +209,11 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			return err
 		}
 
+		openFiles, threads, err = c.ProcessCounts()
+		if err != nil {
+			logrus.Debugf("Getting process counts for %q: %v", c.ID(), err)
+		}
+
 		if !opts.Size && !opts.Namespace {
 			return nil
 		}
@@ -253,6 +266,8 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		Mounts:     ctr.UserVolumes(),
 		Names:      []string{conConfig.Name},
 		Networks:   networks,
+		OpenFiles:  openFiles,
+		Jid:        jid,
 		Pid:        pid,
 		Pod:        conConfig.Pod,
 		Ports:      portMappings,
@@ -261,6 +276,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		StartedAt:  startedTime.Unix(),
 		State:      conState.String(),
 		Status:     healthStatus,
+		Threads:    threads,
 	}
 	if opts.Pod && len(conConfig.Pod) > 0 {
 		podName, err := rt.GetPodName(conConfig.Pod)
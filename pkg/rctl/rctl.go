@@ -25,7 +25,7 @@ func GetRacct(filter string) (map[string]uint64, error) {
 		uintptr(unsafe.Pointer(&buf[0])),
 		uintptr(len(buf)), 0, 0)
 	if errno != 0 {
-		return nil, fmt.Errorf("error calling rctl_get_racct with filter %s: %v", errno)
+		return nil, fmt.Errorf("error calling rctl_get_racct with filter %s: %v", filter, errno)
 	}
 	len := bytes.IndexByte(buf[:], byte(0))
 	entries := strings.Split(string(buf[:len]), ",")
@@ -40,3 +40,36 @@ func GetRacct(filter string) (map[string]uint64, error) {
 	}
 	return res, nil
 }
+
+func rctlCall(trap uintptr, rule string) error {
+	bp, err := syscall.ByteSliceFromString(rule)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(trap,
+		uintptr(unsafe.Pointer(&bp[0])),
+		uintptr(len(bp)),
+		0, 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// AddRule adds a resource limit rule, e.g. jail:name:maxproc:deny=100. See
+// rctl(8) for the rule syntax.
+func AddRule(rule string) error {
+	if err := rctlCall(syscall.SYS_RCTL_ADD_RULE, rule); err != nil {
+		return fmt.Errorf("error calling rctl_add_rule with rule %s: %w", rule, err)
+	}
+	return nil
+}
+
+// RemoveRule removes all rules matching the filter. It returns
+// syscall.ESRCH if no rules matched.
+func RemoveRule(filter string) error {
+	if err := rctlCall(syscall.SYS_RCTL_REMOVE_RULE, filter); err != nil {
+		return fmt.Errorf("error calling rctl_remove_rule with filter %s: %w", filter, err)
+	}
+	return nil
+}
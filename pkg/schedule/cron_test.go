@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// A Wednesday
+	start := time.Date(2024, time.May, 15, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.May, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC)},
+		{"5 3 * * *", time.Date(2024, time.May, 16, 3, 5, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, time.May, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * sun", time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{"30 1 1 jan,jul *", time.Date(2024, time.July, 1, 1, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of month or day of week
+		{"0 12 20 * mon", time.Date(2024, time.May, 20, 12, 0, 0, 0, time.UTC)},
+		{"0 12 17 * mon", time.Date(2024, time.May, 17, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.May, 15, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.next, s.Next(start), tt.expr)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@reboot",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
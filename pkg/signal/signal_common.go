@@ -13,6 +13,9 @@ import (
 // runc is using the same value.
 const SignalBufferSize = 2048
 
+// SIGRTMAX is the highest signal number of the platform containers run on.
+const SIGRTMAX = syscall.Signal(sigrtmax)
+
 // ParseSignal translates a string to a valid syscall signal.
 // It returns an error if the signal map doesn't include the given signal.
 func ParseSignal(rawSignal string) (syscall.Signal, error) {
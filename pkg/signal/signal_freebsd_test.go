@@ -0,0 +1,31 @@
+//go:build freebsd
+
+package signal
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFreeBSDSignal(t *testing.T) {
+	sig, err := ParseSignal("SIGINFO")
+	require.NoError(t, err)
+	assert.Equal(t, syscall.Signal(29), sig)
+
+	sig, err = ParseSignalNameOrNumber("RTMIN+3")
+	require.NoError(t, err)
+	assert.Equal(t, syscall.Signal(68), sig)
+
+	// Linux only signals
+	_, err = ParseSignal("SIGPWR")
+	assert.Error(t, err)
+	_, err = ParseSignal("SIGSTKFLT")
+	assert.Error(t, err)
+
+	name, err := ParseSysSignalToName(syscall.Signal(31))
+	require.NoError(t, err)
+	assert.Equal(t, "USR2", name)
+}
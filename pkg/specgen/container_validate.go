@@ -37,11 +37,17 @@ func (s *SpecGenerator) Validate() error {
 		if len(s.HostAdd) > 0 {
 			return fmt.Errorf("extra host entries must be specified on the pod: %w", define.ErrNetworkOnPodContainer)
 		}
+		if len(s.Routes) > 0 {
+			return fmt.Errorf("routes must be specified on the pod: %w", define.ErrNetworkOnPodContainer)
+		}
 	}
 
 	if s.NetNS.IsContainer() && len(s.HostAdd) > 0 {
 		return fmt.Errorf("cannot set extra host entries when the container is joined to another containers network namespace: %w", ErrInvalidSpecConfig)
 	}
+	if len(s.Routes) > 0 && (s.NetNS.IsContainer() || s.NetNS.IsHost() || s.NetNS.NSMode == NoNetwork) {
+		return fmt.Errorf("routes can only be added to a network namespace created for the container: %w", ErrInvalidSpecConfig)
+	}
 
 	//
 	// ContainerBasicConfig
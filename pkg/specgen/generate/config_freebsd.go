@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/containers/podman/v5/pkg/util"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
@@ -28,7 +29,7 @@ func DevicesFromPath(g *generate.Generator, devicePath string) error {
 		if err != nil {
 			return fmt.Errorf("setting up CDI devices: %w", err)
 		}
-		return nil
+		return util.ConvertCDIEdits(g.Config)
 	}
 	devs := strings.Split(devicePath, ":")
 	resolvedDevicePath := devs[0]
@@ -173,6 +173,10 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		}
 	}
 
+	if len(s.RuntimeFlags) > 0 {
+		options = append(options, libpod.WithCtrRuntimeFlags(s.RuntimeFlags))
+	}
+
 	if newImage != nil {
 		// If the input name changed, we could properly resolve the
 		// image. Otherwise, it must have been an ID where we're
@@ -229,6 +233,9 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 	if len(s.HostUsers) > 0 {
 		options = append(options, libpod.WithHostUsers(s.HostUsers))
 	}
+	if s.HostUserGroups {
+		options = append(options, libpod.WithHostUserGroups())
+	}
 
 	command := makeCommand(s, imageData)
 
@@ -381,6 +388,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.GroupEntry != "" {
 		options = append(options, libpod.WithGroupEntry(s.GroupEntry))
 	}
+	if s.NoPasswd {
+		options = append(options, libpod.WithNoPasswdFile())
+	}
+	if s.NoGroup {
+		options = append(options, libpod.WithNoGroupFile())
+	}
 	if s.BaseHostsFile != "" {
 		options = append(options, libpod.WithBaseHostsFile(s.BaseHostsFile))
 	}
@@ -533,6 +546,19 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.StopTimeout != nil {
 		options = append(options, libpod.WithStopTimeout(*s.StopTimeout))
 	}
+	if s.MemoryLock != nil {
+		options = append(options, libpod.WithMemoryLock(*s.MemoryLock))
+	}
+	ioPriority, err := getIOPriority(s)
+	if err != nil {
+		return nil, err
+	}
+	if ioPriority != nil {
+		options = append(options, libpod.WithIOPriority(*ioPriority))
+	}
+	if s.MemoryReclaimSignal != nil {
+		options = append(options, libpod.WithMemoryReclaimSignal(*s.MemoryReclaimSignal))
+	}
 	if s.Timeout != 0 {
 		options = append(options, libpod.WithTimeout(s.Timeout))
 	}
@@ -543,9 +569,21 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if s.LogConfiguration.Size > 0 {
 			options = append(options, libpod.WithMaxLogSize(s.LogConfiguration.Size))
 		}
+		if s.LogConfiguration.MaxFiles > 0 {
+			options = append(options, libpod.WithMaxLogFiles(s.LogConfiguration.MaxFiles))
+		}
 		if len(s.LogConfiguration.Options) > 0 && s.LogConfiguration.Options["tag"] != "" {
 			options = append(options, libpod.WithLogTag(s.LogConfiguration.Options["tag"]))
 		}
+		if s.LogConfiguration.Driver == define.SyslogLogging {
+			logOptions := make(map[string]string, len(s.LogConfiguration.Options))
+			for key, val := range s.LogConfiguration.Options {
+				if key != "tag" {
+					logOptions[key] = val
+				}
+			}
+			options = append(options, libpod.WithLogOptions(logOptions))
+		}
 
 		if len(s.LogConfiguration.Driver) > 0 {
 			options = append(options, libpod.WithLogDriver(s.LogConfiguration.Driver))
@@ -587,6 +625,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithConmonPidFile(s.ConmonPidFile))
 	}
 	options = append(options, libpod.WithLabels(s.Labels))
+	if len(s.MetadataKeys) > 0 {
+		options = append(options, libpod.WithMetadataKeys(s.MetadataKeys))
+	}
+	if s.IdentityCA != "" {
+		options = append(options, libpod.WithIdentity(s.IdentityCA, s.IdentityTTL))
+	}
 	if s.ShmSize != nil {
 		options = append(options, libpod.WithShmSize(*s.ShmSize))
 	}
@@ -652,11 +696,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 				return nil, err
 			}
 			secrs = append(secrs, &libpod.ContainerSecret{
-				Secret: secr,
-				UID:    s.UID,
-				GID:    s.GID,
-				Mode:   s.Mode,
-				Target: s.Target,
+				Secret:    secr,
+				UID:       s.UID,
+				GID:       s.GID,
+				Mode:      s.Mode,
+				Target:    s.Target,
+				Ephemeral: s.Ephemeral,
 			})
 		}
 		options = append(options, libpod.WithSecrets(secrs))
@@ -685,6 +730,14 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithChrootDirs(s.ChrootDirs))
 	}
 
+	if s.CollectCores {
+		options = append(options, libpod.WithCollectCores())
+	}
+
+	if s.Console {
+		options = append(options, libpod.WithConsole())
+	}
+
 	options = append(options, libpod.WithSelectedPasswordManagement(s.Passwd))
 
 	return options, nil
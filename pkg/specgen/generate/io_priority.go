@@ -0,0 +1,102 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/docker/go-units"
+)
+
+// ioPriorityClasses are the built-in I/O priority classes. They can be
+// redefined using annotations, see define.IOPriorityClassAnnotationPrefix.
+var ioPriorityClasses = map[string]define.IOPriority{
+	define.IOPriorityCritical: {
+		Class: define.IOPriorityCritical,
+		Nice:  -5,
+	},
+	define.IOPriorityNormal: {
+		Class: define.IOPriorityNormal,
+	},
+	define.IOPriorityBatch: {
+		Class:     define.IOPriorityBatch,
+		Nice:      10,
+		ReadIOPS:  500,
+		WriteIOPS: 500,
+	},
+}
+
+// getIOPriority returns the I/O priority class of the container, if any.
+// The class given in the spec generator takes precedence over a default set
+// using annotations in containers.conf.
+func getIOPriority(s *specgen.SpecGenerator) (*define.IOPriority, error) {
+	name := s.IOPriority
+	if name == "" {
+		name = s.Annotations[define.IOPriorityAnnotation]
+	}
+	if name == "" {
+		return nil, nil
+	}
+	if definition, ok := s.Annotations[define.IOPriorityClassAnnotationPrefix+name]; ok {
+		return parseIOPriorityClass(name, definition)
+	}
+	class, ok := ioPriorityClasses[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown I/O priority class %q: %w", name, define.ErrInvalidArg)
+	}
+	return &class, nil
+}
+
+// parseIOPriorityClass parses the definition of an I/O priority class, a
+// comma-separated list of nice, readbps, writebps, readiops and writeiops
+// settings, e.g. nice=10,writebps=10m.
+func parseIOPriorityClass(name, definition string) (*define.IOPriority, error) {
+	class := &define.IOPriority{Class: name}
+	for _, setting := range strings.Split(definition, ",") {
+		if setting == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(setting, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid setting %q of I/O priority class %s: %w", setting, name, define.ErrInvalidArg)
+		}
+		var err error
+		switch key {
+		case "nice":
+			class.Nice, err = strconv.Atoi(value)
+			if err == nil && (class.Nice < -20 || class.Nice > 20) {
+				err = fmt.Errorf("nice must be between -20 and 20, got %d", class.Nice)
+			}
+		case "readbps":
+			class.ReadBps, err = parseIOPriorityBps(value)
+		case "writebps":
+			class.WriteBps, err = parseIOPriorityBps(value)
+		case "readiops":
+			class.ReadIOPS, err = strconv.ParseUint(value, 10, 64)
+		case "writeiops":
+			class.WriteIOPS, err = strconv.ParseUint(value, 10, 64)
+		default:
+			err = fmt.Errorf("unknown setting %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid I/O priority class %s: %v: %w", name, err, define.ErrInvalidArg)
+		}
+	}
+	return class, nil
+}
+
+// parseIOPriorityBps parses a rate in bytes per second with an optional unit.
+func parseIOPriorityBps(value string) (uint64, error) {
+	bps, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, err
+	}
+	if bps < 0 {
+		return 0, fmt.Errorf("rate must not be negative, got %s", value)
+	}
+	return uint64(bps), nil
+}
@@ -0,0 +1,50 @@
+//go:build !remote
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIOPriority(t *testing.T) {
+	s := specgen.NewSpecGenerator("", false)
+	class, err := getIOPriority(s)
+	assert.NoError(t, err)
+	assert.Nil(t, class)
+
+	s.Annotations = map[string]string{define.IOPriorityAnnotation: define.IOPriorityCritical}
+	class, err = getIOPriority(s)
+	assert.NoError(t, err)
+	assert.Equal(t, &define.IOPriority{Class: define.IOPriorityCritical, Nice: -5}, class)
+
+	s.IOPriority = define.IOPriorityBatch
+	class, err = getIOPriority(s)
+	assert.NoError(t, err)
+	assert.Equal(t, define.IOPriorityBatch, class.Class)
+	assert.Equal(t, uint64(500), class.WriteIOPS)
+
+	s.Annotations[define.IOPriorityClassAnnotationPrefix+define.IOPriorityBatch] = "nice=15,writebps=10m,writeiops=100"
+	class, err = getIOPriority(s)
+	assert.NoError(t, err)
+	assert.Equal(t, &define.IOPriority{Class: define.IOPriorityBatch, Nice: 15, WriteBps: 10 * 1024 * 1024, WriteIOPS: 100}, class)
+
+	s.IOPriority = "bulk"
+	_, err = getIOPriority(s)
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	s.Annotations[define.IOPriorityClassAnnotationPrefix+"bulk"] = "readiops=50"
+	class, err = getIOPriority(s)
+	assert.NoError(t, err)
+	assert.Equal(t, &define.IOPriority{Class: "bulk", ReadIOPS: 50}, class)
+}
+
+func TestParseIOPriorityClass(t *testing.T) {
+	for _, definition := range []string{"nice", "nice=30", "readiops=-1", "writebps=fast", "weight=10"} {
+		_, err := parseIOPriorityClass("test", definition)
+		assert.ErrorIs(t, err, define.ErrInvalidArg, definition)
+	}
+}
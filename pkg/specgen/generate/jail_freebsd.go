@@ -0,0 +1,259 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/sirupsen/logrus"
+)
+
+// jailAnnotationPrefix is the prefix used for annotations which the OCI
+// runtime translates into jail parameters.
+const jailAnnotationPrefix = "org.freebsd.jail."
+
+// jailProfile is a named set of jail parameters.
+type jailProfile struct {
+	// devfsRuleset is the devfs ruleset used for the container's /dev.
+	devfsRuleset int
+	// enforceStatfs is the value of the enforce_statfs jail parameter.
+	enforceStatfs int
+	// securelevel is the value of the securelevel jail parameter.
+	securelevel int
+	// allow lists the allow.* jail parameters which are enabled.
+	allow []string
+}
+
+// defaultJailProfiles contains the built-in profiles which can be selected
+// with --jail-profile or the io.podman.annotations.jail-profile annotation.
+// They can be overridden and extended in the [jail_profiles] table of
+// containers.conf.
+var defaultJailProfiles = map[string]jailProfile{
+	"hardened": {
+		devfsRuleset:  4,
+		enforceStatfs: 2,
+		securelevel:   3,
+	},
+	"permissive": {
+		devfsRuleset:  4,
+		enforceStatfs: 1,
+		securelevel:   -1,
+		allow:         []string{"chflags", "mlock", "raw_sockets", "sysvipc"},
+	},
+	"router": {
+		devfsRuleset:  5,
+		enforceStatfs: 2,
+		securelevel:   -1,
+		allow:         []string{"raw_sockets"},
+	},
+}
+
+// jailSysctls maps sysctls which are backed by jail parameters to the name of
+// the parameter. Most of these are the legacy security.jail.* sysctls which
+// were replaced by the allow.* jail parameters.
+var jailSysctls = map[string]string{
+	"kern.securelevel":                   "securelevel",
+	"security.jail.allow_raw_sockets":    "allow.raw_sockets",
+	"security.jail.chflags_allowed":      "allow.chflags",
+	"security.jail.enforce_statfs":       "enforce_statfs",
+	"security.jail.mlock_allowed":        "allow.mlock",
+	"security.jail.mount_allowed":        "allow.mount",
+	"security.jail.set_hostname_allowed": "allow.set_hostname",
+	"security.jail.sysvipc_allowed":      "allow.sysvipc",
+}
+
+// jailParamForSysctl returns the jail parameter and value which are
+// equivalent to setting the given sysctl. The security.jail.param.* sysctls
+// can be used to set any jail parameter.
+func jailParamForSysctl(key, value string) (string, string, error) {
+	param, ok := strings.CutPrefix(key, "security.jail.param.")
+	if !ok {
+		param, ok = jailSysctls[key]
+		if !ok {
+			return "", "", fmt.Errorf("sysctl %s is not supported in a jail: %w", key, define.ErrInvalidArg)
+		}
+	}
+	if param == "" || strings.HasSuffix(param, ".") {
+		return "", "", fmt.Errorf("invalid jail parameter sysctl %s: %w", key, define.ErrInvalidArg)
+	}
+	if strings.HasPrefix(param, "allow.") {
+		switch value {
+		case "0":
+			value = "false"
+		case "1":
+			value = "true"
+		}
+	}
+	return param, value, nil
+}
+
+// CheckSysctl returns an error if the sysctl cannot be set for a container,
+// either because it is not backed by a jail parameter or because it is a net.*
+// sysctl and the container does not have its own vnet.
+func CheckSysctl(key, value string, vnet bool) error {
+	if strings.HasPrefix(key, "net.") {
+		if !vnet {
+			return fmt.Errorf("sysctl %s=%s can't be set since the container does not have its own vnet: %w", key, value, define.ErrInvalidArg)
+		}
+		if strings.Contains(value, ",") {
+			return fmt.Errorf("sysctl %s=%s: value must not contain a comma: %w", key, value, define.ErrInvalidArg)
+		}
+		return nil
+	}
+	_, _, err := jailParamForSysctl(key, value)
+	return err
+}
+
+// hasVnet returns true if the container gets its own vnet.
+func hasVnet(s *specgen.SpecGenerator) bool {
+	switch s.NetNS.NSMode {
+	case specgen.Bridge, specgen.Private, specgen.Default:
+		return true
+	}
+	return false
+}
+
+// configureSysctls translates the container's sysctls into jail parameters.
+// The net.* sysctls are virtualised per vnet and are set by libpod inside the
+// container's vnet once its network has been configured. Defaults from
+// containers.conf which cannot be applied to the container are ignored.
+func configureSysctls(s *specgen.SpecGenerator, g *generate.Generator, defaults map[string]string) error {
+	sysctls := make(map[string]string, len(defaults)+len(s.Sysctl))
+	for key, val := range defaults {
+		if strings.HasPrefix(key, "net.") && !hasVnet(s) {
+			logrus.Infof("Sysctl %s=%s ignored in containers.conf, since the container has no vnet", key, val)
+			continue
+		}
+		if !strings.HasPrefix(key, "net.") {
+			if _, _, err := jailParamForSysctl(key, val); err != nil {
+				logrus.Infof("Sysctl %s=%s ignored in containers.conf: %v", key, val, err)
+				continue
+			}
+		}
+		sysctls[key] = val
+	}
+	for key, val := range s.Sysctl {
+		if strings.HasPrefix(key, "net.") && !hasVnet(s) {
+			return fmt.Errorf("sysctl %s=%s can't be set since the container does not have its own vnet: %w", key, val, define.ErrInvalidArg)
+		}
+		sysctls[key] = val
+	}
+
+	var vnetSysctls []string
+	for key, val := range sysctls {
+		if strings.HasPrefix(key, "net.") {
+			if strings.Contains(val, ",") {
+				return fmt.Errorf("sysctl %s=%s: value must not contain a comma: %w", key, val, define.ErrInvalidArg)
+			}
+			vnetSysctls = append(vnetSysctls, key+"="+val)
+			continue
+		}
+		param, value, err := jailParamForSysctl(key, val)
+		if err != nil {
+			return err
+		}
+		g.AddAnnotation(jailAnnotationPrefix+param, value)
+	}
+	if len(vnetSysctls) > 0 {
+		sort.Strings(vnetSysctls)
+		g.AddAnnotation(define.VnetSysctlsAnnotation, strings.Join(vnetSysctls, ","))
+	}
+	return nil
+}
+
+// getJailProfile returns the name and definition of the jail profile for the
+// container, if any. The profile given in the spec generator takes precedence
+// over a default set using annotations in containers.conf.
+func getJailProfile(s *specgen.SpecGenerator, profiles map[string]jailProfile) (string, *jailProfile, error) {
+	name := s.JailProfile
+	if name == "" {
+		name = s.Annotations[define.JailProfileAnnotation]
+	}
+	if name == "" {
+		return "", nil, nil
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown jail profile %q: %w", name, define.ErrInvalidArg)
+	}
+	return name, &profile, nil
+}
+
+// addJailParam sets a jail parameter unless it was already set explicitly
+// using an annotation.
+func addJailParam(g *generate.Generator, param, value string) {
+	key := jailAnnotationPrefix + param
+	if _, ok := g.Config.Annotations[key]; ok {
+		return
+	}
+	g.AddAnnotation(key, value)
+}
+
+// setDevfsRuleset changes the ruleset used for all devfs mounts in the spec.
+func setDevfsRuleset(g *generate.Generator, ruleset int) {
+	for i, m := range g.Config.Mounts {
+		if m.Type != "devfs" {
+			continue
+		}
+		options := []string{"ruleset=" + strconv.Itoa(ruleset)}
+		for _, o := range m.Options {
+			if !strings.HasPrefix(o, "ruleset=") {
+				options = append(options, o)
+			}
+		}
+		g.Config.Mounts[i].Options = options
+	}
+}
+
+// configureJail adds the jail parameters for the container to the spec.
+// Parameters set explicitly for the container take precedence over those from
+// the container's jail profile.
+func configureJail(s *specgen.SpecGenerator, g *generate.Generator, profiles map[string]jailProfile) error {
+	if s.EnforceStatfs != nil {
+		if *s.EnforceStatfs < 0 || *s.EnforceStatfs > 2 {
+			return fmt.Errorf("enforce_statfs must be 0, 1 or 2, got %d: %w", *s.EnforceStatfs, define.ErrInvalidArg)
+		}
+		g.AddAnnotation(jailAnnotationPrefix+"enforce_statfs", strconv.Itoa(*s.EnforceStatfs))
+	}
+	if s.Securelevel != nil {
+		if *s.Securelevel < -1 || *s.Securelevel > 3 {
+			return fmt.Errorf("securelevel must be between -1 and 3, got %d: %w", *s.Securelevel, define.ErrInvalidArg)
+		}
+		g.AddAnnotation(jailAnnotationPrefix+"securelevel", strconv.Itoa(*s.Securelevel))
+	}
+	for param, allow := range s.JailAllow {
+		if param == "" || strings.HasSuffix(param, ".") {
+			return fmt.Errorf("invalid jail parameter allow.%s: %w", param, define.ErrInvalidArg)
+		}
+		g.AddAnnotation(jailAnnotationPrefix+"allow."+param, strconv.FormatBool(allow))
+	}
+	return applyJailProfile(s, g, profiles)
+}
+
+// applyJailProfile adds the jail parameters from the container's jail
+// profile to the spec. Parameters set explicitly using annotations are not
+// overridden.
+func applyJailProfile(s *specgen.SpecGenerator, g *generate.Generator, profiles map[string]jailProfile) error {
+	name, profile, err := getJailProfile(s, profiles)
+	if err != nil || profile == nil {
+		return err
+	}
+	g.AddAnnotation(define.JailProfileAnnotation, name)
+
+	// Privileged containers already expose all devices.
+	if !s.IsPrivileged() {
+		setDevfsRuleset(g, profile.devfsRuleset)
+	}
+	addJailParam(g, "enforce_statfs", strconv.Itoa(profile.enforceStatfs))
+	addJailParam(g, "securelevel", strconv.Itoa(profile.securelevel))
+	for _, allow := range profile.allow {
+		addJailParam(g, "allow."+allow, "true")
+	}
+	return nil
+}
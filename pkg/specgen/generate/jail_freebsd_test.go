@@ -0,0 +1,178 @@
+//go:build !remote
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyJailProfile(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+	g.AddAnnotation(jailAnnotationPrefix+"securelevel", "1")
+
+	s := specgen.NewSpecGenerator("", false)
+	s.JailProfile = "router"
+	assert.NoError(t, applyJailProfile(s, &g, defaultJailProfiles))
+
+	annotations := g.Config.Annotations
+	assert.Equal(t, "router", annotations[define.JailProfileAnnotation])
+	assert.Equal(t, "2", annotations[jailAnnotationPrefix+"enforce_statfs"])
+	assert.Equal(t, "1", annotations[jailAnnotationPrefix+"securelevel"])
+	assert.Equal(t, "true", annotations[jailAnnotationPrefix+"allow.raw_sockets"])
+	for _, m := range g.Config.Mounts {
+		if m.Type == "devfs" {
+			assert.Equal(t, []string{"ruleset=5"}, m.Options)
+		}
+	}
+}
+
+func TestApplyJailProfileFromAnnotation(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+
+	s := specgen.NewSpecGenerator("", false)
+	s.Annotations = map[string]string{define.JailProfileAnnotation: "hardened"}
+	assert.NoError(t, applyJailProfile(s, &g, defaultJailProfiles))
+	assert.Equal(t, "3", g.Config.Annotations[jailAnnotationPrefix+"securelevel"])
+
+	s.Annotations[define.JailProfileAnnotation] = "bogus"
+	assert.ErrorIs(t, applyJailProfile(s, &g, defaultJailProfiles), define.ErrInvalidArg)
+}
+
+func TestLoadJailProfiles(t *testing.T) {
+	dir := t.TempDir()
+	system := filepath.Join(dir, "containers.conf")
+	override := filepath.Join(dir, "override.conf")
+	assert.NoError(t, os.WriteFile(system, []byte(`
+[containers]
+annotations = ["io.podman.annotations.jail-profile=web"]
+
+[jail_profiles.web]
+devfs_ruleset = 10
+allow = ["mlock"]
+
+[jail_profiles.router]
+securelevel = 1
+`), 0o644))
+	assert.NoError(t, os.WriteFile(override, []byte(`
+[jail_profiles.db]
+securelevel = 2
+`), 0o644))
+	t.Setenv("CONTAINERS_CONF", system)
+	t.Setenv("CONTAINERS_CONF_OVERRIDE", override)
+
+	rtc, err := config.New(nil)
+	assert.NoError(t, err)
+	profiles, err := loadJailProfiles(rtc)
+	assert.NoError(t, err)
+	assert.Equal(t, jailProfile{devfsRuleset: 10, enforceStatfs: 2, securelevel: -1, allow: []string{"mlock"}}, profiles["web"])
+	assert.Equal(t, jailProfile{devfsRuleset: 4, enforceStatfs: 2, securelevel: 2}, profiles["db"])
+	assert.Equal(t, jailProfile{devfsRuleset: 5, enforceStatfs: 2, securelevel: 1, allow: []string{"raw_sockets"}}, profiles["router"])
+	assert.Equal(t, defaultJailProfiles["hardened"], profiles["hardened"])
+	assert.Equal(t, -1, defaultJailProfiles["router"].securelevel)
+
+	profiles, err = loadJailProfiles(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultJailProfiles, profiles)
+
+	enforceStatfs := 3
+	rtc.JailProfiles["web"] = config.JailProfile{EnforceStatfs: &enforceStatfs}
+	_, err = loadJailProfiles(rtc)
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+}
+
+func TestConfigureJailEnforceStatfs(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+
+	enforceStatfs := 0
+	s := specgen.NewSpecGenerator("", false)
+	s.JailProfile = "hardened"
+	s.EnforceStatfs = &enforceStatfs
+	assert.NoError(t, configureJail(s, &g, defaultJailProfiles))
+	assert.Equal(t, "0", g.Config.Annotations[jailAnnotationPrefix+"enforce_statfs"])
+
+	enforceStatfs = 3
+	assert.ErrorIs(t, configureJail(s, &g, defaultJailProfiles), define.ErrInvalidArg)
+}
+
+func TestConfigureSysctls(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+
+	s := specgen.NewSpecGenerator("", false)
+	s.NetNS = specgen.Namespace{NSMode: specgen.Bridge}
+	s.Sysctl = map[string]string{
+		"security.jail.sysvipc_allowed":    "1",
+		"security.jail.param.allow.nfsd":   "true",
+		"kern.securelevel":                 "2",
+		"net.inet.tcp.blackhole":           "2",
+		"net.inet.ip.portrange.randomized": "0",
+		"security.jail.param.children.max": "4",
+	}
+	defaults := map[string]string{
+		"kernel.shmmax":          "1024",
+		"net.inet.tcp.blackhole": "1",
+	}
+	assert.NoError(t, configureSysctls(s, &g, defaults))
+
+	annotations := g.Config.Annotations
+	assert.Equal(t, "true", annotations[jailAnnotationPrefix+"allow.sysvipc"])
+	assert.Equal(t, "true", annotations[jailAnnotationPrefix+"allow.nfsd"])
+	assert.Equal(t, "2", annotations[jailAnnotationPrefix+"securelevel"])
+	assert.Equal(t, "4", annotations[jailAnnotationPrefix+"children.max"])
+	assert.Equal(t, "net.inet.ip.portrange.randomized=0,net.inet.tcp.blackhole=2", annotations[define.VnetSysctlsAnnotation])
+}
+
+func TestConfigureSysctlsInvalid(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+
+	s := specgen.NewSpecGenerator("", false)
+	s.NetNS = specgen.Namespace{NSMode: specgen.Host}
+	s.Sysctl = map[string]string{"net.inet.tcp.blackhole": "2"}
+	assert.ErrorIs(t, configureSysctls(s, &g, nil), define.ErrInvalidArg)
+
+	s.NetNS = specgen.Namespace{NSMode: specgen.Bridge}
+	s.Sysctl = map[string]string{"kern.maxfiles": "1000"}
+	assert.ErrorIs(t, configureSysctls(s, &g, nil), define.ErrInvalidArg)
+}
+
+func TestCheckSysctl(t *testing.T) {
+	assert.NoError(t, CheckSysctl("net.inet.tcp.blackhole", "2", true))
+	assert.ErrorIs(t, CheckSysctl("net.inet.tcp.blackhole", "2", false), define.ErrInvalidArg)
+	assert.ErrorIs(t, CheckSysctl("net.inet.ip.portrange.reservedhigh", "1,2", true), define.ErrInvalidArg)
+	assert.NoError(t, CheckSysctl("security.jail.sysvipc_allowed", "1", false))
+	assert.NoError(t, CheckSysctl("security.jail.param.allow.mlock", "1", false))
+	assert.ErrorIs(t, CheckSysctl("kernel.shmmax", "1000", true), define.ErrInvalidArg)
+}
+
+func TestConfigureJailSecurityOpts(t *testing.T) {
+	g, err := generate.New("freebsd")
+	assert.NoError(t, err)
+
+	securelevel := 2
+	s := specgen.NewSpecGenerator("", false)
+	s.JailProfile = "permissive"
+	s.Securelevel = &securelevel
+	s.JailAllow = map[string]bool{"mount": false, "chflags": false}
+	assert.NoError(t, configureJail(s, &g, defaultJailProfiles))
+
+	annotations := g.Config.Annotations
+	assert.Equal(t, "2", annotations[jailAnnotationPrefix+"securelevel"])
+	assert.Equal(t, "false", annotations[jailAnnotationPrefix+"allow.mount"])
+	assert.Equal(t, "false", annotations[jailAnnotationPrefix+"allow.chflags"])
+	assert.Equal(t, "true", annotations[jailAnnotationPrefix+"allow.mlock"])
+
+	securelevel = 4
+	assert.ErrorIs(t, configureJail(s, &g, defaultJailProfiles), define.ErrInvalidArg)
+}
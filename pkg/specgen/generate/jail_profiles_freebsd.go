@@ -0,0 +1,67 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// loadJailProfiles returns the built-in jail profiles merged with the
+// profiles defined in the [jail_profiles] table of containers.conf.
+func loadJailProfiles(rtc *config.Config) (map[string]jailProfile, error) {
+	profiles := make(map[string]jailProfile, len(defaultJailProfiles))
+	for name, profile := range defaultJailProfiles {
+		profiles[name] = profile
+	}
+	if rtc == nil {
+		return profiles, nil
+	}
+	for name, c := range rtc.JailProfiles {
+		profile, ok := profiles[name]
+		if !ok {
+			// Start out with the values jail(8) uses by default.
+			profile = jailProfile{devfsRuleset: 4, enforceStatfs: 2, securelevel: -1}
+		}
+		profile, err := mergeJailProfile(profile, c)
+		if err != nil {
+			return nil, fmt.Errorf("jail profile %q in containers.conf: %w", name, err)
+		}
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// mergeJailProfile overrides the fields of profile which are set in c.
+func mergeJailProfile(profile jailProfile, c config.JailProfile) (jailProfile, error) {
+	if c.DevfsRuleset != nil {
+		if *c.DevfsRuleset < 0 {
+			return profile, fmt.Errorf("invalid devfs_ruleset %d: %w", *c.DevfsRuleset, define.ErrInvalidArg)
+		}
+		profile.devfsRuleset = *c.DevfsRuleset
+	}
+	if c.EnforceStatfs != nil {
+		if *c.EnforceStatfs < 0 || *c.EnforceStatfs > 2 {
+			return profile, fmt.Errorf("enforce_statfs must be 0, 1 or 2, got %d: %w", *c.EnforceStatfs, define.ErrInvalidArg)
+		}
+		profile.enforceStatfs = *c.EnforceStatfs
+	}
+	if c.Securelevel != nil {
+		if *c.Securelevel < -1 || *c.Securelevel > 3 {
+			return profile, fmt.Errorf("securelevel must be between -1 and 3, got %d: %w", *c.Securelevel, define.ErrInvalidArg)
+		}
+		profile.securelevel = *c.Securelevel
+	}
+	if c.Allow != nil {
+		for _, param := range *c.Allow {
+			if param == "" || strings.HasSuffix(param, ".") {
+				return profile, fmt.Errorf("invalid jail parameter allow.%s: %w", param, define.ErrInvalidArg)
+			}
+		}
+		profile.allow = *c.Allow
+	}
+	return profile, nil
+}
@@ -42,6 +42,10 @@ import (
 )
 
 func ToPodOpt(ctx context.Context, podName string, p entities.PodCreateOptions, publishAllPorts bool, podYAML *v1.PodTemplateSpec) (entities.PodCreateOptions, error) {
+	if err := checkPodSpec(podYAML); err != nil {
+		return p, err
+	}
+
 	p.Net = &entities.NetOptions{NoHosts: p.Net.NoHosts}
 
 	p.Name = podName
@@ -240,6 +244,12 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				return nil, err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFiles, err := strconv.ParseUint(val, 10, 32)
+			if err != nil || maxFiles == 0 {
+				return nil, fmt.Errorf("invalid max-file log option %q: must be a positive number", val)
+			}
+			s.LogConfiguration.MaxFiles = uint(maxFiles)
 		default:
 			switch len(val) {
 			case 0:
@@ -258,6 +268,7 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	s.InitContainerType = opts.InitContainerType
 
 	setupSecurityContext(s, opts.Container.SecurityContext, opts.PodSecurityContext)
+	setupPlatformSecurityContext(s, opts.Container.SecurityContext)
 	err = setupLivenessProbe(s, opts.Container, opts.RestartPolicy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure livenessProbe: %w", err)
@@ -379,6 +390,9 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		annotations[ann.SandboxID] = opts.PodInfraID
 	}
 	s.Annotations = annotations
+	for k, v := range freebsdAnnotations(opts.Annotations, opts.Container.Name) {
+		s.Annotations[k] = v
+	}
 
 	if containerCIDFile, ok := opts.Annotations[define.InspectAnnotationCIDFile+"/"+opts.Container.Name]; ok {
 		s.Annotations[define.InspectAnnotationCIDFile] = containerCIDFile
@@ -825,6 +839,22 @@ func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32,
 	return &hc, nil
 }
 
+// freebsdAnnotations returns the org.freebsd.* annotations, such as jail
+// parameters, which generate kube recorded for the named container as
+// <annotation>/<container name>, under their original names.
+func freebsdAnnotations(annotations map[string]string, ctrName string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range annotations {
+		if !strings.HasPrefix(k, "org.freebsd.") {
+			continue
+		}
+		if key, ok := strings.CutSuffix(k, "/"+ctrName); ok {
+			result[key] = v
+		}
+	}
+	return result
+}
+
 func setupSecurityContext(s *specgen.SpecGenerator, securityContext *v1.SecurityContext, podSecurityContext *v1.PodSecurityContext) {
 	if securityContext == nil {
 		securityContext = &v1.SecurityContext{}
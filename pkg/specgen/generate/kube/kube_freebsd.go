@@ -0,0 +1,137 @@
+//go:build !remote
+
+package kube
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/podman/v5/pkg/specgen/generate"
+	"github.com/sirupsen/logrus"
+)
+
+// capabilityJailParams maps the capabilities which have an equivalent jail
+// parameter to the sysctl setting it. Other capabilities have no meaning in a
+// jail and are ignored.
+var capabilityJailParams = map[string]string{
+	"CAP_IPC_LOCK":        "security.jail.mlock_allowed",
+	"CAP_LINUX_IMMUTABLE": "security.jail.chflags_allowed",
+	"CAP_NET_RAW":         "security.jail.allow_raw_sockets",
+}
+
+func normalizeCapability(capability v1.Capability) string {
+	name := strings.ToUpper(string(capability))
+	if !strings.HasPrefix(name, "CAP_") {
+		name = "CAP_" + name
+	}
+	return name
+}
+
+// checkSeccompProfile rejects a custom seccomp profile, since the container
+// would silently run without the restrictions it asks for. The default
+// profiles are ignored like they are for other containers.
+func checkSeccompProfile(profile *v1.SeccompProfile) error {
+	if profile == nil || profile.Type != v1.SeccompProfileTypeLocalhost {
+		return nil
+	}
+	name := ""
+	if profile.LocalhostProfile != nil {
+		name = *profile.LocalhostProfile
+	}
+	return fmt.Errorf("seccomp profile %q: seccomp is not supported on FreeBSD: %w", name, define.ErrOSNotSupported)
+}
+
+// checkPodSpec checks the pod spec for fields which cannot be supported in a
+// jail before any part of the pod is created. Fields which would make the
+// pod behave differently from what it asks for are rejected, and fields
+// which are only hints on Linux, such as security labels, are ignored with a
+// warning.
+func checkPodSpec(podYAML *v1.PodTemplateSpec) error {
+	podSpec := podYAML.Spec
+	if podSpec.HostPID {
+		logrus.Warnf("Ignoring hostPID in pod %s, processes in a jail cannot see processes outside of it", podYAML.Name)
+	}
+	if psc := podSpec.SecurityContext; psc != nil {
+		if psc.SELinuxOptions != nil {
+			logrus.Warnf("Ignoring seLinuxOptions in pod %s, SELinux is not supported on FreeBSD", podYAML.Name)
+		}
+		if err := checkSeccompProfile(psc.SeccompProfile); err != nil {
+			return fmt.Errorf("pod %s: %w", podYAML.Name, err)
+		}
+		for _, sysctl := range psc.Sysctls {
+			if err := generate.CheckSysctl(sysctl.Name, sysctl.Value, !podSpec.HostNetwork); err != nil {
+				return fmt.Errorf("pod %s: %w", podYAML.Name, err)
+			}
+		}
+	}
+
+	// Devices are exposed through the container's devfs, which cannot
+	// move them to a different path.
+	devices := make(map[string]string)
+	for _, volume := range podSpec.Volumes {
+		hostPath := volume.HostPath
+		if hostPath == nil || hostPath.Type == nil {
+			continue
+		}
+		if *hostPath.Type == v1.HostPathCharDev || *hostPath.Type == v1.HostPathBlockDev {
+			devices[volume.Name] = filepath.Clean(hostPath.Path)
+		}
+	}
+
+	containers := make([]v1.Container, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+	containers = append(containers, podSpec.InitContainers...)
+	containers = append(containers, podSpec.Containers...)
+	for _, ctr := range containers {
+		if sc := ctr.SecurityContext; sc != nil {
+			if sc.SELinuxOptions != nil {
+				logrus.Warnf("Ignoring seLinuxOptions in container %s, SELinux is not supported on FreeBSD", ctr.Name)
+			}
+			if sc.ProcMount != nil && *sc.ProcMount == v1.UnmaskedProcMount {
+				logrus.Warnf("Ignoring procMount in container %s, there are no masked paths on FreeBSD", ctr.Name)
+			}
+			if err := checkSeccompProfile(sc.SeccompProfile); err != nil {
+				return fmt.Errorf("container %s: %w", ctr.Name, err)
+			}
+			if caps := sc.Capabilities; caps != nil {
+				for _, capability := range caps.Add {
+					name := normalizeCapability(capability)
+					if name == "CAP_SYS_TIME" {
+						return fmt.Errorf("container %s: capability %s: containers cannot set the system clock on FreeBSD, run the time daemon on the host instead: %w", ctr.Name, capability, define.ErrOSNotSupported)
+					}
+					if _, ok := capabilityJailParams[name]; !ok && name != "CAP_ALL" {
+						logrus.Infof("Ignoring capability %s in container %s, it has no equivalent in a jail", capability, ctr.Name)
+					}
+				}
+			}
+		}
+		for _, mount := range ctr.VolumeMounts {
+			path, ok := devices[mount.Name]
+			if ok && filepath.Clean(mount.MountPath) != path {
+				return fmt.Errorf("container %s: device %s must be mounted at the same path in the container on FreeBSD, not %s: %w", ctr.Name, path, mount.MountPath, define.ErrOSNotSupported)
+			}
+		}
+	}
+	return nil
+}
+
+// setupPlatformSecurityContext sets the jail parameters equivalent to the
+// capabilities added by the container's security context.
+func setupPlatformSecurityContext(s *specgen.SpecGenerator, securityContext *v1.SecurityContext) {
+	if securityContext == nil || securityContext.Capabilities == nil {
+		return
+	}
+	for _, capability := range securityContext.Capabilities.Add {
+		sysctl, ok := capabilityJailParams[normalizeCapability(capability)]
+		if !ok {
+			continue
+		}
+		if s.Sysctl == nil {
+			s.Sysctl = make(map[string]string)
+		}
+		s.Sysctl[sysctl] = "1"
+	}
+}
@@ -0,0 +1,68 @@
+//go:build !remote
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPodSpec(t *testing.T) {
+	charDev := v1.HostPathCharDev
+	podYAML := &v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			SecurityContext: &v1.PodSecurityContext{
+				Sysctls: []v1.Sysctl{{Name: "net.inet.tcp.blackhole", Value: "2"}},
+			},
+			Volumes: []v1.Volume{{
+				Name: "tty",
+				VolumeSource: v1.VolumeSource{
+					HostPath: &v1.HostPathVolumeSource{Path: "/dev/ttyu0", Type: &charDev},
+				},
+			}},
+			Containers: []v1.Container{{
+				Name:         "ctr",
+				VolumeMounts: []v1.VolumeMount{{Name: "tty", MountPath: "/dev/ttyu0"}},
+			}},
+		},
+	}
+	assert.NoError(t, checkPodSpec(podYAML))
+
+	podYAML.Spec.HostNetwork = true
+	assert.ErrorIs(t, checkPodSpec(podYAML), define.ErrInvalidArg)
+	podYAML.Spec.HostNetwork = false
+
+	podYAML.Spec.SecurityContext.Sysctls = []v1.Sysctl{{Name: "kernel.shmmax", Value: "1000"}}
+	assert.ErrorIs(t, checkPodSpec(podYAML), define.ErrInvalidArg)
+	podYAML.Spec.SecurityContext.Sysctls = nil
+
+	podYAML.Spec.Containers[0].VolumeMounts[0].MountPath = "/dev/console"
+	assert.ErrorIs(t, checkPodSpec(podYAML), define.ErrOSNotSupported)
+	podYAML.Spec.Containers[0].VolumeMounts[0].MountPath = "/dev/ttyu0"
+
+	profile := "profile.json"
+	podYAML.Spec.Containers[0].SecurityContext = &v1.SecurityContext{
+		SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeLocalhost, LocalhostProfile: &profile},
+	}
+	assert.ErrorIs(t, checkPodSpec(podYAML), define.ErrOSNotSupported)
+
+	podYAML.Spec.Containers[0].SecurityContext = &v1.SecurityContext{
+		Capabilities: &v1.Capabilities{Add: []v1.Capability{"SYS_TIME"}},
+	}
+	assert.ErrorIs(t, checkPodSpec(podYAML), define.ErrOSNotSupported)
+}
+
+func TestSetupPlatformSecurityContext(t *testing.T) {
+	s := specgen.NewSpecGenerator("", false)
+	setupPlatformSecurityContext(s, &v1.SecurityContext{
+		Capabilities: &v1.Capabilities{Add: []v1.Capability{"NET_RAW", "CAP_IPC_LOCK", "NET_ADMIN"}},
+	})
+	assert.Equal(t, map[string]string{
+		"security.jail.allow_raw_sockets": "1",
+		"security.jail.mlock_allowed":     "1",
+	}, s.Sysctl)
+}
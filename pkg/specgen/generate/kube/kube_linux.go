@@ -0,0 +1,19 @@
+//go:build !remote
+
+package kube
+
+import (
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v5/pkg/specgen"
+)
+
+// checkPodSpec does nothing on Linux, all the fields of the pod spec which
+// kube play understands are supported.
+func checkPodSpec(podYAML *v1.PodTemplateSpec) error {
+	return nil
+}
+
+// setupPlatformSecurityContext does nothing on Linux, the security context is
+// applied by setupSecurityContext.
+func setupPlatformSecurityContext(s *specgen.SpecGenerator, securityContext *v1.SecurityContext) {
+}
@@ -98,3 +98,16 @@ func TestGetPortNumber(t *testing.T) {
 	assert.NoError(t, e)
 	assert.Equal(t, i, 6000)
 }
+
+func TestFreebsdAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"org.freebsd.jail.allow.raw_sockets/ctr1": "true",
+		"org.freebsd.jail.securelevel/ctr2":       "3",
+		"org.freebsd.jail.enforce_statfs":         "1",
+		"io.podman.annotations.init/ctr1":         "TRUE",
+	}
+	assert.Equal(t, map[string]string{
+		"org.freebsd.jail.allow.raw_sockets": "true",
+	}, freebsdAnnotations(annotations, "ctr1"))
+	assert.Empty(t, freebsdAnnotations(annotations, "ctr3"))
+}
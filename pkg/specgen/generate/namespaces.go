@@ -387,6 +387,12 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 	if len(s.DNSOptions) > 0 {
 		toReturn = append(toReturn, libpod.WithDNSOption(s.DNSOptions))
 	}
+	if len(s.Routes) > 0 {
+		toReturn = append(toReturn, libpod.WithRoutes(s.Routes))
+	}
+	if s.VnetDevfsRuleset != nil {
+		toReturn = append(toReturn, libpod.WithVnetDevfsRuleset(*s.VnetDevfsRuleset))
+	}
 	if s.NetworkOptions != nil {
 		toReturn = append(toReturn, libpod.WithNetworkOptions(s.NetworkOptions))
 	}
@@ -15,6 +15,8 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
 // SpecGenToOCI returns the base configuration for the container.
@@ -78,8 +80,29 @@ func SpecGenToOCI(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runt
 		g.AddProcessEnv(name, val)
 	}
 
+	s.Rlimits = supportedRlimits(s.Rlimits)
 	addRlimits(s, &g)
 
+	// Resource limits are applied by libpod using rctl(8) rules for the
+	// container's jail. Only the pids limit, the memory limit, the CPU
+	// quota and the memory reservation, which triggers the memory reclaim
+	// signal, are supported.
+	if s.ResourceLimits != nil && s.ResourceLimits.Pids != nil {
+		g.SetLinuxResourcesPidsLimit(s.ResourceLimits.Pids.Limit)
+	}
+	if s.ResourceLimits != nil && s.ResourceLimits.Memory != nil {
+		if s.ResourceLimits.Memory.Limit != nil {
+			g.SetLinuxResourcesMemoryLimit(*s.ResourceLimits.Memory.Limit)
+		}
+		if s.ResourceLimits.Memory.Reservation != nil {
+			g.SetLinuxResourcesMemoryReservation(*s.ResourceLimits.Memory.Reservation)
+		}
+	}
+	if s.ResourceLimits != nil && s.ResourceLimits.CPU != nil && s.ResourceLimits.CPU.Quota != nil && s.ResourceLimits.CPU.Period != nil {
+		g.SetLinuxResourcesCPUQuota(*s.ResourceLimits.CPU.Quota)
+		g.SetLinuxResourcesCPUPeriod(*s.ResourceLimits.CPU.Period)
+	}
+
 	// NAMESPACES
 	if err := specConfigureNamespaces(s, &g, rt, pod); err != nil {
 		return nil, err
@@ -135,6 +158,14 @@ func SpecGenToOCI(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runt
 		configSpec.Mounts = mounts
 	}
 
+	profiles, err := loadJailProfiles(rtc)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureJail(s, &g, profiles); err != nil {
+		return nil, err
+	}
+
 	// BIND MOUNTS
 	configSpec.Mounts = SupersedeUserMounts(mounts, configSpec.Mounts)
 	// Process mounts to ensure correct options
@@ -175,6 +206,40 @@ func WeightDevices(wtDevices map[string]spec.LinuxWeightDevice) ([]spec.LinuxWei
 	return devs, nil
 }
 
+// unsupportedRlimits lists the rlimit types accepted by --ulimit which have
+// no equivalent on FreeBSD.
+var unsupportedRlimits = map[string]bool{
+	"locks":      true,
+	"msgqueue":   true,
+	"nice":       true,
+	"rtprio":     true,
+	"rttime":     true,
+	"sigpending": true,
+}
+
+// supportedRlimits removes rlimits which are not supported on FreeBSD. These
+// may come from a containers.conf shared with Linux hosts so they are
+// dropped with a warning instead of failing the container creation.
+func supportedRlimits(rlimits []specs.POSIXRlimit) []specs.POSIXRlimit {
+	supported := make([]specs.POSIXRlimit, 0, len(rlimits))
+	for _, u := range rlimits {
+		name := strings.ToLower(strings.TrimPrefix(strings.ToUpper(u.Type), "RLIMIT_"))
+		if unsupportedRlimits[name] {
+			logrus.Warnf("Ulimit %q is not supported on FreeBSD, ignoring", name)
+			continue
+		}
+		supported = append(supported, u)
+	}
+	return supported
+}
+
+// subNegativeOne translates hard or soft limits of -1 to RLIM_INFINITY
 func subNegativeOne(u specs.POSIXRlimit) specs.POSIXRlimit {
+	if int64(u.Hard) == -1 {
+		u.Hard = unix.RLIM_INFINITY
+	}
+	if int64(u.Soft) == -1 {
+		u.Soft = unix.RLIM_INFINITY
+	}
 	return u
 }
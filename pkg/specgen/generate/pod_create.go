@@ -157,6 +157,10 @@ func createPodOptions(p *specgen.PodSpecGenerator) ([]libpod.PodCreateOption, er
 		options = append(options, libpod.WithPodResources(*p.ResourceLimits))
 	}
 
+	if p.CPUPlacement != "" {
+		options = append(options, libpod.WithPodCPUPlacement(p.CPUPlacement))
+	}
+
 	options = append(options, libpod.WithPodExitPolicy(p.ExitPolicy))
 	options = append(options, libpod.WithPodRestartPolicy(p.RestartPolicy))
 	if p.RestartRetries != nil {
@@ -249,6 +253,9 @@ func MapSpec(p *specgen.PodSpecGenerator) (*specgen.SpecGenerator, error) {
 	if len(p.DNSSearch) > 0 {
 		spec.DNSSearch = p.DNSSearch
 	}
+	if len(p.Routes) > 0 {
+		spec.Routes = p.Routes
+	}
 	if p.NoManageResolvConf {
 		localTrue := true
 		spec.UseImageResolvConf = &localTrue
@@ -3,11 +3,19 @@
 package generate
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/seccomp"
 	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/podman/v5/pkg/util"
 	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/sirupsen/logrus"
 )
 
 // setLabelOpts sets the label options of the SecurityConfig according to the
@@ -29,9 +37,73 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 		}
 	}
 
+	if err := checkSeccomp(s, newImage, rtc); err != nil {
+		return err
+	}
+
+	if err := checkClockCapabilities(s); err != nil {
+		return err
+	}
+
 	if s.ReadOnlyFilesystem != nil {
 		g.SetRootReadonly(*s.ReadOnlyFilesystem)
 	}
 
+	// Add default sysctls
+	defaultSysctls, err := util.ValidateSysctls(rtc.Sysctls())
+	if err != nil {
+		return err
+	}
+	return configureSysctls(s, g, defaultSysctls)
+}
+
+// checkSeccomp verifies the container's seccomp configuration. FreeBSD has no
+// seccomp, so the default profile and profiles requested by the image are
+// ignored, allowing images written for Linux to run unchanged. A custom
+// profile requested explicitly for the container is an error, since the
+// container would silently run without the restrictions it asked for.
+func checkSeccomp(s *specgen.SpecGenerator, img *libimage.Image, rtc *config.Config) error {
+	scp, err := seccomp.LookupPolicy(s.SeccompPolicy)
+	if err != nil {
+		return err
+	}
+	if scp == seccomp.PolicyImage && img != nil {
+		labels, err := img.Labels(context.Background())
+		if err != nil {
+			return err
+		}
+		if labels[seccomp.ContainerImageLabel] != "" {
+			logrus.Warnf("Ignoring the seccomp profile defined by image %s, seccomp is not supported on FreeBSD", img.ID())
+		}
+	}
+
+	switch s.SeccompProfilePath {
+	case "", "unconfined", config.SeccompOverridePath, config.SeccompDefaultPath, rtc.Containers.SeccompProfile:
+		if s.SeccompProfilePath == "" || s.SeccompProfilePath == "unconfined" {
+			return nil
+		}
+		// Only warn if the default profile was requested explicitly
+		if s.Annotations[define.InspectAnnotationSeccomp] != "" {
+			logrus.Warnf("Ignoring seccomp profile %s, seccomp is not supported on FreeBSD", s.SeccompProfilePath)
+		} else {
+			logrus.Debugf("Ignoring the default seccomp profile %s, seccomp is not supported on FreeBSD", s.SeccompProfilePath)
+		}
+		return nil
+	}
+	return fmt.Errorf("seccomp profile %s: seccomp is not supported on FreeBSD: %w", s.SeccompProfilePath, define.ErrOSNotSupported)
+}
+
+// checkClockCapabilities rejects containers which ask for CAP_SYS_TIME. The
+// system clock is shared by all jails and the kernel never allows processes
+// in a jail to set it, regardless of the jail's parameters, so a time daemon
+// in the container could not work. Capabilities are otherwise ignored on
+// FreeBSD, but failing here is clearer than a daemon which silently fails to
+// discipline the clock.
+func checkClockCapabilities(s *specgen.SpecGenerator) error {
+	for _, capability := range s.CapAdd {
+		if name := strings.ToUpper(capability); name == "SYS_TIME" || name == "CAP_SYS_TIME" {
+			return fmt.Errorf("capability %s: containers cannot set the system clock on FreeBSD, run the time daemon on the host instead: %w", capability, define.ErrOSNotSupported)
+		}
+	}
 	return nil
 }
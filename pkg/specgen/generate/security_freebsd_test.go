@@ -0,0 +1,45 @@
+//go:build !remote
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSeccomp(t *testing.T) {
+	rtc := &config.Config{}
+	s := specgen.NewSpecGenerator("", false)
+	assert.NoError(t, checkSeccomp(s, nil, rtc))
+
+	s.SeccompProfilePath = "unconfined"
+	assert.NoError(t, checkSeccomp(s, nil, rtc))
+
+	s.SeccompProfilePath = config.SeccompDefaultPath
+	assert.NoError(t, checkSeccomp(s, nil, rtc))
+
+	rtc.Containers.SeccompProfile = "/etc/containers/custom.json"
+	s.SeccompProfilePath = "/etc/containers/custom.json"
+	assert.NoError(t, checkSeccomp(s, nil, rtc))
+
+	s.SeccompProfilePath = "/tmp/profile.json"
+	assert.ErrorIs(t, checkSeccomp(s, nil, rtc), define.ErrOSNotSupported)
+}
+
+func TestCheckClockCapabilities(t *testing.T) {
+	s := specgen.NewSpecGenerator("", false)
+	assert.NoError(t, checkClockCapabilities(s))
+
+	s.CapAdd = []string{"NET_ADMIN", "ALL"}
+	assert.NoError(t, checkClockCapabilities(s))
+
+	s.CapAdd = []string{"sys_time"}
+	assert.ErrorIs(t, checkClockCapabilities(s), define.ErrOSNotSupported)
+
+	s.CapAdd = []string{"CAP_SYS_TIME"}
+	assert.ErrorIs(t, checkClockCapabilities(s), define.ErrOSNotSupported)
+}
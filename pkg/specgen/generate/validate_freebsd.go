@@ -1,8 +1,18 @@
 package generate
 
-import "github.com/containers/podman/v5/pkg/specgen"
+import (
+	"errors"
 
-// verifyContainerResources does nothing on freebsd as it has no cgroups
+	"github.com/containers/podman/v5/pkg/specgen"
+)
+
+// verifyContainerResources checks the resource limits which are implemented
+// using rctl(8) as freebsd has no cgroups
 func verifyContainerResources(s *specgen.SpecGenerator) ([]string, error) {
+	if s.MemoryReclaimSignal != nil {
+		if s.ResourceLimits == nil || s.ResourceLimits.Memory == nil || s.ResourceLimits.Memory.Reservation == nil || *s.ResourceLimits.Memory.Reservation <= 0 {
+			return nil, errors.New("a memory reclaim signal requires a memory reservation")
+		}
+	}
 	return nil, nil
 }
@@ -224,12 +224,24 @@ func verifyContainerResourcesCgroupV2(s *specgen.SpecGenerator) ([]string, error
 // Verify resource limits are sanely set, removing any limits that are not
 // possible with the current cgroups config.
 func verifyContainerResources(s *specgen.SpecGenerator) ([]string, error) {
+	var warnings []string
+	if s.MemoryReclaimSignal != nil {
+		s.MemoryReclaimSignal = nil
+		warnings = append(warnings, "Memory reclaim signal is only supported on FreeBSD, discarding")
+	}
+	if s.IOPriority != "" {
+		s.IOPriority = ""
+		warnings = append(warnings, "I/O priority classes are only supported on FreeBSD, discarding")
+	}
 	cgroup2, err := cgroups.IsCgroup2UnifiedMode()
 	if err != nil {
 		return []string{}, err
 	}
+	var cgroupWarnings []string
 	if cgroup2 {
-		return verifyContainerResourcesCgroupV2(s)
+		cgroupWarnings, err = verifyContainerResourcesCgroupV2(s)
+	} else {
+		cgroupWarnings, err = verifyContainerResourcesCgroupV1(s)
 	}
-	return verifyContainerResourcesCgroupV1(s)
+	return append(warnings, cgroupWarnings...), err
 }
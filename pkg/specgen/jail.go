@@ -0,0 +1,27 @@
+package specgen
+
+// jailAllowParams are the allow.* jail parameters which can be disabled using
+// the --security-opt no<param> shorthand, e.g. nomount.
+var jailAllowParams = map[string]bool{
+	"chflags":                 true,
+	"extattr":                 true,
+	"mlock":                   true,
+	"mount":                   true,
+	"nfsd":                    true,
+	"quotas":                  true,
+	"raw_sockets":             true,
+	"read_msgbuf":             true,
+	"reserved_ports":          true,
+	"set_hostname":            true,
+	"socket_af":               true,
+	"suser":                   true,
+	"sysvipc":                 true,
+	"unprivileged_proc_debug": true,
+	"vmm":                     true,
+}
+
+// IsJailAllowParam returns true if param is the name of an allow.* jail
+// parameter, without the allow. prefix.
+func IsJailAllowParam(param string) bool {
+	return jailAllowParams[param]
+}
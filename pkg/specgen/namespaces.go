@@ -355,7 +355,17 @@ func ParseNetworkFlag(networks []string) (Namespace, map[string]types.PerNetwork
 		_, options, hasOptions := strings.Cut(ns, ":")
 		netOpts := types.PerNetworkOptions{}
 		if hasOptions {
-			var err error
+			var (
+				ctrOpts []string
+				err     error
+			)
+			options, ctrOpts, err = cutContainerBridgeOptions(options)
+			if err != nil {
+				return toReturn, nil, nil, err
+			}
+			if ctrOpts != nil {
+				networkOptions = map[string][]string{string(Bridge): ctrOpts}
+			}
 			netOpts, err = parseBridgeNetworkOptions(options)
 			if err != nil {
 				return toReturn, nil, nil, err
@@ -390,6 +400,13 @@ func ParseNetworkFlag(networks []string) (Namespace, map[string]types.PerNetwork
 			if name == "" {
 				return toReturn, nil, nil, errors.New("network name cannot be empty")
 			}
+			options, ctrOpts, err := cutContainerBridgeOptions(options)
+			if err != nil {
+				return toReturn, nil, nil, fmt.Errorf("invalid option for network %s: %w", name, err)
+			}
+			if ctrOpts != nil {
+				networkOptions = map[string][]string{string(Bridge): ctrOpts}
+			}
 			netOpts, err := parseBridgeNetworkOptions(options)
 			if err != nil {
 				return toReturn, nil, nil, fmt.Errorf("invalid option for network %s: %w", name, err)
@@ -436,6 +453,40 @@ func ParseNetworkFlag(networks []string) (Namespace, map[string]types.PerNetwork
 	return toReturn, podmanNetworks, networkOptions, nil
 }
 
+// cutContainerBridgeOptions removes the port_handler, ingress_rate and
+// egress_rate options from the options of the first network of a container
+// and returns them separately as they apply to the container rather than to
+// the network.
+func cutContainerBridgeOptions(opts string) (string, []string, error) {
+	var (
+		rest    []string
+		ctrOpts []string
+	)
+	for _, opt := range strings.Split(opts, ",") {
+		name, value, _ := strings.Cut(opt, "=")
+		switch name {
+		case define.BridgePortHandlerOption:
+			switch value {
+			case define.FirewallPortHandler, define.ProxyPortHandler:
+			default:
+				return "", nil, fmt.Errorf("invalid port_handler %q, must be %s or %s", value, define.FirewallPortHandler, define.ProxyPortHandler)
+			}
+		case define.BridgeIngressRateOption, define.BridgeEgressRateOption:
+			if _, err := util.ParseNetworkRate(value); err != nil {
+				return "", nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+		default:
+			rest = append(rest, opt)
+			continue
+		}
+		ctrOpts = slices.DeleteFunc(ctrOpts, func(o string) bool {
+			return strings.HasPrefix(o, name+"=")
+		})
+		ctrOpts = append(ctrOpts, opt)
+	}
+	return strings.Join(rest, ","), ctrOpts, nil
+}
+
 func parseBridgeNetworkOptions(opts string) (types.PerNetworkOptions, error) {
 	netOpts := types.PerNetworkOptions{}
 	if len(opts) == 0 {
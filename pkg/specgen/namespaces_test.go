@@ -157,6 +157,40 @@ func TestParseNetworkFlag(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "bridge mode with port handler option",
+			args:   []string{"bridge:port_handler=proxy,ip=10.0.0.1"},
+			nsmode: Namespace{NSMode: Bridge},
+			networks: map[string]types.PerNetworkOptions{
+				defaultNetName: {
+					StaticIPs: []net.IP{net.ParseIP("10.0.0.1")},
+				},
+			},
+			options: map[string][]string{"bridge": {"port_handler=proxy"}},
+		},
+		{
+			name:   "bridge mode with invalid port handler",
+			args:   []string{"bridge:port_handler=abc"},
+			nsmode: Namespace{NSMode: Bridge},
+			err:    "invalid port_handler \"abc\", must be firewall or proxy",
+		},
+		{
+			name:   "bridge mode with rate options",
+			args:   []string{"bridge:ingress_rate=10Mbit/s,egress_rate=1M,egress_rate=2M,ip=10.0.0.1"},
+			nsmode: Namespace{NSMode: Bridge},
+			networks: map[string]types.PerNetworkOptions{
+				defaultNetName: {
+					StaticIPs: []net.IP{net.ParseIP("10.0.0.1")},
+				},
+			},
+			options: map[string][]string{"bridge": {"ingress_rate=10Mbit/s", "egress_rate=2M"}},
+		},
+		{
+			name:   "bridge mode with invalid rate",
+			args:   []string{"bridge:egress_rate=fast"},
+			nsmode: Namespace{NSMode: Bridge},
+			err:    "invalid egress_rate: invalid network rate \"fast\", must be a number with an optional K, M or G multiplier and an optional bit/s or Byte/s unit",
+		},
 		{
 			name:   "bridge mode with invalid option",
 			args:   []string{"bridge:abc=123"},
@@ -191,6 +225,15 @@ func TestParseNetworkFlag(t *testing.T) {
 				"someName": {StaticIPs: []net.IP{net.ParseIP("10.0.0.1")}},
 			},
 		},
+		{
+			name:   "network name with port handler option",
+			args:   []string{"someName:port_handler=proxy"},
+			nsmode: Namespace{NSMode: Bridge},
+			networks: map[string]types.PerNetworkOptions{
+				"someName": {},
+			},
+			options: map[string][]string{"bridge": {"port_handler=proxy"}},
+		},
 		{
 			name:   "multiple networks",
 			args:   []string{"someName", "net2"},
@@ -159,6 +159,11 @@ type PodNetworkConfig struct {
 	// Conflicts with NoInfra=true.
 	// Optional.
 	DNSOption []string `json:"dns_option,omitempty"`
+	// Routes are static routes added to the network of the infra
+	// container, which is shared with all containers in the pod.
+	// Conflicts with NoInfra=true.
+	// Optional.
+	Routes []types.Route `json:"routes,omitempty"`
 	// NoManageHosts indicates that /etc/hosts should not be managed by the
 	// pod. Instead, each container will create a separate /etc/hosts as
 	// they would if not in a pod.
@@ -241,6 +246,12 @@ type PodResourceConfig struct {
 	CPUPeriod uint64 `json:"cpu_period,omitempty"`
 	// CPU quota of the cpuset, determined by --cpus
 	CPUQuota int64 `json:"cpu_quota,omitempty"`
+	// CPUPlacement is a hint to choose the cpuset of the pod so that it
+	// shares as few CPUs as possible with other pods. It can be "domain"
+	// to use the CPUs of a NUMA domain or "cpus" to use as many CPUs as
+	// set by --cpus. Conflicts with a cpuset in ResourceLimits.
+	// Optional.
+	CPUPlacement string `json:"cpu_placement,omitempty"`
 	// ThrottleReadBpsDevice contains the rate at which the devices in the pod can be read from/accessed
 	ThrottleReadBpsDevice map[string]spec.LinuxThrottleDevice `json:"throttleReadBpsDevice,omitempty"`
 }
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 	"syscall"
+	"time"
 
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/image/v5/manifest"
@@ -26,6 +27,10 @@ type LogConfig struct {
 	// Size is the maximum size of the log file
 	// Optional.
 	Size int64 `json:"size,omitempty"`
+	// MaxFiles is the number of log files kept when the log is rotated
+	// at its maximum size, including the current one.
+	// Optional.
+	MaxFiles uint `json:"max_files,omitempty"`
 	// A set of options to accompany the log driver.
 	// Optional.
 	Options map[string]string `json:"options,omitempty"`
@@ -67,6 +72,11 @@ type ContainerBasicConfig struct {
 	// Stdin is whether the container will keep its STDIN open.
 	// Optional.
 	Stdin *bool `json:"stdin,omitempty"`
+	// Console is whether the container gets a console which can be
+	// attached to at any time, even if it was started detached. The
+	// console is separate from the container's standard streams.
+	// Optional.
+	Console bool `json:"console,omitempty"`
 	// Labels are key-value pairs that are used to add metadata to
 	// containers.
 	// Optional.
@@ -75,6 +85,19 @@ type ContainerBasicConfig struct {
 	// that can be used to trigger special behavior.
 	// Optional.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// MetadataKeys selects the labels and annotations which are written
+	// to /run/.containermeta in the container, so that agents running in
+	// the container can identify it. Keys may contain shell patterns.
+	// Optional.
+	MetadataKeys []string `json:"metadata_keys,omitempty"`
+	// IdentityCA is the directory on the host containing the certificate
+	// (ca.crt) and key (ca.key) of the CA which issues a new identity
+	// certificate to the container each time it starts.
+	// Optional.
+	IdentityCA string `json:"identity_ca,omitempty"`
+	// IdentityTTL is the validity of the identity certificate.
+	// Optional.
+	IdentityTTL time.Duration `json:"identity_ttl,omitempty"`
 	// StopSignal is the signal that will be used to stop the container.
 	// Must be a non-zero integer below SIGRTMAX.
 	// If not provided, the default, SIGTERM, will be used.
@@ -118,6 +141,11 @@ type ContainerBasicConfig struct {
 	// If not specified, the default will be used.
 	// Optional.
 	OCIRuntime string `json:"oci_runtime,omitempty"`
+	// RuntimeFlags are additional flags, without their leading "--",
+	// passed to the OCI runtime every time it is invoked for the
+	// container.
+	// Optional.
+	RuntimeFlags []string `json:"runtime_flags,omitempty"`
 	// Systemd is whether the container will be started in systemd mode.
 	// Valid options are "true", "false", and "always".
 	// "true" enables this mode only if the binary run in the container is
@@ -153,6 +181,10 @@ type ContainerBasicConfig struct {
 	// HostUsers is a list of host usernames or UIDs to add to the container
 	// /etc/passwd file
 	HostUsers []string `json:"hostusers,omitempty"`
+	// HostUserGroups adds the primary and supplementary groups of the
+	// HostUsers to the container /etc/group file.
+	// Optional.
+	HostUserGroups bool `json:"hostuser_groups,omitempty"`
 	// Sysctl sets kernel parameters for the container
 	Sysctl map[string]string `json:"sysctl,omitempty"`
 	// Remove indicates if the container should be removed once it has been started
@@ -222,6 +254,12 @@ type ContainerBasicConfig struct {
 	// GroupEntry specifies an arbitrary string to append to the container's /etc/group file.
 	// Optional.
 	GroupEntry string `json:"group_entry,omitempty"`
+	// NoPasswd prevents adding entries to the container's /etc/passwd file.
+	// Optional.
+	NoPasswd bool `json:"no_passwd,omitempty"`
+	// NoGroup prevents adding entries to the container's /etc/group file.
+	// Optional.
+	NoGroup bool `json:"no_group,omitempty"`
 }
 
 // ContainerStorageConfig contains information on the storage configuration of a
@@ -350,6 +388,10 @@ type ContainerStorageConfig struct {
 	// into paths relative to these directories.
 	// Optional.
 	ChrootDirs []string `json:"chroot_directories,omitempty"`
+	// CollectCores redirects core dumps of the container's processes to a
+	// directory on the host. This is only supported on FreeBSD.
+	// Optional.
+	CollectCores bool `json:"collect_cores,omitempty"`
 }
 
 // ContainerSecurityConfig is a container's security features, including
@@ -442,6 +484,32 @@ type ContainerSecurityConfig struct {
 	// that masking. If ALL is passed, all paths will be unmasked.
 	// Optional.
 	Unmask []string `json:"unmask,omitempty"`
+	// JailProfile is the name of the jail parameter profile to use for
+	// the container. Only supported on FreeBSD.
+	// Optional.
+	JailProfile string `json:"jail_profile,omitempty"`
+	// EnforceStatfs is the value of the enforce_statfs jail parameter,
+	// which controls which mount points are visible in the container. If
+	// not set, the value from the jail profile is used. Only supported on
+	// FreeBSD.
+	// Optional.
+	EnforceStatfs *int `json:"enforce_statfs,omitempty"`
+	// Securelevel is the value of the securelevel jail parameter. If not
+	// set, the value from the jail profile is used. Only supported on
+	// FreeBSD.
+	// Optional.
+	Securelevel *int `json:"securelevel,omitempty"`
+	// VnetDevfsRuleset is the devfs ruleset of the vnet jail holding the
+	// network of the container. If not set, the ruleset from the labels
+	// of the networks of the container or from containers.conf is used.
+	// Only supported on FreeBSD.
+	// Optional.
+	VnetDevfsRuleset *int `json:"vnet_devfs_ruleset,omitempty"`
+	// JailAllow enables or disables allow.* jail parameters, keyed by
+	// the name of the parameter without the allow. prefix. These take
+	// precedence over the jail profile. Only supported on FreeBSD.
+	// Optional.
+	JailAllow map[string]bool `json:"jail_allow,omitempty"`
 }
 
 // ContainerCgroupConfig contains configuration information about a container's
@@ -528,6 +596,10 @@ type ContainerNetworkConfig struct {
 	// Conflicts with UseImageResolvConf.
 	// Optional.
 	DNSOptions []string `json:"dns_option,omitempty"`
+	// Routes are static routes added to the container's network once it
+	// is set up.
+	// Optional.
+	Routes []nettypes.Route `json:"routes,omitempty"`
 	// UseImageHosts indicates that /etc/hosts should not be managed by
 	// Podman, and instead sourced from the image.
 	// Conflicts with HostAdd.
@@ -568,6 +640,20 @@ type ContainerResourceConfig struct {
 	// processes to kill for the container's process.
 	// Optional.
 	OOMScoreAdj *int `json:"oom_score_adj,omitempty"`
+	// MemoryReclaimSignal is sent to the container when its memory use
+	// exceeds the memory reservation (soft limit), so that it can free
+	// memory. This is only supported on FreeBSD.
+	// Optional.
+	MemoryReclaimSignal *syscall.Signal `json:"memory_reclaim_signal,omitempty"`
+	// IOPriority is the name of the I/O priority class of the container,
+	// e.g. critical, normal or batch. Only supported on FreeBSD.
+	// Optional.
+	IOPriority string `json:"io_priority,omitempty"`
+	// MemoryLock is the amount of memory in bytes the container may lock,
+	// e.g. using mlockall(2), to protect latency critical processes from
+	// being paged out. This is experimental and only supported on FreeBSD.
+	// Optional.
+	MemoryLock *int64 `json:"memory_lock,omitempty"`
 	// Weight per cgroup per device, can override BlkioWeight
 	WeightDevice map[string]spec.LinuxWeightDevice `json:"weightDevice,omitempty"`
 	// IO read rate limit per cgroup per device, bytes per second
@@ -624,11 +710,12 @@ func (s *SpecGenerator) IsInitContainer() bool {
 }
 
 type Secret struct {
-	Source string
-	Target string
-	UID    uint32
-	GID    uint32
-	Mode   uint32
+	Source    string
+	Target    string
+	UID       uint32
+	GID       uint32
+	Mode      uint32
+	Ephemeral bool
 }
 
 var (
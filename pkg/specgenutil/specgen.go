@@ -16,6 +16,7 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	envLib "github.com/containers/podman/v5/pkg/env"
 	"github.com/containers/podman/v5/pkg/namespaces"
+	"github.com/containers/podman/v5/pkg/schedule"
 	"github.com/containers/podman/v5/pkg/specgen"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
 	"github.com/containers/podman/v5/pkg/util"
@@ -396,6 +397,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.Terminal = &c.TTY
 	}
 
+	if !s.Console {
+		s.Console = c.Console
+	}
+
 	if err := verifyExpose(c.Expose); err != nil {
 		return err
 	}
@@ -440,6 +445,26 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.StopSignal = &stopSignal
 	}
 
+	if len(s.IOPriority) == 0 || len(c.IOPriority) != 0 {
+		s.IOPriority = c.IOPriority
+	}
+
+	if m := c.MemoryLock; len(m) > 0 {
+		ml, err := units.RAMInBytes(m)
+		if err != nil {
+			return fmt.Errorf("invalid value for memory lock: %w", err)
+		}
+		s.MemoryLock = &ml
+	}
+
+	if sig := c.MemoryReclaimSignal; len(sig) > 0 {
+		reclaimSignal, err := util.ParseSignal(sig)
+		if err != nil {
+			return err
+		}
+		s.MemoryReclaimSignal = &reclaimSignal
+	}
+
 	// ENVIRONMENT VARIABLES
 	//
 	// Precedence order (higher index wins):
@@ -490,6 +515,13 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		labels[systemdDefine.EnvVariable] = systemdUnit
 	}
 
+	if c.Schedule != "" {
+		if _, err := schedule.Parse(c.Schedule); err != nil {
+			return err
+		}
+		labels[schedule.Label] = c.Schedule
+	}
+
 	if len(s.Labels) == 0 {
 		s.Labels = labels
 	}
@@ -579,12 +611,16 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.DNSServers = c.Net.DNSServers
 		s.DNSSearch = c.Net.DNSSearch
 		s.DNSOptions = c.Net.DNSOptions
+		s.Routes = c.Net.Routes
 		s.NetworkOptions = c.Net.NetworkOptions
 		s.UseImageHosts = &c.Net.NoHosts
 	}
 	if len(s.HostUsers) == 0 || len(c.HostUsers) != 0 {
 		s.HostUsers = c.HostUsers
 	}
+	if c.HostUserGroups {
+		s.HostUserGroups = true
+	}
 	if len(c.ImageVolume) != 0 {
 		if len(s.ImageVolumeMode) == 0 {
 			s.ImageVolumeMode = c.ImageVolume
@@ -695,10 +731,28 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		} else {
 			key, val, hasVal = strings.Cut(opt, ":")
 		}
-		if !hasVal &&
-			key != "no-new-privileges" {
+		if !hasVal && key != "no-new-privileges" {
+			// Disable a jail allow.* parameter, e.g. nomount
+			if param, ok := strings.CutPrefix(key, "no"); ok && specgen.IsJailAllowParam(param) {
+				if s.ContainerSecurityConfig.JailAllow == nil {
+					s.ContainerSecurityConfig.JailAllow = make(map[string]bool)
+				}
+				s.ContainerSecurityConfig.JailAllow[param] = false
+				continue
+			}
 			return fmt.Errorf("invalid --security-opt 1: %q", opt)
 		}
+		if param, ok := strings.CutPrefix(key, "allow."); ok {
+			allow, err := strconv.ParseBool(val)
+			if err != nil || param == "" {
+				return fmt.Errorf("invalid --security-opt 2: %q", opt)
+			}
+			if s.ContainerSecurityConfig.JailAllow == nil {
+				s.ContainerSecurityConfig.JailAllow = make(map[string]bool)
+			}
+			s.ContainerSecurityConfig.JailAllow[param] = allow
+			continue
+		}
 		switch key {
 		case "apparmor":
 			s.ContainerSecurityConfig.ApparmorProfile = val
@@ -714,6 +768,24 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 			s.Annotations[define.InspectAnnotationLabel] = strings.Join(s.ContainerSecurityConfig.SelinuxOpts, ",label=")
 		case "mask":
 			s.ContainerSecurityConfig.Mask = append(s.ContainerSecurityConfig.Mask, strings.Split(val, ":")...)
+		case "enforce_statfs":
+			enforceStatfs, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid --security-opt 2: %q", opt)
+			}
+			s.ContainerSecurityConfig.EnforceStatfs = &enforceStatfs
+		case "securelevel":
+			securelevel, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid --security-opt 2: %q", opt)
+			}
+			s.ContainerSecurityConfig.Securelevel = &securelevel
+		case "vnet_devfs_ruleset":
+			ruleset, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid --security-opt 2: %q", opt)
+			}
+			s.ContainerSecurityConfig.VnetDevfsRuleset = &ruleset
 		case "proc-opts":
 			s.ProcOpts = strings.Split(val, ",")
 		case "seccomp":
@@ -835,6 +907,12 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 				return err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFiles, err := strconv.ParseUint(val, 10, 32)
+			if err != nil || maxFiles == 0 {
+				return fmt.Errorf("invalid max-file log option %q: must be a positive number", val)
+			}
+			s.LogConfiguration.MaxFiles = uint(maxFiles)
 		default:
 			logOpts[key] = val
 		}
@@ -899,6 +977,9 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if len(s.PidFile) == 0 || len(c.PidFile) != 0 {
 		s.PidFile = c.PidFile
 	}
+	if len(s.JailProfile) == 0 || len(c.JailProfile) != 0 {
+		s.JailProfile = c.JailProfile
+	}
 	if s.Volatile == nil {
 		s.Volatile = &c.Rm
 	}
@@ -915,6 +996,25 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.ChrootDirs = c.ChrootDirs
 	}
 
+	if !s.CollectCores {
+		s.CollectCores = c.CollectCores
+	}
+
+	if len(s.MetadataKeys) == 0 || len(c.MetadataKeys) != 0 {
+		s.MetadataKeys = c.MetadataKeys
+	}
+
+	if c.IdentityCA != "" {
+		s.IdentityCA = c.IdentityCA
+	}
+	if c.IdentityTTL != "" {
+		ttl, err := time.ParseDuration(c.IdentityTTL)
+		if err != nil {
+			return fmt.Errorf("invalid identity certificate validity %q: %w", c.IdentityTTL, err)
+		}
+		s.IdentityTTL = ttl
+	}
+
 	// Initcontainers
 	if len(s.InitContainerType) == 0 || len(c.InitContainerType) != 0 {
 		s.InitContainerType = c.InitContainerType
@@ -932,6 +1032,12 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if len(s.GroupEntry) == 0 || len(c.GroupEntry) != 0 {
 		s.GroupEntry = c.GroupEntry
 	}
+	if c.NoPasswd {
+		s.NoPasswd = true
+	}
+	if c.NoGroup {
+		s.NoGroup = true
+	}
 
 	return nil
 }
@@ -1087,6 +1193,7 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 		secretType := ""
 		target := ""
 		var uid, gid uint32
+		ephemeral := false
 		// default mode 444 octal = 292 decimal
 		var mode uint32 = 292
 		split := strings.Split(val, ",")
@@ -1115,7 +1222,7 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 				return nil, nil, fmt.Errorf("option %s must be in form option=value: %w", val, secretParseError)
 			}
 			switch name {
-			case "source":
+			case "source", "id":
 				source = value
 			case "type":
 				if secretType != "" {
@@ -1130,7 +1237,7 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 			case "mode":
 				mountOnly = true
 				mode64, err := strconv.ParseUint(value, 8, 32)
-				if err != nil {
+				if err != nil || mode64 > 0o777 {
 					return nil, nil, fmt.Errorf("mode %s invalid: %w", value, secretParseError)
 				}
 				mode = uint32(mode64)
@@ -1148,6 +1255,13 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 					return nil, nil, fmt.Errorf("GID %s invalid: %w", value, secretParseError)
 				}
 				gid = uint32(gid64)
+			case "ephemeral":
+				mountOnly = true
+				var err error
+				ephemeral, err = strconv.ParseBool(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("ephemeral %s invalid: %w", value, secretParseError)
+				}
 
 			default:
 				return nil, nil, fmt.Errorf("option %s invalid: %w", val, secretParseError)
@@ -1162,17 +1276,18 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 		}
 		if secretType == "mount" {
 			mountSecret := specgen.Secret{
-				Source: source,
-				Target: target,
-				UID:    uid,
-				GID:    gid,
-				Mode:   mode,
+				Source:    source,
+				Target:    target,
+				UID:       uid,
+				GID:       gid,
+				Mode:      mode,
+				Ephemeral: ephemeral,
 			}
 			mount = append(mount, mountSecret)
 		}
 		if secretType == "env" {
 			if mountOnly {
-				return nil, nil, fmt.Errorf("UID, GID, Mode options cannot be set with secret type env: %w", secretParseError)
+				return nil, nil, fmt.Errorf("UID, GID, Mode, Ephemeral options cannot be set with secret type env: %w", secretParseError)
 			}
 			if target == "" {
 				target = source
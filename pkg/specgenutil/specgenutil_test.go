@@ -216,3 +216,31 @@ func TestGenRlimits(t *testing.T) {
 	_, err = GenRlimits([]string{"nofile=bar:buzz"})
 	assert.Error(t, err, "err is not nil")
 }
+
+func TestParseSecretsEphemeral(t *testing.T) {
+	mounts, envs, err := parseSecrets([]string{"mysecret,ephemeral=true,mode=0400", "other"})
+	assert.NoError(t, err)
+	assert.Empty(t, envs)
+	assert.Equal(t, []specgen.Secret{
+		{Source: "mysecret", Mode: 0o400, Ephemeral: true},
+		{Source: "other", Mode: 0o444},
+	}, mounts)
+
+	_, _, err = parseSecrets([]string{"mysecret,type=env,ephemeral=true"})
+	assert.Error(t, err)
+	_, _, err = parseSecrets([]string{"mysecret,ephemeral=maybe"})
+	assert.Error(t, err)
+}
+
+func TestParseSecretsOwnership(t *testing.T) {
+	mounts, _, err := parseSecrets([]string{"id=foo,mode=0400,uid=100,gid=101,target=/etc/app/secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, []specgen.Secret{
+		{Source: "foo", Target: "/etc/app/secret", UID: 100, GID: 101, Mode: 0o400},
+	}, mounts)
+
+	_, _, err = parseSecrets([]string{"foo,mode=04755"})
+	assert.Error(t, err)
+	_, _, err = parseSecrets([]string{"foo,mode=0999"})
+	assert.Error(t, err)
+}
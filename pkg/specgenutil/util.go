@@ -267,7 +267,40 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 	// user of the API.
 	// As such, provide a way to specify a path to Podman, so we can
 	// still invoke a cleanup process.
+	command, err := podmanCommandArgs(storageConfig, config, syslog)
+	if err != nil {
+		return nil, err
+	}
+
+	command = append(command, []string{"container", "cleanup"}...)
+
+	if rm {
+		command = append(command, "--rm")
+	}
+
+	// This has to be absolutely last, to ensure that the exec session ID
+	// will be added after it by Libpod.
+	if exec {
+		command = append(command, "--exec")
+	}
+
+	return command, nil
+}
 
+// CreateLogManagerCommandArgs returns the command which manages the log of a
+// running container, i.e. rotates it or forwards it to syslog.
+func CreateLogManagerCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, ctrID string) ([]string, error) {
+	command, err := podmanCommandArgs(storageConfig, config, logrus.IsLevelEnabled(logrus.DebugLevel))
+	if err != nil {
+		return nil, err
+	}
+	return append(command, "container", "log-manager", ctrID), nil
+}
+
+// podmanCommandArgs returns the path to Podman and the global options which
+// make a Podman process started by Libpod use the same configuration as the
+// current one.
+func podmanCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog bool) ([]string, error) {
 	podmanPath, err := os.Executable()
 	if err != nil {
 		return nil, err
@@ -311,17 +344,5 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 		command = append(command, "--module", module)
 	}
 
-	command = append(command, []string{"container", "cleanup"}...)
-
-	if rm {
-		command = append(command, "--rm")
-	}
-
-	// This has to be absolutely last, to ensure that the exec session ID
-	// will be added after it by Libpod.
-	if exec {
-		command = append(command, "--exec")
-	}
-
 	return command, nil
 }
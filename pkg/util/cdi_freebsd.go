@@ -0,0 +1,106 @@
+//go:build freebsd
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/exp/slices"
+)
+
+// ConvertCDIEdits rewrites the edits which CDI made to the spec into their
+// FreeBSD equivalents. CDI describes devices the way Linux runtimes create
+// them, as device nodes with cgroup access rules and bind mounts. A jail
+// instead sees the host's device nodes through its devfs mount, so each
+// device node becomes a devfs rule unhiding the device, and bind mounts
+// become nullfs mounts.
+func ConvertCDIEdits(s *spec.Spec) error {
+	if s.Linux != nil && len(s.Linux.Devices) > 0 {
+		devfs := -1
+		for i, m := range s.Mounts {
+			if m.Type == "devfs" && m.Destination == "/dev" {
+				devfs = i
+				break
+			}
+		}
+		if devfs < 0 {
+			return errors.New("adding CDI devices: the container has no devfs mount")
+		}
+		for _, dev := range s.Linux.Devices {
+			rule, err := cdiDevfsRule(dev, cdiDeviceAccess(s.Linux.Resources, dev))
+			if err != nil {
+				return err
+			}
+			s.Mounts[devfs].Options = append(s.Mounts[devfs].Options, rule)
+		}
+		// The cgroup rules granting access to the devices have no
+		// meaning for a jail.
+		if s.Linux.Resources != nil {
+			s.Linux.Resources.Devices = slices.DeleteFunc(s.Linux.Resources.Devices, func(rule spec.LinuxDeviceCgroup) bool {
+				return rule.Allow && rule.Major != nil && rule.Minor != nil
+			})
+		}
+		s.Linux.Devices = nil
+	}
+
+	for i, m := range s.Mounts {
+		if m.Type != "bind" && !(m.Type == "" && (slices.Contains(m.Options, "bind") || slices.Contains(m.Options, "rbind"))) {
+			continue
+		}
+		options := make([]string, 0, len(m.Options))
+		for _, o := range m.Options {
+			if o != "bind" && o != "rbind" {
+				options = append(options, o)
+			}
+		}
+		s.Mounts[i].Type = "nullfs"
+		s.Mounts[i].Options = filterPropagationOptions(options)
+	}
+	return nil
+}
+
+// cdiDeviceAccess returns the access which the cgroup rules of the spec
+// grant to the device, defaulting to read and write access.
+func cdiDeviceAccess(resources *spec.LinuxResources, dev spec.LinuxDevice) string {
+	if resources != nil {
+		for _, rule := range resources.Devices {
+			if rule.Allow && rule.Type == dev.Type && rule.Major != nil && *rule.Major == dev.Major && rule.Minor != nil && *rule.Minor == dev.Minor {
+				return rule.Access
+			}
+		}
+	}
+	return "rw"
+}
+
+// cdiDevfsRule returns the devfs mount option unhiding the device with the
+// given access in the container's /dev. Unlike on Linux, devices cannot be
+// renamed, so the device must have the same path in the container as on the
+// host.
+func cdiDevfsRule(dev spec.LinuxDevice, access string) (string, error) {
+	name, ok := strings.CutPrefix(dev.Path, "/dev/")
+	if !ok {
+		return "", fmt.Errorf("CDI device %s: devices must be below /dev on FreeBSD", dev.Path)
+	}
+	mode := 0
+	if dev.FileMode != nil {
+		mode = int(dev.FileMode.Perm())
+	} else {
+		if strings.Contains(access, "r") {
+			mode |= 0o400
+		}
+		if strings.Contains(access, "w") {
+			mode |= 0o200
+		}
+	}
+	rule := fmt.Sprintf("rule=path %s unhide mode %04o", name, mode)
+	if dev.UID != nil {
+		rule += fmt.Sprintf(" user %d", *dev.UID)
+	}
+	if dev.GID != nil {
+		rule += fmt.Sprintf(" group %d", *dev.GID)
+	}
+	return rule, nil
+}
@@ -0,0 +1,47 @@
+//go:build freebsd
+
+package util
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCDIEdits(t *testing.T) {
+	major, minor := int64(0), int64(91)
+	gid := uint32(44)
+	s := &spec.Spec{
+		Mounts: []spec.Mount{
+			{Destination: "/dev", Type: "devfs", Source: "devfs", Options: []string{"ruleset=4"}},
+			{Destination: "/usr/local/lib/dri", Type: "bind", Source: "/usr/local/lib/dri", Options: []string{"rbind", "ro", "rprivate"}},
+		},
+		Linux: &spec.Linux{
+			Devices: []spec.LinuxDevice{
+				{Path: "/dev/dri/renderD128", Type: "c", Major: major, Minor: minor, GID: &gid},
+				{Path: "/dev/dsp0", Type: "c", Major: 0, Minor: 92},
+			},
+			Resources: &spec.LinuxResources{
+				Devices: []spec.LinuxDeviceCgroup{
+					{Allow: false, Access: "rwm"},
+					{Allow: true, Type: "c", Major: &major, Minor: &minor, Access: "r"},
+				},
+			},
+		},
+	}
+	require.NoError(t, ConvertCDIEdits(s))
+	assert.Equal(t, []string{
+		"ruleset=4",
+		"rule=path dri/renderD128 unhide mode 0400 group 44",
+		"rule=path dsp0 unhide mode 0600",
+	}, s.Mounts[0].Options)
+	assert.Equal(t, "nullfs", s.Mounts[1].Type)
+	assert.Equal(t, []string{"ro"}, s.Mounts[1].Options)
+	assert.Empty(t, s.Linux.Devices)
+	assert.Equal(t, []spec.LinuxDeviceCgroup{{Allow: false, Access: "rwm"}}, s.Linux.Resources.Devices)
+
+	s = &spec.Spec{Linux: &spec.Linux{Devices: []spec.LinuxDevice{{Path: "/dev/null"}}}}
+	assert.Error(t, ConvertCDIEdits(s))
+}
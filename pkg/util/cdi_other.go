@@ -0,0 +1,13 @@
+//go:build !freebsd
+
+package util
+
+import (
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ConvertCDIEdits does nothing on platforms where the runtime applies the
+// edits CDI made to the spec as they are.
+func ConvertCDIEdits(s *spec.Spec) error {
+	return nil
+}
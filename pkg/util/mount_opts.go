@@ -29,7 +29,11 @@ type getDefaultMountOptionsFn func(path string) (defaultMountOptions, error)
 // whether extra, tmpfs-specific options will be allowed.
 // The sourcePath variable, if not empty, contains a bind mount source.
 func ProcessOptions(options []string, isTmpfs bool, sourcePath string) ([]string, error) {
-	return processOptionsInternal(options, isTmpfs, sourcePath, getDefaultMountOptions)
+	newOptions, err := processOptionsInternal(options, isTmpfs, sourcePath, getDefaultMountOptions)
+	if err != nil {
+		return nil, err
+	}
+	return filterPropagationOptions(newOptions), nil
 }
 
 func processOptionsInternal(options []string, isTmpfs bool, sourcePath string, getDefaultMountOptions getDefaultMountOptionsFn) ([]string, error) {
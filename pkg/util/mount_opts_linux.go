@@ -21,3 +21,9 @@ func getDefaultMountOptions(path string) (defaultMountOptions, error) {
 
 	return opts, nil
 }
+
+// filterPropagationOptions returns the options unchanged, mount propagation
+// is supported on Linux.
+func filterPropagationOptions(options []string) []string {
+	return options
+}
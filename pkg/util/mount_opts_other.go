@@ -2,6 +2,30 @@
 
 package util
 
+import (
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
 func getDefaultMountOptions(path string) (opts defaultMountOptions, err error) {
 	return
 }
+
+// filterPropagationOptions removes mount propagation options which are not
+// supported on this platform. Private propagation matches the behaviour of
+// nullfs so it is removed silently; other modes are removed with a warning.
+func filterPropagationOptions(options []string) []string {
+	newOptions := make([]string, 0, len(options))
+	for _, opt := range options {
+		switch opt {
+		case "private", "rprivate":
+			continue
+		case "slave", "rslave", "shared", "rshared", "unbindable", "runbindable":
+			logrus.Warnf("Mount propagation option %q is not supported on %s, ignoring", opt, runtime.GOOS)
+			continue
+		}
+		newOptions = append(newOptions, opt)
+	}
+	return newOptions
+}
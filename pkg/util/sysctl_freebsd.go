@@ -0,0 +1,15 @@
+//go:build freebsd
+
+package util
+
+// platformSysctls lists the sysctls which can be set for a jail in addition
+// to the net.* sysctls. They are translated to jail parameters.
+var platformSysctls = map[string]bool{
+	"kern.securelevel": true,
+}
+
+// platformSysctlPrefixes lists prefixes of sysctls which are backed by jail
+// parameters.
+var platformSysctlPrefixes = []string{
+	"security.jail.",
+}
@@ -0,0 +1,8 @@
+//go:build !freebsd
+
+package util
+
+var (
+	platformSysctls        map[string]bool
+	platformSysctlPrefixes []string
+)
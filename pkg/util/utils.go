@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/bits"
 	"os"
+	"os/exec"
 	"os/user"
 	"path/filepath"
 	"regexp"
@@ -173,9 +174,8 @@ func ParseSignal(rawSignal string) (syscall.Signal, error) {
 	if err != nil {
 		return -1, err
 	}
-	// 64 is SIGRTMAX; wish we could get this from a standard Go library
-	if sig < 1 || sig > 64 {
-		return -1, errors.New("valid signals are 1 through 64")
+	if sig < 1 || sig > signal.SIGRTMAX {
+		return -1, fmt.Errorf("valid signals are 1 through %d", signal.SIGRTMAX)
 	}
 	return sig, nil
 }
@@ -1175,6 +1175,39 @@ func Tmpdir() string {
 	return tmpdir
 }
 
+var networkRateRegexp = regexp.MustCompile(`^([0-9]+)([kKmMgG]?)(bit|bit/s|B|Byte|Byte/s)?$`)
+
+// ParseNetworkRate parses a network rate limit, a number with an optional
+// K, M or G multiplier (powers of 1000) and an optional bit/s or Byte/s
+// unit, for instance 10Mbit/s or 512KByte/s. The unit defaults to bit/s.
+// It returns the rate in bits per second.
+func ParseNetworkRate(rate string) (uint64, error) {
+	match := networkRateRegexp.FindStringSubmatch(rate)
+	if match == nil {
+		return 0, fmt.Errorf("invalid network rate %q, must be a number with an optional K, M or G multiplier and an optional bit/s or Byte/s unit", rate)
+	}
+	value, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid network rate %q: %w", rate, err)
+	}
+	multiplier := uint64(1)
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		multiplier = 1000
+	case "M":
+		multiplier = 1000 * 1000
+	case "G":
+		multiplier = 1000 * 1000 * 1000
+	}
+	if strings.HasPrefix(match[3], "B") {
+		multiplier *= 8
+	}
+	if value == 0 || value > math.MaxUint64/multiplier {
+		return 0, fmt.Errorf("network rate %q is out of range", rate)
+	}
+	return value * multiplier, nil
+}
+
 // ValidateSysctls validates a list of sysctl and returns it.
 func ValidateSysctls(strSlice []string) (map[string]string, error) {
 	sysctl := make(map[string]string)
@@ -1192,6 +1225,10 @@ func ValidateSysctls(strSlice []string) (map[string]string, error) {
 		"net.",
 		"fs.mqueue.",
 	}
+	for key := range platformSysctls {
+		validSysctlMap[key] = true
+	}
+	validSysctlPrefixes = append(validSysctlPrefixes, platformSysctlPrefixes...)
 
 	for _, val := range strSlice {
 		foundMatch := false
@@ -1294,7 +1331,56 @@ func LookupUser(name string) (*user.User, error) {
 	if u, err := user.LookupId(name); err == nil {
 		return u, nil
 	}
-	return user.Lookup(name)
+	u, err := user.Lookup(name)
+	if err == nil {
+		return u, nil
+	}
+	// Without cgo, the Go resolver only reads /etc/passwd. Ask the name
+	// service switch for users from other sources, like LDAP or NIS.
+	if fields, nssErr := getent("passwd", name); nssErr == nil && len(fields) >= 7 {
+		fullName, _, _ := strings.Cut(fields[4], ",")
+		return &user.User{Username: fields[0], Uid: fields[2], Gid: fields[3], Name: fullName, HomeDir: fields[5]}, nil
+	}
+	return nil, err
+}
+
+// LookupGroup looks up a group by GID or, failing that, by name. Like
+// LookupUser, it falls back to the name service switch.
+func LookupGroup(name string) (*user.Group, error) {
+	if g, err := user.LookupGroupId(name); err == nil {
+		return g, nil
+	}
+	g, err := user.LookupGroup(name)
+	if err == nil {
+		return g, nil
+	}
+	if fields, nssErr := getent("group", name); nssErr == nil && len(fields) >= 3 {
+		return &user.Group{Name: fields[0], Gid: fields[2]}, nil
+	}
+	return nil, err
+}
+
+// UserGroupIDs returns the GIDs of the groups the user is a member of.
+// id(1) is asked first, as it uses the name service switch; without cgo
+// u.GroupIds() only reads /etc/group.
+func UserGroupIDs(u *user.User) ([]string, error) {
+	if out, err := exec.Command("id", "-G", u.Username).Output(); err == nil {
+		if gids := strings.Fields(string(out)); len(gids) > 0 {
+			return gids, nil
+		}
+	}
+	return u.GroupIds()
+}
+
+// getent returns the fields of the entry for key in the given database of
+// the name service switch, as printed by getent(1).
+var getent = func(database, key string) ([]string, error) {
+	out, err := exec.Command("getent", database, key).Output()
+	if err != nil {
+		return nil, err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return strings.Split(line, ":"), nil
 }
 
 // SizeOfPath determines the file usage of a given path. it was called volumeSize in v1
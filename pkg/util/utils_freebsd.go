@@ -8,12 +8,17 @@ import (
 
 func GetContainerPidInformationDescriptors() ([]string, error) {
 	// These are chosen to match the set of AIX format descriptors
-	// supported in Linux - FreeBSD ps does support (many) others.
+	// supported in Linux - FreeBSD ps does support (many) others. The
+	// emulation, fib, jailname and jid descriptors are FreeBSD specific.
 	return []string{
 		"args",
 		"comm",
+		"emulation",
 		"etime",
+		"fib",
 		"group",
+		"jailname",
+		"jid",
 		"nice",
 		"pcpu",
 		"pgid",
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -764,3 +765,62 @@ func TestProcessOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupNSS(t *testing.T) {
+	oldGetent := getent
+	defer func() { getent = oldGetent }()
+	getent = func(database, key string) ([]string, error) {
+		switch {
+		case database == "passwd" && key == "ldapuser":
+			return []string{"ldapuser", "*", "54321", "5000", "LDAP User,Room 1", "/home/ldapuser", "/bin/sh"}, nil
+		case database == "group" && key == "ldapgroup":
+			return []string{"ldapgroup", "*", "5000", "ldapuser"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	u, err := LookupUser("ldapuser")
+	assert.NoError(t, err)
+	assert.Equal(t, "54321", u.Uid)
+	assert.Equal(t, "5000", u.Gid)
+	assert.Equal(t, "LDAP User", u.Name)
+	assert.Equal(t, "/home/ldapuser", u.HomeDir)
+
+	g, err := LookupGroup("ldapgroup")
+	assert.NoError(t, err)
+	assert.Equal(t, "5000", g.Gid)
+
+	_, err = LookupUser("no-such-user-anywhere")
+	assert.Error(t, err)
+	_, err = LookupGroup("no-such-group-anywhere")
+	assert.Error(t, err)
+}
+
+func TestParseNetworkRate(t *testing.T) {
+	tests := []struct {
+		rate string
+		want uint64
+		err  bool
+	}{
+		{rate: "1000", want: 1000},
+		{rate: "10Mbit/s", want: 10000000},
+		{rate: "10mbit", want: 10000000},
+		{rate: "2G", want: 2000000000},
+		{rate: "512KByte/s", want: 4096000},
+		{rate: "1B", want: 8},
+		{rate: "0", err: true},
+		{rate: "", err: true},
+		{rate: "10Tbit", err: true},
+		{rate: "-5M", err: true},
+		{rate: "99999999999999999999G", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNetworkRate(tt.rate)
+		if tt.err {
+			assert.Error(t, err, tt.rate)
+			continue
+		}
+		assert.NoError(t, err, tt.rate)
+		assert.Equal(t, tt.want, got, tt.rate)
+	}
+}
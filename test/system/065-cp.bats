@@ -590,6 +590,41 @@ load helpers
 }
 
 
+@test "podman cp --follow-link=false copies the symlink itself" {
+    srcdir=$PODMAN_TMPDIR/cp-nofollow-src
+    destdir=$PODMAN_TMPDIR/cp-nofollow-dest
+    mkdir -p $srcdir $destdir
+    echo "host content" > $srcdir/hostfile
+    ln -s hostfile $srcdir/hostlink
+
+    run_podman run -d --name cpcontainer $IMAGE sh -c "echo ctr content > /tmp/ctrfile; \
+         ln -s ctrfile /tmp/ctrlink; \
+         echo READY;
+         sleep infinity"
+    wait_for_ready cpcontainer
+
+    # Container to host
+    run_podman cp --follow-link=false cpcontainer:/tmp/ctrlink $destdir/ctrlink
+    test -L $destdir/ctrlink || die "$destdir/ctrlink is not a symlink"
+    assert "$(readlink $destdir/ctrlink)" = "ctrfile" "container to host: link target"
+
+    run_podman cp cpcontainer:/tmp/ctrlink $destdir/ctrfollow
+    test -L $destdir/ctrfollow && die "$destdir/ctrfollow should not be a symlink"
+    assert "$(< $destdir/ctrfollow)" = "ctr content" "container to host: followed link"
+
+    # Host to container
+    run_podman cp --follow-link=false $srcdir/hostlink cpcontainer:/tmp/hostlink
+    run_podman exec cpcontainer readlink /tmp/hostlink
+    assert "$output" = "hostfile" "host to container: link target"
+
+    run_podman cp $srcdir/hostlink cpcontainer:/tmp/hostfollow
+    run_podman exec cpcontainer cat /tmp/hostfollow
+    assert "$output" = "host content" "host to container: followed link"
+
+    run_podman rm -t 0 -f cpcontainer
+}
+
+
 @test "podman cp file from host to container volume" {
     srcdir=$PODMAN_TMPDIR/cp-test-volume
     mkdir -p $srcdir
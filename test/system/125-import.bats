@@ -44,6 +44,32 @@ load helpers
     run_podman rmi -f $fqin
 }
 
+@test "podman export --format zfs, re-import" {
+    run_podman info --format '{{.Store.GraphDriverName}}'
+    if [[ "$output" != "zfs" ]]; then
+        run_podman create --name export $IMAGE
+        run_podman 125 export --format zfs -o $PODMAN_TMPDIR/archive.zfs export
+        is "$output" ".*requires the zfs storage driver.*" "zfs export without zfs"
+        run_podman 125 export --format bogus -o $PODMAN_TMPDIR/archive.zfs export
+        is "$output" ".*unsupported export format \"bogus\".*"
+        run_podman rm -t 0 -f export
+        skip "storage driver is not zfs"
+    fi
+
+    local archive=$PODMAN_TMPDIR/archive.zfs
+    local random_content=$(random_string 12)
+
+    run_podman run --name export $IMAGE sh -c "echo ${random_content} > /random.txt"
+    run_podman export --format zfs -o $archive export
+    run_podman rm -t 0 -f export
+
+    run_podman import -q $archive
+    iid="$output"
+    run_podman run --rm $iid cat /random.txt
+    is "$output" "$random_content" "import of zfs stream"
+    run_podman rmi -f $iid
+}
+
 # Integration tag to catch future breakage in tar, e.g. #19407
 # bats test_tags=distro-integration
 @test "podman export, alter tarball, re-import" {